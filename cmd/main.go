@@ -1,6 +1,8 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
@@ -10,19 +12,34 @@ import (
 
 	"context"
 
+	"github.com/google/uuid"
 	"github.com/liriquew/test_task/internal/app"
+	domain "github.com/liriquew/test_task/internal/domain"
 	"github.com/liriquew/test_task/internal/lib/config"
 	"github.com/liriquew/test_task/pkg/logger"
 	"github.com/liriquew/test_task/pkg/logger/sl"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "bootstrap-admin" {
+		bootstrapAdmin(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		migrate(os.Args[2:])
+		return
+	}
+
 	cfg := config.MustLoad()
 	log := logger.SetupPrettySlog("USER SERVICE")
 
 	log.Info("loaded config", slog.Any("config", cfg))
 
-	app := app.New(log, cfg)
+	app, err := app.New(log, cfg)
+	if err != nil {
+		log.Error("error while starting service", sl.Err(err))
+		os.Exit(1)
+	}
 
 	app.Run()
 
@@ -41,3 +58,52 @@ func main() {
 		log.Warn("error while shutdown server", sl.Err(err))
 	}
 }
+
+// bootstrapAdmin creates or promotes an administrator directly in the storage,
+// used to recover an organization locked out of the API.
+// Password is read from BOOTSTRAP_ADMIN_PASSWORD, so it doesn't get into shell history
+func bootstrapAdmin(args []string) {
+	fs := flag.NewFlagSet("bootstrap-admin", flag.ExitOnError)
+	org := fs.String("org", "default", "organization name")
+	username := fs.String("username", "", "username of the administrator")
+	email := fs.String("email", "", "email, required for a new user")
+	fs.Parse(args)
+
+	cfg := config.MustLoad()
+	log := logger.SetupPrettySlog("BOOTSTRAP ADMIN")
+
+	user := &domain.User{
+		Username: domain.NewOptString(*username),
+	}
+	if *email != "" {
+		user.Email.SetTo(*email)
+	}
+	if password := os.Getenv("BOOTSTRAP_ADMIN_PASSWORD"); password != "" {
+		user.Password.SetTo(password)
+	}
+
+	id, err := app.BootstrapAdmin(context.Background(), log, cfg, *org, user)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	log.Info("admin bootstrapped", slog.String("org", *org), slog.String("id", uuid.UUID(id).String()))
+}
+
+// migrate applies or rolls back migrations embedded into the binary,
+// commands are up, down (a single migration), redo and status
+func migrate(args []string) {
+	command := "up"
+	if len(args) > 0 {
+		command = args[0]
+	}
+
+	cfg := config.MustLoad()
+	log := logger.SetupPrettySlog("MIGRATE")
+
+	if err := app.Migrate(context.Background(), log, cfg.Storage, command, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
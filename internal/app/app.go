@@ -6,55 +6,249 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"sync"
 
+	"github.com/liriquew/test_task/internal/authcache"
 	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/idempotency"
 	"github.com/liriquew/test_task/internal/lib/config"
-	"github.com/liriquew/test_task/internal/repository"
+	"github.com/liriquew/test_task/internal/lib/tenant"
+	"github.com/liriquew/test_task/internal/outbox"
+	"github.com/liriquew/test_task/internal/ratelimit"
+	"github.com/liriquew/test_task/internal/scim"
 	"github.com/liriquew/test_task/internal/service"
+	"github.com/liriquew/test_task/internal/watch"
+	"github.com/liriquew/test_task/internal/webhooks"
+	"github.com/ogen-go/ogen/middleware"
+	"github.com/ogen-go/ogen/ogenerrors"
 )
 
 type App struct {
-	srv     *http.Server
+	srv *http.Server
+	// grpc is nil if the gRPC API is disabled
+	grpc *grpcServer
+	// workers run in the background from Run until Close
+	workers []func(ctx context.Context)
+	stop    context.CancelFunc
+	wg      sync.WaitGroup
 	closers []func() error
 }
 
-func New(log *slog.Logger, cfg config.AppConfig) App {
-	storage := repository.New(cfg.Storage)
-	srvs := service.New(log, storage)
-	mdlwr := service.NewMiddleware(log, storage)
+// New connects to the storage, prepares its schema and the first administrator
+func New(log *slog.Logger, cfg config.AppConfig) (*App, error) {
+	ctx := context.Background()
 
-	server, err := domain.NewServer(srvs, mdlwr, []domain.ServerOption{
-		domain.WithMiddleware(
-			service.Logging(log),
-			mdlwr.CheckAdminPermission(),
-		),
+	repo, err := newStorage(ctx, cfg.Storage)
+	if err != nil {
+		return nil, fmt.Errorf("storage: %w", err)
+	}
+
+	if err := prepareSchema(ctx, log, cfg.Storage); err != nil {
+		repo.Close()
+		return nil, fmt.Errorf("schema: %w", err)
+	}
+
+	// metrics of the service are served on the port of the REST API
+	var metrics http.Handler
+	closeMetrics := func() error { return nil }
+	if cfg.Metrics.Enabled {
+		metrics, closeMetrics, err = newMetrics()
+		if err != nil {
+			repo.Close()
+			return nil, fmt.Errorf("metrics: %w", err)
+		}
+	}
+
+	// postgres notifies the hub of changes, other storages are polled
+	hub := watch.NewHub(log, repo, cfg.Watch)
+
+	// the cache of authentications drops entries on changes made by the service and
+	// on changes of other instances read by the hub
+	var srvsRepo service.Repository = repo
+	var authCache *authcache.Cache
+	if cfg.AuthCache.Enabled {
+		authCache = authcache.New(cfg.AuthCache)
+		hub.OnChange(authCache.Observe)
+		srvsRepo = authCache.Wrap(repo)
+	}
+
+	srvs := service.New(log, srvsRepo, service.WithChanges(hub))
+	mdlwr := service.NewMiddleware(log, repo)
+	var sec domain.SecurityHandler = mdlwr
+	if authCache != nil {
+		sec = authCache.Authenticator(mdlwr)
+	}
+
+	if err := bootstrap(ctx, log, cfg, srvs); err != nil {
+		repo.Close()
+		return nil, err
+	}
+
+	// the middlewares are shared by the REST, the gRPC and the GraphQL API
+	middlewares := []middleware.Middleware{
+		service.Logging(log),
+	}
+	// limited requests are logged, failed authentications are limited before the credentials
+	// are checked. limited keeps the client ip and the response header in the context
+	limited := func(h http.Handler) http.Handler { return h }
+	if cfg.RateLimit.Enabled {
+		limiter := ratelimit.New(log, ratelimit.NewMemoryStore(), cfg.RateLimit)
+		middlewares = append(middlewares, limiter.Middleware())
+		sec = limiter.Authenticator(sec)
+		limited = limiter.Wrap
+	}
+	// retries of REST requests with the Idempotency-Key header get the stored response,
+	// the key is claimed by the last middleware, after the checks of the request
+	idempotent := idempotency.New(log, repo, cfg.Idempotency)
+	middlewares = append(middlewares,
+		service.ReadYourWrites(),
+		mdlwr.RequirePasswordChange(),
+		mdlwr.ResolveTenant(),
+		mdlwr.CheckAdminPermission(),
+		idempotent.Middleware(),
+	)
+
+	server, err := domain.NewServer(srvs, sec, []domain.ServerOption{
+		domain.WithMiddleware(middlewares...),
+		domain.WithErrorHandler(ratelimit.ErrorHandler(idempotency.ErrorHandler(
+			service.ProjectionErrorHandler(ogenerrors.DefaultErrorHandler),
+		))),
 	}...)
 	if err != nil {
-		panic(err)
+		repo.Close()
+		return nil, err
+	}
+
+	ops := newOperations(sec, middlewares)
+	graphqlHandler, err := newGraphQLHandler(srvs, ops, cfg.GraphQL)
+	if err != nil {
+		repo.Close()
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/graphql", limited(graphqlHandler))
+	mux.Handle("/", limited(idempotent.Wrap(server)))
+	if cfg.SCIM.Enabled {
+		scimHandler, err := scim.NewHandler(log, srvs, repo, cfg.SCIM)
+		if err != nil {
+			repo.Close()
+			return nil, err
+		}
+		mux.Handle(scim.BasePath+"/", scimHandler)
+	}
+	if metrics != nil {
+		mux.Handle(cfg.Metrics.Path, metrics)
 	}
 
 	addr := fmt.Sprintf("%s:%d", cfg.API.Host, cfg.API.Port)
 
-	return App{
+	app := &App{
 		srv: &http.Server{
-			Handler: server,
+			Handler: service.FlushEventStream(mux),
 			Addr:    addr,
 		},
+		workers: []func(ctx context.Context){
+			hub.Run,
+			idempotent.Run,
+		},
 		closers: []func() error{
-			storage.Close,
+			closeMetrics,
+			repo.Close,
 		},
 	}
+	if cfg.GRPC.Enabled {
+		app.grpc = newGRPCServer(srvs, ops, fmt.Sprintf("%s:%d", cfg.API.Host, cfg.GRPC.Port))
+	}
+
+	// streams of changes never become idle, they are ended for the shutdown to complete
+	app.srv.RegisterOnShutdown(hub.Close)
+
+	// events are relayed to the outbox publisher and to webhooks, webhook deliveries
+	// are enqueued first, enqueueing is idempotent if the publisher fails
+	var publishers outbox.Publishers
+	if cfg.Webhooks.Enabled {
+		publishers = append(publishers, webhooks.NewEnqueuer(repo))
+
+		worker := webhooks.NewWorker(log, repo, cfg.Webhooks)
+		app.workers = append(app.workers, worker.Run)
+	}
+	if cfg.Outbox.Enabled {
+		publisher, err := outbox.NewPublisher(cfg.Outbox)
+		if err != nil {
+			repo.Close()
+			return nil, err
+		}
+		publishers = append(publishers, publisher)
+	}
+
+	if len(publishers) != 0 {
+		relay := outbox.NewRelay(log, repo, publishers, cfg.Outbox)
+		app.workers = append(app.workers, relay.Run)
+		// publishers are closed before the repository
+		app.closers = append([]func() error{publishers.Close}, app.closers...)
+	}
+
+	return app, nil
 }
 
+// Run starts the background workers and the servers, it doesn't block
 func (s *App) Run() {
-	if err := s.srv.ListenAndServe(); err != nil && errors.Is(err, http.ErrServerClosed) {
-		panic(err)
+	ctx, stop := context.WithCancel(context.Background())
+	s.stop = stop
+
+	for _, worker := range s.workers {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			worker(ctx)
+		}()
+	}
+
+	go func() {
+		if err := s.srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			panic(err)
+		}
+	}()
+
+	if s.grpc != nil {
+		go s.grpc.run()
 	}
 }
 
+// Close stops the servers, waits for the background workers and closes the storage
 func (s *App) Close(ctx context.Context) error {
-	if err := s.srv.Shutdown(ctx); err != nil {
-		return err
+	errs := []error{s.srv.Shutdown(ctx)}
+	if s.grpc != nil {
+		errs = append(errs, s.grpc.stop(ctx))
 	}
-	return nil
+
+	if s.stop != nil {
+		s.stop()
+	}
+	s.wg.Wait()
+
+	for _, closer := range s.closers {
+		errs = append(errs, closer())
+	}
+
+	return errors.Join(errs...)
+}
+
+// BootstrapAdmin grants admin permissions to the user of the organization without the API,
+// the user is created if it doesn't exist
+func BootstrapAdmin(ctx context.Context, log *slog.Logger, cfg config.AppConfig, org string, user *domain.User) (domain.UUID, error) {
+	repo, err := newStorage(ctx, cfg.Storage)
+	if err != nil {
+		return domain.UUID{}, err
+	}
+	defer repo.Close()
+
+	organization, err := repo.GetOrganizationByName(ctx, org)
+	if err != nil {
+		return domain.UUID{}, fmt.Errorf("organization %q: %w", org, err)
+	}
+	ctx = tenant.WithTenant(ctx, organization.ID.Value)
+
+	return service.New(log, repo).BootstrapAdmin(ctx, user)
 }
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/lib/config"
+	"github.com/liriquew/test_task/internal/service"
+)
+
+var (
+	// ErrDefaultCredential is returned in production while admin:admin signs in
+	ErrDefaultCredential = errors.New("default credential admin:admin is in use, change the password of admin before starting in production")
+	// ErrBootstrapPassword is returned in production while the administrator keeps the password of the config
+	ErrBootstrapPassword = errors.New("bootstrap administrator keeps the configured password, change it or leave bootstrap.password empty in production")
+)
+
+// bootstrap creates the first administrator of the default organization,
+// the password is logged only when it is generated. In production the service
+// doesn't start while the administrator seeded by earlier versions keeps admin:admin
+// or the administrator created with the password of the config hasn't changed it
+func bootstrap(ctx context.Context, log *slog.Logger, cfg config.AppConfig, srvs *service.Service) error {
+	if cfg.Env == config.EnvProduction {
+		inUse, err := srvs.DefaultCredentialInUse(ctx)
+		if err != nil {
+			return err
+		}
+		if inUse {
+			return ErrDefaultCredential
+		}
+	}
+
+	password, created, err := srvs.InitAdmin(ctx, &domain.User{
+		Username: domain.NewOptString(cfg.Bootstrap.Username),
+		Email:    domain.NewOptString(cfg.Bootstrap.Email),
+		Password: domain.NewOptString(cfg.Bootstrap.Password),
+	})
+	if err != nil {
+		return err
+	}
+	if created {
+		attrs := []any{slog.String("username", cfg.Bootstrap.Username)}
+		if cfg.Bootstrap.Password == "" {
+			attrs = append(attrs, slog.String("password", password))
+		}
+		log.Warn("initial administrator created, the password must be changed on the first login", attrs...)
+	}
+
+	if cfg.Env == config.EnvProduction && cfg.Bootstrap.Password != "" {
+		inUse, err := srvs.BootstrapPasswordInUse(ctx, cfg.Bootstrap.Username)
+		if err != nil {
+			return err
+		}
+		if inUse {
+			return ErrBootstrapPassword
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,698 @@
+package app
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/lib/config"
+	"github.com/liriquew/test_task/internal/lib/consistency"
+	"github.com/liriquew/test_task/internal/ratelimit"
+	"github.com/liriquew/test_task/internal/service"
+)
+
+const (
+	// size of a page of the users connection without first
+	graphqlDefaultFirst = 10
+	graphqlMaxFirst     = 100
+
+	// operation id of the middlewares for resolvers
+	graphqlOperationID = "graphql"
+)
+
+// codes in extensions of errors, by status codes of error responses of the REST API
+var graphqlCodes = map[int]string{
+	http.StatusBadRequest:            "BAD_USER_INPUT",
+	http.StatusUnauthorized:          "UNAUTHENTICATED",
+	http.StatusForbidden:             "FORBIDDEN",
+	http.StatusNotFound:              "NOT_FOUND",
+	http.StatusConflict:              "CONFLICT",
+	http.StatusRequestEntityTooLarge: "PAYLOAD_TOO_LARGE",
+	http.StatusTooManyRequests:       "TOO_MANY_REQUESTS",
+	http.StatusInternalServerError:   "INTERNAL_SERVER_ERROR",
+}
+
+// graphqlError is an error of a resolver with its code in extensions
+type graphqlError struct {
+	code    int
+	message string
+}
+
+func (e *graphqlError) Error() string {
+	return e.message
+}
+
+func (e *graphqlError) Extensions() map[string]any {
+	return map[string]any{"code": graphqlCodes[e.code]}
+}
+
+// graphqlHeader is the key of headers of the request, the middlewares read them
+type graphqlHeader struct{}
+
+// graphqlHandler serves GraphQL queries of users at /graphql with handlers of the REST API,
+// every resolver runs as the operation of the REST API with its authorization
+type graphqlHandler struct {
+	srvs   *service.Service
+	ops    *operations
+	schema graphql.Schema
+
+	maxDepth      int
+	maxComplexity int
+	maxBodySize   int64
+}
+
+func newGraphQLHandler(srvs *service.Service, ops *operations, cfg config.GraphQLConfig) (*graphqlHandler, error) {
+	h := &graphqlHandler{
+		srvs:          srvs,
+		ops:           ops,
+		maxDepth:      cfg.MaxDepth,
+		maxComplexity: cfg.MaxComplexity,
+		maxBodySize:   cfg.MaxBodySize,
+	}
+	if h.maxDepth <= 0 {
+		h.maxDepth = 10
+	}
+	if h.maxComplexity <= 0 {
+		h.maxComplexity = 1000
+	}
+	if h.maxBodySize <= 0 {
+		h.maxBodySize = 1 << 20
+	}
+
+	schema, err := h.newSchema()
+	if err != nil {
+		return nil, fmt.Errorf("graphql schema: %w", err)
+	}
+	h.schema = schema
+
+	return h, nil
+}
+
+type graphqlRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+func (h *graphqlHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeGraphQLErrors(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	ctx, err := h.ops.authenticate(r.Context(), graphqlOperationID, r.Header)
+	if err != nil {
+		if errors.Is(err, service.ErrUnauthorized) {
+			writeGraphQLErrors(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+		if limited := new(ratelimit.Error); errors.As(err, &limited) {
+			ratelimit.SetHeaders(w.Header(), limited.Quota, limited.Result)
+			writeGraphQLErrors(w, http.StatusTooManyRequests, err.Error())
+			return
+		}
+		writeGraphQLErrors(w, http.StatusInternalServerError, fmt.Sprintf("internal error: %s", err))
+		return
+	}
+
+	var req graphqlRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, h.maxBodySize)).Decode(&req); err != nil {
+		if tooLarge := new(http.MaxBytesError); errors.As(err, &tooLarge) {
+			writeGraphQLErrors(w, http.StatusRequestEntityTooLarge, "request body too large")
+			return
+		}
+		writeGraphQLErrors(w, http.StatusBadRequest, fmt.Sprintf("bad request body: %s", err))
+		return
+	}
+
+	doc, err := parser.Parse(parser.ParseParams{Source: req.Query})
+	if err != nil {
+		writeGraphQLErrors(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if res := graphql.ValidateDocument(&h.schema, doc, nil); !res.IsValid {
+		writeGraphQLResult(w, http.StatusBadRequest, &graphql.Result{Errors: res.Errors})
+		return
+	}
+	if err := h.checkLimits(doc, req.Variables); err != nil {
+		writeGraphQLErrors(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	res := graphql.Execute(graphql.ExecuteParams{
+		Schema:        h.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       context.WithValue(ctx, graphqlHeader{}, r.Header),
+	})
+	writeGraphQLResult(w, http.StatusOK, res)
+}
+
+func writeGraphQLResult(w http.ResponseWriter, code int, res *graphql.Result) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(res)
+}
+
+func writeGraphQLErrors(w http.ResponseWriter, code int, message string) {
+	writeGraphQLResult(w, code, &graphql.Result{
+		Errors: []gqlerrors.FormattedError{
+			gqlerrors.FormatError(&gqlerrors.Error{
+				Message:       message,
+				OriginalError: &graphqlError{code: code, message: message},
+			}),
+		},
+	})
+}
+
+// resolve runs handle as the operation of the REST API, error responses are
+// returned as errors of the field
+func (h *graphqlHandler) resolve(
+	p graphql.ResolveParams,
+	operation domain.OperationName,
+	handle func(ctx context.Context) (any, error),
+) (any, error) {
+	header, _ := p.Context.Value(graphqlHeader{}).(http.Header)
+
+	res, err := h.ops.run(p.Context, operation, graphqlOperationID, header, handle)
+	if err != nil {
+		return nil, &graphqlError{
+			code:    http.StatusInternalServerError,
+			message: fmt.Sprintf("internal error: %s", err),
+		}
+	}
+
+	if code, message, ok := errorResponse(res); ok {
+		return nil, &graphqlError{code: code, message: message}
+	}
+	return res, nil
+}
+
+func badUserInput(message string) error {
+	return &graphqlError{code: http.StatusBadRequest, message: message}
+}
+
+func (h *graphqlHandler) newSchema() (graphql.Schema, error) {
+	group := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Group",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.ID),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return uuid.UUID(p.Source.(domain.Group).ID.Value).String(), nil
+				},
+			},
+			"name": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return optValue(p.Source.(domain.Group).Name.Get()), nil
+				},
+			},
+			"description": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return optValue(p.Source.(domain.Group).Description.Get()), nil
+				},
+			},
+			"isAdmin": &graphql.Field{
+				Type: graphql.Boolean,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return optValue(p.Source.(domain.Group).IsAdmin.Get()), nil
+				},
+			},
+		},
+	})
+
+	user := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "User",
+		Description: "User of the organization, password hashes aren't exposed",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.ID),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return uuid.UUID(p.Source.(*domain.User).ID.Value).String(), nil
+				},
+			},
+			"username": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return optValue(p.Source.(*domain.User).Username.Get()), nil
+				},
+			},
+			"email": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return optValue(p.Source.(*domain.User).Email.Get()), nil
+				},
+			},
+			"isAdmin": &graphql.Field{
+				Type: graphql.Boolean,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return optValue(p.Source.(*domain.User).IsAdmin.Get()), nil
+				},
+			},
+			"mustChangePassword": &graphql.Field{
+				Type: graphql.Boolean,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return optValue(p.Source.(*domain.User).MustChangePassword.Get()), nil
+				},
+			},
+			"groups": &graphql.Field{
+				Type:        graphql.NewList(graphql.NewNonNull(group)),
+				Description: "Groups of the user, including the ones inherited through nested groups",
+				Resolve:     h.resolveUserGroups,
+			},
+		},
+	})
+
+	pageInfo := graphql.NewObject(graphql.ObjectConfig{
+		Name: "PageInfo",
+		Fields: graphql.Fields{
+			"hasNextPage": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"endCursor":   &graphql.Field{Type: graphql.String},
+		},
+	})
+
+	userEdge := graphql.NewObject(graphql.ObjectConfig{
+		Name: "UserEdge",
+		Fields: graphql.Fields{
+			"cursor": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"node":   &graphql.Field{Type: graphql.NewNonNull(user)},
+		},
+	})
+
+	userConnection := graphql.NewObject(graphql.ObjectConfig{
+		Name: "UserConnection",
+		Fields: graphql.Fields{
+			"edges":    &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(userEdge)))},
+			"pageInfo": &graphql.Field{Type: graphql.NewNonNull(pageInfo)},
+		},
+	})
+
+	userFilter := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "UserFilter",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"isAdmin": &graphql.InputObjectFieldConfig{Type: graphql.Boolean},
+			"search": &graphql.InputObjectFieldConfig{
+				Type:        graphql.String,
+				Description: "Case-insensitive substring of username or email",
+			},
+		},
+	})
+
+	userInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "UserInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"username": &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"password": &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"email":    &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"isAdmin":  &graphql.InputObjectFieldConfig{Type: graphql.Boolean},
+		},
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"user": &graphql.Field{
+				Type:        user,
+				Description: "User by id, null if it doesn't exist. Admin permission required",
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: h.resolveUser,
+			},
+			"users": &graphql.Field{
+				Type:        graphql.NewNonNull(userConnection),
+				Description: "Users of the organization ordered by id",
+				Args: graphql.FieldConfigArgument{
+					"filter": &graphql.ArgumentConfig{Type: userFilter},
+					"first": &graphql.ArgumentConfig{
+						Type:         graphql.Int,
+						DefaultValue: graphqlDefaultFirst,
+					},
+					"after": &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: h.resolveUsers,
+			},
+		},
+	})
+
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createUser": &graphql.Field{
+				Type:        graphql.NewNonNull(user),
+				Description: "Create a user, all fields must be provided. Admin permission required",
+				Args: graphql.FieldConfigArgument{
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(userInput)},
+				},
+				Resolve: h.resolveCreateUser,
+			},
+			"updateUser": &graphql.Field{
+				Type:        graphql.NewNonNull(user),
+				Description: "Update provided fields of the user. Admin permission required",
+				Args: graphql.FieldConfigArgument{
+					"id":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(userInput)},
+				},
+				Resolve: h.resolveUpdateUser,
+			},
+			"deleteUser": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.ID),
+				Description: "Delete the user, returns its id. Admin permission required",
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: h.resolveDeleteUser,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{
+		Query:    query,
+		Mutation: mutation,
+	})
+}
+
+// optValue returns nil for an unset value, so the field is null
+func optValue[T any](v T, ok bool) any {
+	if !ok {
+		return nil
+	}
+	return v
+}
+
+func userIDArg(p graphql.ResolveParams) (domain.UUID, error) {
+	id, err := uuid.Parse(p.Args["id"].(string))
+	if err != nil {
+		return domain.UUID{}, badUserInput(string(domain.ValidationErrorMessageBadParams))
+	}
+
+	return domain.UUID(id), nil
+}
+
+// userFromInput returns the user with fields present in the input
+func userFromInput(p graphql.ResolveParams) *domain.User {
+	input, _ := p.Args["input"].(map[string]any)
+
+	user := &domain.User{}
+	if username, ok := input["username"].(string); ok {
+		user.Username = domain.NewOptString(username)
+	}
+	if password, ok := input["password"].(string); ok {
+		user.Password = domain.NewOptString(password)
+	}
+	if email, ok := input["email"].(string); ok {
+		user.Email = domain.NewOptString(email)
+	}
+	if isAdmin, ok := input["isAdmin"].(bool); ok {
+		user.IsAdmin = domain.NewOptBool(isAdmin)
+	}
+
+	return user
+}
+
+func (h *graphqlHandler) resolveUser(p graphql.ResolveParams) (any, error) {
+	id, err := userIDArg(p)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := h.resolve(p, domain.ServiceGetUserOperation, func(ctx context.Context) (any, error) {
+		return h.srvs.ServiceGetUser(ctx, domain.ServiceGetUserParams{UserId: id})
+	})
+	var gqlErr *graphqlError
+	if errors.As(err, &gqlErr) && gqlErr.code == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (h *graphqlHandler) resolveUserGroups(p graphql.ResolveParams) (any, error) {
+	id := p.Source.(*domain.User).ID.Value
+
+	res, err := h.resolve(p, domain.ServiceGetUserGroupsOperation, func(ctx context.Context) (any, error) {
+		return h.srvs.ServiceGetUserGroups(ctx, domain.ServiceGetUserGroupsParams{UserId: id})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return []domain.Group(*res.(*domain.ServiceGetUserGroupsOKApplicationJSON)), nil
+}
+
+// cursor of the users connection is the offset of the user in the list
+func encodeCursor(offset int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("offset:" + strconv.FormatInt(offset, 10)))
+}
+
+func decodeCursor(cursor string) (int64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, err
+	}
+
+	value, ok := strings.CutPrefix(string(raw), "offset:")
+	if !ok {
+		return 0, errors.New("unknown cursor")
+	}
+	offset, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || offset < 0 {
+		return 0, errors.New("unknown cursor")
+	}
+
+	return offset, nil
+}
+
+func (h *graphqlHandler) resolveUsers(p graphql.ResolveParams) (any, error) {
+	first, _ := p.Args["first"].(int)
+	if first < 0 || first > graphqlMaxFirst {
+		return nil, badUserInput(fmt.Sprintf("first must be between 0 and %d", graphqlMaxFirst))
+	}
+
+	var start int64
+	if after, ok := p.Args["after"].(string); ok {
+		offset, err := decodeCursor(after)
+		if err != nil {
+			return nil, badUserInput("invalid cursor")
+		}
+		start = offset + 1
+	}
+
+	params := domain.ServiceListUsersParams{}
+	if filter, ok := p.Args["filter"].(map[string]any); ok {
+		if isAdmin, ok := filter["isAdmin"].(bool); ok {
+			params.IsAdmin = domain.NewOptBool(isAdmin)
+		}
+		if search, ok := filter["search"].(string); ok {
+			params.Search = domain.NewOptString(search)
+		}
+	}
+
+	// pages of the list are read until one more user than requested, it tells there is the next page
+	res, err := h.resolve(p, domain.ServiceListUsersOperation, func(ctx context.Context) (any, error) {
+		users := domain.ServiceListUsersOKApplicationJSON{}
+		for len(users) <= first {
+			params.Offset = domain.NewOptInt64(start + int64(len(users)))
+			res, err := h.srvs.ServiceListUsers(ctx, params)
+			if err != nil {
+				return nil, err
+			}
+			page, ok := res.(*domain.ServiceListUsersOKApplicationJSON)
+			if !ok {
+				return res, nil
+			}
+			if len(*page) == 0 {
+				break
+			}
+			users = append(users, *page...)
+		}
+		return &users, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	users := *res.(*domain.ServiceListUsersOKApplicationJSON)
+	hasNextPage := len(users) > first
+	users = users[:min(first, len(users))]
+
+	edges := make([]map[string]any, 0, len(users))
+	for i := range users {
+		edges = append(edges, map[string]any{
+			"cursor": encodeCursor(start + int64(i)),
+			"node":   &users[i],
+		})
+	}
+
+	pageInfo := map[string]any{"hasNextPage": hasNextPage}
+	if len(edges) != 0 {
+		pageInfo["endCursor"] = edges[len(edges)-1]["cursor"]
+	}
+
+	return map[string]any{
+		"edges":    edges,
+		"pageInfo": pageInfo,
+	}, nil
+}
+
+func (h *graphqlHandler) resolveCreateUser(p graphql.ResolveParams) (any, error) {
+	user := userFromInput(p)
+
+	return h.resolve(p, domain.ServiceCreateUserOperation, func(ctx context.Context) (any, error) {
+		return h.srvs.ServiceCreateUser(ctx, user)
+	})
+}
+
+func (h *graphqlHandler) resolveUpdateUser(p graphql.ResolveParams) (any, error) {
+	id, err := userIDArg(p)
+	if err != nil {
+		return nil, err
+	}
+	user := userFromInput(p)
+
+	return h.resolve(p, domain.ServicePatchUserOperation, func(ctx context.Context) (any, error) {
+		res, err := h.srvs.ServicePatchUser(ctx, user, domain.ServicePatchUserParams{UserId: id})
+		if err != nil {
+			return nil, err
+		}
+		if _, _, ok := errorResponse(res); ok {
+			return res, nil
+		}
+
+		// a replica may not have the change yet
+		return h.srvs.ServiceGetUser(consistency.WithPrimary(ctx), domain.ServiceGetUserParams{UserId: id})
+	})
+}
+
+func (h *graphqlHandler) resolveDeleteUser(p graphql.ResolveParams) (any, error) {
+	id, err := userIDArg(p)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = h.resolve(p, domain.ServiceDeleteUserOperation, func(ctx context.Context) (any, error) {
+		return h.srvs.ServiceDeleteUser(ctx, domain.ServiceDeleteUserParams{UserId: id})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return uuid.UUID(id).String(), nil
+}
+
+// checkLimits rejects documents with operations nested deeper than the max depth
+// or more complex than the max complexity. Every field costs 1, fields of a page
+// of the users connection cost as much as users in the page. Introspection isn't limited
+func (h *graphqlHandler) checkLimits(doc *ast.Document, variables map[string]any) error {
+	fragments := map[string]*ast.FragmentDefinition{}
+	for _, def := range doc.Definitions {
+		if fragment, ok := def.(*ast.FragmentDefinition); ok {
+			fragments[fragment.Name.Value] = fragment
+		}
+	}
+
+	for _, def := range doc.Definitions {
+		op, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+
+		l := &limits{fragments: fragments, variables: variables}
+		depth, complexity := l.measure(op.SelectionSet, map[string]bool{})
+		if depth > h.maxDepth {
+			return fmt.Errorf("query depth %d exceeds the limit of %d", depth, h.maxDepth)
+		}
+		if complexity > h.maxComplexity {
+			return fmt.Errorf("query complexity %d exceeds the limit of %d", complexity, h.maxComplexity)
+		}
+	}
+
+	return nil
+}
+
+type limits struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]any
+}
+
+// measure returns the depth and the complexity of the selection set, visited holds
+// fragments spread on the path to it, validation rejects cycles of fragments
+func (l *limits) measure(set *ast.SelectionSet, visited map[string]bool) (depth, complexity int) {
+	if set == nil {
+		return 0, 0
+	}
+
+	for _, selection := range set.Selections {
+		var d, c int
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(selection.Name.Value, "__") {
+				continue
+			}
+			d, c = l.measure(selection.SelectionSet, visited)
+			d, c = d+1, c*l.pageSize(selection)+1
+		case *ast.InlineFragment:
+			d, c = l.measure(selection.SelectionSet, visited)
+		case *ast.FragmentSpread:
+			name := selection.Name.Value
+			fragment, ok := l.fragments[name]
+			if !ok || visited[name] {
+				continue
+			}
+			visited[name] = true
+			d, c = l.measure(fragment.SelectionSet, visited)
+			delete(visited, name)
+		}
+
+		depth = max(depth, d)
+		complexity += c
+	}
+
+	return depth, complexity
+}
+
+// pageSize returns how many times fields of the selection of the field are resolved
+func (l *limits) pageSize(field *ast.Field) int {
+	if field.Name.Value != "users" {
+		return 1
+	}
+
+	for _, arg := range field.Arguments {
+		if arg.Name.Value != "first" {
+			continue
+		}
+
+		switch value := arg.Value.(type) {
+		case *ast.IntValue:
+			if first, err := strconv.Atoi(value.Value); err == nil {
+				return max(first, 1)
+			}
+		case *ast.Variable:
+			// variables are decoded from json
+			if first, ok := l.variables[value.Name.Value].(float64); ok {
+				return max(int(first), 1)
+			}
+		}
+		return graphqlMaxFirst
+	}
+
+	return graphqlDefaultFirst
+}
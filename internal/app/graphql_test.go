@@ -0,0 +1,189 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/liriquew/test_task/internal/lib/config"
+	"github.com/stretchr/testify/require"
+)
+
+type graphqlResponse struct {
+	Data   map[string]any `json:"data"`
+	Errors []struct {
+		Message    string         `json:"message"`
+		Extensions map[string]any `json:"extensions"`
+	} `json:"errors"`
+}
+
+// graphqlClient posts queries to the handler with the credentials
+type graphqlClient struct {
+	t                  *testing.T
+	handler            http.Handler
+	username, password string
+}
+
+func startGraphQL(t *testing.T, cfg config.GraphQLConfig) *graphqlHandler {
+	t.Helper()
+
+	srvs, ops := newTestAPI(t)
+	h, err := newGraphQLHandler(srvs, ops, cfg)
+	require.NoError(t, err)
+
+	return h
+}
+
+func (c graphqlClient) do(query string, variables map[string]any) (int, graphqlResponse) {
+	c.t.Helper()
+
+	body, err := json.Marshal(graphqlRequest{Query: query, Variables: variables})
+	require.NoError(c.t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body))
+	req.SetBasicAuth(c.username, c.password)
+	rec := httptest.NewRecorder()
+	c.handler.ServeHTTP(rec, req)
+
+	var res graphqlResponse
+	require.NoError(c.t, json.Unmarshal(rec.Body.Bytes(), &res))
+
+	return rec.Code, res
+}
+
+func TestGraphQL(t *testing.T) {
+	h := startGraphQL(t, config.GraphQLConfig{})
+	admin := graphqlClient{t: t, handler: h, username: "administrator", password: "Passw0rd1"}
+
+	t.Run("Unauthenticated", func(t *testing.T) {
+		code, res := graphqlClient{t: t, handler: h, username: "administrator", password: "wrong"}.
+			do(`{ users { edges { cursor } } }`, nil)
+		require.Equal(t, http.StatusUnauthorized, code)
+		require.Equal(t, "UNAUTHENTICATED", res.Errors[0].Extensions["code"])
+	})
+
+	t.Run("Users", func(t *testing.T) {
+		admin := admin
+		admin.t = t
+
+		code, res := admin.do(`mutation($input: UserInput!) {
+			createUser(input: $input) { id username isAdmin }
+		}`, map[string]any{"input": map[string]any{
+			"username": "username001",
+			"password": "Passw0rd2",
+			"email":    "usernameone@mail.ru",
+			"isAdmin":  false,
+		}})
+		require.Equal(t, http.StatusOK, code)
+		require.Empty(t, res.Errors)
+		created := res.Data["createUser"].(map[string]any)
+		require.Equal(t, "username001", created["username"])
+		id := created["id"].(string)
+
+		code, res = admin.do(`mutation($id: ID!) {
+			updateUser(id: $id, input: {email: "usernameone@gmail.com"}) { email }
+		}`, map[string]any{"id": id})
+		require.Equal(t, http.StatusOK, code)
+		require.Empty(t, res.Errors)
+		require.Equal(t, "usernameone@gmail.com", res.Data["updateUser"].(map[string]any)["email"])
+
+		// the user and its groups in one round trip
+		_, res = admin.do(`query($id: ID!) { user(id: $id) { username groups { name } } }`, map[string]any{"id": id})
+		require.Empty(t, res.Errors)
+		require.Equal(t, map[string]any{
+			"username": "username001",
+			"groups":   []any{},
+		}, res.Data["user"])
+
+		code, res = admin.do(`mutation($id: ID!) { deleteUser(id: $id) }`, map[string]any{"id": id})
+		require.Equal(t, http.StatusOK, code)
+		require.Empty(t, res.Errors)
+		require.Equal(t, id, res.Data["deleteUser"])
+
+		_, res = admin.do(`query($id: ID!) { user(id: $id) { id } }`, map[string]any{"id": id})
+		require.Empty(t, res.Errors)
+		require.Nil(t, res.Data["user"])
+	})
+
+	t.Run("Connection", func(t *testing.T) {
+		admin := admin
+		admin.t = t
+
+		query := `query($after: String) {
+			users(first: 1, after: $after) {
+				edges { cursor node { username } }
+				pageInfo { hasNextPage endCursor }
+			}
+		}`
+
+		var usernames []string
+		var after any
+		for {
+			_, res := admin.do(query, map[string]any{"after": after})
+			require.Empty(t, res.Errors)
+
+			users := res.Data["users"].(map[string]any)
+			edges := users["edges"].([]any)
+			require.Len(t, edges, 1)
+			node := edges[0].(map[string]any)["node"].(map[string]any)
+			usernames = append(usernames, node["username"].(string))
+
+			pageInfo := users["pageInfo"].(map[string]any)
+			if !pageInfo["hasNextPage"].(bool) {
+				break
+			}
+			after = pageInfo["endCursor"]
+		}
+		require.ElementsMatch(t, []string{"administrator", "username000"}, usernames)
+
+		_, res := admin.do(`{ users(filter: {search: "username"}) { edges { node { username } } } }`, nil)
+		require.Empty(t, res.Errors)
+		require.Len(t, res.Data["users"].(map[string]any)["edges"], 1)
+
+		_, res = admin.do(`{ users(after: "cursor") { edges { cursor } } }`, nil)
+		require.Equal(t, "BAD_USER_INPUT", res.Errors[0].Extensions["code"])
+	})
+
+	t.Run("Forbidden", func(t *testing.T) {
+		user := graphqlClient{t: t, handler: h, username: "username000", password: "Passw0rd1"}
+
+		// users are listed to everyone, their groups to admins only
+		code, res := user.do(`{ users { edges { node { username groups { name } } } } }`, nil)
+		require.Equal(t, http.StatusOK, code)
+		require.NotEmpty(t, res.Errors)
+		require.Equal(t, "FORBIDDEN", res.Errors[0].Extensions["code"])
+
+		_, res = user.do(`mutation { deleteUser(id: "019763a9-7fc4-7e1a-9756-41c2ec1b9980") }`, nil)
+		require.Equal(t, "FORBIDDEN", res.Errors[0].Extensions["code"])
+	})
+}
+
+func TestGraphQLLimits(t *testing.T) {
+	h := startGraphQL(t, config.GraphQLConfig{MaxDepth: 4, MaxComplexity: 50, MaxBodySize: 512})
+	admin := graphqlClient{t: t, handler: h, username: "administrator", password: "Passw0rd1"}
+
+	code, res := admin.do(`{ users { edges { node { groups { name } } } } }`, nil)
+	require.Equal(t, http.StatusBadRequest, code)
+	require.Equal(t, "query depth 5 exceeds the limit of 4", res.Errors[0].Message)
+
+	// fragments count as their fields
+	code, res = admin.do(`
+		query($first: Int) { users(first: $first) { edges { ...edge } } }
+		fragment edge on UserEdge { cursor node { id username email } }
+	`, map[string]any{"first": 20})
+	require.Equal(t, http.StatusBadRequest, code)
+	require.Equal(t, "query complexity 121 exceeds the limit of 50", res.Errors[0].Message)
+
+	code, res = admin.do(`{ users(first: 5) { edges { cursor node { id } } } }`, nil)
+	require.Equal(t, http.StatusOK, code)
+	require.Empty(t, res.Errors)
+
+	// the body is read up to the limit
+	code, res = admin.do(`{ users { edges { cursor } } }`, map[string]any{"padding": strings.Repeat("a", 512)})
+	require.Equal(t, http.StatusRequestEntityTooLarge, code)
+	require.Equal(t, "request body too large", res.Errors[0].Message)
+	require.Equal(t, "PAYLOAD_TOO_LARGE", res.Errors[0].Extensions["code"])
+}
@@ -0,0 +1,357 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+
+	"github.com/google/uuid"
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/domain/pb"
+	"github.com/liriquew/test_task/internal/ratelimit"
+	"github.com/liriquew/test_task/internal/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// grpcServer serves the gRPC API of spec/user_service.proto with the standard health
+// and reflection services
+type grpcServer struct {
+	srv    *grpc.Server
+	health *health.Server
+	addr   string
+}
+
+// newGRPCServer serves users with the business logic of the REST API
+func newGRPCServer(srvs *service.Service, ops *operations, addr string) *grpcServer {
+	srv := grpc.NewServer()
+	pb.RegisterUserServiceServer(srv, &grpcUsers{
+		srvs: srvs,
+		ops:  ops,
+	})
+
+	healthSrv := health.NewServer()
+	healthSrv.SetServingStatus(pb.UserService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(srv, healthSrv)
+
+	reflection.Register(srv)
+
+	return &grpcServer{
+		srv:    srv,
+		health: healthSrv,
+		addr:   addr,
+	}
+}
+
+func (s *grpcServer) run() {
+	lis, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		panic(err)
+	}
+
+	if err := s.srv.Serve(lis); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		panic(err)
+	}
+}
+
+// stop reports the server as not serving and waits for the running calls until ctx is done,
+// the calls left are cancelled
+func (s *grpcServer) stop(ctx context.Context) error {
+	s.health.Shutdown()
+
+	stopped := make(chan struct{})
+	go func() {
+		s.srv.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.srv.Stop()
+		return ctx.Err()
+	}
+}
+
+// grpcUsers implements the UserService of the gRPC API with handlers of the REST API
+type grpcUsers struct {
+	pb.UnimplementedUserServiceServer
+
+	srvs *service.Service
+	ops  *operations
+}
+
+// metadata of the call passed as headers of the REST API
+var grpcHeaders = []string{
+	"Authorization",
+	service.TenantHeader,
+	service.ReadYourWritesHeader,
+}
+
+// status codes of error responses of the REST API
+var grpcCodes = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusConflict:            codes.AlreadyExists,
+	http.StatusTooManyRequests:     codes.ResourceExhausted,
+	http.StatusInternalServerError: codes.Internal,
+}
+
+// grpcCode returns the status code of the error response, conflicts which aren't
+// duplicates are mapped by the type of the response
+func grpcCode(res any, code int) codes.Code {
+	switch res := res.(type) {
+	case *domain.LastAdminResponse:
+		return codes.FailedPrecondition
+	case *domain.ServicePatchUserConflict:
+		switch res.Type {
+		case domain.LastAdminResponseServicePatchUserConflict:
+			return codes.FailedPrecondition
+		case domain.PatchTestFailedResponseServicePatchUserConflict:
+			return codes.Aborted
+		}
+	}
+
+	return grpcCodes[code]
+}
+
+// call authenticates the call by its authorization metadata and runs handle as
+// the operation of the REST API. Error responses are returned as status errors
+func (u *grpcUsers) call(
+	ctx context.Context,
+	operation domain.OperationName,
+	handle func(ctx context.Context) (any, error),
+) (any, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	header := http.Header{}
+	for _, key := range grpcHeaders {
+		for _, value := range md.Get(key) {
+			header.Add(key, value)
+		}
+	}
+
+	// failed authentications are limited by the address of the peer
+	if p, ok := peer.FromContext(ctx); ok {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			ctx = ratelimit.WithClientIP(ctx, host)
+		}
+	}
+
+	ctx, err := u.ops.authenticate(ctx, operation, header)
+	if err != nil {
+		if errors.Is(err, service.ErrUnauthorized) {
+			return nil, status.Error(codes.Unauthenticated, "unauthorized")
+		}
+		if errors.As(err, new(*ratelimit.Error)) {
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "internal error: %s", err)
+	}
+
+	method, _ := grpc.Method(ctx)
+	res, err := u.ops.run(ctx, operation, method, header, handle)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "internal error: %s", err)
+	}
+
+	if code, message, ok := errorResponse(res); ok {
+		return nil, status.Error(grpcCode(res, code), message)
+	}
+	return res, nil
+}
+
+func (u *grpcUsers) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	params := domain.ServiceListUsersParams{}
+	if req.Offset != nil {
+		params.Offset = domain.NewOptInt64(req.GetOffset())
+	}
+	if req.IsAdmin != nil {
+		params.IsAdmin = domain.NewOptBool(req.GetIsAdmin())
+	}
+	if req.Search != nil {
+		params.Search = domain.NewOptString(req.GetSearch())
+	}
+
+	res, err := u.call(ctx, domain.ServiceListUsersOperation, func(ctx context.Context) (any, error) {
+		return u.srvs.ServiceListUsers(ctx, params)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	users := *res.(*domain.ServiceListUsersOKApplicationJSON)
+	out := &pb.ListUsersResponse{Users: make([]*pb.User, 0, len(users))}
+	for i := range users {
+		out.Users = append(out.Users, userToProto(&users[i]))
+	}
+
+	return out, nil
+}
+
+func (u *grpcUsers) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.User, error) {
+	id, err := parseUserID(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := u.call(ctx, domain.ServiceGetUserOperation, func(ctx context.Context) (any, error) {
+		return u.srvs.ServiceGetUser(ctx, domain.ServiceGetUserParams{UserId: id})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return userToProto(res.(*domain.User)), nil
+}
+
+func (u *grpcUsers) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.User, error) {
+	user, err := userFromProto(req.GetUser())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := u.call(ctx, domain.ServiceCreateUserOperation, func(ctx context.Context) (any, error) {
+		return u.srvs.ServiceCreateUser(ctx, user)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return userToProto(res.(*domain.User)), nil
+}
+
+func (u *grpcUsers) PatchUser(ctx context.Context, req *pb.PatchUserRequest) (*emptypb.Empty, error) {
+	id, err := parseUserID(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	user, err := userFromProto(req.GetUser())
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = u.call(ctx, domain.ServicePatchUserOperation, func(ctx context.Context) (any, error) {
+		return u.srvs.ServicePatchUser(ctx, user, domain.ServicePatchUserParams{UserId: id})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (u *grpcUsers) PutUser(ctx context.Context, req *pb.PutUserRequest) (*emptypb.Empty, error) {
+	id, err := parseUserID(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	user, err := userFromProto(req.GetUser())
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = u.call(ctx, domain.ServicePutUserOperation, func(ctx context.Context) (any, error) {
+		return u.srvs.ServicePutUser(ctx, user, domain.ServicePutUserParams{UserId: id})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (u *grpcUsers) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest) (*emptypb.Empty, error) {
+	id, err := parseUserID(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = u.call(ctx, domain.ServiceDeleteUserOperation, func(ctx context.Context) (any, error) {
+		return u.srvs.ServiceDeleteUser(ctx, domain.ServiceDeleteUserParams{UserId: id})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func parseUserID(id string) (domain.UUID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return domain.UUID{}, status.Error(codes.InvalidArgument, string(domain.ValidationErrorMessageBadParams))
+	}
+
+	return domain.UUID(parsed), nil
+}
+
+func userToProto(user *domain.User) *pb.User {
+	out := &pb.User{}
+	if user.ID.Set {
+		out.Id = proto.String(uuid.UUID(user.ID.Value).String())
+	}
+	if user.Username.Set {
+		out.Username = proto.String(user.Username.Value)
+	}
+	// passwords of listed users are cleared
+	if user.Password.Set && user.Password.Value != "" {
+		out.Password = proto.String(user.Password.Value)
+	}
+	if user.Email.Set {
+		out.Email = proto.String(user.Email.Value)
+	}
+	if user.IsAdmin.Set {
+		out.IsAdmin = proto.Bool(user.IsAdmin.Value)
+	}
+	if user.MustChangePassword.Set {
+		out.MustChangePassword = proto.Bool(user.MustChangePassword.Value)
+	}
+
+	return out
+}
+
+// userFromProto returns the user with fields set in the message, the same as fields
+// present in the body of the REST API
+func userFromProto(user *pb.User) (*domain.User, error) {
+	out := &domain.User{}
+	if user == nil {
+		return out, nil
+	}
+
+	if user.Id != nil {
+		id, err := parseUserID(user.GetId())
+		if err != nil {
+			return nil, err
+		}
+		out.ID = domain.NewOptUUID(id)
+	}
+	if user.Username != nil {
+		out.Username = domain.NewOptString(user.GetUsername())
+	}
+	if user.Password != nil {
+		out.Password = domain.NewOptString(user.GetPassword())
+	}
+	if user.Email != nil {
+		out.Email = domain.NewOptString(user.GetEmail())
+	}
+	if user.IsAdmin != nil {
+		out.IsAdmin = domain.NewOptBool(user.GetIsAdmin())
+	}
+	if user.MustChangePassword != nil {
+		out.MustChangePassword = domain.NewOptBool(user.GetMustChangePassword())
+	}
+
+	return out, nil
+}
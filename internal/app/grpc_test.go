@@ -0,0 +1,192 @@
+package app
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/domain/pb"
+	"github.com/liriquew/test_task/internal/lib/tenant"
+	"github.com/liriquew/test_task/internal/repository/memory"
+	"github.com/liriquew/test_task/internal/service"
+	"github.com/ogen-go/ogen/middleware"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+// newTestAPI returns the service of the memory storage with the administrator
+// and the user, both with the password "Passw0rd1"
+func newTestAPI(t *testing.T) (*service.Service, *operations) {
+	t.Helper()
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	repo := memory.New()
+	srvs := service.New(log, repo)
+	mdlwr := service.NewMiddleware(log, repo)
+
+	ctx := tenant.WithTenant(context.Background(), tenant.Default)
+	for _, user := range []*domain.User{
+		{
+			Username: domain.NewOptString("administrator"),
+			Password: domain.NewOptString("Passw0rd1"),
+			Email:    domain.NewOptString("administrator@mail.ru"),
+			IsAdmin:  domain.NewOptBool(true),
+		},
+		{
+			Username: domain.NewOptString("username000"),
+			Password: domain.NewOptString("Passw0rd1"),
+			Email:    domain.NewOptString("username@mail.ru"),
+		},
+	} {
+		res, err := srvs.ServiceCreateUser(ctx, user)
+		require.NoError(t, err)
+		require.IsType(t, &domain.User{}, res)
+	}
+
+	return srvs, newOperations(mdlwr, []middleware.Middleware{
+		service.ReadYourWrites(),
+		mdlwr.RequirePasswordChange(),
+		mdlwr.ResolveTenant(),
+		mdlwr.CheckAdminPermission(),
+	})
+}
+
+func startGRPC(t *testing.T) *grpc.ClientConn {
+	t.Helper()
+
+	srvs, ops := newTestAPI(t)
+	srv := newGRPCServer(srvs, ops, "")
+
+	lis := bufconn.Listen(1 << 20)
+	go srv.srv.Serve(lis)
+	t.Cleanup(srv.srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+func withBasicAuth(username, password string) context.Context {
+	credentials := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Basic "+credentials)
+}
+
+func requireCode(t *testing.T, code codes.Code, err error) {
+	t.Helper()
+
+	require.Error(t, err)
+	require.Equal(t, code, status.Code(err), err.Error())
+}
+
+func TestGRPC(t *testing.T) {
+	conn := startGRPC(t)
+	client := pb.NewUserServiceClient(conn)
+	admin := withBasicAuth("administrator", "Passw0rd1")
+
+	t.Run("Unauthenticated", func(t *testing.T) {
+		_, err := client.ListUsers(context.Background(), &pb.ListUsersRequest{})
+		requireCode(t, codes.Unauthenticated, err)
+
+		_, err = client.ListUsers(withBasicAuth("administrator", "wrong"), &pb.ListUsersRequest{})
+		requireCode(t, codes.Unauthenticated, err)
+	})
+
+	t.Run("Users", func(t *testing.T) {
+		created, err := client.CreateUser(admin, &pb.CreateUserRequest{User: &pb.User{
+			Username: proto.String("username001"),
+			Password: proto.String("Passw0rd2"),
+			Email:    proto.String("usernameone@mail.ru"),
+			IsAdmin:  proto.Bool(false),
+		}})
+		require.NoError(t, err)
+		require.NotEmpty(t, created.GetId())
+
+		_, err = client.PatchUser(admin, &pb.PatchUserRequest{
+			UserId: created.GetId(),
+			User:   &pb.User{Email: proto.String("usernameone@gmail.com")},
+		})
+		require.NoError(t, err)
+
+		user, err := client.GetUser(admin, &pb.GetUserRequest{UserId: created.GetId()})
+		require.NoError(t, err)
+		require.Equal(t, "username001", user.GetUsername())
+		require.Equal(t, "usernameone@gmail.com", user.GetEmail())
+
+		users, err := client.ListUsers(admin, &pb.ListUsersRequest{Search: proto.String("username001")})
+		require.NoError(t, err)
+		require.Len(t, users.GetUsers(), 1)
+		require.Nil(t, users.GetUsers()[0].Password)
+
+		_, err = client.DeleteUser(admin, &pb.DeleteUserRequest{UserId: created.GetId()})
+		require.NoError(t, err)
+
+		_, err = client.GetUser(admin, &pb.GetUserRequest{UserId: created.GetId()})
+		requireCode(t, codes.NotFound, err)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		_, err := client.GetUser(admin, &pb.GetUserRequest{UserId: "user"})
+		requireCode(t, codes.InvalidArgument, err)
+
+		_, err = client.CreateUser(admin, &pb.CreateUserRequest{User: &pb.User{
+			Username: proto.String("username000"),
+			Password: proto.String("Passw0rd1"),
+			Email:    proto.String("username2@mail.ru"),
+		}})
+		requireCode(t, codes.AlreadyExists, err)
+
+		admins, err := client.ListUsers(admin, &pb.ListUsersRequest{Search: proto.String("administrator")})
+		require.NoError(t, err)
+		require.Len(t, admins.GetUsers(), 1)
+
+		_, err = client.DeleteUser(admin, &pb.DeleteUserRequest{UserId: admins.GetUsers()[0].GetId()})
+		requireCode(t, codes.FailedPrecondition, err)
+
+		_, err = client.PatchUser(admin, &pb.PatchUserRequest{
+			UserId: admins.GetUsers()[0].GetId(),
+			User:   &pb.User{IsAdmin: proto.Bool(false)},
+		})
+		requireCode(t, codes.FailedPrecondition, err)
+
+		// only super-admins scope calls to other organizations
+		user := withBasicAuth("username000", "Passw0rd1")
+		_, err = client.ListUsers(
+			metadata.AppendToOutgoingContext(user, "x-tenant-id", "00000000-0000-0000-0000-000000000001"),
+			&pb.ListUsersRequest{},
+		)
+		requireCode(t, codes.PermissionDenied, err)
+
+		_, err = client.CreateUser(user, &pb.CreateUserRequest{User: &pb.User{
+			Username: proto.String("username003"),
+			Password: proto.String("Passw0rd1"),
+			Email:    proto.String("usernamethree@mail.ru"),
+		}})
+		requireCode(t, codes.PermissionDenied, err)
+	})
+
+	t.Run("Health", func(t *testing.T) {
+		res, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{
+			Service: pb.UserService_ServiceDesc.ServiceName,
+		})
+		require.NoError(t, err)
+		require.Equal(t, healthpb.HealthCheckResponse_SERVING, res.GetStatus())
+	})
+}
@@ -0,0 +1,31 @@
+package app
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// newMetrics makes the global meter provider export instruments to the returned handler,
+// instruments created before it are exported too. shutdown stops the provider
+func newMetrics() (handler http.Handler, shutdown func() error, err error) {
+	registry := prometheus.NewRegistry()
+	exporter, err := otelprometheus.New(otelprometheus.WithRegisterer(registry))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(exporter))
+	otel.SetMeterProvider(provider)
+
+	shutdown = func() error {
+		return provider.Shutdown(context.Background())
+	}
+
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{}), shutdown, nil
+}
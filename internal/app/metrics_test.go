@@ -0,0 +1,27 @@
+package app
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+func TestMetrics(t *testing.T) {
+	// instruments created before the provider is set are exported too
+	counter, err := otel.GetMeterProvider().Meter("test").Int64Counter("test.requests")
+	require.NoError(t, err)
+
+	handler, shutdown, err := newMetrics()
+	require.NoError(t, err)
+	defer shutdown()
+
+	counter.Add(context.Background(), 2)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	require.Equal(t, 200, rec.Code)
+	require.Regexp(t, `test_requests_total\{[^}]*\} 2`, rec.Body.String())
+}
@@ -0,0 +1,95 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"text/tabwriter"
+	"time"
+
+	"github.com/liriquew/test_task/internal/lib/config"
+	"github.com/liriquew/test_task/internal/migrator"
+	"github.com/pressly/goose/v3"
+)
+
+// prepareSchema applies pending migrations when auto_migrate is enabled
+// and fails if the schema is still behind the binary
+func prepareSchema(ctx context.Context, log *slog.Logger, cfg config.StorageConfig) error {
+	m, err := migrator.New(cfg)
+	if err != nil {
+		if errors.Is(err, migrator.ErrNoMigrations) {
+			return nil
+		}
+		return err
+	}
+	defer m.Close()
+
+	if cfg.AutoMigrate {
+		results, err := m.Up(ctx)
+		if err != nil {
+			return fmt.Errorf("auto migrate: %w", err)
+		}
+		for _, res := range results {
+			log.Info("migration applied", slog.String("source", res.Source.Path), slog.Duration("duration", res.Duration))
+		}
+	}
+
+	return m.CheckSchema(ctx)
+}
+
+// Migrate runs a migrate subcommand (up, down, redo, status) against the storage
+func Migrate(ctx context.Context, log *slog.Logger, cfg config.StorageConfig, command string, out io.Writer) error {
+	m, err := migrator.New(cfg)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	var results []*goose.MigrationResult
+
+	switch command {
+	case "up":
+		results, err = m.Up(ctx)
+	case "down":
+		var res *goose.MigrationResult
+		if res, err = m.Down(ctx); res != nil {
+			results = append(results, res)
+		}
+	case "redo":
+		results, err = m.Redo(ctx)
+	case "status":
+		return printStatus(ctx, m, out)
+	default:
+		return fmt.Errorf("unknown migrate command %q, expected up, down, redo or status", command)
+	}
+
+	for _, res := range results {
+		log.Info("migration "+string(res.Direction), slog.String("source", res.Source.Path), slog.Duration("duration", res.Duration))
+	}
+	if len(results) == 0 && err == nil {
+		log.Info("no migrations to run")
+	}
+
+	return err
+}
+
+func printStatus(ctx context.Context, m *migrator.Migrator, out io.Writer) error {
+	status, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "APPLIED AT\tMIGRATION")
+	for _, s := range status {
+		appliedAt := "pending"
+		if s.State == goose.StateApplied {
+			appliedAt = s.AppliedAt.Format(time.DateTime)
+		}
+		fmt.Fprintf(w, "%s\t%s\n", appliedAt, s.Source.Path)
+	}
+
+	return w.Flush()
+}
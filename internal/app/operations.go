@@ -0,0 +1,116 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/service"
+	"github.com/ogen-go/ogen/middleware"
+	"github.com/ogen-go/ogen/ogenerrors"
+)
+
+// operations runs handlers of the REST API for the gRPC and the GraphQL API,
+// so they share its authentication and middlewares
+type operations struct {
+	auth       domain.SecurityHandler
+	middleware middleware.Middleware
+}
+
+func newOperations(auth domain.SecurityHandler, middlewares []middleware.Middleware) *operations {
+	return &operations{
+		auth:       auth,
+		middleware: middleware.ChainMiddlewares(middlewares...),
+	}
+}
+
+// authenticate checks Basic credentials of the Authorization header,
+// service.ErrUnauthorized is returned if they are missing or wrong,
+// *ratelimit.Error if the client has failed too many times
+func (o *operations) authenticate(
+	ctx context.Context,
+	operation domain.OperationName,
+	header http.Header,
+) (context.Context, error) {
+	username, password, ok := (&http.Request{Header: header}).BasicAuth()
+	if !ok {
+		return ctx, service.ErrUnauthorized
+	}
+
+	return o.auth.HandleBasicAuth(ctx, operation, domain.BasicAuth{
+		Username: username,
+		Password: password,
+	})
+}
+
+// run runs handle as the operation of the REST API through its middlewares, ctx must be
+// authenticated. The middlewares read headers of the REST API from header. Rejected
+// parameters are returned as the validation error response, the error is internal
+func (o *operations) run(
+	ctx context.Context,
+	operation domain.OperationName,
+	operationID string,
+	header http.Header,
+	handle func(ctx context.Context) (any, error),
+) (any, error) {
+	res, err := o.middleware(
+		middleware.Request{
+			Context:       ctx,
+			OperationName: operation,
+			OperationID:   operationID,
+			Raw:           &http.Request{Header: header},
+		},
+		func(req middleware.Request) (middleware.Response, error) {
+			res, err := handle(req.Context)
+			return middleware.Response{Type: res}, err
+		},
+	)
+	if err != nil {
+		if errors.As(err, new(*ogenerrors.DecodeParamsError)) {
+			return &domain.ValidationErrorResponse{
+				Message: domain.ValidationErrorMessageBadParams,
+			}, nil
+		}
+		return nil, err
+	}
+
+	return res.Type, nil
+}
+
+// errorResponse returns the status code and the message of the error response
+// of the REST API, ok is false for other responses
+func errorResponse(res any) (code int, message string, ok bool) {
+	switch res := res.(type) {
+	case *domain.ValidationErrorResponse:
+		return http.StatusBadRequest, string(res.Message), true
+	case *domain.ForbiddenResponse:
+		message := string(res.Message)
+		if message == "" {
+			message = string(domain.ForbiddenResponseMessageForbiddenAdminPermissionRequired)
+		}
+		return http.StatusForbidden, message, true
+	case *domain.NotFoundResponse:
+		return http.StatusNotFound, string(res.Message), true
+	case *domain.AlreadyExistsResponse:
+		return http.StatusConflict, string(res.Message), true
+	case *domain.LastAdminResponse:
+		return http.StatusConflict, string(res.Message), true
+	case *domain.PatchTestFailedResponse:
+		return http.StatusConflict, string(res.Message), true
+	case *domain.ServicePatchUserConflict:
+		switch {
+		case res.IsLastAdminResponse():
+			return errorResponse(&res.LastAdminResponse)
+		case res.IsPatchTestFailedResponse():
+			return errorResponse(&res.PatchTestFailedResponse)
+		}
+		return errorResponse(&res.AlreadyExistsResponse)
+	case *domain.TooManyRequestsResponse:
+		return http.StatusTooManyRequests, string(res.Message), true
+	case *domain.InternalErrorResponse:
+		return http.StatusInternalServerError, string(res.Message), true
+	}
+
+	return 0, "", false
+}
@@ -0,0 +1,59 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/liriquew/test_task/internal/idempotency"
+	"github.com/liriquew/test_task/internal/lib/config"
+	"github.com/liriquew/test_task/internal/outbox"
+	"github.com/liriquew/test_task/internal/repository"
+	"github.com/liriquew/test_task/internal/repository/memory"
+	"github.com/liriquew/test_task/internal/repository/sqlite"
+	"github.com/liriquew/test_task/internal/service"
+	"github.com/liriquew/test_task/internal/watch"
+	"github.com/liriquew/test_task/internal/webhooks"
+)
+
+type storage interface {
+	service.Repository
+	outbox.Store
+	webhooks.Store
+	watch.Store
+	idempotency.Store
+	Close() error
+}
+
+// newStorage returns the repository of cfg.Driver
+func newStorage(ctx context.Context, cfg config.StorageConfig) (storage, error) {
+	switch cfg.Driver {
+	case config.DriverPostgres, "":
+		repo, err := repository.New(ctx, cfg)
+		if err != nil {
+			return nil, err
+		}
+		return postgres{repo}, nil
+	case config.DriverMemory:
+		return memory.New(), nil
+	case config.DriverSQLite:
+		return sqlite.New(ctx, cfg)
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", cfg.Driver)
+	}
+}
+
+// postgres adapts repository transactions to service.Repository,
+// repository package can't refer to the service interface
+type postgres struct {
+	*repository.Repository
+}
+
+func (s postgres) WithTx(
+	ctx context.Context,
+	fn func(service.Repository) error,
+	opts ...repository.TxOption,
+) error {
+	return s.Repository.WithTx(ctx, func(r *repository.Repository) error {
+		return fn(postgres{r})
+	}, opts...)
+}
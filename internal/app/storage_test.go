@@ -0,0 +1,32 @@
+package app
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/liriquew/test_task/internal/lib/config"
+	"github.com/liriquew/test_task/internal/repository/repotest"
+	"github.com/liriquew/test_task/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPostgresConformance needs a migrated database,
+// STORAGE_TEST_CONF is the path to the config with its connection
+func TestPostgresConformance(t *testing.T) {
+	path := os.Getenv("STORAGE_TEST_CONF")
+	if path == "" {
+		t.Skip("STORAGE_TEST_CONF is not set")
+	}
+
+	cfg := config.MustLoadPath(path)
+	cfg.Storage.Driver = config.DriverPostgres
+
+	repo, err := newStorage(context.Background(), cfg.Storage)
+	require.NoError(t, err)
+	t.Cleanup(func() { repo.Close() })
+
+	repotest.Run(t, func(t *testing.T) service.Repository {
+		return repo
+	})
+}
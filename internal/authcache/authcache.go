@@ -0,0 +1,214 @@
+// Package authcache caches successful authentications, so a request with the credentials
+// of a recent one skips the lookup of the user and the bcrypt comparison. Entries are
+// keyed by HMAC of the credentials with a key of the process, the password isn't kept.
+// Entries of a user are dropped when the user is changed through the repository of the
+// cache or when the change stream reads a change of the user made by another instance
+package authcache
+
+import (
+	"container/list"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/lib/config"
+	"github.com/liriquew/test_task/internal/repository"
+	"github.com/liriquew/test_task/internal/service"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const meterName = "github.com/liriquew/test_task/internal/authcache"
+
+type Cache struct {
+	ttl  time.Duration
+	size int
+	// key of HMAC of credentials
+	key []byte
+
+	hits   metric.Int64Counter
+	misses metric.Int64Counter
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	// lru is the list of entries, the most recently used first
+	lru *list.List
+	// gen is incremented by every invalidation, an authentication which started
+	// before an invalidation isn't cached, it may have read the state before the change
+	gen uint64
+}
+
+type entry struct {
+	key       string
+	principal service.Principal
+	isAdmin   bool
+	expires   time.Time
+}
+
+func New(cfg config.AuthCacheConfig) *Cache {
+	c := &Cache{
+		ttl:     cfg.TTL,
+		size:    cfg.Size,
+		key:     make([]byte, 32),
+		entries: map[string]*list.Element{},
+		lru:     list.New(),
+	}
+	if c.ttl <= 0 {
+		c.ttl = time.Minute
+	}
+	if c.size <= 0 {
+		c.size = 10000
+	}
+	// never returns an error
+	rand.Read(c.key)
+
+	// instruments of the global provider never fail, it exports them when metrics are enabled
+	meter := otel.GetMeterProvider().Meter(meterName)
+	c.hits, _ = meter.Int64Counter("auth_cache.hits",
+		metric.WithDescription("Authentications served from the cache"))
+	c.misses, _ = meter.Int64Counter("auth_cache.misses",
+		metric.WithDescription("Authentications that checked the credentials"))
+
+	return c
+}
+
+// Authenticator serves authentications of sec from the cache,
+// successful authentications of sec are cached
+func (c *Cache) Authenticator(sec domain.SecurityHandler) domain.SecurityHandler {
+	return &authenticator{cache: c, next: sec}
+}
+
+type authenticator struct {
+	cache *Cache
+	next  domain.SecurityHandler
+}
+
+func (a *authenticator) HandleBasicAuth(
+	ctx context.Context,
+	operationName domain.OperationName,
+	t domain.BasicAuth,
+) (context.Context, error) {
+	c := a.cache
+	key := c.hash(t.Username, t.Password)
+	if e, ok := c.get(key, time.Now()); ok {
+		c.hits.Add(ctx, 1)
+		return service.WithPrincipal(ctx, e.principal, e.isAdmin), nil
+	}
+	c.misses.Add(ctx, 1)
+
+	gen := c.generation()
+	authCtx, err := a.next.HandleBasicAuth(ctx, operationName, t)
+	if err != nil {
+		return authCtx, err
+	}
+
+	if principal, ok := authCtx.Value(service.CurrentUser{}).(service.Principal); ok {
+		isAdmin, _ := authCtx.Value(service.IsAdmin{}).(bool)
+		c.put(gen, entry{
+			key:       key,
+			principal: principal,
+			isAdmin:   isAdmin,
+			expires:   time.Now().Add(c.ttl),
+		})
+	}
+
+	return authCtx, nil
+}
+
+// Observe drops entries of users changed by the event, it's the listener
+// of the change stream
+func (c *Cache) Observe(event repository.OutboxEvent) {
+	if event.Type == repository.EventUserUpdated || event.Type == repository.EventUserDeleted {
+		c.InvalidateUser(domain.UUID(event.TenantID), domain.UUID(event.UserID))
+	}
+}
+
+// InvalidateUser drops entries of the user
+func (c *Cache) InvalidateUser(tenantID, userID domain.UUID) {
+	c.invalidate(func(e *entry) bool {
+		return e.principal.TenantID == tenantID && e.principal.ID == userID
+	})
+}
+
+// InvalidateTenant drops entries of users of the organization
+func (c *Cache) InvalidateTenant(tenantID domain.UUID) {
+	c.invalidate(func(e *entry) bool {
+		return e.principal.TenantID == tenantID
+	})
+}
+
+func (c *Cache) invalidate(match func(e *entry) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.gen++
+	for el := c.lru.Front(); el != nil; {
+		next := el.Next()
+		if e := el.Value.(*entry); match(e) {
+			c.remove(el)
+		}
+		el = next
+	}
+}
+
+func (c *Cache) hash(username, password string) string {
+	mac := hmac.New(sha256.New, c.key)
+	mac.Write([]byte(username))
+	mac.Write([]byte{0})
+	mac.Write([]byte(password))
+
+	return string(mac.Sum(nil))
+}
+
+func (c *Cache) generation() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.gen
+}
+
+func (c *Cache) get(key string, now time.Time) (entry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return entry{}, false
+	}
+	e := el.Value.(*entry)
+	if !now.Before(e.expires) {
+		c.remove(el)
+		return entry{}, false
+	}
+	c.lru.MoveToFront(el)
+
+	return *e, true
+}
+
+// put stores the entry unless the cache was invalidated after gen
+func (c *Cache) put(gen uint64, e entry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.gen != gen {
+		return
+	}
+	if el, ok := c.entries[e.key]; ok {
+		c.remove(el)
+	}
+	c.entries[e.key] = c.lru.PushFront(&e)
+
+	for c.lru.Len() > c.size {
+		c.remove(c.lru.Back())
+	}
+}
+
+// remove drops the entry of the list element, c.mu must be held
+func (c *Cache) remove(el *list.Element) {
+	delete(c.entries, el.Value.(*entry).key)
+	c.lru.Remove(el)
+}
@@ -0,0 +1,206 @@
+package authcache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/liriquew/test_task/internal/authcache"
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/lib/config"
+	"github.com/liriquew/test_task/internal/lib/tenant"
+	"github.com/liriquew/test_task/internal/repository"
+	"github.com/liriquew/test_task/internal/repository/memory"
+	"github.com/liriquew/test_task/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+// stubSecurity authenticates users by the password "Passw0rd1", the username is the id
+type stubSecurity struct {
+	calls int
+	// during is called during the authentication
+	during func()
+}
+
+func (s *stubSecurity) HandleBasicAuth(
+	ctx context.Context,
+	operationName domain.OperationName,
+	t domain.BasicAuth,
+) (context.Context, error) {
+	s.calls++
+	if s.during != nil {
+		s.during()
+	}
+	if t.Password != "Passw0rd1" {
+		return ctx, service.ErrUnauthorized
+	}
+
+	return service.WithPrincipal(ctx, service.Principal{
+		ID:       domain.UUID(uuid.MustParse(t.Username)),
+		TenantID: tenant.Default,
+	}, true), nil
+}
+
+func login(t *testing.T, sec domain.SecurityHandler, id domain.UUID, password string) (context.Context, error) {
+	t.Helper()
+
+	return sec.HandleBasicAuth(context.Background(), domain.ServiceListUsersOperation, domain.BasicAuth{
+		Username: uuid.UUID(id).String(),
+		Password: password,
+	})
+}
+
+func TestAuthenticator(t *testing.T) {
+	cache := authcache.New(config.AuthCacheConfig{TTL: time.Hour, Size: 2})
+	stub := &stubSecurity{}
+	sec := cache.Authenticator(stub)
+
+	t.Run("Hit", func(t *testing.T) {
+		_, err := login(t, sec, domain.UUID{1}, "Passw0rd1")
+		require.NoError(t, err)
+		calls := stub.calls
+
+		ctx, err := login(t, sec, domain.UUID{1}, "Passw0rd1")
+		require.NoError(t, err)
+		require.Equal(t, calls, stub.calls)
+		require.Equal(t, service.Principal{ID: domain.UUID{1}, TenantID: tenant.Default}, ctx.Value(service.CurrentUser{}))
+		require.Equal(t, true, ctx.Value(service.IsAdmin{}))
+		require.Equal(t, true, ctx.Value(service.IsSuperAdmin{}))
+		tenantID, ok := tenant.FromContext(ctx)
+		require.True(t, ok)
+		require.Equal(t, tenant.Default, tenantID)
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		calls := stub.calls
+		// failures aren't cached, another password isn't served by the entry
+		for range 2 {
+			_, err := login(t, sec, domain.UUID{1}, "wrong")
+			require.ErrorIs(t, err, service.ErrUnauthorized)
+		}
+		require.Equal(t, calls+2, stub.calls)
+	})
+
+	t.Run("Eviction", func(t *testing.T) {
+		for _, id := range []domain.UUID{{1}, {2}, {3}} {
+			_, err := login(t, sec, id, "Passw0rd1")
+			require.NoError(t, err)
+		}
+
+		// the least recently used entry is evicted
+		calls := stub.calls
+		login(t, sec, domain.UUID{3}, "Passw0rd1")
+		login(t, sec, domain.UUID{2}, "Passw0rd1")
+		require.Equal(t, calls, stub.calls)
+		login(t, sec, domain.UUID{1}, "Passw0rd1")
+		require.Equal(t, calls+1, stub.calls)
+	})
+
+	t.Run("Invalidation during authentication", func(t *testing.T) {
+		stub.during = func() { cache.InvalidateUser(tenant.Default, domain.UUID{9}) }
+
+		// the authentication may have read the state before the change
+		login(t, sec, domain.UUID{4}, "Passw0rd1")
+		calls := stub.calls
+		stub.during = nil
+		login(t, sec, domain.UUID{4}, "Passw0rd1")
+		require.Equal(t, calls+1, stub.calls)
+	})
+}
+
+func TestExpiration(t *testing.T) {
+	cache := authcache.New(config.AuthCacheConfig{TTL: 10 * time.Millisecond})
+	stub := &stubSecurity{}
+	sec := cache.Authenticator(stub)
+
+	login(t, sec, domain.UUID{1}, "Passw0rd1")
+	time.Sleep(20 * time.Millisecond)
+	login(t, sec, domain.UUID{1}, "Passw0rd1")
+	require.Equal(t, 2, stub.calls)
+}
+
+func TestRepository(t *testing.T) {
+	ctx := tenant.WithTenant(context.Background(), tenant.Default)
+	cache := authcache.New(config.AuthCacheConfig{})
+	stub := &stubSecurity{}
+	sec := cache.Authenticator(stub)
+	repo := cache.Wrap(memory.New())
+
+	id, err := repo.CreateUser(ctx, &domain.User{
+		Username: domain.NewOptString("username1"),
+		Password: domain.NewOptString("password"),
+		Email:    domain.NewOptString("username1@mail.ru"),
+	})
+	require.NoError(t, err)
+	groupID, err := repo.CreateGroup(ctx, &domain.Group{Name: domain.NewOptString("group1")})
+	require.NoError(t, err)
+
+	// cached asserts whether the next authentication of the user is served from the cache
+	cached := func(t *testing.T, id domain.UUID, expected bool) {
+		t.Helper()
+
+		calls := stub.calls
+		login(t, sec, id, "Passw0rd1")
+		require.Equal(t, expected, stub.calls == calls)
+	}
+
+	tests := []struct {
+		name   string
+		change func() error
+	}{
+		{
+			name: "Update",
+			change: func() error {
+				return repo.UpdateUser(ctx, &domain.User{ID: domain.NewOptUUID(*id), Password: domain.NewOptString("other")})
+			},
+		},
+		{
+			name: "Update in transaction",
+			change: func() error {
+				return repo.WithTx(ctx, func(tx service.Repository) error {
+					return tx.UpdateUser(ctx, &domain.User{ID: domain.NewOptUUID(*id), IsAdmin: domain.NewOptBool(true)})
+				})
+			},
+		},
+		{
+			name: "Group member",
+			change: func() error {
+				return repo.AddGroupMember(ctx, *groupID, &domain.GroupMember{UserID: domain.NewOptUUID(*id)})
+			},
+		},
+		{
+			name: "Change of another instance",
+			change: func() error {
+				cache.Observe(repository.OutboxEvent{
+					Type:     repository.EventUserUpdated,
+					TenantID: uuid.UUID(tenant.Default),
+					UserID:   uuid.UUID(*id),
+				})
+				return nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			login(t, sec, *id, "Passw0rd1")
+			cached(t, *id, true)
+
+			require.NoError(t, tt.change())
+			cached(t, *id, false)
+		})
+	}
+
+	t.Run("Other users", func(t *testing.T) {
+		login(t, sec, domain.UUID{1}, "Passw0rd1")
+		require.NoError(t, repo.UpdateUser(ctx, &domain.User{ID: domain.NewOptUUID(*id), Password: domain.NewOptString("other")}))
+		cached(t, domain.UUID{1}, true)
+
+		// entries of other organizations are kept
+		require.NoError(t, repo.DeleteOrganization(ctx, domain.UUID{2}))
+		cached(t, domain.UUID{1}, true)
+		require.NoError(t, repo.DeleteOrganization(ctx, tenant.Default))
+		cached(t, domain.UUID{1}, false)
+	})
+}
@@ -0,0 +1,111 @@
+package authcache
+
+import (
+	"context"
+
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/lib/tenant"
+	"github.com/liriquew/test_task/internal/repository"
+	"github.com/liriquew/test_task/internal/service"
+)
+
+// Repository drops entries of the cache on changes made through it: entries of the user on
+// changes of the user, entries of the organization on changes of groups, which grant admin
+// permissions, and on imports. Entries are dropped after the change, a change in a
+// transaction drops them once more after the transaction, so an authentication which read
+// the state before the commit isn't cached
+type Repository struct {
+	service.Repository
+	cache *Cache
+	// invalidations of the transaction, nil outside of a transaction
+	pending *[]func()
+}
+
+// Wrap returns repo dropping entries of the cache on changes
+func (c *Cache) Wrap(repo service.Repository) *Repository {
+	return &Repository{Repository: repo, cache: c}
+}
+
+func (r *Repository) invalidate(fn func()) {
+	fn()
+	if r.pending != nil {
+		*r.pending = append(*r.pending, fn)
+	}
+}
+
+// invalidateUser drops entries of the user of the organization of ctx,
+// the storage writes nothing without the organization
+func (r *Repository) invalidateUser(ctx context.Context, id domain.UUID) {
+	if tenantID, ok := tenant.FromContext(ctx); ok {
+		r.invalidate(func() { r.cache.InvalidateUser(tenantID, id) })
+	}
+}
+
+// invalidateScope drops entries of the organization of ctx
+func (r *Repository) invalidateScope(ctx context.Context) {
+	if tenantID, ok := tenant.FromContext(ctx); ok {
+		r.invalidateTenant(tenantID)
+	}
+}
+
+func (r *Repository) invalidateTenant(tenantID domain.UUID) {
+	r.invalidate(func() { r.cache.InvalidateTenant(tenantID) })
+}
+
+func (r *Repository) UpdateUser(ctx context.Context, u *domain.User) error {
+	defer r.invalidateUser(ctx, u.ID.Value)
+	return r.Repository.UpdateUser(ctx, u)
+}
+
+func (r *Repository) DeleteUser(ctx context.Context, id domain.UUID) error {
+	defer r.invalidateUser(ctx, id)
+	return r.Repository.DeleteUser(ctx, id)
+}
+
+func (r *Repository) ImportUsers(
+	ctx context.Context,
+	users []domain.User,
+	onConflict domain.ServiceImportUsersOnConflict,
+) ([]domain.ImportRowResult, error) {
+	defer r.invalidateScope(ctx)
+	return r.Repository.ImportUsers(ctx, users, onConflict)
+}
+
+func (r *Repository) UpdateGroup(ctx context.Context, g *domain.Group) error {
+	defer r.invalidateScope(ctx)
+	return r.Repository.UpdateGroup(ctx, g)
+}
+
+func (r *Repository) DeleteGroup(ctx context.Context, id domain.UUID) error {
+	defer r.invalidateScope(ctx)
+	return r.Repository.DeleteGroup(ctx, id)
+}
+
+func (r *Repository) AddGroupMember(ctx context.Context, groupID domain.UUID, member *domain.GroupMember) error {
+	defer r.invalidateScope(ctx)
+	return r.Repository.AddGroupMember(ctx, groupID, member)
+}
+
+func (r *Repository) RemoveGroupMember(ctx context.Context, groupID, memberID domain.UUID) error {
+	defer r.invalidateScope(ctx)
+	return r.Repository.RemoveGroupMember(ctx, groupID, memberID)
+}
+
+func (r *Repository) DeleteOrganization(ctx context.Context, id domain.UUID) error {
+	defer r.invalidateTenant(id)
+	return r.Repository.DeleteOrganization(ctx, id)
+}
+
+func (r *Repository) WithTx(ctx context.Context, fn func(service.Repository) error, opts ...repository.TxOption) error {
+	var pending []func()
+	defer func() {
+		for _, invalidate := range pending {
+			invalidate()
+		}
+	}()
+
+	return r.Repository.WithTx(ctx, func(tx service.Repository) error {
+		// fn is retried, invalidations of failed attempts are kept
+		return fn(&Repository{Repository: tx, cache: r.cache, pending: &pending})
+	}, opts...)
+}
@@ -30,10 +30,118 @@ func trimTrailingSlashes(u *url.URL) {
 
 // Invoker invokes operations described by OpenAPI v3 specification.
 type Invoker interface {
+	// ChangePassword invokes changePassword operation.
+	//
+	// Change the password of the authenticated user
+	// - password is validated like in createUser
+	// - new password must differ from the current one
+	// - clears must_change_password
+	// - allowed for every user.
+	//
+	// POST /me/password
+	ChangePassword(ctx context.Context, request *PasswordChange) (ChangePasswordRes, error)
+	// GroupsAddGroupMember invokes Groups_addGroupMember operation.
+	//
+	// Add a user or a nested group to the group
+	// - exactly one of user_id, group_id must be provided, 400 otherwise
+	// - membership cycles are rejected with 400
+	// - admin permission required.
+	//
+	// POST /groups/{groupId}/members
+	GroupsAddGroupMember(ctx context.Context, request *GroupMember, params GroupsAddGroupMemberParams) (GroupsAddGroupMemberRes, error)
+	// GroupsCreateGroup invokes Groups_createGroup operation.
+	//
+	// Create a group
+	// - name must be provided, 400 otherwise
+	// - admin permission required.
+	//
+	// POST /groups/
+	GroupsCreateGroup(ctx context.Context, request *Group) (GroupsCreateGroupRes, error)
+	// GroupsDeleteGroup invokes Groups_deleteGroup operation.
+	//
+	// Delete Group, memberships of the group are removed too
+	// - admin permission required.
+	//
+	// DELETE /groups/{groupId}
+	GroupsDeleteGroup(ctx context.Context, params GroupsDeleteGroupParams) (GroupsDeleteGroupRes, error)
+	// GroupsGetGroup invokes Groups_getGroup operation.
+	//
+	// Returns a Group if group with provided groupId exists, 404 otherwise.
+	//
+	// GET /groups/{groupId}
+	GroupsGetGroup(ctx context.Context, params GroupsGetGroupParams) (GroupsGetGroupRes, error)
+	// GroupsListGroupMembers invokes Groups_listGroupMembers operation.
+	//
+	// Returns direct members of the group
+	// - admin permission required.
+	//
+	// GET /groups/{groupId}/members
+	GroupsListGroupMembers(ctx context.Context, params GroupsListGroupMembersParams) (GroupsListGroupMembersRes, error)
+	// GroupsListGroups invokes Groups_listGroups operation.
+	//
+	// Returns a list of groups
+	// - admin permission required.
+	//
+	// GET /groups/
+	GroupsListGroups(ctx context.Context, params GroupsListGroupsParams) (GroupsListGroupsRes, error)
+	// GroupsPatchGroup invokes Groups_patchGroup operation.
+	//
+	// Patch Group
+	// - one of the fields must be provided, except id
+	// - admin permission required.
+	//
+	// PATCH /groups/{groupId}
+	GroupsPatchGroup(ctx context.Context, request *Group, params GroupsPatchGroupParams) (GroupsPatchGroupRes, error)
+	// GroupsRemoveGroupMember invokes Groups_removeGroupMember operation.
+	//
+	// Remove a user or a nested group from the group
+	// - admin permission required.
+	//
+	// DELETE /groups/{groupId}/members/{memberId}
+	GroupsRemoveGroupMember(ctx context.Context, params GroupsRemoveGroupMemberParams) (GroupsRemoveGroupMemberRes, error)
 	// Health invokes health operation.
 	//
 	// GET /health
 	Health(ctx context.Context) error
+	// OrganizationsCreateOrganization invokes Organizations_createOrganization operation.
+	//
+	// Create an organization
+	// - name must be provided, 400 otherwise
+	// - super admin permission required.
+	//
+	// POST /organizations/
+	OrganizationsCreateOrganization(ctx context.Context, request *Organization) (OrganizationsCreateOrganizationRes, error)
+	// OrganizationsDeleteOrganization invokes Organizations_deleteOrganization operation.
+	//
+	// Delete Organization with all its users and groups
+	// - the default organization can't be deleted, 400 otherwise
+	// - super admin permission required.
+	//
+	// DELETE /organizations/{organizationId}
+	OrganizationsDeleteOrganization(ctx context.Context, params OrganizationsDeleteOrganizationParams) (OrganizationsDeleteOrganizationRes, error)
+	// OrganizationsGetOrganization invokes Organizations_getOrganization operation.
+	//
+	// Returns an Organization if organization with provided organizationId exists, 404 otherwise.
+	//
+	// GET /organizations/{organizationId}
+	OrganizationsGetOrganization(ctx context.Context, params OrganizationsGetOrganizationParams) (OrganizationsGetOrganizationRes, error)
+	// OrganizationsListOrganizations invokes Organizations_listOrganizations operation.
+	//
+	// Returns a list of organizations
+	// - super admin permission required.
+	//
+	// GET /organizations/
+	OrganizationsListOrganizations(ctx context.Context, params OrganizationsListOrganizationsParams) (OrganizationsListOrganizationsRes, error)
+	// ServiceBatchUsers invokes Service_batchUsers operation.
+	//
+	// Execute create, patch, put and delete operations on users in a single request
+	// - every operation gets the status and body of the single user endpoint
+	// - with atomic=true operations run in a single transaction, the first failed
+	// operation rolls the batch back and the rest aren't executed (status 424)
+	// - admin permission required.
+	//
+	// POST /users:batch
+	ServiceBatchUsers(ctx context.Context, request []BatchOperation, params ServiceBatchUsersParams) (ServiceBatchUsersRes, error)
 	// ServiceCreateUser invokes Service_createUser operation.
 	//
 	// Create a user
@@ -45,16 +153,47 @@ type Invoker interface {
 	// ServiceDeleteUser invokes Service_deleteUser operation.
 	//
 	// Delete User
+	// - the last administrator of the organization can't be demoted or deleted, 409 otherwise
 	// - admin permission required.
 	//
 	// DELETE /users/{userId}
 	ServiceDeleteUser(ctx context.Context, params ServiceDeleteUserParams) (ServiceDeleteUserRes, error)
+	// ServiceExportUsers invokes Service_exportUsers operation.
+	//
+	// Export all users of the organization as a stream, password hashes are never exported
+	// - format is chosen by Accept: application/x-ndjson (default), text/csv or application/json (array)
+	// - every format is written while users are read, application/json is an array of users
+	// - users are ordered by id, pass the id of the last received user as cursor to resume
+	// - filters are the same as in listUsers
+	// - admin permission required.
+	//
+	// GET /users:export
+	ServiceExportUsers(ctx context.Context, params ServiceExportUsersParams) (ServiceExportUsersRes, error)
 	// ServiceGetUser invokes Service_getUser operation.
 	//
 	// Returns a User if user with provided userId exists, 404 otherwise.
 	//
 	// GET /users/{userId}
 	ServiceGetUser(ctx context.Context, params ServiceGetUserParams) (ServiceGetUserRes, error)
+	// ServiceGetUserGroups invokes Service_getUserGroups operation.
+	//
+	// Returns groups of the user, including groups inherited through nested groups
+	// - admin permission required.
+	//
+	// GET /users/{userId}/groups
+	ServiceGetUserGroups(ctx context.Context, params ServiceGetUserGroupsParams) (ServiceGetUserGroupsRes, error)
+	// ServiceImportUsers invokes Service_importUsers operation.
+	//
+	// Import users from CSV (header: username,email,password,is_admin)
+	// or NDJSON (one User per line) body, the body is processed as a stream
+	// - every row is validated like in createUser
+	// - on_conflict defines what to do with existing username or email, fail by default, fail stops at
+	// the first conflict and rolls back the whole import
+	// - dry_run validates rows without writing them, rows are checked against previous rows of the body
+	// - admin permission required.
+	//
+	// POST /users:import
+	ServiceImportUsers(ctx context.Context, request ServiceImportUsersReq, params ServiceImportUsersParams) (ServiceImportUsersRes, error)
 	// ServiceListUsers invokes Service_listUsers operation.
 	//
 	// Returns a list of all users.
@@ -64,19 +203,90 @@ type Invoker interface {
 	// ServicePatchUser invokes Service_patchUser operation.
 	//
 	// Patch User
-	// - one of the fields must be provided, except id
+	// - application/json: one of the fields must be provided, except id
+	// - application/merge-patch+json (RFC 7396): null clears the field
+	// - application/json-patch+json (RFC 6902): operations on the stored user without password,
+	// password can be added or replaced, failed test operation rejects the patch with 409
+	// - patches are applied to the stored user, changed fields are validated like application/json ones
+	// - the last administrator of the organization can't be demoted or deleted, 409 otherwise
 	// - admin permission required.
 	//
 	// PATCH /users/{userId}
-	ServicePatchUser(ctx context.Context, request *User, params ServicePatchUserParams) (ServicePatchUserRes, error)
+	ServicePatchUser(ctx context.Context, request ServicePatchUserReq, params ServicePatchUserParams) (ServicePatchUserRes, error)
 	// ServicePutUser invokes Service_putUser operation.
 	//
 	// Put a new User params
 	// - all fields must be provided, except id
+	// - the last administrator of the organization can't be demoted or deleted, 409 otherwise
 	// - admin permission required.
 	//
 	// PUT /users/{userId}
 	ServicePutUser(ctx context.Context, request *User, params ServicePutUserParams) (ServicePutUserRes, error)
+	// ServiceWatchUsers invokes Service_watchUsers operation.
+	//
+	// Stream changes of users of the organization as server-sent events
+	// - every event is user.created, user.updated or user.deleted with the outbox
+	// event as data, the id of the event is its id in the outbox
+	// - reconnect with Last-Event-ID to receive the events missed since it, the event
+	// log is bounded, a reset event means missed events are lost and the list must be reloaded
+	// - the stream is closed after a change of the password or deletion of the authenticated user
+	// - comments are sent as heartbeats while there are no changes
+	// - can be used by all users.
+	//
+	// GET /users:watch
+	ServiceWatchUsers(ctx context.Context, params ServiceWatchUsersParams) (ServiceWatchUsersRes, error)
+	// WebhooksCreateWebhook invokes Webhooks_createWebhook operation.
+	//
+	// Register a webhook
+	// - url must be provided, 400 otherwise
+	// - the secret is returned only in this response
+	// - admin permission required.
+	//
+	// POST /webhooks/
+	WebhooksCreateWebhook(ctx context.Context, request *Webhook) (WebhooksCreateWebhookRes, error)
+	// WebhooksDeleteWebhook invokes Webhooks_deleteWebhook operation.
+	//
+	// Delete Webhook with its delivery log
+	// - admin permission required.
+	//
+	// DELETE /webhooks/{webhookId}
+	WebhooksDeleteWebhook(ctx context.Context, params WebhooksDeleteWebhookParams) (WebhooksDeleteWebhookRes, error)
+	// WebhooksGetWebhook invokes Webhooks_getWebhook operation.
+	//
+	// Returns a Webhook if webhook with provided webhookId exists, 404 otherwise.
+	//
+	// GET /webhooks/{webhookId}
+	WebhooksGetWebhook(ctx context.Context, params WebhooksGetWebhookParams) (WebhooksGetWebhookRes, error)
+	// WebhooksListWebhookDeliveries invokes Webhooks_listWebhookDeliveries operation.
+	//
+	// Returns deliveries of the webhook, the latest events first
+	// - admin permission required.
+	//
+	// GET /webhooks/{webhookId}/deliveries
+	WebhooksListWebhookDeliveries(ctx context.Context, params WebhooksListWebhookDeliveriesParams) (WebhooksListWebhookDeliveriesRes, error)
+	// WebhooksListWebhooks invokes Webhooks_listWebhooks operation.
+	//
+	// Returns a list of webhooks, secrets are never returned
+	// - admin permission required.
+	//
+	// GET /webhooks/
+	WebhooksListWebhooks(ctx context.Context, params WebhooksListWebhooksParams) (WebhooksListWebhooksRes, error)
+	// WebhooksPatchWebhook invokes Webhooks_patchWebhook operation.
+	//
+	// Patch Webhook
+	// - one of url, events, secret, enabled must be provided
+	// - enabling a webhook resets its failures
+	// - admin permission required.
+	//
+	// PATCH /webhooks/{webhookId}
+	WebhooksPatchWebhook(ctx context.Context, request *Webhook, params WebhooksPatchWebhookParams) (WebhooksPatchWebhookRes, error)
+	// WebhooksRedeliverWebhookDelivery invokes Webhooks_redeliverWebhookDelivery operation.
+	//
+	// Queue the delivery again, attempts start over
+	// - admin permission required.
+	//
+	// POST /webhooks/{webhookId}/deliveries/{deliveryId}/redeliver
+	WebhooksRedeliverWebhookDelivery(ctx context.Context, params WebhooksRedeliverWebhookDeliveryParams) (WebhooksRedeliverWebhookDeliveryRes, error)
 }
 
 // Client implements OAS client.
@@ -86,57 +296,3426 @@ type Client struct {
 	baseClient
 }
 
-var _ Handler = struct {
-	*Client
-}{}
+var _ Handler = struct {
+	*Client
+}{}
+
+// NewClient initializes new Client defined by OAS.
+func NewClient(serverURL string, sec SecuritySource, opts ...ClientOption) (*Client, error) {
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return nil, err
+	}
+	trimTrailingSlashes(u)
+
+	c, err := newClientConfig(opts...).baseClient()
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		serverURL:  u,
+		sec:        sec,
+		baseClient: c,
+	}, nil
+}
+
+type serverURLKey struct{}
+
+// WithServerURL sets context key to override server URL.
+func WithServerURL(ctx context.Context, u *url.URL) context.Context {
+	return context.WithValue(ctx, serverURLKey{}, u)
+}
+
+func (c *Client) requestURL(ctx context.Context) *url.URL {
+	u, ok := ctx.Value(serverURLKey{}).(*url.URL)
+	if !ok {
+		return c.serverURL
+	}
+	return u
+}
+
+// ChangePassword invokes changePassword operation.
+//
+// Change the password of the authenticated user
+// - password is validated like in createUser
+// - new password must differ from the current one
+// - clears must_change_password
+// - allowed for every user.
+//
+// POST /me/password
+func (c *Client) ChangePassword(ctx context.Context, request *PasswordChange) (ChangePasswordRes, error) {
+	res, err := c.sendChangePassword(ctx, request)
+	return res, err
+}
+
+func (c *Client) sendChangePassword(ctx context.Context, request *PasswordChange) (res ChangePasswordRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("changePassword"),
+		semconv.HTTPRequestMethodKey.String("POST"),
+		semconv.HTTPRouteKey.String("/me/password"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, ChangePasswordOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/me/password"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeChangePasswordRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BasicAuth"
+			switch err := c.securityBasicAuth(ctx, ChangePasswordOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BasicAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeChangePasswordResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// GroupsAddGroupMember invokes Groups_addGroupMember operation.
+//
+// Add a user or a nested group to the group
+// - exactly one of user_id, group_id must be provided, 400 otherwise
+// - membership cycles are rejected with 400
+// - admin permission required.
+//
+// POST /groups/{groupId}/members
+func (c *Client) GroupsAddGroupMember(ctx context.Context, request *GroupMember, params GroupsAddGroupMemberParams) (GroupsAddGroupMemberRes, error) {
+	res, err := c.sendGroupsAddGroupMember(ctx, request, params)
+	return res, err
+}
+
+func (c *Client) sendGroupsAddGroupMember(ctx context.Context, request *GroupMember, params GroupsAddGroupMemberParams) (res GroupsAddGroupMemberRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("Groups_addGroupMember"),
+		semconv.HTTPRequestMethodKey.String("POST"),
+		semconv.HTTPRouteKey.String("/groups/{groupId}/members"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, GroupsAddGroupMemberOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [3]string
+	pathParts[0] = "/groups/"
+	{
+		// Encode "groupId" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "groupId",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			if unwrapped := uuid.UUID(params.GroupId); true {
+				return e.EncodeValue(conv.UUIDToString(unwrapped))
+			}
+			return nil
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[1] = encoded
+	}
+	pathParts[2] = "/members"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeGroupsAddGroupMemberRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BasicAuth"
+			switch err := c.securityBasicAuth(ctx, GroupsAddGroupMemberOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BasicAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeGroupsAddGroupMemberResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// GroupsCreateGroup invokes Groups_createGroup operation.
+//
+// Create a group
+// - name must be provided, 400 otherwise
+// - admin permission required.
+//
+// POST /groups/
+func (c *Client) GroupsCreateGroup(ctx context.Context, request *Group) (GroupsCreateGroupRes, error) {
+	res, err := c.sendGroupsCreateGroup(ctx, request)
+	return res, err
+}
+
+func (c *Client) sendGroupsCreateGroup(ctx context.Context, request *Group) (res GroupsCreateGroupRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("Groups_createGroup"),
+		semconv.HTTPRequestMethodKey.String("POST"),
+		semconv.HTTPRouteKey.String("/groups/"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, GroupsCreateGroupOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/groups/"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeGroupsCreateGroupRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BasicAuth"
+			switch err := c.securityBasicAuth(ctx, GroupsCreateGroupOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BasicAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeGroupsCreateGroupResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// GroupsDeleteGroup invokes Groups_deleteGroup operation.
+//
+// Delete Group, memberships of the group are removed too
+// - admin permission required.
+//
+// DELETE /groups/{groupId}
+func (c *Client) GroupsDeleteGroup(ctx context.Context, params GroupsDeleteGroupParams) (GroupsDeleteGroupRes, error) {
+	res, err := c.sendGroupsDeleteGroup(ctx, params)
+	return res, err
+}
+
+func (c *Client) sendGroupsDeleteGroup(ctx context.Context, params GroupsDeleteGroupParams) (res GroupsDeleteGroupRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("Groups_deleteGroup"),
+		semconv.HTTPRequestMethodKey.String("DELETE"),
+		semconv.HTTPRouteKey.String("/groups/{groupId}"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, GroupsDeleteGroupOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [2]string
+	pathParts[0] = "/groups/"
+	{
+		// Encode "groupId" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "groupId",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			if unwrapped := uuid.UUID(params.GroupId); true {
+				return e.EncodeValue(conv.UUIDToString(unwrapped))
+			}
+			return nil
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[1] = encoded
+	}
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "DELETE", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BasicAuth"
+			switch err := c.securityBasicAuth(ctx, GroupsDeleteGroupOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BasicAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeGroupsDeleteGroupResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// GroupsGetGroup invokes Groups_getGroup operation.
+//
+// Returns a Group if group with provided groupId exists, 404 otherwise.
+//
+// GET /groups/{groupId}
+func (c *Client) GroupsGetGroup(ctx context.Context, params GroupsGetGroupParams) (GroupsGetGroupRes, error) {
+	res, err := c.sendGroupsGetGroup(ctx, params)
+	return res, err
+}
+
+func (c *Client) sendGroupsGetGroup(ctx context.Context, params GroupsGetGroupParams) (res GroupsGetGroupRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("Groups_getGroup"),
+		semconv.HTTPRequestMethodKey.String("GET"),
+		semconv.HTTPRouteKey.String("/groups/{groupId}"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, GroupsGetGroupOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [2]string
+	pathParts[0] = "/groups/"
+	{
+		// Encode "groupId" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "groupId",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			if unwrapped := uuid.UUID(params.GroupId); true {
+				return e.EncodeValue(conv.UUIDToString(unwrapped))
+			}
+			return nil
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[1] = encoded
+	}
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BasicAuth"
+			switch err := c.securityBasicAuth(ctx, GroupsGetGroupOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BasicAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeGroupsGetGroupResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// GroupsListGroupMembers invokes Groups_listGroupMembers operation.
+//
+// Returns direct members of the group
+// - admin permission required.
+//
+// GET /groups/{groupId}/members
+func (c *Client) GroupsListGroupMembers(ctx context.Context, params GroupsListGroupMembersParams) (GroupsListGroupMembersRes, error) {
+	res, err := c.sendGroupsListGroupMembers(ctx, params)
+	return res, err
+}
+
+func (c *Client) sendGroupsListGroupMembers(ctx context.Context, params GroupsListGroupMembersParams) (res GroupsListGroupMembersRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("Groups_listGroupMembers"),
+		semconv.HTTPRequestMethodKey.String("GET"),
+		semconv.HTTPRouteKey.String("/groups/{groupId}/members"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, GroupsListGroupMembersOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [3]string
+	pathParts[0] = "/groups/"
+	{
+		// Encode "groupId" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "groupId",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			if unwrapped := uuid.UUID(params.GroupId); true {
+				return e.EncodeValue(conv.UUIDToString(unwrapped))
+			}
+			return nil
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[1] = encoded
+	}
+	pathParts[2] = "/members"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BasicAuth"
+			switch err := c.securityBasicAuth(ctx, GroupsListGroupMembersOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BasicAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeGroupsListGroupMembersResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// GroupsListGroups invokes Groups_listGroups operation.
+//
+// Returns a list of groups
+// - admin permission required.
+//
+// GET /groups/
+func (c *Client) GroupsListGroups(ctx context.Context, params GroupsListGroupsParams) (GroupsListGroupsRes, error) {
+	res, err := c.sendGroupsListGroups(ctx, params)
+	return res, err
+}
+
+func (c *Client) sendGroupsListGroups(ctx context.Context, params GroupsListGroupsParams) (res GroupsListGroupsRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("Groups_listGroups"),
+		semconv.HTTPRequestMethodKey.String("GET"),
+		semconv.HTTPRouteKey.String("/groups/"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, GroupsListGroupsOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/groups/"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeQueryParams"
+	q := uri.NewQueryEncoder()
+	{
+		// Encode "offset" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "offset",
+			Style:   uri.QueryStyleForm,
+			Explode: false,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.Offset.Get(); ok {
+				return e.EncodeValue(conv.Int64ToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	u.RawQuery = q.Values().Encode()
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BasicAuth"
+			switch err := c.securityBasicAuth(ctx, GroupsListGroupsOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BasicAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeGroupsListGroupsResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// GroupsPatchGroup invokes Groups_patchGroup operation.
+//
+// Patch Group
+// - one of the fields must be provided, except id
+// - admin permission required.
+//
+// PATCH /groups/{groupId}
+func (c *Client) GroupsPatchGroup(ctx context.Context, request *Group, params GroupsPatchGroupParams) (GroupsPatchGroupRes, error) {
+	res, err := c.sendGroupsPatchGroup(ctx, request, params)
+	return res, err
+}
+
+func (c *Client) sendGroupsPatchGroup(ctx context.Context, request *Group, params GroupsPatchGroupParams) (res GroupsPatchGroupRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("Groups_patchGroup"),
+		semconv.HTTPRequestMethodKey.String("PATCH"),
+		semconv.HTTPRouteKey.String("/groups/{groupId}"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, GroupsPatchGroupOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [2]string
+	pathParts[0] = "/groups/"
+	{
+		// Encode "groupId" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "groupId",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			if unwrapped := uuid.UUID(params.GroupId); true {
+				return e.EncodeValue(conv.UUIDToString(unwrapped))
+			}
+			return nil
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[1] = encoded
+	}
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "PATCH", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeGroupsPatchGroupRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BasicAuth"
+			switch err := c.securityBasicAuth(ctx, GroupsPatchGroupOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BasicAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeGroupsPatchGroupResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// GroupsRemoveGroupMember invokes Groups_removeGroupMember operation.
+//
+// Remove a user or a nested group from the group
+// - admin permission required.
+//
+// DELETE /groups/{groupId}/members/{memberId}
+func (c *Client) GroupsRemoveGroupMember(ctx context.Context, params GroupsRemoveGroupMemberParams) (GroupsRemoveGroupMemberRes, error) {
+	res, err := c.sendGroupsRemoveGroupMember(ctx, params)
+	return res, err
+}
+
+func (c *Client) sendGroupsRemoveGroupMember(ctx context.Context, params GroupsRemoveGroupMemberParams) (res GroupsRemoveGroupMemberRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("Groups_removeGroupMember"),
+		semconv.HTTPRequestMethodKey.String("DELETE"),
+		semconv.HTTPRouteKey.String("/groups/{groupId}/members/{memberId}"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, GroupsRemoveGroupMemberOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [4]string
+	pathParts[0] = "/groups/"
+	{
+		// Encode "groupId" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "groupId",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			if unwrapped := uuid.UUID(params.GroupId); true {
+				return e.EncodeValue(conv.UUIDToString(unwrapped))
+			}
+			return nil
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[1] = encoded
+	}
+	pathParts[2] = "/members/"
+	{
+		// Encode "memberId" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "memberId",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			if unwrapped := uuid.UUID(params.MemberId); true {
+				return e.EncodeValue(conv.UUIDToString(unwrapped))
+			}
+			return nil
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[3] = encoded
+	}
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "DELETE", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BasicAuth"
+			switch err := c.securityBasicAuth(ctx, GroupsRemoveGroupMemberOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BasicAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeGroupsRemoveGroupMemberResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// Health invokes health operation.
+//
+// GET /health
+func (c *Client) Health(ctx context.Context) error {
+	_, err := c.sendHealth(ctx)
+	return err
+}
+
+func (c *Client) sendHealth(ctx context.Context) (res *HealthOK, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("health"),
+		semconv.HTTPRequestMethodKey.String("GET"),
+		semconv.HTTPRouteKey.String("/health"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, HealthOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/health"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BasicAuth"
+			switch err := c.securityBasicAuth(ctx, HealthOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BasicAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeHealthResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// OrganizationsCreateOrganization invokes Organizations_createOrganization operation.
+//
+// Create an organization
+// - name must be provided, 400 otherwise
+// - super admin permission required.
+//
+// POST /organizations/
+func (c *Client) OrganizationsCreateOrganization(ctx context.Context, request *Organization) (OrganizationsCreateOrganizationRes, error) {
+	res, err := c.sendOrganizationsCreateOrganization(ctx, request)
+	return res, err
+}
+
+func (c *Client) sendOrganizationsCreateOrganization(ctx context.Context, request *Organization) (res OrganizationsCreateOrganizationRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("Organizations_createOrganization"),
+		semconv.HTTPRequestMethodKey.String("POST"),
+		semconv.HTTPRouteKey.String("/organizations/"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, OrganizationsCreateOrganizationOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/organizations/"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeOrganizationsCreateOrganizationRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BasicAuth"
+			switch err := c.securityBasicAuth(ctx, OrganizationsCreateOrganizationOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BasicAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeOrganizationsCreateOrganizationResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// OrganizationsDeleteOrganization invokes Organizations_deleteOrganization operation.
+//
+// Delete Organization with all its users and groups
+// - the default organization can't be deleted, 400 otherwise
+// - super admin permission required.
+//
+// DELETE /organizations/{organizationId}
+func (c *Client) OrganizationsDeleteOrganization(ctx context.Context, params OrganizationsDeleteOrganizationParams) (OrganizationsDeleteOrganizationRes, error) {
+	res, err := c.sendOrganizationsDeleteOrganization(ctx, params)
+	return res, err
+}
+
+func (c *Client) sendOrganizationsDeleteOrganization(ctx context.Context, params OrganizationsDeleteOrganizationParams) (res OrganizationsDeleteOrganizationRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("Organizations_deleteOrganization"),
+		semconv.HTTPRequestMethodKey.String("DELETE"),
+		semconv.HTTPRouteKey.String("/organizations/{organizationId}"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, OrganizationsDeleteOrganizationOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [2]string
+	pathParts[0] = "/organizations/"
+	{
+		// Encode "organizationId" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "organizationId",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			if unwrapped := uuid.UUID(params.OrganizationId); true {
+				return e.EncodeValue(conv.UUIDToString(unwrapped))
+			}
+			return nil
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[1] = encoded
+	}
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "DELETE", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BasicAuth"
+			switch err := c.securityBasicAuth(ctx, OrganizationsDeleteOrganizationOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BasicAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeOrganizationsDeleteOrganizationResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// OrganizationsGetOrganization invokes Organizations_getOrganization operation.
+//
+// Returns an Organization if organization with provided organizationId exists, 404 otherwise.
+//
+// GET /organizations/{organizationId}
+func (c *Client) OrganizationsGetOrganization(ctx context.Context, params OrganizationsGetOrganizationParams) (OrganizationsGetOrganizationRes, error) {
+	res, err := c.sendOrganizationsGetOrganization(ctx, params)
+	return res, err
+}
+
+func (c *Client) sendOrganizationsGetOrganization(ctx context.Context, params OrganizationsGetOrganizationParams) (res OrganizationsGetOrganizationRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("Organizations_getOrganization"),
+		semconv.HTTPRequestMethodKey.String("GET"),
+		semconv.HTTPRouteKey.String("/organizations/{organizationId}"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, OrganizationsGetOrganizationOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [2]string
+	pathParts[0] = "/organizations/"
+	{
+		// Encode "organizationId" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "organizationId",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			if unwrapped := uuid.UUID(params.OrganizationId); true {
+				return e.EncodeValue(conv.UUIDToString(unwrapped))
+			}
+			return nil
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[1] = encoded
+	}
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BasicAuth"
+			switch err := c.securityBasicAuth(ctx, OrganizationsGetOrganizationOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BasicAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeOrganizationsGetOrganizationResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// OrganizationsListOrganizations invokes Organizations_listOrganizations operation.
+//
+// Returns a list of organizations
+// - super admin permission required.
+//
+// GET /organizations/
+func (c *Client) OrganizationsListOrganizations(ctx context.Context, params OrganizationsListOrganizationsParams) (OrganizationsListOrganizationsRes, error) {
+	res, err := c.sendOrganizationsListOrganizations(ctx, params)
+	return res, err
+}
+
+func (c *Client) sendOrganizationsListOrganizations(ctx context.Context, params OrganizationsListOrganizationsParams) (res OrganizationsListOrganizationsRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("Organizations_listOrganizations"),
+		semconv.HTTPRequestMethodKey.String("GET"),
+		semconv.HTTPRouteKey.String("/organizations/"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, OrganizationsListOrganizationsOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/organizations/"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeQueryParams"
+	q := uri.NewQueryEncoder()
+	{
+		// Encode "offset" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "offset",
+			Style:   uri.QueryStyleForm,
+			Explode: false,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.Offset.Get(); ok {
+				return e.EncodeValue(conv.Int64ToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	u.RawQuery = q.Values().Encode()
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BasicAuth"
+			switch err := c.securityBasicAuth(ctx, OrganizationsListOrganizationsOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BasicAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeOrganizationsListOrganizationsResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// ServiceBatchUsers invokes Service_batchUsers operation.
+//
+// Execute create, patch, put and delete operations on users in a single request
+// - every operation gets the status and body of the single user endpoint
+// - with atomic=true operations run in a single transaction, the first failed
+// operation rolls the batch back and the rest aren't executed (status 424)
+// - admin permission required.
+//
+// POST /users:batch
+func (c *Client) ServiceBatchUsers(ctx context.Context, request []BatchOperation, params ServiceBatchUsersParams) (ServiceBatchUsersRes, error) {
+	res, err := c.sendServiceBatchUsers(ctx, request, params)
+	return res, err
+}
+
+func (c *Client) sendServiceBatchUsers(ctx context.Context, request []BatchOperation, params ServiceBatchUsersParams) (res ServiceBatchUsersRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("Service_batchUsers"),
+		semconv.HTTPRequestMethodKey.String("POST"),
+		semconv.HTTPRouteKey.String("/users:batch"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, ServiceBatchUsersOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/users:batch"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeQueryParams"
+	q := uri.NewQueryEncoder()
+	{
+		// Encode "atomic" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "atomic",
+			Style:   uri.QueryStyleForm,
+			Explode: false,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.Atomic.Get(); ok {
+				return e.EncodeValue(conv.BoolToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	u.RawQuery = q.Values().Encode()
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeServiceBatchUsersRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BasicAuth"
+			switch err := c.securityBasicAuth(ctx, ServiceBatchUsersOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BasicAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeServiceBatchUsersResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// ServiceCreateUser invokes Service_createUser operation.
+//
+// Create a user
+// - all fields must be provided, 400 otherwise
+// - admin permission required.
+//
+// POST /users/
+func (c *Client) ServiceCreateUser(ctx context.Context, request *User) (ServiceCreateUserRes, error) {
+	res, err := c.sendServiceCreateUser(ctx, request)
+	return res, err
+}
+
+func (c *Client) sendServiceCreateUser(ctx context.Context, request *User) (res ServiceCreateUserRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("Service_createUser"),
+		semconv.HTTPRequestMethodKey.String("POST"),
+		semconv.HTTPRouteKey.String("/users/"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, ServiceCreateUserOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/users/"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeServiceCreateUserRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BasicAuth"
+			switch err := c.securityBasicAuth(ctx, ServiceCreateUserOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BasicAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeServiceCreateUserResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// ServiceDeleteUser invokes Service_deleteUser operation.
+//
+// Delete User
+// - the last administrator of the organization can't be demoted or deleted, 409 otherwise
+// - admin permission required.
+//
+// DELETE /users/{userId}
+func (c *Client) ServiceDeleteUser(ctx context.Context, params ServiceDeleteUserParams) (ServiceDeleteUserRes, error) {
+	res, err := c.sendServiceDeleteUser(ctx, params)
+	return res, err
+}
+
+func (c *Client) sendServiceDeleteUser(ctx context.Context, params ServiceDeleteUserParams) (res ServiceDeleteUserRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("Service_deleteUser"),
+		semconv.HTTPRequestMethodKey.String("DELETE"),
+		semconv.HTTPRouteKey.String("/users/{userId}"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, ServiceDeleteUserOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [2]string
+	pathParts[0] = "/users/"
+	{
+		// Encode "userId" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "userId",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			if unwrapped := uuid.UUID(params.UserId); true {
+				return e.EncodeValue(conv.UUIDToString(unwrapped))
+			}
+			return nil
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[1] = encoded
+	}
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "DELETE", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BasicAuth"
+			switch err := c.securityBasicAuth(ctx, ServiceDeleteUserOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BasicAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeServiceDeleteUserResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// ServiceExportUsers invokes Service_exportUsers operation.
+//
+// Export all users of the organization as a stream, password hashes are never exported
+// - format is chosen by Accept: application/x-ndjson (default), text/csv or application/json (array)
+// - every format is written while users are read, application/json is an array of users
+// - users are ordered by id, pass the id of the last received user as cursor to resume
+// - filters are the same as in listUsers
+// - admin permission required.
+//
+// GET /users:export
+func (c *Client) ServiceExportUsers(ctx context.Context, params ServiceExportUsersParams) (ServiceExportUsersRes, error) {
+	res, err := c.sendServiceExportUsers(ctx, params)
+	return res, err
+}
+
+func (c *Client) sendServiceExportUsers(ctx context.Context, params ServiceExportUsersParams) (res ServiceExportUsersRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("Service_exportUsers"),
+		semconv.HTTPRequestMethodKey.String("GET"),
+		semconv.HTTPRouteKey.String("/users:export"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, ServiceExportUsersOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/users:export"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeQueryParams"
+	q := uri.NewQueryEncoder()
+	{
+		// Encode "cursor" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "cursor",
+			Style:   uri.QueryStyleForm,
+			Explode: false,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.Cursor.Get(); ok {
+				if unwrapped := uuid.UUID(val); true {
+					return e.EncodeValue(conv.UUIDToString(unwrapped))
+				}
+				return nil
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "is_admin" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "is_admin",
+			Style:   uri.QueryStyleForm,
+			Explode: false,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.IsAdmin.Get(); ok {
+				return e.EncodeValue(conv.BoolToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "search" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "search",
+			Style:   uri.QueryStyleForm,
+			Explode: false,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.Search.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	u.RawQuery = q.Values().Encode()
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	stage = "EncodeHeaderParams"
+	h := uri.NewHeaderEncoder(r.Header)
+	{
+		cfg := uri.HeaderParameterEncodingConfig{
+			Name:    "accept",
+			Explode: false,
+		}
+		if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.Accept.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode header")
+		}
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BasicAuth"
+			switch err := c.securityBasicAuth(ctx, ServiceExportUsersOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BasicAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeServiceExportUsersResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// ServiceGetUser invokes Service_getUser operation.
+//
+// Returns a User if user with provided userId exists, 404 otherwise.
+//
+// GET /users/{userId}
+func (c *Client) ServiceGetUser(ctx context.Context, params ServiceGetUserParams) (ServiceGetUserRes, error) {
+	res, err := c.sendServiceGetUser(ctx, params)
+	return res, err
+}
+
+func (c *Client) sendServiceGetUser(ctx context.Context, params ServiceGetUserParams) (res ServiceGetUserRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("Service_getUser"),
+		semconv.HTTPRequestMethodKey.String("GET"),
+		semconv.HTTPRouteKey.String("/users/{userId}"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, ServiceGetUserOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [2]string
+	pathParts[0] = "/users/"
+	{
+		// Encode "userId" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "userId",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			if unwrapped := uuid.UUID(params.UserId); true {
+				return e.EncodeValue(conv.UUIDToString(unwrapped))
+			}
+			return nil
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[1] = encoded
+	}
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeQueryParams"
+	q := uri.NewQueryEncoder()
+	{
+		// Encode "fields" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "fields",
+			Style:   uri.QueryStyleForm,
+			Explode: false,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if params.Fields != nil {
+				return e.EncodeArray(func(e uri.Encoder) error {
+					for i, item := range params.Fields {
+						if err := func() error {
+							return e.EncodeValue(conv.StringToString(string(item)))
+						}(); err != nil {
+							return errors.Wrapf(err, "[%d]", i)
+						}
+					}
+					return nil
+				})
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "expand" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "expand",
+			Style:   uri.QueryStyleForm,
+			Explode: false,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if params.Expand != nil {
+				return e.EncodeArray(func(e uri.Encoder) error {
+					for i, item := range params.Expand {
+						if err := func() error {
+							return e.EncodeValue(conv.StringToString(string(item)))
+						}(); err != nil {
+							return errors.Wrapf(err, "[%d]", i)
+						}
+					}
+					return nil
+				})
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	u.RawQuery = q.Values().Encode()
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BasicAuth"
+			switch err := c.securityBasicAuth(ctx, ServiceGetUserOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BasicAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeServiceGetUserResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// ServiceGetUserGroups invokes Service_getUserGroups operation.
+//
+// Returns groups of the user, including groups inherited through nested groups
+// - admin permission required.
+//
+// GET /users/{userId}/groups
+func (c *Client) ServiceGetUserGroups(ctx context.Context, params ServiceGetUserGroupsParams) (ServiceGetUserGroupsRes, error) {
+	res, err := c.sendServiceGetUserGroups(ctx, params)
+	return res, err
+}
+
+func (c *Client) sendServiceGetUserGroups(ctx context.Context, params ServiceGetUserGroupsParams) (res ServiceGetUserGroupsRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("Service_getUserGroups"),
+		semconv.HTTPRequestMethodKey.String("GET"),
+		semconv.HTTPRouteKey.String("/users/{userId}/groups"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, ServiceGetUserGroupsOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [3]string
+	pathParts[0] = "/users/"
+	{
+		// Encode "userId" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "userId",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			if unwrapped := uuid.UUID(params.UserId); true {
+				return e.EncodeValue(conv.UUIDToString(unwrapped))
+			}
+			return nil
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[1] = encoded
+	}
+	pathParts[2] = "/groups"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BasicAuth"
+			switch err := c.securityBasicAuth(ctx, ServiceGetUserGroupsOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BasicAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeServiceGetUserGroupsResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// ServiceImportUsers invokes Service_importUsers operation.
+//
+// Import users from CSV (header: username,email,password,is_admin)
+// or NDJSON (one User per line) body, the body is processed as a stream
+// - every row is validated like in createUser
+// - on_conflict defines what to do with existing username or email, fail by default, fail stops at
+// the first conflict and rolls back the whole import
+// - dry_run validates rows without writing them, rows are checked against previous rows of the body
+// - admin permission required.
+//
+// POST /users:import
+func (c *Client) ServiceImportUsers(ctx context.Context, request ServiceImportUsersReq, params ServiceImportUsersParams) (ServiceImportUsersRes, error) {
+	res, err := c.sendServiceImportUsers(ctx, request, params)
+	return res, err
+}
+
+func (c *Client) sendServiceImportUsers(ctx context.Context, request ServiceImportUsersReq, params ServiceImportUsersParams) (res ServiceImportUsersRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("Service_importUsers"),
+		semconv.HTTPRequestMethodKey.String("POST"),
+		semconv.HTTPRouteKey.String("/users:import"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, ServiceImportUsersOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/users:import"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeQueryParams"
+	q := uri.NewQueryEncoder()
+	{
+		// Encode "dry_run" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "dry_run",
+			Style:   uri.QueryStyleForm,
+			Explode: false,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.DryRun.Get(); ok {
+				return e.EncodeValue(conv.BoolToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "on_conflict" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "on_conflict",
+			Style:   uri.QueryStyleForm,
+			Explode: false,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.OnConflict.Get(); ok {
+				return e.EncodeValue(conv.StringToString(string(val)))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	u.RawQuery = q.Values().Encode()
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeServiceImportUsersRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BasicAuth"
+			switch err := c.securityBasicAuth(ctx, ServiceImportUsersOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BasicAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeServiceImportUsersResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// ServiceListUsers invokes Service_listUsers operation.
+//
+// Returns a list of all users.
+//
+// GET /users/
+func (c *Client) ServiceListUsers(ctx context.Context, params ServiceListUsersParams) (ServiceListUsersRes, error) {
+	res, err := c.sendServiceListUsers(ctx, params)
+	return res, err
+}
+
+func (c *Client) sendServiceListUsers(ctx context.Context, params ServiceListUsersParams) (res ServiceListUsersRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("Service_listUsers"),
+		semconv.HTTPRequestMethodKey.String("GET"),
+		semconv.HTTPRouteKey.String("/users/"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, ServiceListUsersOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/users/"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeQueryParams"
+	q := uri.NewQueryEncoder()
+	{
+		// Encode "offset" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "offset",
+			Style:   uri.QueryStyleForm,
+			Explode: false,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.Offset.Get(); ok {
+				return e.EncodeValue(conv.Int64ToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "is_admin" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "is_admin",
+			Style:   uri.QueryStyleForm,
+			Explode: false,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.IsAdmin.Get(); ok {
+				return e.EncodeValue(conv.BoolToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "search" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "search",
+			Style:   uri.QueryStyleForm,
+			Explode: false,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.Search.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "fields" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "fields",
+			Style:   uri.QueryStyleForm,
+			Explode: false,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if params.Fields != nil {
+				return e.EncodeArray(func(e uri.Encoder) error {
+					for i, item := range params.Fields {
+						if err := func() error {
+							return e.EncodeValue(conv.StringToString(string(item)))
+						}(); err != nil {
+							return errors.Wrapf(err, "[%d]", i)
+						}
+					}
+					return nil
+				})
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "expand" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "expand",
+			Style:   uri.QueryStyleForm,
+			Explode: false,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if params.Expand != nil {
+				return e.EncodeArray(func(e uri.Encoder) error {
+					for i, item := range params.Expand {
+						if err := func() error {
+							return e.EncodeValue(conv.StringToString(string(item)))
+						}(); err != nil {
+							return errors.Wrapf(err, "[%d]", i)
+						}
+					}
+					return nil
+				})
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	u.RawQuery = q.Values().Encode()
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BasicAuth"
+			switch err := c.securityBasicAuth(ctx, ServiceListUsersOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BasicAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeServiceListUsersResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// ServicePatchUser invokes Service_patchUser operation.
+//
+// Patch User
+// - application/json: one of the fields must be provided, except id
+// - application/merge-patch+json (RFC 7396): null clears the field
+// - application/json-patch+json (RFC 6902): operations on the stored user without password,
+// password can be added or replaced, failed test operation rejects the patch with 409
+// - patches are applied to the stored user, changed fields are validated like application/json ones
+// - the last administrator of the organization can't be demoted or deleted, 409 otherwise
+// - admin permission required.
+//
+// PATCH /users/{userId}
+func (c *Client) ServicePatchUser(ctx context.Context, request ServicePatchUserReq, params ServicePatchUserParams) (ServicePatchUserRes, error) {
+	res, err := c.sendServicePatchUser(ctx, request, params)
+	return res, err
+}
+
+func (c *Client) sendServicePatchUser(ctx context.Context, request ServicePatchUserReq, params ServicePatchUserParams) (res ServicePatchUserRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("Service_patchUser"),
+		semconv.HTTPRequestMethodKey.String("PATCH"),
+		semconv.HTTPRouteKey.String("/users/{userId}"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, ServicePatchUserOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [2]string
+	pathParts[0] = "/users/"
+	{
+		// Encode "userId" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "userId",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			if unwrapped := uuid.UUID(params.UserId); true {
+				return e.EncodeValue(conv.UUIDToString(unwrapped))
+			}
+			return nil
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[1] = encoded
+	}
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "PATCH", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeServicePatchUserRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BasicAuth"
+			switch err := c.securityBasicAuth(ctx, ServicePatchUserOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BasicAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeServicePatchUserResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// ServicePutUser invokes Service_putUser operation.
+//
+// Put a new User params
+// - all fields must be provided, except id
+// - the last administrator of the organization can't be demoted or deleted, 409 otherwise
+// - admin permission required.
+//
+// PUT /users/{userId}
+func (c *Client) ServicePutUser(ctx context.Context, request *User, params ServicePutUserParams) (ServicePutUserRes, error) {
+	res, err := c.sendServicePutUser(ctx, request, params)
+	return res, err
+}
+
+func (c *Client) sendServicePutUser(ctx context.Context, request *User, params ServicePutUserParams) (res ServicePutUserRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("Service_putUser"),
+		semconv.HTTPRequestMethodKey.String("PUT"),
+		semconv.HTTPRouteKey.String("/users/{userId}"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, ServicePutUserOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [2]string
+	pathParts[0] = "/users/"
+	{
+		// Encode "userId" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "userId",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			if unwrapped := uuid.UUID(params.UserId); true {
+				return e.EncodeValue(conv.UUIDToString(unwrapped))
+			}
+			return nil
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[1] = encoded
+	}
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "PUT", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeServicePutUserRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BasicAuth"
+			switch err := c.securityBasicAuth(ctx, ServicePutUserOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BasicAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeServicePutUserResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// ServiceWatchUsers invokes Service_watchUsers operation.
+//
+// Stream changes of users of the organization as server-sent events
+// - every event is user.created, user.updated or user.deleted with the outbox
+// event as data, the id of the event is its id in the outbox
+// - reconnect with Last-Event-ID to receive the events missed since it, the event
+// log is bounded, a reset event means missed events are lost and the list must be reloaded
+// - the stream is closed after a change of the password or deletion of the authenticated user
+// - comments are sent as heartbeats while there are no changes
+// - can be used by all users.
+//
+// GET /users:watch
+func (c *Client) ServiceWatchUsers(ctx context.Context, params ServiceWatchUsersParams) (ServiceWatchUsersRes, error) {
+	res, err := c.sendServiceWatchUsers(ctx, params)
+	return res, err
+}
+
+func (c *Client) sendServiceWatchUsers(ctx context.Context, params ServiceWatchUsersParams) (res ServiceWatchUsersRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("Service_watchUsers"),
+		semconv.HTTPRequestMethodKey.String("GET"),
+		semconv.HTTPRouteKey.String("/users:watch"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, ServiceWatchUsersOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/users:watch"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	stage = "EncodeHeaderParams"
+	h := uri.NewHeaderEncoder(r.Header)
+	{
+		cfg := uri.HeaderParameterEncodingConfig{
+			Name:    "Last-Event-ID",
+			Explode: false,
+		}
+		if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.LastEventID.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode header")
+		}
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BasicAuth"
+			switch err := c.securityBasicAuth(ctx, ServiceWatchUsersOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BasicAuth\"")
+			}
+		}
 
-// NewClient initializes new Client defined by OAS.
-func NewClient(serverURL string, sec SecuritySource, opts ...ClientOption) (*Client, error) {
-	u, err := url.Parse(serverURL)
-	if err != nil {
-		return nil, err
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
 	}
-	trimTrailingSlashes(u)
 
-	c, err := newClientConfig(opts...).baseClient()
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
 	if err != nil {
-		return nil, err
+		return res, errors.Wrap(err, "do request")
 	}
-	return &Client{
-		serverURL:  u,
-		sec:        sec,
-		baseClient: c,
-	}, nil
-}
-
-type serverURLKey struct{}
-
-// WithServerURL sets context key to override server URL.
-func WithServerURL(ctx context.Context, u *url.URL) context.Context {
-	return context.WithValue(ctx, serverURLKey{}, u)
-}
+	defer resp.Body.Close()
 
-func (c *Client) requestURL(ctx context.Context) *url.URL {
-	u, ok := ctx.Value(serverURLKey{}).(*url.URL)
-	if !ok {
-		return c.serverURL
+	stage = "DecodeResponse"
+	result, err := decodeServiceWatchUsersResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
 	}
-	return u
+
+	return result, nil
 }
 
-// Health invokes health operation.
+// WebhooksCreateWebhook invokes Webhooks_createWebhook operation.
 //
-// GET /health
-func (c *Client) Health(ctx context.Context) error {
-	_, err := c.sendHealth(ctx)
-	return err
+// Register a webhook
+// - url must be provided, 400 otherwise
+// - the secret is returned only in this response
+// - admin permission required.
+//
+// POST /webhooks/
+func (c *Client) WebhooksCreateWebhook(ctx context.Context, request *Webhook) (WebhooksCreateWebhookRes, error) {
+	res, err := c.sendWebhooksCreateWebhook(ctx, request)
+	return res, err
 }
 
-func (c *Client) sendHealth(ctx context.Context) (res *HealthOK, err error) {
+func (c *Client) sendWebhooksCreateWebhook(ctx context.Context, request *Webhook) (res WebhooksCreateWebhookRes, err error) {
 	otelAttrs := []attribute.KeyValue{
-		otelogen.OperationID("health"),
-		semconv.HTTPRequestMethodKey.String("GET"),
-		semconv.HTTPRouteKey.String("/health"),
+		otelogen.OperationID("Webhooks_createWebhook"),
+		semconv.HTTPRequestMethodKey.String("POST"),
+		semconv.HTTPRouteKey.String("/webhooks/"),
 	}
 
 	// Run stopwatch.
@@ -151,7 +3730,7 @@ func (c *Client) sendHealth(ctx context.Context) (res *HealthOK, err error) {
 	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
 
 	// Start a span for this request.
-	ctx, span := c.cfg.Tracer.Start(ctx, HealthOperation,
+	ctx, span := c.cfg.Tracer.Start(ctx, WebhooksCreateWebhookOperation,
 		trace.WithAttributes(otelAttrs...),
 		clientSpanKind,
 	)
@@ -169,21 +3748,24 @@ func (c *Client) sendHealth(ctx context.Context) (res *HealthOK, err error) {
 	stage = "BuildURL"
 	u := uri.Clone(c.requestURL(ctx))
 	var pathParts [1]string
-	pathParts[0] = "/health"
+	pathParts[0] = "/webhooks/"
 	uri.AddPathParts(u, pathParts[:]...)
 
 	stage = "EncodeRequest"
-	r, err := ht.NewRequest(ctx, "GET", u)
+	r, err := ht.NewRequest(ctx, "POST", u)
 	if err != nil {
 		return res, errors.Wrap(err, "create request")
 	}
+	if err := encodeWebhooksCreateWebhookRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
 
 	{
 		type bitset = [1]uint8
 		var satisfied bitset
 		{
 			stage = "Security:BasicAuth"
-			switch err := c.securityBasicAuth(ctx, HealthOperation, r); {
+			switch err := c.securityBasicAuth(ctx, WebhooksCreateWebhookOperation, r); {
 			case err == nil: // if NO error
 				satisfied[0] |= 1 << 0
 			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
@@ -219,7 +3801,7 @@ func (c *Client) sendHealth(ctx context.Context) (res *HealthOK, err error) {
 	defer resp.Body.Close()
 
 	stage = "DecodeResponse"
-	result, err := decodeHealthResponse(resp)
+	result, err := decodeWebhooksCreateWebhookResponse(resp)
 	if err != nil {
 		return res, errors.Wrap(err, "decode response")
 	}
@@ -227,23 +3809,22 @@ func (c *Client) sendHealth(ctx context.Context) (res *HealthOK, err error) {
 	return result, nil
 }
 
-// ServiceCreateUser invokes Service_createUser operation.
+// WebhooksDeleteWebhook invokes Webhooks_deleteWebhook operation.
 //
-// Create a user
-// - all fields must be provided, 400 otherwise
+// Delete Webhook with its delivery log
 // - admin permission required.
 //
-// POST /users/
-func (c *Client) ServiceCreateUser(ctx context.Context, request *User) (ServiceCreateUserRes, error) {
-	res, err := c.sendServiceCreateUser(ctx, request)
+// DELETE /webhooks/{webhookId}
+func (c *Client) WebhooksDeleteWebhook(ctx context.Context, params WebhooksDeleteWebhookParams) (WebhooksDeleteWebhookRes, error) {
+	res, err := c.sendWebhooksDeleteWebhook(ctx, params)
 	return res, err
 }
 
-func (c *Client) sendServiceCreateUser(ctx context.Context, request *User) (res ServiceCreateUserRes, err error) {
+func (c *Client) sendWebhooksDeleteWebhook(ctx context.Context, params WebhooksDeleteWebhookParams) (res WebhooksDeleteWebhookRes, err error) {
 	otelAttrs := []attribute.KeyValue{
-		otelogen.OperationID("Service_createUser"),
-		semconv.HTTPRequestMethodKey.String("POST"),
-		semconv.HTTPRouteKey.String("/users/"),
+		otelogen.OperationID("Webhooks_deleteWebhook"),
+		semconv.HTTPRequestMethodKey.String("DELETE"),
+		semconv.HTTPRouteKey.String("/webhooks/{webhookId}"),
 	}
 
 	// Run stopwatch.
@@ -258,7 +3839,7 @@ func (c *Client) sendServiceCreateUser(ctx context.Context, request *User) (res
 	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
 
 	// Start a span for this request.
-	ctx, span := c.cfg.Tracer.Start(ctx, ServiceCreateUserOperation,
+	ctx, span := c.cfg.Tracer.Start(ctx, WebhooksDeleteWebhookOperation,
 		trace.WithAttributes(otelAttrs...),
 		clientSpanKind,
 	)
@@ -275,25 +3856,43 @@ func (c *Client) sendServiceCreateUser(ctx context.Context, request *User) (res
 
 	stage = "BuildURL"
 	u := uri.Clone(c.requestURL(ctx))
-	var pathParts [1]string
-	pathParts[0] = "/users/"
+	var pathParts [2]string
+	pathParts[0] = "/webhooks/"
+	{
+		// Encode "webhookId" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "webhookId",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			if unwrapped := uuid.UUID(params.WebhookId); true {
+				return e.EncodeValue(conv.UUIDToString(unwrapped))
+			}
+			return nil
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[1] = encoded
+	}
 	uri.AddPathParts(u, pathParts[:]...)
 
 	stage = "EncodeRequest"
-	r, err := ht.NewRequest(ctx, "POST", u)
+	r, err := ht.NewRequest(ctx, "DELETE", u)
 	if err != nil {
 		return res, errors.Wrap(err, "create request")
 	}
-	if err := encodeServiceCreateUserRequest(request, r); err != nil {
-		return res, errors.Wrap(err, "encode request")
-	}
 
 	{
 		type bitset = [1]uint8
 		var satisfied bitset
 		{
 			stage = "Security:BasicAuth"
-			switch err := c.securityBasicAuth(ctx, ServiceCreateUserOperation, r); {
+			switch err := c.securityBasicAuth(ctx, WebhooksDeleteWebhookOperation, r); {
 			case err == nil: // if NO error
 				satisfied[0] |= 1 << 0
 			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
@@ -329,7 +3928,7 @@ func (c *Client) sendServiceCreateUser(ctx context.Context, request *User) (res
 	defer resp.Body.Close()
 
 	stage = "DecodeResponse"
-	result, err := decodeServiceCreateUserResponse(resp)
+	result, err := decodeWebhooksDeleteWebhookResponse(resp)
 	if err != nil {
 		return res, errors.Wrap(err, "decode response")
 	}
@@ -337,22 +3936,21 @@ func (c *Client) sendServiceCreateUser(ctx context.Context, request *User) (res
 	return result, nil
 }
 
-// ServiceDeleteUser invokes Service_deleteUser operation.
+// WebhooksGetWebhook invokes Webhooks_getWebhook operation.
 //
-// Delete User
-// - admin permission required.
+// Returns a Webhook if webhook with provided webhookId exists, 404 otherwise.
 //
-// DELETE /users/{userId}
-func (c *Client) ServiceDeleteUser(ctx context.Context, params ServiceDeleteUserParams) (ServiceDeleteUserRes, error) {
-	res, err := c.sendServiceDeleteUser(ctx, params)
+// GET /webhooks/{webhookId}
+func (c *Client) WebhooksGetWebhook(ctx context.Context, params WebhooksGetWebhookParams) (WebhooksGetWebhookRes, error) {
+	res, err := c.sendWebhooksGetWebhook(ctx, params)
 	return res, err
 }
 
-func (c *Client) sendServiceDeleteUser(ctx context.Context, params ServiceDeleteUserParams) (res ServiceDeleteUserRes, err error) {
+func (c *Client) sendWebhooksGetWebhook(ctx context.Context, params WebhooksGetWebhookParams) (res WebhooksGetWebhookRes, err error) {
 	otelAttrs := []attribute.KeyValue{
-		otelogen.OperationID("Service_deleteUser"),
-		semconv.HTTPRequestMethodKey.String("DELETE"),
-		semconv.HTTPRouteKey.String("/users/{userId}"),
+		otelogen.OperationID("Webhooks_getWebhook"),
+		semconv.HTTPRequestMethodKey.String("GET"),
+		semconv.HTTPRouteKey.String("/webhooks/{webhookId}"),
 	}
 
 	// Run stopwatch.
@@ -367,7 +3965,7 @@ func (c *Client) sendServiceDeleteUser(ctx context.Context, params ServiceDelete
 	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
 
 	// Start a span for this request.
-	ctx, span := c.cfg.Tracer.Start(ctx, ServiceDeleteUserOperation,
+	ctx, span := c.cfg.Tracer.Start(ctx, WebhooksGetWebhookOperation,
 		trace.WithAttributes(otelAttrs...),
 		clientSpanKind,
 	)
@@ -385,16 +3983,16 @@ func (c *Client) sendServiceDeleteUser(ctx context.Context, params ServiceDelete
 	stage = "BuildURL"
 	u := uri.Clone(c.requestURL(ctx))
 	var pathParts [2]string
-	pathParts[0] = "/users/"
+	pathParts[0] = "/webhooks/"
 	{
-		// Encode "userId" parameter.
+		// Encode "webhookId" parameter.
 		e := uri.NewPathEncoder(uri.PathEncoderConfig{
-			Param:   "userId",
+			Param:   "webhookId",
 			Style:   uri.PathStyleSimple,
 			Explode: false,
 		})
 		if err := func() error {
-			if unwrapped := uuid.UUID(params.UserId); true {
+			if unwrapped := uuid.UUID(params.WebhookId); true {
 				return e.EncodeValue(conv.UUIDToString(unwrapped))
 			}
 			return nil
@@ -410,7 +4008,7 @@ func (c *Client) sendServiceDeleteUser(ctx context.Context, params ServiceDelete
 	uri.AddPathParts(u, pathParts[:]...)
 
 	stage = "EncodeRequest"
-	r, err := ht.NewRequest(ctx, "DELETE", u)
+	r, err := ht.NewRequest(ctx, "GET", u)
 	if err != nil {
 		return res, errors.Wrap(err, "create request")
 	}
@@ -420,7 +4018,7 @@ func (c *Client) sendServiceDeleteUser(ctx context.Context, params ServiceDelete
 		var satisfied bitset
 		{
 			stage = "Security:BasicAuth"
-			switch err := c.securityBasicAuth(ctx, ServiceDeleteUserOperation, r); {
+			switch err := c.securityBasicAuth(ctx, WebhooksGetWebhookOperation, r); {
 			case err == nil: // if NO error
 				satisfied[0] |= 1 << 0
 			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
@@ -456,7 +4054,7 @@ func (c *Client) sendServiceDeleteUser(ctx context.Context, params ServiceDelete
 	defer resp.Body.Close()
 
 	stage = "DecodeResponse"
-	result, err := decodeServiceDeleteUserResponse(resp)
+	result, err := decodeWebhooksGetWebhookResponse(resp)
 	if err != nil {
 		return res, errors.Wrap(err, "decode response")
 	}
@@ -464,21 +4062,22 @@ func (c *Client) sendServiceDeleteUser(ctx context.Context, params ServiceDelete
 	return result, nil
 }
 
-// ServiceGetUser invokes Service_getUser operation.
+// WebhooksListWebhookDeliveries invokes Webhooks_listWebhookDeliveries operation.
 //
-// Returns a User if user with provided userId exists, 404 otherwise.
+// Returns deliveries of the webhook, the latest events first
+// - admin permission required.
 //
-// GET /users/{userId}
-func (c *Client) ServiceGetUser(ctx context.Context, params ServiceGetUserParams) (ServiceGetUserRes, error) {
-	res, err := c.sendServiceGetUser(ctx, params)
+// GET /webhooks/{webhookId}/deliveries
+func (c *Client) WebhooksListWebhookDeliveries(ctx context.Context, params WebhooksListWebhookDeliveriesParams) (WebhooksListWebhookDeliveriesRes, error) {
+	res, err := c.sendWebhooksListWebhookDeliveries(ctx, params)
 	return res, err
 }
 
-func (c *Client) sendServiceGetUser(ctx context.Context, params ServiceGetUserParams) (res ServiceGetUserRes, err error) {
+func (c *Client) sendWebhooksListWebhookDeliveries(ctx context.Context, params WebhooksListWebhookDeliveriesParams) (res WebhooksListWebhookDeliveriesRes, err error) {
 	otelAttrs := []attribute.KeyValue{
-		otelogen.OperationID("Service_getUser"),
+		otelogen.OperationID("Webhooks_listWebhookDeliveries"),
 		semconv.HTTPRequestMethodKey.String("GET"),
-		semconv.HTTPRouteKey.String("/users/{userId}"),
+		semconv.HTTPRouteKey.String("/webhooks/{webhookId}/deliveries"),
 	}
 
 	// Run stopwatch.
@@ -493,7 +4092,7 @@ func (c *Client) sendServiceGetUser(ctx context.Context, params ServiceGetUserPa
 	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
 
 	// Start a span for this request.
-	ctx, span := c.cfg.Tracer.Start(ctx, ServiceGetUserOperation,
+	ctx, span := c.cfg.Tracer.Start(ctx, WebhooksListWebhookDeliveriesOperation,
 		trace.WithAttributes(otelAttrs...),
 		clientSpanKind,
 	)
@@ -510,17 +4109,17 @@ func (c *Client) sendServiceGetUser(ctx context.Context, params ServiceGetUserPa
 
 	stage = "BuildURL"
 	u := uri.Clone(c.requestURL(ctx))
-	var pathParts [2]string
-	pathParts[0] = "/users/"
+	var pathParts [3]string
+	pathParts[0] = "/webhooks/"
 	{
-		// Encode "userId" parameter.
+		// Encode "webhookId" parameter.
 		e := uri.NewPathEncoder(uri.PathEncoderConfig{
-			Param:   "userId",
+			Param:   "webhookId",
 			Style:   uri.PathStyleSimple,
 			Explode: false,
 		})
 		if err := func() error {
-			if unwrapped := uuid.UUID(params.UserId); true {
+			if unwrapped := uuid.UUID(params.WebhookId); true {
 				return e.EncodeValue(conv.UUIDToString(unwrapped))
 			}
 			return nil
@@ -533,8 +4132,30 @@ func (c *Client) sendServiceGetUser(ctx context.Context, params ServiceGetUserPa
 		}
 		pathParts[1] = encoded
 	}
+	pathParts[2] = "/deliveries"
 	uri.AddPathParts(u, pathParts[:]...)
 
+	stage = "EncodeQueryParams"
+	q := uri.NewQueryEncoder()
+	{
+		// Encode "offset" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "offset",
+			Style:   uri.QueryStyleForm,
+			Explode: false,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.Offset.Get(); ok {
+				return e.EncodeValue(conv.Int64ToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	u.RawQuery = q.Values().Encode()
+
 	stage = "EncodeRequest"
 	r, err := ht.NewRequest(ctx, "GET", u)
 	if err != nil {
@@ -546,7 +4167,7 @@ func (c *Client) sendServiceGetUser(ctx context.Context, params ServiceGetUserPa
 		var satisfied bitset
 		{
 			stage = "Security:BasicAuth"
-			switch err := c.securityBasicAuth(ctx, ServiceGetUserOperation, r); {
+			switch err := c.securityBasicAuth(ctx, WebhooksListWebhookDeliveriesOperation, r); {
 			case err == nil: // if NO error
 				satisfied[0] |= 1 << 0
 			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
@@ -582,7 +4203,7 @@ func (c *Client) sendServiceGetUser(ctx context.Context, params ServiceGetUserPa
 	defer resp.Body.Close()
 
 	stage = "DecodeResponse"
-	result, err := decodeServiceGetUserResponse(resp)
+	result, err := decodeWebhooksListWebhookDeliveriesResponse(resp)
 	if err != nil {
 		return res, errors.Wrap(err, "decode response")
 	}
@@ -590,21 +4211,22 @@ func (c *Client) sendServiceGetUser(ctx context.Context, params ServiceGetUserPa
 	return result, nil
 }
 
-// ServiceListUsers invokes Service_listUsers operation.
+// WebhooksListWebhooks invokes Webhooks_listWebhooks operation.
 //
-// Returns a list of all users.
+// Returns a list of webhooks, secrets are never returned
+// - admin permission required.
 //
-// GET /users/
-func (c *Client) ServiceListUsers(ctx context.Context, params ServiceListUsersParams) (ServiceListUsersRes, error) {
-	res, err := c.sendServiceListUsers(ctx, params)
+// GET /webhooks/
+func (c *Client) WebhooksListWebhooks(ctx context.Context, params WebhooksListWebhooksParams) (WebhooksListWebhooksRes, error) {
+	res, err := c.sendWebhooksListWebhooks(ctx, params)
 	return res, err
 }
 
-func (c *Client) sendServiceListUsers(ctx context.Context, params ServiceListUsersParams) (res ServiceListUsersRes, err error) {
+func (c *Client) sendWebhooksListWebhooks(ctx context.Context, params WebhooksListWebhooksParams) (res WebhooksListWebhooksRes, err error) {
 	otelAttrs := []attribute.KeyValue{
-		otelogen.OperationID("Service_listUsers"),
+		otelogen.OperationID("Webhooks_listWebhooks"),
 		semconv.HTTPRequestMethodKey.String("GET"),
-		semconv.HTTPRouteKey.String("/users/"),
+		semconv.HTTPRouteKey.String("/webhooks/"),
 	}
 
 	// Run stopwatch.
@@ -619,7 +4241,7 @@ func (c *Client) sendServiceListUsers(ctx context.Context, params ServiceListUse
 	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
 
 	// Start a span for this request.
-	ctx, span := c.cfg.Tracer.Start(ctx, ServiceListUsersOperation,
+	ctx, span := c.cfg.Tracer.Start(ctx, WebhooksListWebhooksOperation,
 		trace.WithAttributes(otelAttrs...),
 		clientSpanKind,
 	)
@@ -637,7 +4259,7 @@ func (c *Client) sendServiceListUsers(ctx context.Context, params ServiceListUse
 	stage = "BuildURL"
 	u := uri.Clone(c.requestURL(ctx))
 	var pathParts [1]string
-	pathParts[0] = "/users/"
+	pathParts[0] = "/webhooks/"
 	uri.AddPathParts(u, pathParts[:]...)
 
 	stage = "EncodeQueryParams"
@@ -672,7 +4294,7 @@ func (c *Client) sendServiceListUsers(ctx context.Context, params ServiceListUse
 		var satisfied bitset
 		{
 			stage = "Security:BasicAuth"
-			switch err := c.securityBasicAuth(ctx, ServiceListUsersOperation, r); {
+			switch err := c.securityBasicAuth(ctx, WebhooksListWebhooksOperation, r); {
 			case err == nil: // if NO error
 				satisfied[0] |= 1 << 0
 			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
@@ -708,7 +4330,7 @@ func (c *Client) sendServiceListUsers(ctx context.Context, params ServiceListUse
 	defer resp.Body.Close()
 
 	stage = "DecodeResponse"
-	result, err := decodeServiceListUsersResponse(resp)
+	result, err := decodeWebhooksListWebhooksResponse(resp)
 	if err != nil {
 		return res, errors.Wrap(err, "decode response")
 	}
@@ -716,23 +4338,24 @@ func (c *Client) sendServiceListUsers(ctx context.Context, params ServiceListUse
 	return result, nil
 }
 
-// ServicePatchUser invokes Service_patchUser operation.
+// WebhooksPatchWebhook invokes Webhooks_patchWebhook operation.
 //
-// Patch User
-// - one of the fields must be provided, except id
+// Patch Webhook
+// - one of url, events, secret, enabled must be provided
+// - enabling a webhook resets its failures
 // - admin permission required.
 //
-// PATCH /users/{userId}
-func (c *Client) ServicePatchUser(ctx context.Context, request *User, params ServicePatchUserParams) (ServicePatchUserRes, error) {
-	res, err := c.sendServicePatchUser(ctx, request, params)
+// PATCH /webhooks/{webhookId}
+func (c *Client) WebhooksPatchWebhook(ctx context.Context, request *Webhook, params WebhooksPatchWebhookParams) (WebhooksPatchWebhookRes, error) {
+	res, err := c.sendWebhooksPatchWebhook(ctx, request, params)
 	return res, err
 }
 
-func (c *Client) sendServicePatchUser(ctx context.Context, request *User, params ServicePatchUserParams) (res ServicePatchUserRes, err error) {
+func (c *Client) sendWebhooksPatchWebhook(ctx context.Context, request *Webhook, params WebhooksPatchWebhookParams) (res WebhooksPatchWebhookRes, err error) {
 	otelAttrs := []attribute.KeyValue{
-		otelogen.OperationID("Service_patchUser"),
+		otelogen.OperationID("Webhooks_patchWebhook"),
 		semconv.HTTPRequestMethodKey.String("PATCH"),
-		semconv.HTTPRouteKey.String("/users/{userId}"),
+		semconv.HTTPRouteKey.String("/webhooks/{webhookId}"),
 	}
 
 	// Run stopwatch.
@@ -747,7 +4370,7 @@ func (c *Client) sendServicePatchUser(ctx context.Context, request *User, params
 	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
 
 	// Start a span for this request.
-	ctx, span := c.cfg.Tracer.Start(ctx, ServicePatchUserOperation,
+	ctx, span := c.cfg.Tracer.Start(ctx, WebhooksPatchWebhookOperation,
 		trace.WithAttributes(otelAttrs...),
 		clientSpanKind,
 	)
@@ -765,16 +4388,16 @@ func (c *Client) sendServicePatchUser(ctx context.Context, request *User, params
 	stage = "BuildURL"
 	u := uri.Clone(c.requestURL(ctx))
 	var pathParts [2]string
-	pathParts[0] = "/users/"
+	pathParts[0] = "/webhooks/"
 	{
-		// Encode "userId" parameter.
+		// Encode "webhookId" parameter.
 		e := uri.NewPathEncoder(uri.PathEncoderConfig{
-			Param:   "userId",
+			Param:   "webhookId",
 			Style:   uri.PathStyleSimple,
 			Explode: false,
 		})
 		if err := func() error {
-			if unwrapped := uuid.UUID(params.UserId); true {
+			if unwrapped := uuid.UUID(params.WebhookId); true {
 				return e.EncodeValue(conv.UUIDToString(unwrapped))
 			}
 			return nil
@@ -794,7 +4417,7 @@ func (c *Client) sendServicePatchUser(ctx context.Context, request *User, params
 	if err != nil {
 		return res, errors.Wrap(err, "create request")
 	}
-	if err := encodeServicePatchUserRequest(request, r); err != nil {
+	if err := encodeWebhooksPatchWebhookRequest(request, r); err != nil {
 		return res, errors.Wrap(err, "encode request")
 	}
 
@@ -803,7 +4426,7 @@ func (c *Client) sendServicePatchUser(ctx context.Context, request *User, params
 		var satisfied bitset
 		{
 			stage = "Security:BasicAuth"
-			switch err := c.securityBasicAuth(ctx, ServicePatchUserOperation, r); {
+			switch err := c.securityBasicAuth(ctx, WebhooksPatchWebhookOperation, r); {
 			case err == nil: // if NO error
 				satisfied[0] |= 1 << 0
 			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
@@ -839,7 +4462,7 @@ func (c *Client) sendServicePatchUser(ctx context.Context, request *User, params
 	defer resp.Body.Close()
 
 	stage = "DecodeResponse"
-	result, err := decodeServicePatchUserResponse(resp)
+	result, err := decodeWebhooksPatchWebhookResponse(resp)
 	if err != nil {
 		return res, errors.Wrap(err, "decode response")
 	}
@@ -847,23 +4470,22 @@ func (c *Client) sendServicePatchUser(ctx context.Context, request *User, params
 	return result, nil
 }
 
-// ServicePutUser invokes Service_putUser operation.
+// WebhooksRedeliverWebhookDelivery invokes Webhooks_redeliverWebhookDelivery operation.
 //
-// Put a new User params
-// - all fields must be provided, except id
+// Queue the delivery again, attempts start over
 // - admin permission required.
 //
-// PUT /users/{userId}
-func (c *Client) ServicePutUser(ctx context.Context, request *User, params ServicePutUserParams) (ServicePutUserRes, error) {
-	res, err := c.sendServicePutUser(ctx, request, params)
+// POST /webhooks/{webhookId}/deliveries/{deliveryId}/redeliver
+func (c *Client) WebhooksRedeliverWebhookDelivery(ctx context.Context, params WebhooksRedeliverWebhookDeliveryParams) (WebhooksRedeliverWebhookDeliveryRes, error) {
+	res, err := c.sendWebhooksRedeliverWebhookDelivery(ctx, params)
 	return res, err
 }
 
-func (c *Client) sendServicePutUser(ctx context.Context, request *User, params ServicePutUserParams) (res ServicePutUserRes, err error) {
+func (c *Client) sendWebhooksRedeliverWebhookDelivery(ctx context.Context, params WebhooksRedeliverWebhookDeliveryParams) (res WebhooksRedeliverWebhookDeliveryRes, err error) {
 	otelAttrs := []attribute.KeyValue{
-		otelogen.OperationID("Service_putUser"),
-		semconv.HTTPRequestMethodKey.String("PUT"),
-		semconv.HTTPRouteKey.String("/users/{userId}"),
+		otelogen.OperationID("Webhooks_redeliverWebhookDelivery"),
+		semconv.HTTPRequestMethodKey.String("POST"),
+		semconv.HTTPRouteKey.String("/webhooks/{webhookId}/deliveries/{deliveryId}/redeliver"),
 	}
 
 	// Run stopwatch.
@@ -878,7 +4500,7 @@ func (c *Client) sendServicePutUser(ctx context.Context, request *User, params S
 	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
 
 	// Start a span for this request.
-	ctx, span := c.cfg.Tracer.Start(ctx, ServicePutUserOperation,
+	ctx, span := c.cfg.Tracer.Start(ctx, WebhooksRedeliverWebhookDeliveryOperation,
 		trace.WithAttributes(otelAttrs...),
 		clientSpanKind,
 	)
@@ -895,17 +4517,17 @@ func (c *Client) sendServicePutUser(ctx context.Context, request *User, params S
 
 	stage = "BuildURL"
 	u := uri.Clone(c.requestURL(ctx))
-	var pathParts [2]string
-	pathParts[0] = "/users/"
+	var pathParts [5]string
+	pathParts[0] = "/webhooks/"
 	{
-		// Encode "userId" parameter.
+		// Encode "webhookId" parameter.
 		e := uri.NewPathEncoder(uri.PathEncoderConfig{
-			Param:   "userId",
+			Param:   "webhookId",
 			Style:   uri.PathStyleSimple,
 			Explode: false,
 		})
 		if err := func() error {
-			if unwrapped := uuid.UUID(params.UserId); true {
+			if unwrapped := uuid.UUID(params.WebhookId); true {
 				return e.EncodeValue(conv.UUIDToString(unwrapped))
 			}
 			return nil
@@ -918,23 +4540,43 @@ func (c *Client) sendServicePutUser(ctx context.Context, request *User, params S
 		}
 		pathParts[1] = encoded
 	}
+	pathParts[2] = "/deliveries/"
+	{
+		// Encode "deliveryId" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "deliveryId",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			if unwrapped := uuid.UUID(params.DeliveryId); true {
+				return e.EncodeValue(conv.UUIDToString(unwrapped))
+			}
+			return nil
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[3] = encoded
+	}
+	pathParts[4] = "/redeliver"
 	uri.AddPathParts(u, pathParts[:]...)
 
 	stage = "EncodeRequest"
-	r, err := ht.NewRequest(ctx, "PUT", u)
+	r, err := ht.NewRequest(ctx, "POST", u)
 	if err != nil {
 		return res, errors.Wrap(err, "create request")
 	}
-	if err := encodeServicePutUserRequest(request, r); err != nil {
-		return res, errors.Wrap(err, "encode request")
-	}
 
 	{
 		type bitset = [1]uint8
 		var satisfied bitset
 		{
 			stage = "Security:BasicAuth"
-			switch err := c.securityBasicAuth(ctx, ServicePutUserOperation, r); {
+			switch err := c.securityBasicAuth(ctx, WebhooksRedeliverWebhookDeliveryOperation, r); {
 			case err == nil: // if NO error
 				satisfied[0] |= 1 << 0
 			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
@@ -970,7 +4612,7 @@ func (c *Client) sendServicePutUser(ctx context.Context, request *User, params S
 	defer resp.Body.Close()
 
 	stage = "DecodeResponse"
-	result, err := decodeServicePutUserResponse(resp)
+	result, err := decodeWebhooksRedeliverWebhookDeliveryResponse(resp)
 	if err != nil {
 		return res, errors.Wrap(err, "decode response")
 	}
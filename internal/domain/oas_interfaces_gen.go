@@ -1,6 +1,62 @@
 // Code generated by ogen, DO NOT EDIT.
 package api
 
+type ChangePasswordRes interface {
+	changePasswordRes()
+}
+
+type GroupsAddGroupMemberRes interface {
+	groupsAddGroupMemberRes()
+}
+
+type GroupsCreateGroupRes interface {
+	groupsCreateGroupRes()
+}
+
+type GroupsDeleteGroupRes interface {
+	groupsDeleteGroupRes()
+}
+
+type GroupsGetGroupRes interface {
+	groupsGetGroupRes()
+}
+
+type GroupsListGroupMembersRes interface {
+	groupsListGroupMembersRes()
+}
+
+type GroupsListGroupsRes interface {
+	groupsListGroupsRes()
+}
+
+type GroupsPatchGroupRes interface {
+	groupsPatchGroupRes()
+}
+
+type GroupsRemoveGroupMemberRes interface {
+	groupsRemoveGroupMemberRes()
+}
+
+type OrganizationsCreateOrganizationRes interface {
+	organizationsCreateOrganizationRes()
+}
+
+type OrganizationsDeleteOrganizationRes interface {
+	organizationsDeleteOrganizationRes()
+}
+
+type OrganizationsGetOrganizationRes interface {
+	organizationsGetOrganizationRes()
+}
+
+type OrganizationsListOrganizationsRes interface {
+	organizationsListOrganizationsRes()
+}
+
+type ServiceBatchUsersRes interface {
+	serviceBatchUsersRes()
+}
+
 type ServiceCreateUserRes interface {
 	serviceCreateUserRes()
 }
@@ -9,14 +65,34 @@ type ServiceDeleteUserRes interface {
 	serviceDeleteUserRes()
 }
 
+type ServiceExportUsersRes interface {
+	serviceExportUsersRes()
+}
+
+type ServiceGetUserGroupsRes interface {
+	serviceGetUserGroupsRes()
+}
+
 type ServiceGetUserRes interface {
 	serviceGetUserRes()
 }
 
+type ServiceImportUsersReq interface {
+	serviceImportUsersReq()
+}
+
+type ServiceImportUsersRes interface {
+	serviceImportUsersRes()
+}
+
 type ServiceListUsersRes interface {
 	serviceListUsersRes()
 }
 
+type ServicePatchUserReq interface {
+	servicePatchUserReq()
+}
+
 type ServicePatchUserRes interface {
 	servicePatchUserRes()
 }
@@ -24,3 +100,35 @@ type ServicePatchUserRes interface {
 type ServicePutUserRes interface {
 	servicePutUserRes()
 }
+
+type ServiceWatchUsersRes interface {
+	serviceWatchUsersRes()
+}
+
+type WebhooksCreateWebhookRes interface {
+	webhooksCreateWebhookRes()
+}
+
+type WebhooksDeleteWebhookRes interface {
+	webhooksDeleteWebhookRes()
+}
+
+type WebhooksGetWebhookRes interface {
+	webhooksGetWebhookRes()
+}
+
+type WebhooksListWebhookDeliveriesRes interface {
+	webhooksListWebhookDeliveriesRes()
+}
+
+type WebhooksListWebhooksRes interface {
+	webhooksListWebhooksRes()
+}
+
+type WebhooksPatchWebhookRes interface {
+	webhooksPatchWebhookRes()
+}
+
+type WebhooksRedeliverWebhookDeliveryRes interface {
+	webhooksRedeliverWebhookDeliveryRes()
+}
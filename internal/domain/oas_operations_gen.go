@@ -6,11 +6,36 @@ package api
 type OperationName = string
 
 const (
-	HealthOperation            OperationName = "Health"
-	ServiceCreateUserOperation OperationName = "ServiceCreateUser"
-	ServiceDeleteUserOperation OperationName = "ServiceDeleteUser"
-	ServiceGetUserOperation    OperationName = "ServiceGetUser"
-	ServiceListUsersOperation  OperationName = "ServiceListUsers"
-	ServicePatchUserOperation  OperationName = "ServicePatchUser"
-	ServicePutUserOperation    OperationName = "ServicePutUser"
+	ChangePasswordOperation                   OperationName = "ChangePassword"
+	GroupsAddGroupMemberOperation             OperationName = "GroupsAddGroupMember"
+	GroupsCreateGroupOperation                OperationName = "GroupsCreateGroup"
+	GroupsDeleteGroupOperation                OperationName = "GroupsDeleteGroup"
+	GroupsGetGroupOperation                   OperationName = "GroupsGetGroup"
+	GroupsListGroupMembersOperation           OperationName = "GroupsListGroupMembers"
+	GroupsListGroupsOperation                 OperationName = "GroupsListGroups"
+	GroupsPatchGroupOperation                 OperationName = "GroupsPatchGroup"
+	GroupsRemoveGroupMemberOperation          OperationName = "GroupsRemoveGroupMember"
+	HealthOperation                           OperationName = "Health"
+	OrganizationsCreateOrganizationOperation  OperationName = "OrganizationsCreateOrganization"
+	OrganizationsDeleteOrganizationOperation  OperationName = "OrganizationsDeleteOrganization"
+	OrganizationsGetOrganizationOperation     OperationName = "OrganizationsGetOrganization"
+	OrganizationsListOrganizationsOperation   OperationName = "OrganizationsListOrganizations"
+	ServiceBatchUsersOperation                OperationName = "ServiceBatchUsers"
+	ServiceCreateUserOperation                OperationName = "ServiceCreateUser"
+	ServiceDeleteUserOperation                OperationName = "ServiceDeleteUser"
+	ServiceExportUsersOperation               OperationName = "ServiceExportUsers"
+	ServiceGetUserOperation                   OperationName = "ServiceGetUser"
+	ServiceGetUserGroupsOperation             OperationName = "ServiceGetUserGroups"
+	ServiceImportUsersOperation               OperationName = "ServiceImportUsers"
+	ServiceListUsersOperation                 OperationName = "ServiceListUsers"
+	ServicePatchUserOperation                 OperationName = "ServicePatchUser"
+	ServicePutUserOperation                   OperationName = "ServicePutUser"
+	ServiceWatchUsersOperation                OperationName = "ServiceWatchUsers"
+	WebhooksCreateWebhookOperation            OperationName = "WebhooksCreateWebhook"
+	WebhooksDeleteWebhookOperation            OperationName = "WebhooksDeleteWebhook"
+	WebhooksGetWebhookOperation               OperationName = "WebhooksGetWebhook"
+	WebhooksListWebhookDeliveriesOperation    OperationName = "WebhooksListWebhookDeliveries"
+	WebhooksListWebhooksOperation             OperationName = "WebhooksListWebhooks"
+	WebhooksPatchWebhookOperation             OperationName = "WebhooksPatchWebhook"
+	WebhooksRedeliverWebhookDeliveryOperation OperationName = "WebhooksRedeliverWebhookDelivery"
 )
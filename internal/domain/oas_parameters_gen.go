@@ -3,6 +3,7 @@
 package api
 
 import (
+	"fmt"
 	"net/http"
 	"net/url"
 
@@ -16,24 +17,2102 @@ import (
 	"github.com/ogen-go/ogen/validate"
 )
 
+// GroupsAddGroupMemberParams is parameters of Groups_addGroupMember operation.
+type GroupsAddGroupMemberParams struct {
+	GroupId UUID
+}
+
+func unpackGroupsAddGroupMemberParams(packed middleware.Parameters) (params GroupsAddGroupMemberParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "groupId",
+			In:   "path",
+		}
+		params.GroupId = packed[key].(UUID)
+	}
+	return params
+}
+
+func decodeGroupsAddGroupMemberParams(args [1]string, argsEscaped bool, r *http.Request) (params GroupsAddGroupMemberParams, _ error) {
+	// Decode path: groupId.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "groupId",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				var paramsDotGroupIdVal uuid.UUID
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToUUID(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotGroupIdVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.GroupId = UUID(paramsDotGroupIdVal)
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "groupId",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// GroupsDeleteGroupParams is parameters of Groups_deleteGroup operation.
+type GroupsDeleteGroupParams struct {
+	GroupId UUID
+}
+
+func unpackGroupsDeleteGroupParams(packed middleware.Parameters) (params GroupsDeleteGroupParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "groupId",
+			In:   "path",
+		}
+		params.GroupId = packed[key].(UUID)
+	}
+	return params
+}
+
+func decodeGroupsDeleteGroupParams(args [1]string, argsEscaped bool, r *http.Request) (params GroupsDeleteGroupParams, _ error) {
+	// Decode path: groupId.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "groupId",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				var paramsDotGroupIdVal uuid.UUID
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToUUID(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotGroupIdVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.GroupId = UUID(paramsDotGroupIdVal)
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "groupId",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// GroupsGetGroupParams is parameters of Groups_getGroup operation.
+type GroupsGetGroupParams struct {
+	GroupId UUID
+}
+
+func unpackGroupsGetGroupParams(packed middleware.Parameters) (params GroupsGetGroupParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "groupId",
+			In:   "path",
+		}
+		params.GroupId = packed[key].(UUID)
+	}
+	return params
+}
+
+func decodeGroupsGetGroupParams(args [1]string, argsEscaped bool, r *http.Request) (params GroupsGetGroupParams, _ error) {
+	// Decode path: groupId.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "groupId",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				var paramsDotGroupIdVal uuid.UUID
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToUUID(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotGroupIdVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.GroupId = UUID(paramsDotGroupIdVal)
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "groupId",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// GroupsListGroupMembersParams is parameters of Groups_listGroupMembers operation.
+type GroupsListGroupMembersParams struct {
+	GroupId UUID
+}
+
+func unpackGroupsListGroupMembersParams(packed middleware.Parameters) (params GroupsListGroupMembersParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "groupId",
+			In:   "path",
+		}
+		params.GroupId = packed[key].(UUID)
+	}
+	return params
+}
+
+func decodeGroupsListGroupMembersParams(args [1]string, argsEscaped bool, r *http.Request) (params GroupsListGroupMembersParams, _ error) {
+	// Decode path: groupId.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "groupId",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				var paramsDotGroupIdVal uuid.UUID
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToUUID(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotGroupIdVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.GroupId = UUID(paramsDotGroupIdVal)
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "groupId",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// GroupsListGroupsParams is parameters of Groups_listGroups operation.
+type GroupsListGroupsParams struct {
+	Offset OptInt64
+}
+
+func unpackGroupsListGroupsParams(packed middleware.Parameters) (params GroupsListGroupsParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "offset",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.Offset = v.(OptInt64)
+		}
+	}
+	return params
+}
+
+func decodeGroupsListGroupsParams(args [0]string, argsEscaped bool, r *http.Request) (params GroupsListGroupsParams, _ error) {
+	q := uri.NewQueryDecoder(r.URL.Query())
+	// Decode query: offset.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "offset",
+			Style:   uri.QueryStyleForm,
+			Explode: false,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotOffsetVal int64
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToInt64(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotOffsetVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.Offset.SetTo(paramsDotOffsetVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "offset",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// GroupsPatchGroupParams is parameters of Groups_patchGroup operation.
+type GroupsPatchGroupParams struct {
+	GroupId UUID
+}
+
+func unpackGroupsPatchGroupParams(packed middleware.Parameters) (params GroupsPatchGroupParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "groupId",
+			In:   "path",
+		}
+		params.GroupId = packed[key].(UUID)
+	}
+	return params
+}
+
+func decodeGroupsPatchGroupParams(args [1]string, argsEscaped bool, r *http.Request) (params GroupsPatchGroupParams, _ error) {
+	// Decode path: groupId.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "groupId",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				var paramsDotGroupIdVal uuid.UUID
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToUUID(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotGroupIdVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.GroupId = UUID(paramsDotGroupIdVal)
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "groupId",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// GroupsRemoveGroupMemberParams is parameters of Groups_removeGroupMember operation.
+type GroupsRemoveGroupMemberParams struct {
+	GroupId  UUID
+	MemberId UUID
+}
+
+func unpackGroupsRemoveGroupMemberParams(packed middleware.Parameters) (params GroupsRemoveGroupMemberParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "groupId",
+			In:   "path",
+		}
+		params.GroupId = packed[key].(UUID)
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "memberId",
+			In:   "path",
+		}
+		params.MemberId = packed[key].(UUID)
+	}
+	return params
+}
+
+func decodeGroupsRemoveGroupMemberParams(args [2]string, argsEscaped bool, r *http.Request) (params GroupsRemoveGroupMemberParams, _ error) {
+	// Decode path: groupId.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "groupId",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				var paramsDotGroupIdVal uuid.UUID
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToUUID(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotGroupIdVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.GroupId = UUID(paramsDotGroupIdVal)
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "groupId",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	// Decode path: memberId.
+	if err := func() error {
+		param := args[1]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[1])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "memberId",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				var paramsDotMemberIdVal uuid.UUID
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToUUID(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotMemberIdVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.MemberId = UUID(paramsDotMemberIdVal)
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "memberId",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// OrganizationsDeleteOrganizationParams is parameters of Organizations_deleteOrganization operation.
+type OrganizationsDeleteOrganizationParams struct {
+	OrganizationId UUID
+}
+
+func unpackOrganizationsDeleteOrganizationParams(packed middleware.Parameters) (params OrganizationsDeleteOrganizationParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "organizationId",
+			In:   "path",
+		}
+		params.OrganizationId = packed[key].(UUID)
+	}
+	return params
+}
+
+func decodeOrganizationsDeleteOrganizationParams(args [1]string, argsEscaped bool, r *http.Request) (params OrganizationsDeleteOrganizationParams, _ error) {
+	// Decode path: organizationId.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "organizationId",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				var paramsDotOrganizationIdVal uuid.UUID
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToUUID(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotOrganizationIdVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.OrganizationId = UUID(paramsDotOrganizationIdVal)
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "organizationId",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// OrganizationsGetOrganizationParams is parameters of Organizations_getOrganization operation.
+type OrganizationsGetOrganizationParams struct {
+	OrganizationId UUID
+}
+
+func unpackOrganizationsGetOrganizationParams(packed middleware.Parameters) (params OrganizationsGetOrganizationParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "organizationId",
+			In:   "path",
+		}
+		params.OrganizationId = packed[key].(UUID)
+	}
+	return params
+}
+
+func decodeOrganizationsGetOrganizationParams(args [1]string, argsEscaped bool, r *http.Request) (params OrganizationsGetOrganizationParams, _ error) {
+	// Decode path: organizationId.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "organizationId",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				var paramsDotOrganizationIdVal uuid.UUID
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToUUID(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotOrganizationIdVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.OrganizationId = UUID(paramsDotOrganizationIdVal)
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "organizationId",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// OrganizationsListOrganizationsParams is parameters of Organizations_listOrganizations operation.
+type OrganizationsListOrganizationsParams struct {
+	Offset OptInt64
+}
+
+func unpackOrganizationsListOrganizationsParams(packed middleware.Parameters) (params OrganizationsListOrganizationsParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "offset",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.Offset = v.(OptInt64)
+		}
+	}
+	return params
+}
+
+func decodeOrganizationsListOrganizationsParams(args [0]string, argsEscaped bool, r *http.Request) (params OrganizationsListOrganizationsParams, _ error) {
+	q := uri.NewQueryDecoder(r.URL.Query())
+	// Decode query: offset.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "offset",
+			Style:   uri.QueryStyleForm,
+			Explode: false,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotOffsetVal int64
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToInt64(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotOffsetVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.Offset.SetTo(paramsDotOffsetVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "offset",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// ServiceBatchUsersParams is parameters of Service_batchUsers operation.
+type ServiceBatchUsersParams struct {
+	Atomic OptBool
+}
+
+func unpackServiceBatchUsersParams(packed middleware.Parameters) (params ServiceBatchUsersParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "atomic",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.Atomic = v.(OptBool)
+		}
+	}
+	return params
+}
+
+func decodeServiceBatchUsersParams(args [0]string, argsEscaped bool, r *http.Request) (params ServiceBatchUsersParams, _ error) {
+	q := uri.NewQueryDecoder(r.URL.Query())
+	// Decode query: atomic.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "atomic",
+			Style:   uri.QueryStyleForm,
+			Explode: false,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotAtomicVal bool
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToBool(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotAtomicVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.Atomic.SetTo(paramsDotAtomicVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "atomic",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
 // ServiceDeleteUserParams is parameters of Service_deleteUser operation.
 type ServiceDeleteUserParams struct {
 	UserId UUID
 }
 
-func unpackServiceDeleteUserParams(packed middleware.Parameters) (params ServiceDeleteUserParams) {
+func unpackServiceDeleteUserParams(packed middleware.Parameters) (params ServiceDeleteUserParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "userId",
+			In:   "path",
+		}
+		params.UserId = packed[key].(UUID)
+	}
+	return params
+}
+
+func decodeServiceDeleteUserParams(args [1]string, argsEscaped bool, r *http.Request) (params ServiceDeleteUserParams, _ error) {
+	// Decode path: userId.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "userId",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				var paramsDotUserIdVal uuid.UUID
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToUUID(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotUserIdVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.UserId = UUID(paramsDotUserIdVal)
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "userId",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// ServiceExportUsersParams is parameters of Service_exportUsers operation.
+type ServiceExportUsersParams struct {
+	Accept  OptString
+	Cursor  OptUUID
+	IsAdmin OptBool
+	// Case-insensitive substring of username or email.
+	Search OptString
+}
+
+func unpackServiceExportUsersParams(packed middleware.Parameters) (params ServiceExportUsersParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "accept",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.Accept = v.(OptString)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "cursor",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.Cursor = v.(OptUUID)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "is_admin",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.IsAdmin = v.(OptBool)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "search",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.Search = v.(OptString)
+		}
+	}
+	return params
+}
+
+func decodeServiceExportUsersParams(args [0]string, argsEscaped bool, r *http.Request) (params ServiceExportUsersParams, _ error) {
+	q := uri.NewQueryDecoder(r.URL.Query())
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: accept.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "accept",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotAcceptVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotAcceptVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.Accept.SetTo(paramsDotAcceptVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "accept",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	// Decode query: cursor.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "cursor",
+			Style:   uri.QueryStyleForm,
+			Explode: false,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotCursorVal UUID
+				if err := func() error {
+					var paramsDotCursorValVal uuid.UUID
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToUUID(val)
+						if err != nil {
+							return err
+						}
+
+						paramsDotCursorValVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					paramsDotCursorVal = UUID(paramsDotCursorValVal)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.Cursor.SetTo(paramsDotCursorVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "cursor",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode query: is_admin.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "is_admin",
+			Style:   uri.QueryStyleForm,
+			Explode: false,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotIsAdminVal bool
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToBool(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotIsAdminVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.IsAdmin.SetTo(paramsDotIsAdminVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "is_admin",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode query: search.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "search",
+			Style:   uri.QueryStyleForm,
+			Explode: false,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotSearchVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotSearchVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.Search.SetTo(paramsDotSearchVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "search",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// ServiceGetUserParams is parameters of Service_getUser operation.
+type ServiceGetUserParams struct {
+	UserId UUID
+	// Comma-separated fields of the user to return, all of them by default, unknown fields are rejected
+	// with 400.
+	Fields []UserField
+	// Comma-separated related resources to return with the user, unknown resources are rejected with 400.
+	Expand []UserExpand
+}
+
+func unpackServiceGetUserParams(packed middleware.Parameters) (params ServiceGetUserParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "userId",
+			In:   "path",
+		}
+		params.UserId = packed[key].(UUID)
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "fields",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.Fields = v.([]UserField)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "expand",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.Expand = v.([]UserExpand)
+		}
+	}
+	return params
+}
+
+func decodeServiceGetUserParams(args [1]string, argsEscaped bool, r *http.Request) (params ServiceGetUserParams, _ error) {
+	q := uri.NewQueryDecoder(r.URL.Query())
+	// Decode path: userId.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "userId",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				var paramsDotUserIdVal uuid.UUID
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToUUID(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotUserIdVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.UserId = UUID(paramsDotUserIdVal)
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "userId",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	// Decode query: fields.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "fields",
+			Style:   uri.QueryStyleForm,
+			Explode: false,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				return d.DecodeArray(func(d uri.Decoder) error {
+					var paramsDotFieldsVal UserField
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						paramsDotFieldsVal = UserField(c)
+						return nil
+					}(); err != nil {
+						return err
+					}
+					params.Fields = append(params.Fields, paramsDotFieldsVal)
+					return nil
+				})
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				var failures []validate.FieldError
+				for i, elem := range params.Fields {
+					if err := func() error {
+						if err := elem.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						failures = append(failures, validate.FieldError{
+							Name:  fmt.Sprintf("[%d]", i),
+							Error: err,
+						})
+					}
+				}
+				if len(failures) > 0 {
+					return &validate.Error{Fields: failures}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "fields",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode query: expand.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "expand",
+			Style:   uri.QueryStyleForm,
+			Explode: false,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				return d.DecodeArray(func(d uri.Decoder) error {
+					var paramsDotExpandVal UserExpand
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						paramsDotExpandVal = UserExpand(c)
+						return nil
+					}(); err != nil {
+						return err
+					}
+					params.Expand = append(params.Expand, paramsDotExpandVal)
+					return nil
+				})
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				var failures []validate.FieldError
+				for i, elem := range params.Expand {
+					if err := func() error {
+						if err := elem.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						failures = append(failures, validate.FieldError{
+							Name:  fmt.Sprintf("[%d]", i),
+							Error: err,
+						})
+					}
+				}
+				if len(failures) > 0 {
+					return &validate.Error{Fields: failures}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "expand",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// ServiceGetUserGroupsParams is parameters of Service_getUserGroups operation.
+type ServiceGetUserGroupsParams struct {
+	UserId UUID
+}
+
+func unpackServiceGetUserGroupsParams(packed middleware.Parameters) (params ServiceGetUserGroupsParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "userId",
+			In:   "path",
+		}
+		params.UserId = packed[key].(UUID)
+	}
+	return params
+}
+
+func decodeServiceGetUserGroupsParams(args [1]string, argsEscaped bool, r *http.Request) (params ServiceGetUserGroupsParams, _ error) {
+	// Decode path: userId.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "userId",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				var paramsDotUserIdVal uuid.UUID
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToUUID(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotUserIdVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.UserId = UUID(paramsDotUserIdVal)
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "userId",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// ServiceImportUsersParams is parameters of Service_importUsers operation.
+type ServiceImportUsersParams struct {
+	DryRun     OptBool
+	OnConflict OptServiceImportUsersOnConflict
+}
+
+func unpackServiceImportUsersParams(packed middleware.Parameters) (params ServiceImportUsersParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "dry_run",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.DryRun = v.(OptBool)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "on_conflict",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.OnConflict = v.(OptServiceImportUsersOnConflict)
+		}
+	}
+	return params
+}
+
+func decodeServiceImportUsersParams(args [0]string, argsEscaped bool, r *http.Request) (params ServiceImportUsersParams, _ error) {
+	q := uri.NewQueryDecoder(r.URL.Query())
+	// Decode query: dry_run.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "dry_run",
+			Style:   uri.QueryStyleForm,
+			Explode: false,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotDryRunVal bool
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToBool(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotDryRunVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.DryRun.SetTo(paramsDotDryRunVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "dry_run",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode query: on_conflict.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "on_conflict",
+			Style:   uri.QueryStyleForm,
+			Explode: false,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotOnConflictVal ServiceImportUsersOnConflict
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotOnConflictVal = ServiceImportUsersOnConflict(c)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.OnConflict.SetTo(paramsDotOnConflictVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.OnConflict.Get(); ok {
+					if err := func() error {
+						if err := value.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "on_conflict",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// ServiceListUsersParams is parameters of Service_listUsers operation.
+type ServiceListUsersParams struct {
+	Offset  OptInt64
+	IsAdmin OptBool
+	// Case-insensitive substring of username or email.
+	Search OptString
+	// Comma-separated fields of the user to return, all of them by default, unknown fields are rejected
+	// with 400.
+	Fields []UserField
+	// Comma-separated related resources to return with the user, unknown resources are rejected with 400.
+	Expand []UserExpand
+}
+
+func unpackServiceListUsersParams(packed middleware.Parameters) (params ServiceListUsersParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "offset",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.Offset = v.(OptInt64)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "is_admin",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.IsAdmin = v.(OptBool)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "search",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.Search = v.(OptString)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "fields",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.Fields = v.([]UserField)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "expand",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.Expand = v.([]UserExpand)
+		}
+	}
+	return params
+}
+
+func decodeServiceListUsersParams(args [0]string, argsEscaped bool, r *http.Request) (params ServiceListUsersParams, _ error) {
+	q := uri.NewQueryDecoder(r.URL.Query())
+	// Decode query: offset.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "offset",
+			Style:   uri.QueryStyleForm,
+			Explode: false,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotOffsetVal int64
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToInt64(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotOffsetVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.Offset.SetTo(paramsDotOffsetVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "offset",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode query: is_admin.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "is_admin",
+			Style:   uri.QueryStyleForm,
+			Explode: false,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotIsAdminVal bool
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToBool(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotIsAdminVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.IsAdmin.SetTo(paramsDotIsAdminVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "is_admin",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode query: search.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "search",
+			Style:   uri.QueryStyleForm,
+			Explode: false,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotSearchVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotSearchVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.Search.SetTo(paramsDotSearchVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "search",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode query: fields.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "fields",
+			Style:   uri.QueryStyleForm,
+			Explode: false,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				return d.DecodeArray(func(d uri.Decoder) error {
+					var paramsDotFieldsVal UserField
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						paramsDotFieldsVal = UserField(c)
+						return nil
+					}(); err != nil {
+						return err
+					}
+					params.Fields = append(params.Fields, paramsDotFieldsVal)
+					return nil
+				})
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				var failures []validate.FieldError
+				for i, elem := range params.Fields {
+					if err := func() error {
+						if err := elem.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						failures = append(failures, validate.FieldError{
+							Name:  fmt.Sprintf("[%d]", i),
+							Error: err,
+						})
+					}
+				}
+				if len(failures) > 0 {
+					return &validate.Error{Fields: failures}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "fields",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode query: expand.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "expand",
+			Style:   uri.QueryStyleForm,
+			Explode: false,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				return d.DecodeArray(func(d uri.Decoder) error {
+					var paramsDotExpandVal UserExpand
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						paramsDotExpandVal = UserExpand(c)
+						return nil
+					}(); err != nil {
+						return err
+					}
+					params.Expand = append(params.Expand, paramsDotExpandVal)
+					return nil
+				})
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				var failures []validate.FieldError
+				for i, elem := range params.Expand {
+					if err := func() error {
+						if err := elem.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						failures = append(failures, validate.FieldError{
+							Name:  fmt.Sprintf("[%d]", i),
+							Error: err,
+						})
+					}
+				}
+				if len(failures) > 0 {
+					return &validate.Error{Fields: failures}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "expand",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// ServicePatchUserParams is parameters of Service_patchUser operation.
+type ServicePatchUserParams struct {
+	UserId UUID
+}
+
+func unpackServicePatchUserParams(packed middleware.Parameters) (params ServicePatchUserParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "userId",
+			In:   "path",
+		}
+		params.UserId = packed[key].(UUID)
+	}
+	return params
+}
+
+func decodeServicePatchUserParams(args [1]string, argsEscaped bool, r *http.Request) (params ServicePatchUserParams, _ error) {
+	// Decode path: userId.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "userId",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				var paramsDotUserIdVal uuid.UUID
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToUUID(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotUserIdVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.UserId = UUID(paramsDotUserIdVal)
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "userId",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// ServicePutUserParams is parameters of Service_putUser operation.
+type ServicePutUserParams struct {
+	UserId UUID
+}
+
+func unpackServicePutUserParams(packed middleware.Parameters) (params ServicePutUserParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "userId",
+			In:   "path",
+		}
+		params.UserId = packed[key].(UUID)
+	}
+	return params
+}
+
+func decodeServicePutUserParams(args [1]string, argsEscaped bool, r *http.Request) (params ServicePutUserParams, _ error) {
+	// Decode path: userId.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "userId",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				var paramsDotUserIdVal uuid.UUID
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToUUID(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotUserIdVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.UserId = UUID(paramsDotUserIdVal)
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "userId",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// ServiceWatchUsersParams is parameters of Service_watchUsers operation.
+type ServiceWatchUsersParams struct {
+	LastEventID OptString
+}
+
+func unpackServiceWatchUsersParams(packed middleware.Parameters) (params ServiceWatchUsersParams) {
 	{
 		key := middleware.ParameterKey{
-			Name: "userId",
+			Name: "Last-Event-ID",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.LastEventID = v.(OptString)
+		}
+	}
+	return params
+}
+
+func decodeServiceWatchUsersParams(args [0]string, argsEscaped bool, r *http.Request) (params ServiceWatchUsersParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: Last-Event-ID.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "Last-Event-ID",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotLastEventIDVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotLastEventIDVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.LastEventID.SetTo(paramsDotLastEventIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "Last-Event-ID",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// WebhooksDeleteWebhookParams is parameters of Webhooks_deleteWebhook operation.
+type WebhooksDeleteWebhookParams struct {
+	WebhookId UUID
+}
+
+func unpackWebhooksDeleteWebhookParams(packed middleware.Parameters) (params WebhooksDeleteWebhookParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "webhookId",
 			In:   "path",
 		}
-		params.UserId = packed[key].(UUID)
+		params.WebhookId = packed[key].(UUID)
 	}
 	return params
 }
 
-func decodeServiceDeleteUserParams(args [1]string, argsEscaped bool, r *http.Request) (params ServiceDeleteUserParams, _ error) {
-	// Decode path: userId.
+func decodeWebhooksDeleteWebhookParams(args [1]string, argsEscaped bool, r *http.Request) (params WebhooksDeleteWebhookParams, _ error) {
+	// Decode path: webhookId.
 	if err := func() error {
 		param := args[0]
 		if argsEscaped {
@@ -45,14 +2124,14 @@ func decodeServiceDeleteUserParams(args [1]string, argsEscaped bool, r *http.Req
 		}
 		if len(param) > 0 {
 			d := uri.NewPathDecoder(uri.PathDecoderConfig{
-				Param:   "userId",
+				Param:   "webhookId",
 				Value:   param,
 				Style:   uri.PathStyleSimple,
 				Explode: false,
 			})
 
 			if err := func() error {
-				var paramsDotUserIdVal uuid.UUID
+				var paramsDotWebhookIdVal uuid.UUID
 				if err := func() error {
 					val, err := d.DecodeValue()
 					if err != nil {
@@ -64,12 +2143,12 @@ func decodeServiceDeleteUserParams(args [1]string, argsEscaped bool, r *http.Req
 						return err
 					}
 
-					paramsDotUserIdVal = c
+					paramsDotWebhookIdVal = c
 					return nil
 				}(); err != nil {
 					return err
 				}
-				params.UserId = UUID(paramsDotUserIdVal)
+				params.WebhookId = UUID(paramsDotWebhookIdVal)
 				return nil
 			}(); err != nil {
 				return err
@@ -80,7 +2159,7 @@ func decodeServiceDeleteUserParams(args [1]string, argsEscaped bool, r *http.Req
 		return nil
 	}(); err != nil {
 		return params, &ogenerrors.DecodeParamError{
-			Name: "userId",
+			Name: "webhookId",
 			In:   "path",
 			Err:  err,
 		}
@@ -88,24 +2167,24 @@ func decodeServiceDeleteUserParams(args [1]string, argsEscaped bool, r *http.Req
 	return params, nil
 }
 
-// ServiceGetUserParams is parameters of Service_getUser operation.
-type ServiceGetUserParams struct {
-	UserId UUID
+// WebhooksGetWebhookParams is parameters of Webhooks_getWebhook operation.
+type WebhooksGetWebhookParams struct {
+	WebhookId UUID
 }
 
-func unpackServiceGetUserParams(packed middleware.Parameters) (params ServiceGetUserParams) {
+func unpackWebhooksGetWebhookParams(packed middleware.Parameters) (params WebhooksGetWebhookParams) {
 	{
 		key := middleware.ParameterKey{
-			Name: "userId",
+			Name: "webhookId",
 			In:   "path",
 		}
-		params.UserId = packed[key].(UUID)
+		params.WebhookId = packed[key].(UUID)
 	}
 	return params
 }
 
-func decodeServiceGetUserParams(args [1]string, argsEscaped bool, r *http.Request) (params ServiceGetUserParams, _ error) {
-	// Decode path: userId.
+func decodeWebhooksGetWebhookParams(args [1]string, argsEscaped bool, r *http.Request) (params WebhooksGetWebhookParams, _ error) {
+	// Decode path: webhookId.
 	if err := func() error {
 		param := args[0]
 		if argsEscaped {
@@ -117,14 +2196,14 @@ func decodeServiceGetUserParams(args [1]string, argsEscaped bool, r *http.Reques
 		}
 		if len(param) > 0 {
 			d := uri.NewPathDecoder(uri.PathDecoderConfig{
-				Param:   "userId",
+				Param:   "webhookId",
 				Value:   param,
 				Style:   uri.PathStyleSimple,
 				Explode: false,
 			})
 
 			if err := func() error {
-				var paramsDotUserIdVal uuid.UUID
+				var paramsDotWebhookIdVal uuid.UUID
 				if err := func() error {
 					val, err := d.DecodeValue()
 					if err != nil {
@@ -136,12 +2215,12 @@ func decodeServiceGetUserParams(args [1]string, argsEscaped bool, r *http.Reques
 						return err
 					}
 
-					paramsDotUserIdVal = c
+					paramsDotWebhookIdVal = c
 					return nil
 				}(); err != nil {
 					return err
 				}
-				params.UserId = UUID(paramsDotUserIdVal)
+				params.WebhookId = UUID(paramsDotWebhookIdVal)
 				return nil
 			}(); err != nil {
 				return err
@@ -152,7 +2231,7 @@ func decodeServiceGetUserParams(args [1]string, argsEscaped bool, r *http.Reques
 		return nil
 	}(); err != nil {
 		return params, &ogenerrors.DecodeParamError{
-			Name: "userId",
+			Name: "webhookId",
 			In:   "path",
 			Err:  err,
 		}
@@ -160,12 +2239,136 @@ func decodeServiceGetUserParams(args [1]string, argsEscaped bool, r *http.Reques
 	return params, nil
 }
 
-// ServiceListUsersParams is parameters of Service_listUsers operation.
-type ServiceListUsersParams struct {
+// WebhooksListWebhookDeliveriesParams is parameters of Webhooks_listWebhookDeliveries operation.
+type WebhooksListWebhookDeliveriesParams struct {
+	WebhookId UUID
+	Offset    OptInt64
+}
+
+func unpackWebhooksListWebhookDeliveriesParams(packed middleware.Parameters) (params WebhooksListWebhookDeliveriesParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "webhookId",
+			In:   "path",
+		}
+		params.WebhookId = packed[key].(UUID)
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "offset",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.Offset = v.(OptInt64)
+		}
+	}
+	return params
+}
+
+func decodeWebhooksListWebhookDeliveriesParams(args [1]string, argsEscaped bool, r *http.Request) (params WebhooksListWebhookDeliveriesParams, _ error) {
+	q := uri.NewQueryDecoder(r.URL.Query())
+	// Decode path: webhookId.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "webhookId",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				var paramsDotWebhookIdVal uuid.UUID
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToUUID(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotWebhookIdVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.WebhookId = UUID(paramsDotWebhookIdVal)
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "webhookId",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	// Decode query: offset.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "offset",
+			Style:   uri.QueryStyleForm,
+			Explode: false,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotOffsetVal int64
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToInt64(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotOffsetVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.Offset.SetTo(paramsDotOffsetVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "offset",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// WebhooksListWebhooksParams is parameters of Webhooks_listWebhooks operation.
+type WebhooksListWebhooksParams struct {
 	Offset OptInt64
 }
 
-func unpackServiceListUsersParams(packed middleware.Parameters) (params ServiceListUsersParams) {
+func unpackWebhooksListWebhooksParams(packed middleware.Parameters) (params WebhooksListWebhooksParams) {
 	{
 		key := middleware.ParameterKey{
 			Name: "offset",
@@ -178,7 +2381,7 @@ func unpackServiceListUsersParams(packed middleware.Parameters) (params ServiceL
 	return params
 }
 
-func decodeServiceListUsersParams(args [0]string, argsEscaped bool, r *http.Request) (params ServiceListUsersParams, _ error) {
+func decodeWebhooksListWebhooksParams(args [0]string, argsEscaped bool, r *http.Request) (params WebhooksListWebhooksParams, _ error) {
 	q := uri.NewQueryDecoder(r.URL.Query())
 	// Decode query: offset.
 	if err := func() error {
@@ -224,24 +2427,24 @@ func decodeServiceListUsersParams(args [0]string, argsEscaped bool, r *http.Requ
 	return params, nil
 }
 
-// ServicePatchUserParams is parameters of Service_patchUser operation.
-type ServicePatchUserParams struct {
-	UserId UUID
+// WebhooksPatchWebhookParams is parameters of Webhooks_patchWebhook operation.
+type WebhooksPatchWebhookParams struct {
+	WebhookId UUID
 }
 
-func unpackServicePatchUserParams(packed middleware.Parameters) (params ServicePatchUserParams) {
+func unpackWebhooksPatchWebhookParams(packed middleware.Parameters) (params WebhooksPatchWebhookParams) {
 	{
 		key := middleware.ParameterKey{
-			Name: "userId",
+			Name: "webhookId",
 			In:   "path",
 		}
-		params.UserId = packed[key].(UUID)
+		params.WebhookId = packed[key].(UUID)
 	}
 	return params
 }
 
-func decodeServicePatchUserParams(args [1]string, argsEscaped bool, r *http.Request) (params ServicePatchUserParams, _ error) {
-	// Decode path: userId.
+func decodeWebhooksPatchWebhookParams(args [1]string, argsEscaped bool, r *http.Request) (params WebhooksPatchWebhookParams, _ error) {
+	// Decode path: webhookId.
 	if err := func() error {
 		param := args[0]
 		if argsEscaped {
@@ -253,14 +2456,14 @@ func decodeServicePatchUserParams(args [1]string, argsEscaped bool, r *http.Requ
 		}
 		if len(param) > 0 {
 			d := uri.NewPathDecoder(uri.PathDecoderConfig{
-				Param:   "userId",
+				Param:   "webhookId",
 				Value:   param,
 				Style:   uri.PathStyleSimple,
 				Explode: false,
 			})
 
 			if err := func() error {
-				var paramsDotUserIdVal uuid.UUID
+				var paramsDotWebhookIdVal uuid.UUID
 				if err := func() error {
 					val, err := d.DecodeValue()
 					if err != nil {
@@ -272,12 +2475,12 @@ func decodeServicePatchUserParams(args [1]string, argsEscaped bool, r *http.Requ
 						return err
 					}
 
-					paramsDotUserIdVal = c
+					paramsDotWebhookIdVal = c
 					return nil
 				}(); err != nil {
 					return err
 				}
-				params.UserId = UUID(paramsDotUserIdVal)
+				params.WebhookId = UUID(paramsDotWebhookIdVal)
 				return nil
 			}(); err != nil {
 				return err
@@ -288,7 +2491,7 @@ func decodeServicePatchUserParams(args [1]string, argsEscaped bool, r *http.Requ
 		return nil
 	}(); err != nil {
 		return params, &ogenerrors.DecodeParamError{
-			Name: "userId",
+			Name: "webhookId",
 			In:   "path",
 			Err:  err,
 		}
@@ -296,24 +2499,32 @@ func decodeServicePatchUserParams(args [1]string, argsEscaped bool, r *http.Requ
 	return params, nil
 }
 
-// ServicePutUserParams is parameters of Service_putUser operation.
-type ServicePutUserParams struct {
-	UserId UUID
+// WebhooksRedeliverWebhookDeliveryParams is parameters of Webhooks_redeliverWebhookDelivery operation.
+type WebhooksRedeliverWebhookDeliveryParams struct {
+	WebhookId  UUID
+	DeliveryId UUID
 }
 
-func unpackServicePutUserParams(packed middleware.Parameters) (params ServicePutUserParams) {
+func unpackWebhooksRedeliverWebhookDeliveryParams(packed middleware.Parameters) (params WebhooksRedeliverWebhookDeliveryParams) {
 	{
 		key := middleware.ParameterKey{
-			Name: "userId",
+			Name: "webhookId",
 			In:   "path",
 		}
-		params.UserId = packed[key].(UUID)
+		params.WebhookId = packed[key].(UUID)
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "deliveryId",
+			In:   "path",
+		}
+		params.DeliveryId = packed[key].(UUID)
 	}
 	return params
 }
 
-func decodeServicePutUserParams(args [1]string, argsEscaped bool, r *http.Request) (params ServicePutUserParams, _ error) {
-	// Decode path: userId.
+func decodeWebhooksRedeliverWebhookDeliveryParams(args [2]string, argsEscaped bool, r *http.Request) (params WebhooksRedeliverWebhookDeliveryParams, _ error) {
+	// Decode path: webhookId.
 	if err := func() error {
 		param := args[0]
 		if argsEscaped {
@@ -325,14 +2536,14 @@ func decodeServicePutUserParams(args [1]string, argsEscaped bool, r *http.Reques
 		}
 		if len(param) > 0 {
 			d := uri.NewPathDecoder(uri.PathDecoderConfig{
-				Param:   "userId",
+				Param:   "webhookId",
 				Value:   param,
 				Style:   uri.PathStyleSimple,
 				Explode: false,
 			})
 
 			if err := func() error {
-				var paramsDotUserIdVal uuid.UUID
+				var paramsDotWebhookIdVal uuid.UUID
 				if err := func() error {
 					val, err := d.DecodeValue()
 					if err != nil {
@@ -344,12 +2555,12 @@ func decodeServicePutUserParams(args [1]string, argsEscaped bool, r *http.Reques
 						return err
 					}
 
-					paramsDotUserIdVal = c
+					paramsDotWebhookIdVal = c
 					return nil
 				}(); err != nil {
 					return err
 				}
-				params.UserId = UUID(paramsDotUserIdVal)
+				params.WebhookId = UUID(paramsDotWebhookIdVal)
 				return nil
 			}(); err != nil {
 				return err
@@ -360,7 +2571,59 @@ func decodeServicePutUserParams(args [1]string, argsEscaped bool, r *http.Reques
 		return nil
 	}(); err != nil {
 		return params, &ogenerrors.DecodeParamError{
-			Name: "userId",
+			Name: "webhookId",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	// Decode path: deliveryId.
+	if err := func() error {
+		param := args[1]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[1])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "deliveryId",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				var paramsDotDeliveryIdVal uuid.UUID
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToUUID(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotDeliveryIdVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.DeliveryId = UUID(paramsDotDeliveryIdVal)
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "deliveryId",
 			In:   "path",
 			Err:  err,
 		}
@@ -6,11 +6,100 @@ import (
 	"bytes"
 	"net/http"
 
+	"github.com/go-faster/errors"
 	"github.com/go-faster/jx"
 
 	ht "github.com/ogen-go/ogen/http"
 )
 
+func encodeChangePasswordRequest(
+	req *PasswordChange,
+	r *http.Request,
+) error {
+	const contentType = "application/json"
+	e := new(jx.Encoder)
+	{
+		req.Encode(e)
+	}
+	encoded := e.Bytes()
+	ht.SetBody(r, bytes.NewReader(encoded), contentType)
+	return nil
+}
+
+func encodeGroupsAddGroupMemberRequest(
+	req *GroupMember,
+	r *http.Request,
+) error {
+	const contentType = "application/json"
+	e := new(jx.Encoder)
+	{
+		req.Encode(e)
+	}
+	encoded := e.Bytes()
+	ht.SetBody(r, bytes.NewReader(encoded), contentType)
+	return nil
+}
+
+func encodeGroupsCreateGroupRequest(
+	req *Group,
+	r *http.Request,
+) error {
+	const contentType = "application/json"
+	e := new(jx.Encoder)
+	{
+		req.Encode(e)
+	}
+	encoded := e.Bytes()
+	ht.SetBody(r, bytes.NewReader(encoded), contentType)
+	return nil
+}
+
+func encodeGroupsPatchGroupRequest(
+	req *Group,
+	r *http.Request,
+) error {
+	const contentType = "application/json"
+	e := new(jx.Encoder)
+	{
+		req.Encode(e)
+	}
+	encoded := e.Bytes()
+	ht.SetBody(r, bytes.NewReader(encoded), contentType)
+	return nil
+}
+
+func encodeOrganizationsCreateOrganizationRequest(
+	req *Organization,
+	r *http.Request,
+) error {
+	const contentType = "application/json"
+	e := new(jx.Encoder)
+	{
+		req.Encode(e)
+	}
+	encoded := e.Bytes()
+	ht.SetBody(r, bytes.NewReader(encoded), contentType)
+	return nil
+}
+
+func encodeServiceBatchUsersRequest(
+	req []BatchOperation,
+	r *http.Request,
+) error {
+	const contentType = "application/json"
+	e := new(jx.Encoder)
+	{
+		e.ArrStart()
+		for _, elem := range req {
+			elem.Encode(e)
+		}
+		e.ArrEnd()
+	}
+	encoded := e.Bytes()
+	ht.SetBody(r, bytes.NewReader(encoded), contentType)
+	return nil
+}
+
 func encodeServiceCreateUserRequest(
 	req *User,
 	r *http.Request,
@@ -25,7 +114,64 @@ func encodeServiceCreateUserRequest(
 	return nil
 }
 
+func encodeServiceImportUsersRequest(
+	req ServiceImportUsersReq,
+	r *http.Request,
+) error {
+	switch req := req.(type) {
+	case *ServiceImportUsersReqApplicationXNdjson:
+		const contentType = "application/x-ndjson"
+		body := req
+		ht.SetBody(r, body, contentType)
+		return nil
+	case *ServiceImportUsersReqTextCsv:
+		const contentType = "text/csv"
+		body := req
+		ht.SetBody(r, body, contentType)
+		return nil
+	default:
+		return errors.Errorf("unexpected request type: %T", req)
+	}
+}
+
 func encodeServicePatchUserRequest(
+	req ServicePatchUserReq,
+	r *http.Request,
+) error {
+	switch req := req.(type) {
+	case *User:
+		const contentType = "application/json"
+		e := new(jx.Encoder)
+		{
+			req.Encode(e)
+		}
+		encoded := e.Bytes()
+		ht.SetBody(r, bytes.NewReader(encoded), contentType)
+		return nil
+	case *ServicePatchUserReqApplicationJSONPatchJSON:
+		const contentType = "application/json-patch+json"
+		e := new(jx.Encoder)
+		{
+			req.Encode(e)
+		}
+		encoded := e.Bytes()
+		ht.SetBody(r, bytes.NewReader(encoded), contentType)
+		return nil
+	case *UserMergePatch:
+		const contentType = "application/merge-patch+json"
+		e := new(jx.Encoder)
+		{
+			req.Encode(e)
+		}
+		encoded := e.Bytes()
+		ht.SetBody(r, bytes.NewReader(encoded), contentType)
+		return nil
+	default:
+		return errors.Errorf("unexpected request type: %T", req)
+	}
+}
+
+func encodeServicePutUserRequest(
 	req *User,
 	r *http.Request,
 ) error {
@@ -39,8 +185,22 @@ func encodeServicePatchUserRequest(
 	return nil
 }
 
-func encodeServicePutUserRequest(
-	req *User,
+func encodeWebhooksCreateWebhookRequest(
+	req *Webhook,
+	r *http.Request,
+) error {
+	const contentType = "application/json"
+	e := new(jx.Encoder)
+	{
+		req.Encode(e)
+	}
+	encoded := e.Bytes()
+	ht.SetBody(r, bytes.NewReader(encoded), contentType)
+	return nil
+}
+
+func encodeWebhooksPatchWebhookRequest(
+	req *Webhook,
 	r *http.Request,
 ) error {
 	const contentType = "application/json"
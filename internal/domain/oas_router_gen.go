@@ -40,7 +40,7 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		s.notFound(w, r)
 		return
 	}
-	args := [1]string{}
+	args := [2]string{}
 
 	// Static code generated router with unwrapped path search.
 	switch {
@@ -61,6 +61,117 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 				break
 			}
 			switch elem[0] {
+			case 'g': // Prefix: "groups/"
+
+				if l := len("groups/"); len(elem) >= l && elem[0:l] == "groups/" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				if len(elem) == 0 {
+					switch r.Method {
+					case "GET":
+						s.handleGroupsListGroupsRequest([0]string{}, elemIsEscaped, w, r)
+					case "POST":
+						s.handleGroupsCreateGroupRequest([0]string{}, elemIsEscaped, w, r)
+					default:
+						s.notAllowed(w, r, "GET,POST")
+					}
+
+					return
+				}
+				// Param: "groupId"
+				// Match until "/"
+				idx := strings.IndexByte(elem, '/')
+				if idx < 0 {
+					idx = len(elem)
+				}
+				args[0] = elem[:idx]
+				elem = elem[idx:]
+
+				if len(elem) == 0 {
+					switch r.Method {
+					case "DELETE":
+						s.handleGroupsDeleteGroupRequest([1]string{
+							args[0],
+						}, elemIsEscaped, w, r)
+					case "GET":
+						s.handleGroupsGetGroupRequest([1]string{
+							args[0],
+						}, elemIsEscaped, w, r)
+					case "PATCH":
+						s.handleGroupsPatchGroupRequest([1]string{
+							args[0],
+						}, elemIsEscaped, w, r)
+					default:
+						s.notAllowed(w, r, "DELETE,GET,PATCH")
+					}
+
+					return
+				}
+				switch elem[0] {
+				case '/': // Prefix: "/members"
+
+					if l := len("/members"); len(elem) >= l && elem[0:l] == "/members" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						switch r.Method {
+						case "GET":
+							s.handleGroupsListGroupMembersRequest([1]string{
+								args[0],
+							}, elemIsEscaped, w, r)
+						case "POST":
+							s.handleGroupsAddGroupMemberRequest([1]string{
+								args[0],
+							}, elemIsEscaped, w, r)
+						default:
+							s.notAllowed(w, r, "GET,POST")
+						}
+
+						return
+					}
+					switch elem[0] {
+					case '/': // Prefix: "/"
+
+						if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						// Param: "memberId"
+						// Leaf parameter, slashes are prohibited
+						idx := strings.IndexByte(elem, '/')
+						if idx >= 0 {
+							break
+						}
+						args[1] = elem
+						elem = ""
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "DELETE":
+								s.handleGroupsRemoveGroupMemberRequest([2]string{
+									args[0],
+									args[1],
+								}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "DELETE")
+							}
+
+							return
+						}
+
+					}
+
+				}
+
 			case 'h': // Prefix: "health"
 
 				if l := len("health"); len(elem) >= l && elem[0:l] == "health" {
@@ -81,9 +192,29 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 					return
 				}
 
-			case 'u': // Prefix: "users/"
+			case 'm': // Prefix: "me/password"
+
+				if l := len("me/password"); len(elem) >= l && elem[0:l] == "me/password" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				if len(elem) == 0 {
+					// Leaf node.
+					switch r.Method {
+					case "POST":
+						s.handleChangePasswordRequest([0]string{}, elemIsEscaped, w, r)
+					default:
+						s.notAllowed(w, r, "POST")
+					}
+
+					return
+				}
+
+			case 'o': // Prefix: "organizations/"
 
-				if l := len("users/"); len(elem) >= l && elem[0:l] == "users/" {
+				if l := len("organizations/"); len(elem) >= l && elem[0:l] == "organizations/" {
 					elem = elem[l:]
 				} else {
 					break
@@ -92,16 +223,16 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 				if len(elem) == 0 {
 					switch r.Method {
 					case "GET":
-						s.handleServiceListUsersRequest([0]string{}, elemIsEscaped, w, r)
+						s.handleOrganizationsListOrganizationsRequest([0]string{}, elemIsEscaped, w, r)
 					case "POST":
-						s.handleServiceCreateUserRequest([0]string{}, elemIsEscaped, w, r)
+						s.handleOrganizationsCreateOrganizationRequest([0]string{}, elemIsEscaped, w, r)
 					default:
 						s.notAllowed(w, r, "GET,POST")
 					}
 
 					return
 				}
-				// Param: "userId"
+				// Param: "organizationId"
 				// Leaf parameter, slashes are prohibited
 				idx := strings.IndexByte(elem, '/')
 				if idx >= 0 {
@@ -114,27 +245,326 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 					// Leaf node.
 					switch r.Method {
 					case "DELETE":
-						s.handleServiceDeleteUserRequest([1]string{
+						s.handleOrganizationsDeleteOrganizationRequest([1]string{
 							args[0],
 						}, elemIsEscaped, w, r)
 					case "GET":
-						s.handleServiceGetUserRequest([1]string{
+						s.handleOrganizationsGetOrganizationRequest([1]string{
 							args[0],
 						}, elemIsEscaped, w, r)
-					case "PATCH":
-						s.handleServicePatchUserRequest([1]string{
+					default:
+						s.notAllowed(w, r, "DELETE,GET")
+					}
+
+					return
+				}
+
+			case 'u': // Prefix: "users"
+
+				if l := len("users"); len(elem) >= l && elem[0:l] == "users" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				if len(elem) == 0 {
+					break
+				}
+				switch elem[0] {
+				case '/': // Prefix: "/"
+
+					if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						switch r.Method {
+						case "GET":
+							s.handleServiceListUsersRequest([0]string{}, elemIsEscaped, w, r)
+						case "POST":
+							s.handleServiceCreateUserRequest([0]string{}, elemIsEscaped, w, r)
+						default:
+							s.notAllowed(w, r, "GET,POST")
+						}
+
+						return
+					}
+					// Param: "userId"
+					// Match until "/"
+					idx := strings.IndexByte(elem, '/')
+					if idx < 0 {
+						idx = len(elem)
+					}
+					args[0] = elem[:idx]
+					elem = elem[idx:]
+
+					if len(elem) == 0 {
+						switch r.Method {
+						case "DELETE":
+							s.handleServiceDeleteUserRequest([1]string{
+								args[0],
+							}, elemIsEscaped, w, r)
+						case "GET":
+							s.handleServiceGetUserRequest([1]string{
+								args[0],
+							}, elemIsEscaped, w, r)
+						case "PATCH":
+							s.handleServicePatchUserRequest([1]string{
+								args[0],
+							}, elemIsEscaped, w, r)
+						case "PUT":
+							s.handleServicePutUserRequest([1]string{
+								args[0],
+							}, elemIsEscaped, w, r)
+						default:
+							s.notAllowed(w, r, "DELETE,GET,PATCH,PUT")
+						}
+
+						return
+					}
+					switch elem[0] {
+					case '/': // Prefix: "/groups"
+
+						if l := len("/groups"); len(elem) >= l && elem[0:l] == "/groups" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "GET":
+								s.handleServiceGetUserGroupsRequest([1]string{
+									args[0],
+								}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "GET")
+							}
+
+							return
+						}
+
+					}
+
+				case ':': // Prefix: ":"
+
+					if l := len(":"); len(elem) >= l && elem[0:l] == ":" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						break
+					}
+					switch elem[0] {
+					case 'b': // Prefix: "batch"
+
+						if l := len("batch"); len(elem) >= l && elem[0:l] == "batch" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "POST":
+								s.handleServiceBatchUsersRequest([0]string{}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "POST")
+							}
+
+							return
+						}
+
+					case 'e': // Prefix: "export"
+
+						if l := len("export"); len(elem) >= l && elem[0:l] == "export" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "GET":
+								s.handleServiceExportUsersRequest([0]string{}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "GET")
+							}
+
+							return
+						}
+
+					case 'i': // Prefix: "import"
+
+						if l := len("import"); len(elem) >= l && elem[0:l] == "import" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "POST":
+								s.handleServiceImportUsersRequest([0]string{}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "POST")
+							}
+
+							return
+						}
+
+					case 'w': // Prefix: "watch"
+
+						if l := len("watch"); len(elem) >= l && elem[0:l] == "watch" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "GET":
+								s.handleServiceWatchUsersRequest([0]string{}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "GET")
+							}
+
+							return
+						}
+
+					}
+
+				}
+
+			case 'w': // Prefix: "webhooks/"
+
+				if l := len("webhooks/"); len(elem) >= l && elem[0:l] == "webhooks/" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				if len(elem) == 0 {
+					switch r.Method {
+					case "GET":
+						s.handleWebhooksListWebhooksRequest([0]string{}, elemIsEscaped, w, r)
+					case "POST":
+						s.handleWebhooksCreateWebhookRequest([0]string{}, elemIsEscaped, w, r)
+					default:
+						s.notAllowed(w, r, "GET,POST")
+					}
+
+					return
+				}
+				// Param: "webhookId"
+				// Match until "/"
+				idx := strings.IndexByte(elem, '/')
+				if idx < 0 {
+					idx = len(elem)
+				}
+				args[0] = elem[:idx]
+				elem = elem[idx:]
+
+				if len(elem) == 0 {
+					switch r.Method {
+					case "DELETE":
+						s.handleWebhooksDeleteWebhookRequest([1]string{
+							args[0],
+						}, elemIsEscaped, w, r)
+					case "GET":
+						s.handleWebhooksGetWebhookRequest([1]string{
 							args[0],
 						}, elemIsEscaped, w, r)
-					case "PUT":
-						s.handleServicePutUserRequest([1]string{
+					case "PATCH":
+						s.handleWebhooksPatchWebhookRequest([1]string{
 							args[0],
 						}, elemIsEscaped, w, r)
 					default:
-						s.notAllowed(w, r, "DELETE,GET,PATCH,PUT")
+						s.notAllowed(w, r, "DELETE,GET,PATCH")
 					}
 
 					return
 				}
+				switch elem[0] {
+				case '/': // Prefix: "/deliveries"
+
+					if l := len("/deliveries"); len(elem) >= l && elem[0:l] == "/deliveries" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						switch r.Method {
+						case "GET":
+							s.handleWebhooksListWebhookDeliveriesRequest([1]string{
+								args[0],
+							}, elemIsEscaped, w, r)
+						default:
+							s.notAllowed(w, r, "GET")
+						}
+
+						return
+					}
+					switch elem[0] {
+					case '/': // Prefix: "/"
+
+						if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						// Param: "deliveryId"
+						// Match until "/"
+						idx := strings.IndexByte(elem, '/')
+						if idx < 0 {
+							idx = len(elem)
+						}
+						args[1] = elem[:idx]
+						elem = elem[idx:]
+
+						if len(elem) == 0 {
+							break
+						}
+						switch elem[0] {
+						case '/': // Prefix: "/redeliver"
+
+							if l := len("/redeliver"); len(elem) >= l && elem[0:l] == "/redeliver" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch r.Method {
+								case "POST":
+									s.handleWebhooksRedeliverWebhookDeliveryRequest([2]string{
+										args[0],
+										args[1],
+									}, elemIsEscaped, w, r)
+								default:
+									s.notAllowed(w, r, "POST")
+								}
+
+								return
+							}
+
+						}
+
+					}
+
+				}
 
 			}
 
@@ -150,7 +580,7 @@ type Route struct {
 	operationID string
 	pathPattern string
 	count       int
-	args        [1]string
+	args        [2]string
 }
 
 // Name returns ogen operation name.
@@ -230,6 +660,144 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 				break
 			}
 			switch elem[0] {
+			case 'g': // Prefix: "groups/"
+
+				if l := len("groups/"); len(elem) >= l && elem[0:l] == "groups/" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				if len(elem) == 0 {
+					switch method {
+					case "GET":
+						r.name = GroupsListGroupsOperation
+						r.summary = ""
+						r.operationID = "Groups_listGroups"
+						r.pathPattern = "/groups/"
+						r.args = args
+						r.count = 0
+						return r, true
+					case "POST":
+						r.name = GroupsCreateGroupOperation
+						r.summary = ""
+						r.operationID = "Groups_createGroup"
+						r.pathPattern = "/groups/"
+						r.args = args
+						r.count = 0
+						return r, true
+					default:
+						return
+					}
+				}
+				// Param: "groupId"
+				// Match until "/"
+				idx := strings.IndexByte(elem, '/')
+				if idx < 0 {
+					idx = len(elem)
+				}
+				args[0] = elem[:idx]
+				elem = elem[idx:]
+
+				if len(elem) == 0 {
+					switch method {
+					case "DELETE":
+						r.name = GroupsDeleteGroupOperation
+						r.summary = ""
+						r.operationID = "Groups_deleteGroup"
+						r.pathPattern = "/groups/{groupId}"
+						r.args = args
+						r.count = 1
+						return r, true
+					case "GET":
+						r.name = GroupsGetGroupOperation
+						r.summary = ""
+						r.operationID = "Groups_getGroup"
+						r.pathPattern = "/groups/{groupId}"
+						r.args = args
+						r.count = 1
+						return r, true
+					case "PATCH":
+						r.name = GroupsPatchGroupOperation
+						r.summary = ""
+						r.operationID = "Groups_patchGroup"
+						r.pathPattern = "/groups/{groupId}"
+						r.args = args
+						r.count = 1
+						return r, true
+					default:
+						return
+					}
+				}
+				switch elem[0] {
+				case '/': // Prefix: "/members"
+
+					if l := len("/members"); len(elem) >= l && elem[0:l] == "/members" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						switch method {
+						case "GET":
+							r.name = GroupsListGroupMembersOperation
+							r.summary = ""
+							r.operationID = "Groups_listGroupMembers"
+							r.pathPattern = "/groups/{groupId}/members"
+							r.args = args
+							r.count = 1
+							return r, true
+						case "POST":
+							r.name = GroupsAddGroupMemberOperation
+							r.summary = ""
+							r.operationID = "Groups_addGroupMember"
+							r.pathPattern = "/groups/{groupId}/members"
+							r.args = args
+							r.count = 1
+							return r, true
+						default:
+							return
+						}
+					}
+					switch elem[0] {
+					case '/': // Prefix: "/"
+
+						if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						// Param: "memberId"
+						// Leaf parameter, slashes are prohibited
+						idx := strings.IndexByte(elem, '/')
+						if idx >= 0 {
+							break
+						}
+						args[1] = elem
+						elem = ""
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch method {
+							case "DELETE":
+								r.name = GroupsRemoveGroupMemberOperation
+								r.summary = ""
+								r.operationID = "Groups_removeGroupMember"
+								r.pathPattern = "/groups/{groupId}/members/{memberId}"
+								r.args = args
+								r.count = 2
+								return r, true
+							default:
+								return
+							}
+						}
+
+					}
+
+				}
+
 			case 'h': // Prefix: "health"
 
 				if l := len("health"); len(elem) >= l && elem[0:l] == "health" {
@@ -254,9 +822,33 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 					}
 				}
 
-			case 'u': // Prefix: "users/"
+			case 'm': // Prefix: "me/password"
+
+				if l := len("me/password"); len(elem) >= l && elem[0:l] == "me/password" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				if len(elem) == 0 {
+					// Leaf node.
+					switch method {
+					case "POST":
+						r.name = ChangePasswordOperation
+						r.summary = ""
+						r.operationID = "changePassword"
+						r.pathPattern = "/me/password"
+						r.args = args
+						r.count = 0
+						return r, true
+					default:
+						return
+					}
+				}
+
+			case 'o': // Prefix: "organizations/"
 
-				if l := len("users/"); len(elem) >= l && elem[0:l] == "users/" {
+				if l := len("organizations/"); len(elem) >= l && elem[0:l] == "organizations/" {
 					elem = elem[l:]
 				} else {
 					break
@@ -265,18 +857,18 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 				if len(elem) == 0 {
 					switch method {
 					case "GET":
-						r.name = ServiceListUsersOperation
+						r.name = OrganizationsListOrganizationsOperation
 						r.summary = ""
-						r.operationID = "Service_listUsers"
-						r.pathPattern = "/users/"
+						r.operationID = "Organizations_listOrganizations"
+						r.pathPattern = "/organizations/"
 						r.args = args
 						r.count = 0
 						return r, true
 					case "POST":
-						r.name = ServiceCreateUserOperation
+						r.name = OrganizationsCreateOrganizationOperation
 						r.summary = ""
-						r.operationID = "Service_createUser"
-						r.pathPattern = "/users/"
+						r.operationID = "Organizations_createOrganization"
+						r.pathPattern = "/organizations/"
 						r.args = args
 						r.count = 0
 						return r, true
@@ -284,7 +876,7 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 						return
 					}
 				}
-				// Param: "userId"
+				// Param: "organizationId"
 				// Leaf parameter, slashes are prohibited
 				idx := strings.IndexByte(elem, '/')
 				if idx >= 0 {
@@ -297,34 +889,316 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 					// Leaf node.
 					switch method {
 					case "DELETE":
-						r.name = ServiceDeleteUserOperation
+						r.name = OrganizationsDeleteOrganizationOperation
 						r.summary = ""
-						r.operationID = "Service_deleteUser"
-						r.pathPattern = "/users/{userId}"
+						r.operationID = "Organizations_deleteOrganization"
+						r.pathPattern = "/organizations/{organizationId}"
 						r.args = args
 						r.count = 1
 						return r, true
 					case "GET":
-						r.name = ServiceGetUserOperation
+						r.name = OrganizationsGetOrganizationOperation
 						r.summary = ""
-						r.operationID = "Service_getUser"
-						r.pathPattern = "/users/{userId}"
+						r.operationID = "Organizations_getOrganization"
+						r.pathPattern = "/organizations/{organizationId}"
 						r.args = args
 						r.count = 1
 						return r, true
-					case "PATCH":
-						r.name = ServicePatchUserOperation
+					default:
+						return
+					}
+				}
+
+			case 'u': // Prefix: "users"
+
+				if l := len("users"); len(elem) >= l && elem[0:l] == "users" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				if len(elem) == 0 {
+					break
+				}
+				switch elem[0] {
+				case '/': // Prefix: "/"
+
+					if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						switch method {
+						case "GET":
+							r.name = ServiceListUsersOperation
+							r.summary = ""
+							r.operationID = "Service_listUsers"
+							r.pathPattern = "/users/"
+							r.args = args
+							r.count = 0
+							return r, true
+						case "POST":
+							r.name = ServiceCreateUserOperation
+							r.summary = ""
+							r.operationID = "Service_createUser"
+							r.pathPattern = "/users/"
+							r.args = args
+							r.count = 0
+							return r, true
+						default:
+							return
+						}
+					}
+					// Param: "userId"
+					// Match until "/"
+					idx := strings.IndexByte(elem, '/')
+					if idx < 0 {
+						idx = len(elem)
+					}
+					args[0] = elem[:idx]
+					elem = elem[idx:]
+
+					if len(elem) == 0 {
+						switch method {
+						case "DELETE":
+							r.name = ServiceDeleteUserOperation
+							r.summary = ""
+							r.operationID = "Service_deleteUser"
+							r.pathPattern = "/users/{userId}"
+							r.args = args
+							r.count = 1
+							return r, true
+						case "GET":
+							r.name = ServiceGetUserOperation
+							r.summary = ""
+							r.operationID = "Service_getUser"
+							r.pathPattern = "/users/{userId}"
+							r.args = args
+							r.count = 1
+							return r, true
+						case "PATCH":
+							r.name = ServicePatchUserOperation
+							r.summary = ""
+							r.operationID = "Service_patchUser"
+							r.pathPattern = "/users/{userId}"
+							r.args = args
+							r.count = 1
+							return r, true
+						case "PUT":
+							r.name = ServicePutUserOperation
+							r.summary = ""
+							r.operationID = "Service_putUser"
+							r.pathPattern = "/users/{userId}"
+							r.args = args
+							r.count = 1
+							return r, true
+						default:
+							return
+						}
+					}
+					switch elem[0] {
+					case '/': // Prefix: "/groups"
+
+						if l := len("/groups"); len(elem) >= l && elem[0:l] == "/groups" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch method {
+							case "GET":
+								r.name = ServiceGetUserGroupsOperation
+								r.summary = ""
+								r.operationID = "Service_getUserGroups"
+								r.pathPattern = "/users/{userId}/groups"
+								r.args = args
+								r.count = 1
+								return r, true
+							default:
+								return
+							}
+						}
+
+					}
+
+				case ':': // Prefix: ":"
+
+					if l := len(":"); len(elem) >= l && elem[0:l] == ":" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						break
+					}
+					switch elem[0] {
+					case 'b': // Prefix: "batch"
+
+						if l := len("batch"); len(elem) >= l && elem[0:l] == "batch" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch method {
+							case "POST":
+								r.name = ServiceBatchUsersOperation
+								r.summary = ""
+								r.operationID = "Service_batchUsers"
+								r.pathPattern = "/users:batch"
+								r.args = args
+								r.count = 0
+								return r, true
+							default:
+								return
+							}
+						}
+
+					case 'e': // Prefix: "export"
+
+						if l := len("export"); len(elem) >= l && elem[0:l] == "export" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch method {
+							case "GET":
+								r.name = ServiceExportUsersOperation
+								r.summary = ""
+								r.operationID = "Service_exportUsers"
+								r.pathPattern = "/users:export"
+								r.args = args
+								r.count = 0
+								return r, true
+							default:
+								return
+							}
+						}
+
+					case 'i': // Prefix: "import"
+
+						if l := len("import"); len(elem) >= l && elem[0:l] == "import" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch method {
+							case "POST":
+								r.name = ServiceImportUsersOperation
+								r.summary = ""
+								r.operationID = "Service_importUsers"
+								r.pathPattern = "/users:import"
+								r.args = args
+								r.count = 0
+								return r, true
+							default:
+								return
+							}
+						}
+
+					case 'w': // Prefix: "watch"
+
+						if l := len("watch"); len(elem) >= l && elem[0:l] == "watch" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch method {
+							case "GET":
+								r.name = ServiceWatchUsersOperation
+								r.summary = ""
+								r.operationID = "Service_watchUsers"
+								r.pathPattern = "/users:watch"
+								r.args = args
+								r.count = 0
+								return r, true
+							default:
+								return
+							}
+						}
+
+					}
+
+				}
+
+			case 'w': // Prefix: "webhooks/"
+
+				if l := len("webhooks/"); len(elem) >= l && elem[0:l] == "webhooks/" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				if len(elem) == 0 {
+					switch method {
+					case "GET":
+						r.name = WebhooksListWebhooksOperation
+						r.summary = ""
+						r.operationID = "Webhooks_listWebhooks"
+						r.pathPattern = "/webhooks/"
+						r.args = args
+						r.count = 0
+						return r, true
+					case "POST":
+						r.name = WebhooksCreateWebhookOperation
+						r.summary = ""
+						r.operationID = "Webhooks_createWebhook"
+						r.pathPattern = "/webhooks/"
+						r.args = args
+						r.count = 0
+						return r, true
+					default:
+						return
+					}
+				}
+				// Param: "webhookId"
+				// Match until "/"
+				idx := strings.IndexByte(elem, '/')
+				if idx < 0 {
+					idx = len(elem)
+				}
+				args[0] = elem[:idx]
+				elem = elem[idx:]
+
+				if len(elem) == 0 {
+					switch method {
+					case "DELETE":
+						r.name = WebhooksDeleteWebhookOperation
 						r.summary = ""
-						r.operationID = "Service_patchUser"
-						r.pathPattern = "/users/{userId}"
+						r.operationID = "Webhooks_deleteWebhook"
+						r.pathPattern = "/webhooks/{webhookId}"
 						r.args = args
 						r.count = 1
 						return r, true
-					case "PUT":
-						r.name = ServicePutUserOperation
+					case "GET":
+						r.name = WebhooksGetWebhookOperation
 						r.summary = ""
-						r.operationID = "Service_putUser"
-						r.pathPattern = "/users/{userId}"
+						r.operationID = "Webhooks_getWebhook"
+						r.pathPattern = "/webhooks/{webhookId}"
+						r.args = args
+						r.count = 1
+						return r, true
+					case "PATCH":
+						r.name = WebhooksPatchWebhookOperation
+						r.summary = ""
+						r.operationID = "Webhooks_patchWebhook"
+						r.pathPattern = "/webhooks/{webhookId}"
 						r.args = args
 						r.count = 1
 						return r, true
@@ -332,6 +1206,80 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 						return
 					}
 				}
+				switch elem[0] {
+				case '/': // Prefix: "/deliveries"
+
+					if l := len("/deliveries"); len(elem) >= l && elem[0:l] == "/deliveries" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						switch method {
+						case "GET":
+							r.name = WebhooksListWebhookDeliveriesOperation
+							r.summary = ""
+							r.operationID = "Webhooks_listWebhookDeliveries"
+							r.pathPattern = "/webhooks/{webhookId}/deliveries"
+							r.args = args
+							r.count = 1
+							return r, true
+						default:
+							return
+						}
+					}
+					switch elem[0] {
+					case '/': // Prefix: "/"
+
+						if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						// Param: "deliveryId"
+						// Match until "/"
+						idx := strings.IndexByte(elem, '/')
+						if idx < 0 {
+							idx = len(elem)
+						}
+						args[1] = elem[:idx]
+						elem = elem[idx:]
+
+						if len(elem) == 0 {
+							break
+						}
+						switch elem[0] {
+						case '/': // Prefix: "/redeliver"
+
+							if l := len("/redeliver"); len(elem) >= l && elem[0:l] == "/redeliver" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch method {
+								case "POST":
+									r.name = WebhooksRedeliverWebhookDeliveryOperation
+									r.summary = ""
+									r.operationID = "Webhooks_redeliverWebhookDelivery"
+									r.pathPattern = "/webhooks/{webhookId}/deliveries/{deliveryId}/redeliver"
+									r.args = args
+									r.count = 2
+									return r, true
+								default:
+									return
+								}
+							}
+
+						}
+
+					}
+
+				}
 
 			}
 
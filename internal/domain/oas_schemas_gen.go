@@ -3,7 +3,12 @@
 package api
 
 import (
+	"fmt"
+	"io"
+	"time"
+
 	"github.com/go-faster/errors"
+	"github.com/go-faster/jx"
 	"github.com/google/uuid"
 )
 
@@ -22,15 +27,20 @@ func (s *AlreadyExistsResponse) SetMessage(val AlreadyExistsResponseMessage) {
 	s.Message = val
 }
 
-func (*AlreadyExistsResponse) serviceCreateUserRes() {}
-func (*AlreadyExistsResponse) servicePatchUserRes()  {}
-func (*AlreadyExistsResponse) servicePutUserRes()    {}
+func (*AlreadyExistsResponse) groupsAddGroupMemberRes()            {}
+func (*AlreadyExistsResponse) groupsCreateGroupRes()               {}
+func (*AlreadyExistsResponse) groupsPatchGroupRes()                {}
+func (*AlreadyExistsResponse) organizationsCreateOrganizationRes() {}
+func (*AlreadyExistsResponse) serviceCreateUserRes()               {}
 
 type AlreadyExistsResponseMessage string
 
 const (
-	AlreadyExistsResponseMessageAlreadyExistsUsernameTaken AlreadyExistsResponseMessage = "already exists, username taken"
-	AlreadyExistsResponseMessageAlreadyExistsEmailTaken    AlreadyExistsResponseMessage = "already exists, email taken"
+	AlreadyExistsResponseMessageAlreadyExistsUsernameTaken         AlreadyExistsResponseMessage = "already exists, username taken"
+	AlreadyExistsResponseMessageAlreadyExistsEmailTaken            AlreadyExistsResponseMessage = "already exists, email taken"
+	AlreadyExistsResponseMessageAlreadyExistsGroupNameTaken        AlreadyExistsResponseMessage = "already exists, group name taken"
+	AlreadyExistsResponseMessageAlreadyExistsMemberOfTheGroup      AlreadyExistsResponseMessage = "already exists, member of the group"
+	AlreadyExistsResponseMessageAlreadyExistsOrganizationNameTaken AlreadyExistsResponseMessage = "already exists, organization name taken"
 )
 
 // AllValues returns all AlreadyExistsResponseMessage values.
@@ -38,6 +48,9 @@ func (AlreadyExistsResponseMessage) AllValues() []AlreadyExistsResponseMessage {
 	return []AlreadyExistsResponseMessage{
 		AlreadyExistsResponseMessageAlreadyExistsUsernameTaken,
 		AlreadyExistsResponseMessageAlreadyExistsEmailTaken,
+		AlreadyExistsResponseMessageAlreadyExistsGroupNameTaken,
+		AlreadyExistsResponseMessageAlreadyExistsMemberOfTheGroup,
+		AlreadyExistsResponseMessageAlreadyExistsOrganizationNameTaken,
 	}
 }
 
@@ -48,6 +61,12 @@ func (s AlreadyExistsResponseMessage) MarshalText() ([]byte, error) {
 		return []byte(s), nil
 	case AlreadyExistsResponseMessageAlreadyExistsEmailTaken:
 		return []byte(s), nil
+	case AlreadyExistsResponseMessageAlreadyExistsGroupNameTaken:
+		return []byte(s), nil
+	case AlreadyExistsResponseMessageAlreadyExistsMemberOfTheGroup:
+		return []byte(s), nil
+	case AlreadyExistsResponseMessageAlreadyExistsOrganizationNameTaken:
+		return []byte(s), nil
 	default:
 		return nil, errors.Errorf("invalid value: %q", s)
 	}
@@ -62,6 +81,15 @@ func (s *AlreadyExistsResponseMessage) UnmarshalText(data []byte) error {
 	case AlreadyExistsResponseMessageAlreadyExistsEmailTaken:
 		*s = AlreadyExistsResponseMessageAlreadyExistsEmailTaken
 		return nil
+	case AlreadyExistsResponseMessageAlreadyExistsGroupNameTaken:
+		*s = AlreadyExistsResponseMessageAlreadyExistsGroupNameTaken
+		return nil
+	case AlreadyExistsResponseMessageAlreadyExistsMemberOfTheGroup:
+		*s = AlreadyExistsResponseMessageAlreadyExistsMemberOfTheGroup
+		return nil
+	case AlreadyExistsResponseMessageAlreadyExistsOrganizationNameTaken:
+		*s = AlreadyExistsResponseMessageAlreadyExistsOrganizationNameTaken
+		return nil
 	default:
 		return errors.Errorf("invalid value: %q", data)
 	}
@@ -103,6 +131,179 @@ func (s *BasicAuth) SetRoles(val []string) {
 	s.Roles = val
 }
 
+// Result of a single operation
+// - `status`: http status code of the single user endpoint
+// - `body`: response body of the single user endpoint, if any.
+// Ref: #/components/schemas/BatchItemResult
+type BatchItemResult struct {
+	Status int32  `json:"status"`
+	Body   jx.Raw `json:"body"`
+}
+
+// GetStatus returns the value of Status.
+func (s *BatchItemResult) GetStatus() int32 {
+	return s.Status
+}
+
+// GetBody returns the value of Body.
+func (s *BatchItemResult) GetBody() jx.Raw {
+	return s.Body
+}
+
+// SetStatus sets the value of Status.
+func (s *BatchItemResult) SetStatus(val int32) {
+	s.Status = val
+}
+
+// SetBody sets the value of Body.
+func (s *BatchItemResult) SetBody(val jx.Raw) {
+	s.Body = val
+}
+
+// Single operation of a batch
+// - `op`: the single user endpoint to execute
+// - `id`: user id, required for patch, put and delete
+// - `user`: request body, required for create, patch and put.
+// Ref: #/components/schemas/BatchOperation
+type BatchOperation struct {
+	Op   BatchOperationOp `json:"op"`
+	ID   OptUUID          `json:"id"`
+	User OptUser          `json:"user"`
+}
+
+// GetOp returns the value of Op.
+func (s *BatchOperation) GetOp() BatchOperationOp {
+	return s.Op
+}
+
+// GetID returns the value of ID.
+func (s *BatchOperation) GetID() OptUUID {
+	return s.ID
+}
+
+// GetUser returns the value of User.
+func (s *BatchOperation) GetUser() OptUser {
+	return s.User
+}
+
+// SetOp sets the value of Op.
+func (s *BatchOperation) SetOp(val BatchOperationOp) {
+	s.Op = val
+}
+
+// SetID sets the value of ID.
+func (s *BatchOperation) SetID(val OptUUID) {
+	s.ID = val
+}
+
+// SetUser sets the value of User.
+func (s *BatchOperation) SetUser(val OptUser) {
+	s.User = val
+}
+
+type BatchOperationOp string
+
+const (
+	BatchOperationOpCreate BatchOperationOp = "create"
+	BatchOperationOpPatch  BatchOperationOp = "patch"
+	BatchOperationOpPut    BatchOperationOp = "put"
+	BatchOperationOpDelete BatchOperationOp = "delete"
+)
+
+// AllValues returns all BatchOperationOp values.
+func (BatchOperationOp) AllValues() []BatchOperationOp {
+	return []BatchOperationOp{
+		BatchOperationOpCreate,
+		BatchOperationOpPatch,
+		BatchOperationOpPut,
+		BatchOperationOpDelete,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s BatchOperationOp) MarshalText() ([]byte, error) {
+	switch s {
+	case BatchOperationOpCreate:
+		return []byte(s), nil
+	case BatchOperationOpPatch:
+		return []byte(s), nil
+	case BatchOperationOpPut:
+		return []byte(s), nil
+	case BatchOperationOpDelete:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *BatchOperationOp) UnmarshalText(data []byte) error {
+	switch BatchOperationOp(data) {
+	case BatchOperationOpCreate:
+		*s = BatchOperationOpCreate
+		return nil
+	case BatchOperationOpPatch:
+		*s = BatchOperationOpPatch
+		return nil
+	case BatchOperationOpPut:
+		*s = BatchOperationOpPut
+		return nil
+	case BatchOperationOpDelete:
+		*s = BatchOperationOpDelete
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Batch report
+// - `atomic`: operations were executed in a single transaction
+// - `committed`: changes were kept, false when an atomic batch is rolled back
+// - `results`: result of every operation in the order of the request.
+// Ref: #/components/schemas/BatchReport
+type BatchReport struct {
+	Atomic    bool              `json:"atomic"`
+	Committed bool              `json:"committed"`
+	Results   []BatchItemResult `json:"results"`
+}
+
+// GetAtomic returns the value of Atomic.
+func (s *BatchReport) GetAtomic() bool {
+	return s.Atomic
+}
+
+// GetCommitted returns the value of Committed.
+func (s *BatchReport) GetCommitted() bool {
+	return s.Committed
+}
+
+// GetResults returns the value of Results.
+func (s *BatchReport) GetResults() []BatchItemResult {
+	return s.Results
+}
+
+// SetAtomic sets the value of Atomic.
+func (s *BatchReport) SetAtomic(val bool) {
+	s.Atomic = val
+}
+
+// SetCommitted sets the value of Committed.
+func (s *BatchReport) SetCommitted(val bool) {
+	s.Committed = val
+}
+
+// SetResults sets the value of Results.
+func (s *BatchReport) SetResults(val []BatchItemResult) {
+	s.Results = val
+}
+
+func (*BatchReport) serviceBatchUsersRes() {}
+
+// ChangePasswordOK is response for ChangePassword operation.
+type ChangePasswordOK struct{}
+
+func (*ChangePasswordOK) changePasswordRes() {}
+
 // Ref: #/components/schemas/ForbiddenResponse
 type ForbiddenResponse struct {
 	Message ForbiddenResponseMessage `json:"message"`
@@ -118,22 +319,50 @@ func (s *ForbiddenResponse) SetMessage(val ForbiddenResponseMessage) {
 	s.Message = val
 }
 
-func (*ForbiddenResponse) serviceCreateUserRes() {}
-func (*ForbiddenResponse) serviceDeleteUserRes() {}
-func (*ForbiddenResponse) serviceGetUserRes()    {}
-func (*ForbiddenResponse) servicePatchUserRes()  {}
-func (*ForbiddenResponse) servicePutUserRes()    {}
+func (*ForbiddenResponse) groupsAddGroupMemberRes()             {}
+func (*ForbiddenResponse) groupsCreateGroupRes()                {}
+func (*ForbiddenResponse) groupsDeleteGroupRes()                {}
+func (*ForbiddenResponse) groupsGetGroupRes()                   {}
+func (*ForbiddenResponse) groupsListGroupMembersRes()           {}
+func (*ForbiddenResponse) groupsListGroupsRes()                 {}
+func (*ForbiddenResponse) groupsPatchGroupRes()                 {}
+func (*ForbiddenResponse) groupsRemoveGroupMemberRes()          {}
+func (*ForbiddenResponse) organizationsCreateOrganizationRes()  {}
+func (*ForbiddenResponse) organizationsDeleteOrganizationRes()  {}
+func (*ForbiddenResponse) organizationsGetOrganizationRes()     {}
+func (*ForbiddenResponse) organizationsListOrganizationsRes()   {}
+func (*ForbiddenResponse) serviceBatchUsersRes()                {}
+func (*ForbiddenResponse) serviceCreateUserRes()                {}
+func (*ForbiddenResponse) serviceDeleteUserRes()                {}
+func (*ForbiddenResponse) serviceExportUsersRes()               {}
+func (*ForbiddenResponse) serviceGetUserGroupsRes()             {}
+func (*ForbiddenResponse) serviceGetUserRes()                   {}
+func (*ForbiddenResponse) serviceImportUsersRes()               {}
+func (*ForbiddenResponse) serviceListUsersRes()                 {}
+func (*ForbiddenResponse) servicePatchUserRes()                 {}
+func (*ForbiddenResponse) servicePutUserRes()                   {}
+func (*ForbiddenResponse) webhooksCreateWebhookRes()            {}
+func (*ForbiddenResponse) webhooksDeleteWebhookRes()            {}
+func (*ForbiddenResponse) webhooksGetWebhookRes()               {}
+func (*ForbiddenResponse) webhooksListWebhookDeliveriesRes()    {}
+func (*ForbiddenResponse) webhooksListWebhooksRes()             {}
+func (*ForbiddenResponse) webhooksPatchWebhookRes()             {}
+func (*ForbiddenResponse) webhooksRedeliverWebhookDeliveryRes() {}
 
 type ForbiddenResponseMessage string
 
 const (
-	ForbiddenResponseMessageForbiddenAdminPermissionRequired ForbiddenResponseMessage = "forbidden, admin permission required"
+	ForbiddenResponseMessageForbiddenAdminPermissionRequired      ForbiddenResponseMessage = "forbidden, admin permission required"
+	ForbiddenResponseMessageForbiddenSuperAdminPermissionRequired ForbiddenResponseMessage = "forbidden, super admin permission required"
+	ForbiddenResponseMessageForbiddenPasswordChangeRequired       ForbiddenResponseMessage = "forbidden, password change required"
 )
 
 // AllValues returns all ForbiddenResponseMessage values.
 func (ForbiddenResponseMessage) AllValues() []ForbiddenResponseMessage {
 	return []ForbiddenResponseMessage{
 		ForbiddenResponseMessageForbiddenAdminPermissionRequired,
+		ForbiddenResponseMessageForbiddenSuperAdminPermissionRequired,
+		ForbiddenResponseMessageForbiddenPasswordChangeRequired,
 	}
 }
 
@@ -142,6 +371,10 @@ func (s ForbiddenResponseMessage) MarshalText() ([]byte, error) {
 	switch s {
 	case ForbiddenResponseMessageForbiddenAdminPermissionRequired:
 		return []byte(s), nil
+	case ForbiddenResponseMessageForbiddenSuperAdminPermissionRequired:
+		return []byte(s), nil
+	case ForbiddenResponseMessageForbiddenPasswordChangeRequired:
+		return []byte(s), nil
 	default:
 		return nil, errors.Errorf("invalid value: %q", s)
 	}
@@ -153,14 +386,330 @@ func (s *ForbiddenResponseMessage) UnmarshalText(data []byte) error {
 	case ForbiddenResponseMessageForbiddenAdminPermissionRequired:
 		*s = ForbiddenResponseMessageForbiddenAdminPermissionRequired
 		return nil
+	case ForbiddenResponseMessageForbiddenSuperAdminPermissionRequired:
+		*s = ForbiddenResponseMessageForbiddenSuperAdminPermissionRequired
+		return nil
+	case ForbiddenResponseMessageForbiddenPasswordChangeRequired:
+		*s = ForbiddenResponseMessageForbiddenPasswordChangeRequired
+		return nil
 	default:
 		return errors.Errorf("invalid value: %q", data)
 	}
 }
 
+// Group model all fields isn't required
+// - `id`: the uuid
+// - `name`: the group's name, unique
+// - `description`: the group's description
+// - `is_admin`: grants admin permissions to members, including members of nested groups.
+// Ref: #/components/schemas/Group
+type Group struct {
+	ID          OptUUID   `json:"id" db:"id"`
+	Name        OptString `json:"name" db:"name"`
+	Description OptString `json:"description" db:"description"`
+	IsAdmin     OptBool   `json:"is_admin" db:"is_admin"`
+}
+
+// GetID returns the value of ID.
+func (s *Group) GetID() OptUUID {
+	return s.ID
+}
+
+// GetName returns the value of Name.
+func (s *Group) GetName() OptString {
+	return s.Name
+}
+
+// GetDescription returns the value of Description.
+func (s *Group) GetDescription() OptString {
+	return s.Description
+}
+
+// GetIsAdmin returns the value of IsAdmin.
+func (s *Group) GetIsAdmin() OptBool {
+	return s.IsAdmin
+}
+
+// SetID sets the value of ID.
+func (s *Group) SetID(val OptUUID) {
+	s.ID = val
+}
+
+// SetName sets the value of Name.
+func (s *Group) SetName(val OptString) {
+	s.Name = val
+}
+
+// SetDescription sets the value of Description.
+func (s *Group) SetDescription(val OptString) {
+	s.Description = val
+}
+
+// SetIsAdmin sets the value of IsAdmin.
+func (s *Group) SetIsAdmin(val OptBool) {
+	s.IsAdmin = val
+}
+
+func (*Group) groupsCreateGroupRes() {}
+func (*Group) groupsGetGroupRes()    {}
+
+// Group member, exactly one of the fields must be provided
+// - `user_id`: the member user
+// - `group_id`: the nested group.
+// Ref: #/components/schemas/GroupMember
+type GroupMember struct {
+	UserID  OptUUID `json:"user_id" db:"user_id"`
+	GroupID OptUUID `json:"group_id" db:"member_group_id"`
+}
+
+// GetUserID returns the value of UserID.
+func (s *GroupMember) GetUserID() OptUUID {
+	return s.UserID
+}
+
+// GetGroupID returns the value of GroupID.
+func (s *GroupMember) GetGroupID() OptUUID {
+	return s.GroupID
+}
+
+// SetUserID sets the value of UserID.
+func (s *GroupMember) SetUserID(val OptUUID) {
+	s.UserID = val
+}
+
+// SetGroupID sets the value of GroupID.
+func (s *GroupMember) SetGroupID(val OptUUID) {
+	s.GroupID = val
+}
+
+// GroupsAddGroupMemberOK is response for GroupsAddGroupMember operation.
+type GroupsAddGroupMemberOK struct{}
+
+func (*GroupsAddGroupMemberOK) groupsAddGroupMemberRes() {}
+
+// GroupsDeleteGroupOK is response for GroupsDeleteGroup operation.
+type GroupsDeleteGroupOK struct{}
+
+func (*GroupsDeleteGroupOK) groupsDeleteGroupRes() {}
+
+type GroupsListGroupMembersOKApplicationJSON []GroupMember
+
+func (*GroupsListGroupMembersOKApplicationJSON) groupsListGroupMembersRes() {}
+
+type GroupsListGroupsOKApplicationJSON []Group
+
+func (*GroupsListGroupsOKApplicationJSON) groupsListGroupsRes() {}
+
+// GroupsPatchGroupOK is response for GroupsPatchGroup operation.
+type GroupsPatchGroupOK struct{}
+
+func (*GroupsPatchGroupOK) groupsPatchGroupRes() {}
+
+// GroupsRemoveGroupMemberOK is response for GroupsRemoveGroupMember operation.
+type GroupsRemoveGroupMemberOK struct{}
+
+func (*GroupsRemoveGroupMemberOK) groupsRemoveGroupMemberRes() {}
+
 // HealthOK is response for Health operation.
 type HealthOK struct{}
 
+// Import report
+// - `dry_run`: nothing was written
+// - `committed`: rows were kept, false for a dry run and when a conflict with on_conflict=fail
+// rolled back the import
+// - `created`, `updated`, `skipped`, `failed`: number of rows with the status
+// - `rows`: result of every row, rows after a conflict with on_conflict=fail aren't imported.
+// Ref: #/components/schemas/ImportReport
+type ImportReport struct {
+	DryRun    bool              `json:"dry_run"`
+	Committed bool              `json:"committed"`
+	Created   int64             `json:"created"`
+	Updated   int64             `json:"updated"`
+	Skipped   int64             `json:"skipped"`
+	Failed    int64             `json:"failed"`
+	Rows      []ImportRowResult `json:"rows"`
+}
+
+// GetDryRun returns the value of DryRun.
+func (s *ImportReport) GetDryRun() bool {
+	return s.DryRun
+}
+
+// GetCommitted returns the value of Committed.
+func (s *ImportReport) GetCommitted() bool {
+	return s.Committed
+}
+
+// GetCreated returns the value of Created.
+func (s *ImportReport) GetCreated() int64 {
+	return s.Created
+}
+
+// GetUpdated returns the value of Updated.
+func (s *ImportReport) GetUpdated() int64 {
+	return s.Updated
+}
+
+// GetSkipped returns the value of Skipped.
+func (s *ImportReport) GetSkipped() int64 {
+	return s.Skipped
+}
+
+// GetFailed returns the value of Failed.
+func (s *ImportReport) GetFailed() int64 {
+	return s.Failed
+}
+
+// GetRows returns the value of Rows.
+func (s *ImportReport) GetRows() []ImportRowResult {
+	return s.Rows
+}
+
+// SetDryRun sets the value of DryRun.
+func (s *ImportReport) SetDryRun(val bool) {
+	s.DryRun = val
+}
+
+// SetCommitted sets the value of Committed.
+func (s *ImportReport) SetCommitted(val bool) {
+	s.Committed = val
+}
+
+// SetCreated sets the value of Created.
+func (s *ImportReport) SetCreated(val int64) {
+	s.Created = val
+}
+
+// SetUpdated sets the value of Updated.
+func (s *ImportReport) SetUpdated(val int64) {
+	s.Updated = val
+}
+
+// SetSkipped sets the value of Skipped.
+func (s *ImportReport) SetSkipped(val int64) {
+	s.Skipped = val
+}
+
+// SetFailed sets the value of Failed.
+func (s *ImportReport) SetFailed(val int64) {
+	s.Failed = val
+}
+
+// SetRows sets the value of Rows.
+func (s *ImportReport) SetRows(val []ImportRowResult) {
+	s.Rows = val
+}
+
+func (*ImportReport) serviceImportUsersRes() {}
+
+// Result of a single row
+// - `line`: number of the row in the body, starting from 1, CSV header isn't counted
+// - `status`: what happened with the row
+// - `id`: the uuid of the created or updated user
+// - `error`: reason of the failure.
+// Ref: #/components/schemas/ImportRowResult
+type ImportRowResult struct {
+	Line   int64                 `json:"line"`
+	Status ImportRowResultStatus `json:"status"`
+	ID     OptUUID               `json:"id"`
+	Error  OptString             `json:"error"`
+}
+
+// GetLine returns the value of Line.
+func (s *ImportRowResult) GetLine() int64 {
+	return s.Line
+}
+
+// GetStatus returns the value of Status.
+func (s *ImportRowResult) GetStatus() ImportRowResultStatus {
+	return s.Status
+}
+
+// GetID returns the value of ID.
+func (s *ImportRowResult) GetID() OptUUID {
+	return s.ID
+}
+
+// GetError returns the value of Error.
+func (s *ImportRowResult) GetError() OptString {
+	return s.Error
+}
+
+// SetLine sets the value of Line.
+func (s *ImportRowResult) SetLine(val int64) {
+	s.Line = val
+}
+
+// SetStatus sets the value of Status.
+func (s *ImportRowResult) SetStatus(val ImportRowResultStatus) {
+	s.Status = val
+}
+
+// SetID sets the value of ID.
+func (s *ImportRowResult) SetID(val OptUUID) {
+	s.ID = val
+}
+
+// SetError sets the value of Error.
+func (s *ImportRowResult) SetError(val OptString) {
+	s.Error = val
+}
+
+type ImportRowResultStatus string
+
+const (
+	ImportRowResultStatusCreated ImportRowResultStatus = "created"
+	ImportRowResultStatusUpdated ImportRowResultStatus = "updated"
+	ImportRowResultStatusSkipped ImportRowResultStatus = "skipped"
+	ImportRowResultStatusFailed  ImportRowResultStatus = "failed"
+)
+
+// AllValues returns all ImportRowResultStatus values.
+func (ImportRowResultStatus) AllValues() []ImportRowResultStatus {
+	return []ImportRowResultStatus{
+		ImportRowResultStatusCreated,
+		ImportRowResultStatusUpdated,
+		ImportRowResultStatusSkipped,
+		ImportRowResultStatusFailed,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s ImportRowResultStatus) MarshalText() ([]byte, error) {
+	switch s {
+	case ImportRowResultStatusCreated:
+		return []byte(s), nil
+	case ImportRowResultStatusUpdated:
+		return []byte(s), nil
+	case ImportRowResultStatusSkipped:
+		return []byte(s), nil
+	case ImportRowResultStatusFailed:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *ImportRowResultStatus) UnmarshalText(data []byte) error {
+	switch ImportRowResultStatus(data) {
+	case ImportRowResultStatusCreated:
+		*s = ImportRowResultStatusCreated
+		return nil
+	case ImportRowResultStatusUpdated:
+		*s = ImportRowResultStatusUpdated
+		return nil
+	case ImportRowResultStatusSkipped:
+		*s = ImportRowResultStatusSkipped
+		return nil
+	case ImportRowResultStatusFailed:
+		*s = ImportRowResultStatusFailed
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
 // Ref: #/components/schemas/InternalErrorResponse
 type InternalErrorResponse struct {
 	Message InternalErrorResponseMessage `json:"message"`
@@ -176,12 +725,37 @@ func (s *InternalErrorResponse) SetMessage(val InternalErrorResponseMessage) {
 	s.Message = val
 }
 
-func (*InternalErrorResponse) serviceCreateUserRes() {}
-func (*InternalErrorResponse) serviceDeleteUserRes() {}
-func (*InternalErrorResponse) serviceGetUserRes()    {}
-func (*InternalErrorResponse) serviceListUsersRes()  {}
-func (*InternalErrorResponse) servicePatchUserRes()  {}
-func (*InternalErrorResponse) servicePutUserRes()    {}
+func (*InternalErrorResponse) changePasswordRes()                   {}
+func (*InternalErrorResponse) groupsAddGroupMemberRes()             {}
+func (*InternalErrorResponse) groupsCreateGroupRes()                {}
+func (*InternalErrorResponse) groupsDeleteGroupRes()                {}
+func (*InternalErrorResponse) groupsGetGroupRes()                   {}
+func (*InternalErrorResponse) groupsListGroupMembersRes()           {}
+func (*InternalErrorResponse) groupsListGroupsRes()                 {}
+func (*InternalErrorResponse) groupsPatchGroupRes()                 {}
+func (*InternalErrorResponse) groupsRemoveGroupMemberRes()          {}
+func (*InternalErrorResponse) organizationsCreateOrganizationRes()  {}
+func (*InternalErrorResponse) organizationsDeleteOrganizationRes()  {}
+func (*InternalErrorResponse) organizationsGetOrganizationRes()     {}
+func (*InternalErrorResponse) organizationsListOrganizationsRes()   {}
+func (*InternalErrorResponse) serviceBatchUsersRes()                {}
+func (*InternalErrorResponse) serviceCreateUserRes()                {}
+func (*InternalErrorResponse) serviceDeleteUserRes()                {}
+func (*InternalErrorResponse) serviceExportUsersRes()               {}
+func (*InternalErrorResponse) serviceGetUserGroupsRes()             {}
+func (*InternalErrorResponse) serviceGetUserRes()                   {}
+func (*InternalErrorResponse) serviceImportUsersRes()               {}
+func (*InternalErrorResponse) serviceListUsersRes()                 {}
+func (*InternalErrorResponse) servicePatchUserRes()                 {}
+func (*InternalErrorResponse) servicePutUserRes()                   {}
+func (*InternalErrorResponse) serviceWatchUsersRes()                {}
+func (*InternalErrorResponse) webhooksCreateWebhookRes()            {}
+func (*InternalErrorResponse) webhooksDeleteWebhookRes()            {}
+func (*InternalErrorResponse) webhooksGetWebhookRes()               {}
+func (*InternalErrorResponse) webhooksListWebhookDeliveriesRes()    {}
+func (*InternalErrorResponse) webhooksListWebhooksRes()             {}
+func (*InternalErrorResponse) webhooksPatchWebhookRes()             {}
+func (*InternalErrorResponse) webhooksRedeliverWebhookDeliveryRes() {}
 
 type InternalErrorResponseMessage string
 
@@ -217,40 +791,95 @@ func (s *InternalErrorResponseMessage) UnmarshalText(data []byte) error {
 	}
 }
 
-// Ref: #/components/schemas/NotFoundResponse
-type NotFoundResponse struct {
-	Message NotFoundResponseMessage `json:"message"`
+// Operation of JSON Patch (RFC 6902)
+// - `path`, `from`: JSON pointers (RFC 6901) to fields of the user, e.g. /email
+// - `from`: required by move and copy
+// - `value`: required by add, replace and test.
+// Ref: #/components/schemas/JSONPatchOperation
+type JSONPatchOperation struct {
+	Op    JSONPatchOperationOp `json:"op"`
+	Path  string               `json:"path"`
+	From  OptString            `json:"from"`
+	Value jx.Raw               `json:"value"`
 }
 
-// GetMessage returns the value of Message.
-func (s *NotFoundResponse) GetMessage() NotFoundResponseMessage {
-	return s.Message
+// GetOp returns the value of Op.
+func (s *JSONPatchOperation) GetOp() JSONPatchOperationOp {
+	return s.Op
 }
 
-// SetMessage sets the value of Message.
-func (s *NotFoundResponse) SetMessage(val NotFoundResponseMessage) {
-	s.Message = val
+// GetPath returns the value of Path.
+func (s *JSONPatchOperation) GetPath() string {
+	return s.Path
 }
 
-func (*NotFoundResponse) serviceGetUserRes() {}
+// GetFrom returns the value of From.
+func (s *JSONPatchOperation) GetFrom() OptString {
+	return s.From
+}
 
-type NotFoundResponseMessage string
+// GetValue returns the value of Value.
+func (s *JSONPatchOperation) GetValue() jx.Raw {
+	return s.Value
+}
+
+// SetOp sets the value of Op.
+func (s *JSONPatchOperation) SetOp(val JSONPatchOperationOp) {
+	s.Op = val
+}
+
+// SetPath sets the value of Path.
+func (s *JSONPatchOperation) SetPath(val string) {
+	s.Path = val
+}
+
+// SetFrom sets the value of From.
+func (s *JSONPatchOperation) SetFrom(val OptString) {
+	s.From = val
+}
+
+// SetValue sets the value of Value.
+func (s *JSONPatchOperation) SetValue(val jx.Raw) {
+	s.Value = val
+}
+
+type JSONPatchOperationOp string
 
 const (
-	NotFoundResponseMessageNotFound NotFoundResponseMessage = "not found"
+	JSONPatchOperationOpAdd     JSONPatchOperationOp = "add"
+	JSONPatchOperationOpRemove  JSONPatchOperationOp = "remove"
+	JSONPatchOperationOpReplace JSONPatchOperationOp = "replace"
+	JSONPatchOperationOpMove    JSONPatchOperationOp = "move"
+	JSONPatchOperationOpCopy    JSONPatchOperationOp = "copy"
+	JSONPatchOperationOpTest    JSONPatchOperationOp = "test"
 )
 
-// AllValues returns all NotFoundResponseMessage values.
-func (NotFoundResponseMessage) AllValues() []NotFoundResponseMessage {
-	return []NotFoundResponseMessage{
-		NotFoundResponseMessageNotFound,
+// AllValues returns all JSONPatchOperationOp values.
+func (JSONPatchOperationOp) AllValues() []JSONPatchOperationOp {
+	return []JSONPatchOperationOp{
+		JSONPatchOperationOpAdd,
+		JSONPatchOperationOpRemove,
+		JSONPatchOperationOpReplace,
+		JSONPatchOperationOpMove,
+		JSONPatchOperationOpCopy,
+		JSONPatchOperationOpTest,
 	}
 }
 
 // MarshalText implements encoding.TextMarshaler.
-func (s NotFoundResponseMessage) MarshalText() ([]byte, error) {
+func (s JSONPatchOperationOp) MarshalText() ([]byte, error) {
 	switch s {
-	case NotFoundResponseMessageNotFound:
+	case JSONPatchOperationOpAdd:
+		return []byte(s), nil
+	case JSONPatchOperationOpRemove:
+		return []byte(s), nil
+	case JSONPatchOperationOpReplace:
+		return []byte(s), nil
+	case JSONPatchOperationOpMove:
+		return []byte(s), nil
+	case JSONPatchOperationOpCopy:
+		return []byte(s), nil
+	case JSONPatchOperationOpTest:
 		return []byte(s), nil
 	default:
 		return nil, errors.Errorf("invalid value: %q", s)
@@ -258,15 +887,146 @@ func (s NotFoundResponseMessage) MarshalText() ([]byte, error) {
 }
 
 // UnmarshalText implements encoding.TextUnmarshaler.
-func (s *NotFoundResponseMessage) UnmarshalText(data []byte) error {
-	switch NotFoundResponseMessage(data) {
-	case NotFoundResponseMessageNotFound:
-		*s = NotFoundResponseMessageNotFound
+func (s *JSONPatchOperationOp) UnmarshalText(data []byte) error {
+	switch JSONPatchOperationOp(data) {
+	case JSONPatchOperationOpAdd:
+		*s = JSONPatchOperationOpAdd
 		return nil
-	default:
-		return errors.Errorf("invalid value: %q", data)
-	}
-}
+	case JSONPatchOperationOpRemove:
+		*s = JSONPatchOperationOpRemove
+		return nil
+	case JSONPatchOperationOpReplace:
+		*s = JSONPatchOperationOpReplace
+		return nil
+	case JSONPatchOperationOpMove:
+		*s = JSONPatchOperationOpMove
+		return nil
+	case JSONPatchOperationOpCopy:
+		*s = JSONPatchOperationOpCopy
+		return nil
+	case JSONPatchOperationOpTest:
+		*s = JSONPatchOperationOpTest
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/schemas/LastAdminResponse
+type LastAdminResponse struct {
+	Message LastAdminResponseMessage `json:"message"`
+}
+
+// GetMessage returns the value of Message.
+func (s *LastAdminResponse) GetMessage() LastAdminResponseMessage {
+	return s.Message
+}
+
+// SetMessage sets the value of Message.
+func (s *LastAdminResponse) SetMessage(val LastAdminResponseMessage) {
+	s.Message = val
+}
+
+func (*LastAdminResponse) serviceDeleteUserRes() {}
+func (*LastAdminResponse) servicePutUserRes()    {}
+
+type LastAdminResponseMessage string
+
+const (
+	LastAdminResponseMessageConflictLastAdministratorCanTBeRemoved LastAdminResponseMessage = "conflict, last administrator can't be removed"
+)
+
+// AllValues returns all LastAdminResponseMessage values.
+func (LastAdminResponseMessage) AllValues() []LastAdminResponseMessage {
+	return []LastAdminResponseMessage{
+		LastAdminResponseMessageConflictLastAdministratorCanTBeRemoved,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s LastAdminResponseMessage) MarshalText() ([]byte, error) {
+	switch s {
+	case LastAdminResponseMessageConflictLastAdministratorCanTBeRemoved:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *LastAdminResponseMessage) UnmarshalText(data []byte) error {
+	switch LastAdminResponseMessage(data) {
+	case LastAdminResponseMessageConflictLastAdministratorCanTBeRemoved:
+		*s = LastAdminResponseMessageConflictLastAdministratorCanTBeRemoved
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/schemas/NotFoundResponse
+type NotFoundResponse struct {
+	Message NotFoundResponseMessage `json:"message"`
+}
+
+// GetMessage returns the value of Message.
+func (s *NotFoundResponse) GetMessage() NotFoundResponseMessage {
+	return s.Message
+}
+
+// SetMessage sets the value of Message.
+func (s *NotFoundResponse) SetMessage(val NotFoundResponseMessage) {
+	s.Message = val
+}
+
+func (*NotFoundResponse) groupsAddGroupMemberRes()             {}
+func (*NotFoundResponse) groupsGetGroupRes()                   {}
+func (*NotFoundResponse) groupsListGroupMembersRes()           {}
+func (*NotFoundResponse) groupsPatchGroupRes()                 {}
+func (*NotFoundResponse) groupsRemoveGroupMemberRes()          {}
+func (*NotFoundResponse) organizationsGetOrganizationRes()     {}
+func (*NotFoundResponse) serviceGetUserGroupsRes()             {}
+func (*NotFoundResponse) serviceGetUserRes()                   {}
+func (*NotFoundResponse) servicePatchUserRes()                 {}
+func (*NotFoundResponse) servicePutUserRes()                   {}
+func (*NotFoundResponse) webhooksGetWebhookRes()               {}
+func (*NotFoundResponse) webhooksListWebhookDeliveriesRes()    {}
+func (*NotFoundResponse) webhooksPatchWebhookRes()             {}
+func (*NotFoundResponse) webhooksRedeliverWebhookDeliveryRes() {}
+
+type NotFoundResponseMessage string
+
+const (
+	NotFoundResponseMessageNotFound NotFoundResponseMessage = "not found"
+)
+
+// AllValues returns all NotFoundResponseMessage values.
+func (NotFoundResponseMessage) AllValues() []NotFoundResponseMessage {
+	return []NotFoundResponseMessage{
+		NotFoundResponseMessageNotFound,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s NotFoundResponseMessage) MarshalText() ([]byte, error) {
+	switch s {
+	case NotFoundResponseMessageNotFound:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *NotFoundResponseMessage) UnmarshalText(data []byte) error {
+	switch NotFoundResponseMessage(data) {
+	case NotFoundResponseMessageNotFound:
+		*s = NotFoundResponseMessageNotFound
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
 
 // NewOptBool returns new OptBool with value set to v.
 func NewOptBool(v bool) OptBool {
@@ -314,38 +1074,38 @@ func (o OptBool) Or(d bool) bool {
 	return d
 }
 
-// NewOptInt64 returns new OptInt64 with value set to v.
-func NewOptInt64(v int64) OptInt64 {
-	return OptInt64{
+// NewOptDateTime returns new OptDateTime with value set to v.
+func NewOptDateTime(v time.Time) OptDateTime {
+	return OptDateTime{
 		Value: v,
 		Set:   true,
 	}
 }
 
-// OptInt64 is optional int64.
-type OptInt64 struct {
-	Value int64
+// OptDateTime is optional time.Time.
+type OptDateTime struct {
+	Value time.Time
 	Set   bool
 }
 
-// IsSet returns true if OptInt64 was set.
-func (o OptInt64) IsSet() bool { return o.Set }
+// IsSet returns true if OptDateTime was set.
+func (o OptDateTime) IsSet() bool { return o.Set }
 
 // Reset unsets value.
-func (o *OptInt64) Reset() {
-	var v int64
+func (o *OptDateTime) Reset() {
+	var v time.Time
 	o.Value = v
 	o.Set = false
 }
 
 // SetTo sets value to v.
-func (o *OptInt64) SetTo(v int64) {
+func (o *OptDateTime) SetTo(v time.Time) {
 	o.Set = true
 	o.Value = v
 }
 
 // Get returns value and boolean that denotes whether value was set.
-func (o OptInt64) Get() (v int64, ok bool) {
+func (o OptDateTime) Get() (v time.Time, ok bool) {
 	if !o.Set {
 		return v, false
 	}
@@ -353,45 +1113,45 @@ func (o OptInt64) Get() (v int64, ok bool) {
 }
 
 // Or returns value if set, or given parameter if does not.
-func (o OptInt64) Or(d int64) int64 {
+func (o OptDateTime) Or(d time.Time) time.Time {
 	if v, ok := o.Get(); ok {
 		return v
 	}
 	return d
 }
 
-// NewOptString returns new OptString with value set to v.
-func NewOptString(v string) OptString {
-	return OptString{
+// NewOptInt32 returns new OptInt32 with value set to v.
+func NewOptInt32(v int32) OptInt32 {
+	return OptInt32{
 		Value: v,
 		Set:   true,
 	}
 }
 
-// OptString is optional string.
-type OptString struct {
-	Value string
+// OptInt32 is optional int32.
+type OptInt32 struct {
+	Value int32
 	Set   bool
 }
 
-// IsSet returns true if OptString was set.
-func (o OptString) IsSet() bool { return o.Set }
+// IsSet returns true if OptInt32 was set.
+func (o OptInt32) IsSet() bool { return o.Set }
 
 // Reset unsets value.
-func (o *OptString) Reset() {
-	var v string
+func (o *OptInt32) Reset() {
+	var v int32
 	o.Value = v
 	o.Set = false
 }
 
 // SetTo sets value to v.
-func (o *OptString) SetTo(v string) {
+func (o *OptInt32) SetTo(v int32) {
 	o.Set = true
 	o.Value = v
 }
 
 // Get returns value and boolean that denotes whether value was set.
-func (o OptString) Get() (v string, ok bool) {
+func (o OptInt32) Get() (v int32, ok bool) {
 	if !o.Set {
 		return v, false
 	}
@@ -399,45 +1159,45 @@ func (o OptString) Get() (v string, ok bool) {
 }
 
 // Or returns value if set, or given parameter if does not.
-func (o OptString) Or(d string) string {
+func (o OptInt32) Or(d int32) int32 {
 	if v, ok := o.Get(); ok {
 		return v
 	}
 	return d
 }
 
-// NewOptUUID returns new OptUUID with value set to v.
-func NewOptUUID(v UUID) OptUUID {
-	return OptUUID{
+// NewOptInt64 returns new OptInt64 with value set to v.
+func NewOptInt64(v int64) OptInt64 {
+	return OptInt64{
 		Value: v,
 		Set:   true,
 	}
 }
 
-// OptUUID is optional UUID.
-type OptUUID struct {
-	Value UUID
+// OptInt64 is optional int64.
+type OptInt64 struct {
+	Value int64
 	Set   bool
 }
 
-// IsSet returns true if OptUUID was set.
-func (o OptUUID) IsSet() bool { return o.Set }
+// IsSet returns true if OptInt64 was set.
+func (o OptInt64) IsSet() bool { return o.Set }
 
 // Reset unsets value.
-func (o *OptUUID) Reset() {
-	var v UUID
+func (o *OptInt64) Reset() {
+	var v int64
 	o.Value = v
 	o.Set = false
 }
 
 // SetTo sets value to v.
-func (o *OptUUID) SetTo(v UUID) {
+func (o *OptInt64) SetTo(v int64) {
 	o.Set = true
 	o.Value = v
 }
 
 // Get returns value and boolean that denotes whether value was set.
-func (o OptUUID) Get() (v UUID, ok bool) {
+func (o OptInt64) Get() (v int64, ok bool) {
 	if !o.Set {
 		return v, false
 	}
@@ -445,110 +1205,1097 @@ func (o OptUUID) Get() (v UUID, ok bool) {
 }
 
 // Or returns value if set, or given parameter if does not.
-func (o OptUUID) Or(d UUID) UUID {
+func (o OptInt64) Or(d int64) int64 {
 	if v, ok := o.Get(); ok {
 		return v
 	}
 	return d
 }
 
-// ServiceDeleteUserOK is response for ServiceDeleteUser operation.
-type ServiceDeleteUserOK struct{}
-
-func (*ServiceDeleteUserOK) serviceDeleteUserRes() {}
-
-type ServiceListUsersOKApplicationJSON []User
-
-func (*ServiceListUsersOKApplicationJSON) serviceListUsersRes() {}
+// NewOptNilBool returns new OptNilBool with value set to v.
+func NewOptNilBool(v bool) OptNilBool {
+	return OptNilBool{
+		Value: v,
+		Set:   true,
+	}
+}
 
-// ServicePatchUserOK is response for ServicePatchUser operation.
-type ServicePatchUserOK struct{}
+// OptNilBool is optional nullable bool.
+type OptNilBool struct {
+	Value bool
+	Set   bool
+	Null  bool
+}
 
-func (*ServicePatchUserOK) servicePatchUserRes() {}
+// IsSet returns true if OptNilBool was set.
+func (o OptNilBool) IsSet() bool { return o.Set }
 
-// ServicePutUserOK is response for ServicePutUser operation.
-type ServicePutUserOK struct{}
+// Reset unsets value.
+func (o *OptNilBool) Reset() {
+	var v bool
+	o.Value = v
+	o.Set = false
+	o.Null = false
+}
 
-func (*ServicePutUserOK) servicePutUserRes() {}
+// SetTo sets value to v.
+func (o *OptNilBool) SetTo(v bool) {
+	o.Set = true
+	o.Null = false
+	o.Value = v
+}
 
-type UUID uuid.UUID
+// IsNull returns true if value is Null.
+func (o OptNilBool) IsNull() bool { return o.Null }
 
-// User model all fields isn't required
-// - `id`: the uuid
-// - `username`: the user's name
-// - `password`: the user's password, returned like a base64 string
-// - `email`: the user's email
-// - `is_admin`: define user permissions.
-// Ref: #/components/schemas/User
-type User struct {
-	ID       OptUUID   `json:"id" db:"id"`
-	Username OptString `json:"username" db:"username"`
-	Password OptString `json:"password" db:"password"`
-	Email    OptString `json:"email" db:"email"`
-	IsAdmin  OptBool   `json:"is_admin" db:"is_admin"`
+// SetToNull sets value to null.
+func (o *OptNilBool) SetToNull() {
+	o.Set = true
+	o.Null = true
+	var v bool
+	o.Value = v
 }
 
-// GetID returns the value of ID.
-func (s *User) GetID() OptUUID {
-	return s.ID
+// Get returns value and boolean that denotes whether value was set.
+func (o OptNilBool) Get() (v bool, ok bool) {
+	if o.Null {
+		return v, false
+	}
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
 }
 
-// GetUsername returns the value of Username.
-func (s *User) GetUsername() OptString {
-	return s.Username
+// Or returns value if set, or given parameter if does not.
+func (o OptNilBool) Or(d bool) bool {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
 }
 
-// GetPassword returns the value of Password.
-func (s *User) GetPassword() OptString {
-	return s.Password
+// NewOptNilString returns new OptNilString with value set to v.
+func NewOptNilString(v string) OptNilString {
+	return OptNilString{
+		Value: v,
+		Set:   true,
+	}
 }
 
-// GetEmail returns the value of Email.
-func (s *User) GetEmail() OptString {
-	return s.Email
+// OptNilString is optional nullable string.
+type OptNilString struct {
+	Value string
+	Set   bool
+	Null  bool
 }
 
-// GetIsAdmin returns the value of IsAdmin.
-func (s *User) GetIsAdmin() OptBool {
-	return s.IsAdmin
-}
+// IsSet returns true if OptNilString was set.
+func (o OptNilString) IsSet() bool { return o.Set }
 
-// SetID sets the value of ID.
-func (s *User) SetID(val OptUUID) {
-	s.ID = val
+// Reset unsets value.
+func (o *OptNilString) Reset() {
+	var v string
+	o.Value = v
+	o.Set = false
+	o.Null = false
 }
 
-// SetUsername sets the value of Username.
-func (s *User) SetUsername(val OptString) {
-	s.Username = val
+// SetTo sets value to v.
+func (o *OptNilString) SetTo(v string) {
+	o.Set = true
+	o.Null = false
+	o.Value = v
 }
 
-// SetPassword sets the value of Password.
-func (s *User) SetPassword(val OptString) {
-	s.Password = val
+// IsNull returns true if value is Null.
+func (o OptNilString) IsNull() bool { return o.Null }
+
+// SetToNull sets value to null.
+func (o *OptNilString) SetToNull() {
+	o.Set = true
+	o.Null = true
+	var v string
+	o.Value = v
 }
 
-// SetEmail sets the value of Email.
-func (s *User) SetEmail(val OptString) {
-	s.Email = val
+// Get returns value and boolean that denotes whether value was set.
+func (o OptNilString) Get() (v string, ok bool) {
+	if o.Null {
+		return v, false
+	}
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
 }
 
-// SetIsAdmin sets the value of IsAdmin.
-func (s *User) SetIsAdmin(val OptBool) {
-	s.IsAdmin = val
+// Or returns value if set, or given parameter if does not.
+func (o OptNilString) Or(d string) string {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptServiceImportUsersOnConflict returns new OptServiceImportUsersOnConflict with value set to v.
+func NewOptServiceImportUsersOnConflict(v ServiceImportUsersOnConflict) OptServiceImportUsersOnConflict {
+	return OptServiceImportUsersOnConflict{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptServiceImportUsersOnConflict is optional ServiceImportUsersOnConflict.
+type OptServiceImportUsersOnConflict struct {
+	Value ServiceImportUsersOnConflict
+	Set   bool
+}
+
+// IsSet returns true if OptServiceImportUsersOnConflict was set.
+func (o OptServiceImportUsersOnConflict) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptServiceImportUsersOnConflict) Reset() {
+	var v ServiceImportUsersOnConflict
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptServiceImportUsersOnConflict) SetTo(v ServiceImportUsersOnConflict) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptServiceImportUsersOnConflict) Get() (v ServiceImportUsersOnConflict, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptServiceImportUsersOnConflict) Or(d ServiceImportUsersOnConflict) ServiceImportUsersOnConflict {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptString returns new OptString with value set to v.
+func NewOptString(v string) OptString {
+	return OptString{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptString is optional string.
+type OptString struct {
+	Value string
+	Set   bool
+}
+
+// IsSet returns true if OptString was set.
+func (o OptString) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptString) Reset() {
+	var v string
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptString) SetTo(v string) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptString) Get() (v string, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptString) Or(d string) string {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptUUID returns new OptUUID with value set to v.
+func NewOptUUID(v UUID) OptUUID {
+	return OptUUID{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptUUID is optional UUID.
+type OptUUID struct {
+	Value UUID
+	Set   bool
+}
+
+// IsSet returns true if OptUUID was set.
+func (o OptUUID) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptUUID) Reset() {
+	var v UUID
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptUUID) SetTo(v UUID) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptUUID) Get() (v UUID, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptUUID) Or(d UUID) UUID {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptUser returns new OptUser with value set to v.
+func NewOptUser(v User) OptUser {
+	return OptUser{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptUser is optional User.
+type OptUser struct {
+	Value User
+	Set   bool
+}
+
+// IsSet returns true if OptUser was set.
+func (o OptUser) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptUser) Reset() {
+	var v User
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptUser) SetTo(v User) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptUser) Get() (v User, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptUser) Or(d User) User {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// Organization model all fields isn't required
+// - `id`: the uuid
+// - `name`: the organization's name, unique.
+// Ref: #/components/schemas/Organization
+type Organization struct {
+	ID   OptUUID   `json:"id" db:"id"`
+	Name OptString `json:"name" db:"name"`
+}
+
+// GetID returns the value of ID.
+func (s *Organization) GetID() OptUUID {
+	return s.ID
+}
+
+// GetName returns the value of Name.
+func (s *Organization) GetName() OptString {
+	return s.Name
+}
+
+// SetID sets the value of ID.
+func (s *Organization) SetID(val OptUUID) {
+	s.ID = val
+}
+
+// SetName sets the value of Name.
+func (s *Organization) SetName(val OptString) {
+	s.Name = val
+}
+
+func (*Organization) organizationsCreateOrganizationRes() {}
+func (*Organization) organizationsGetOrganizationRes()    {}
+
+// OrganizationsDeleteOrganizationOK is response for OrganizationsDeleteOrganization operation.
+type OrganizationsDeleteOrganizationOK struct{}
+
+func (*OrganizationsDeleteOrganizationOK) organizationsDeleteOrganizationRes() {}
+
+type OrganizationsListOrganizationsOKApplicationJSON []Organization
+
+func (*OrganizationsListOrganizationsOKApplicationJSON) organizationsListOrganizationsRes() {}
+
+// New password of the authenticated user.
+// Ref: #/components/schemas/PasswordChange
+type PasswordChange struct {
+	Password string `json:"password"`
+}
+
+// GetPassword returns the value of Password.
+func (s *PasswordChange) GetPassword() string {
+	return s.Password
+}
+
+// SetPassword sets the value of Password.
+func (s *PasswordChange) SetPassword(val string) {
+	s.Password = val
+}
+
+// Ref: #/components/schemas/PatchTestFailedResponse
+type PatchTestFailedResponse struct {
+	Message PatchTestFailedResponseMessage `json:"message"`
+}
+
+// GetMessage returns the value of Message.
+func (s *PatchTestFailedResponse) GetMessage() PatchTestFailedResponseMessage {
+	return s.Message
+}
+
+// SetMessage sets the value of Message.
+func (s *PatchTestFailedResponse) SetMessage(val PatchTestFailedResponseMessage) {
+	s.Message = val
+}
+
+type PatchTestFailedResponseMessage string
+
+const (
+	PatchTestFailedResponseMessageConflictPatchTestFailed PatchTestFailedResponseMessage = "conflict, patch test failed"
+)
+
+// AllValues returns all PatchTestFailedResponseMessage values.
+func (PatchTestFailedResponseMessage) AllValues() []PatchTestFailedResponseMessage {
+	return []PatchTestFailedResponseMessage{
+		PatchTestFailedResponseMessageConflictPatchTestFailed,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s PatchTestFailedResponseMessage) MarshalText() ([]byte, error) {
+	switch s {
+	case PatchTestFailedResponseMessageConflictPatchTestFailed:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *PatchTestFailedResponseMessage) UnmarshalText(data []byte) error {
+	switch PatchTestFailedResponseMessage(data) {
+	case PatchTestFailedResponseMessageConflictPatchTestFailed:
+		*s = PatchTestFailedResponseMessageConflictPatchTestFailed
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// ServiceDeleteUserOK is response for ServiceDeleteUser operation.
+type ServiceDeleteUserOK struct{}
+
+func (*ServiceDeleteUserOK) serviceDeleteUserRes() {}
+
+type ServiceExportUsersOKApplication struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s ServiceExportUsersOKApplication) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+// ServiceExportUsersOKApplicationHeaders wraps ServiceExportUsersOKApplication with response headers.
+type ServiceExportUsersOKApplicationHeaders struct {
+	ContentType string
+	Response    ServiceExportUsersOKApplication
+}
+
+// GetContentType returns the value of ContentType.
+func (s *ServiceExportUsersOKApplicationHeaders) GetContentType() string {
+	return s.ContentType
+}
+
+// GetResponse returns the value of Response.
+func (s *ServiceExportUsersOKApplicationHeaders) GetResponse() ServiceExportUsersOKApplication {
+	return s.Response
+}
+
+// SetContentType sets the value of ContentType.
+func (s *ServiceExportUsersOKApplicationHeaders) SetContentType(val string) {
+	s.ContentType = val
+}
+
+// SetResponse sets the value of Response.
+func (s *ServiceExportUsersOKApplicationHeaders) SetResponse(val ServiceExportUsersOKApplication) {
+	s.Response = val
+}
+
+func (*ServiceExportUsersOKApplicationHeaders) serviceExportUsersRes() {}
+
+type ServiceExportUsersOKTextCsv struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s ServiceExportUsersOKTextCsv) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+// ServiceExportUsersOKTextCsvHeaders wraps ServiceExportUsersOKTextCsv with response headers.
+type ServiceExportUsersOKTextCsvHeaders struct {
+	ContentType string
+	Response    ServiceExportUsersOKTextCsv
+}
+
+// GetContentType returns the value of ContentType.
+func (s *ServiceExportUsersOKTextCsvHeaders) GetContentType() string {
+	return s.ContentType
+}
+
+// GetResponse returns the value of Response.
+func (s *ServiceExportUsersOKTextCsvHeaders) GetResponse() ServiceExportUsersOKTextCsv {
+	return s.Response
+}
+
+// SetContentType sets the value of ContentType.
+func (s *ServiceExportUsersOKTextCsvHeaders) SetContentType(val string) {
+	s.ContentType = val
+}
+
+// SetResponse sets the value of Response.
+func (s *ServiceExportUsersOKTextCsvHeaders) SetResponse(val ServiceExportUsersOKTextCsv) {
+	s.Response = val
+}
+
+func (*ServiceExportUsersOKTextCsvHeaders) serviceExportUsersRes() {}
+
+type ServiceGetUserGroupsOKApplicationJSON []Group
+
+func (*ServiceGetUserGroupsOKApplicationJSON) serviceGetUserGroupsRes() {}
+
+type ServiceImportUsersOnConflict string
+
+const (
+	ServiceImportUsersOnConflictSkip   ServiceImportUsersOnConflict = "skip"
+	ServiceImportUsersOnConflictUpdate ServiceImportUsersOnConflict = "update"
+	ServiceImportUsersOnConflictFail   ServiceImportUsersOnConflict = "fail"
+)
+
+// AllValues returns all ServiceImportUsersOnConflict values.
+func (ServiceImportUsersOnConflict) AllValues() []ServiceImportUsersOnConflict {
+	return []ServiceImportUsersOnConflict{
+		ServiceImportUsersOnConflictSkip,
+		ServiceImportUsersOnConflictUpdate,
+		ServiceImportUsersOnConflictFail,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s ServiceImportUsersOnConflict) MarshalText() ([]byte, error) {
+	switch s {
+	case ServiceImportUsersOnConflictSkip:
+		return []byte(s), nil
+	case ServiceImportUsersOnConflictUpdate:
+		return []byte(s), nil
+	case ServiceImportUsersOnConflictFail:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *ServiceImportUsersOnConflict) UnmarshalText(data []byte) error {
+	switch ServiceImportUsersOnConflict(data) {
+	case ServiceImportUsersOnConflictSkip:
+		*s = ServiceImportUsersOnConflictSkip
+		return nil
+	case ServiceImportUsersOnConflictUpdate:
+		*s = ServiceImportUsersOnConflictUpdate
+		return nil
+	case ServiceImportUsersOnConflictFail:
+		*s = ServiceImportUsersOnConflictFail
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+type ServiceImportUsersReqApplicationXNdjson struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s ServiceImportUsersReqApplicationXNdjson) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+func (*ServiceImportUsersReqApplicationXNdjson) serviceImportUsersReq() {}
+
+type ServiceImportUsersReqTextCsv struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s ServiceImportUsersReqTextCsv) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+func (*ServiceImportUsersReqTextCsv) serviceImportUsersReq() {}
+
+type ServiceListUsersOKApplicationJSON []User
+
+func (*ServiceListUsersOKApplicationJSON) serviceListUsersRes() {}
+
+// ServicePatchUserConflict represents sum type.
+type ServicePatchUserConflict struct {
+	Type                    ServicePatchUserConflictType // switch on this field
+	AlreadyExistsResponse   AlreadyExistsResponse
+	LastAdminResponse       LastAdminResponse
+	PatchTestFailedResponse PatchTestFailedResponse
+}
+
+// ServicePatchUserConflictType is oneOf type of ServicePatchUserConflict.
+type ServicePatchUserConflictType string
+
+// Possible values for ServicePatchUserConflictType.
+const (
+	ServicePatchUserConflictAlreadyExistsEmailTakenServicePatchUserConflict    ServicePatchUserConflictType = "already exists, email taken"
+	ServicePatchUserConflictAlreadyExistsUsernameTakenServicePatchUserConflict ServicePatchUserConflictType = "already exists, username taken"
+	LastAdminResponseServicePatchUserConflict                                  ServicePatchUserConflictType = "conflict, last administrator can't be removed"
+	PatchTestFailedResponseServicePatchUserConflict                            ServicePatchUserConflictType = "conflict, patch test failed"
+)
+
+// IsAlreadyExistsResponse reports whether ServicePatchUserConflict is AlreadyExistsResponse.
+func (s ServicePatchUserConflict) IsAlreadyExistsResponse() bool {
+	switch s.Type {
+	case ServicePatchUserConflictAlreadyExistsEmailTakenServicePatchUserConflict, ServicePatchUserConflictAlreadyExistsUsernameTakenServicePatchUserConflict:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsLastAdminResponse reports whether ServicePatchUserConflict is LastAdminResponse.
+func (s ServicePatchUserConflict) IsLastAdminResponse() bool {
+	return s.Type == LastAdminResponseServicePatchUserConflict
+}
+
+// IsPatchTestFailedResponse reports whether ServicePatchUserConflict is PatchTestFailedResponse.
+func (s ServicePatchUserConflict) IsPatchTestFailedResponse() bool {
+	return s.Type == PatchTestFailedResponseServicePatchUserConflict
+}
+
+// SetAlreadyExistsResponse sets ServicePatchUserConflict to AlreadyExistsResponse.
+// panics if `t` is not associated with AlreadyExistsResponse
+func (s *ServicePatchUserConflict) SetAlreadyExistsResponse(t ServicePatchUserConflictType, v AlreadyExistsResponse) {
+	s.Type = t
+	s.AlreadyExistsResponse = v
+	if !s.IsAlreadyExistsResponse() {
+		panic(fmt.Errorf("invariant: %v is not AlreadyExistsResponse", t))
+	}
+}
+
+// GetAlreadyExistsResponse returns AlreadyExistsResponse and true boolean if ServicePatchUserConflict is AlreadyExistsResponse.
+func (s ServicePatchUserConflict) GetAlreadyExistsResponse() (v AlreadyExistsResponse, ok bool) {
+	if !s.IsAlreadyExistsResponse() {
+		return v, false
+	}
+	return s.AlreadyExistsResponse, true
+}
+
+// NewServicePatchUserConflictAlreadyExistsEmailTakenServicePatchUserConflict returns new ServicePatchUserConflict from AlreadyExistsResponse.
+func NewServicePatchUserConflictAlreadyExistsEmailTakenServicePatchUserConflict(v AlreadyExistsResponse) ServicePatchUserConflict {
+	var s ServicePatchUserConflict
+	s.SetAlreadyExistsResponse(ServicePatchUserConflictAlreadyExistsEmailTakenServicePatchUserConflict, v)
+	return s
+}
+
+// NewServicePatchUserConflictAlreadyExistsUsernameTakenServicePatchUserConflict returns new ServicePatchUserConflict from AlreadyExistsResponse.
+func NewServicePatchUserConflictAlreadyExistsUsernameTakenServicePatchUserConflict(v AlreadyExistsResponse) ServicePatchUserConflict {
+	var s ServicePatchUserConflict
+	s.SetAlreadyExistsResponse(ServicePatchUserConflictAlreadyExistsUsernameTakenServicePatchUserConflict, v)
+	return s
+}
+
+// SetLastAdminResponse sets ServicePatchUserConflict to LastAdminResponse.
+func (s *ServicePatchUserConflict) SetLastAdminResponse(v LastAdminResponse) {
+	s.Type = LastAdminResponseServicePatchUserConflict
+	s.LastAdminResponse = v
+}
+
+// GetLastAdminResponse returns LastAdminResponse and true boolean if ServicePatchUserConflict is LastAdminResponse.
+func (s ServicePatchUserConflict) GetLastAdminResponse() (v LastAdminResponse, ok bool) {
+	if !s.IsLastAdminResponse() {
+		return v, false
+	}
+	return s.LastAdminResponse, true
+}
+
+// NewLastAdminResponseServicePatchUserConflict returns new ServicePatchUserConflict from LastAdminResponse.
+func NewLastAdminResponseServicePatchUserConflict(v LastAdminResponse) ServicePatchUserConflict {
+	var s ServicePatchUserConflict
+	s.SetLastAdminResponse(v)
+	return s
+}
+
+// SetPatchTestFailedResponse sets ServicePatchUserConflict to PatchTestFailedResponse.
+func (s *ServicePatchUserConflict) SetPatchTestFailedResponse(v PatchTestFailedResponse) {
+	s.Type = PatchTestFailedResponseServicePatchUserConflict
+	s.PatchTestFailedResponse = v
+}
+
+// GetPatchTestFailedResponse returns PatchTestFailedResponse and true boolean if ServicePatchUserConflict is PatchTestFailedResponse.
+func (s ServicePatchUserConflict) GetPatchTestFailedResponse() (v PatchTestFailedResponse, ok bool) {
+	if !s.IsPatchTestFailedResponse() {
+		return v, false
+	}
+	return s.PatchTestFailedResponse, true
+}
+
+// NewPatchTestFailedResponseServicePatchUserConflict returns new ServicePatchUserConflict from PatchTestFailedResponse.
+func NewPatchTestFailedResponseServicePatchUserConflict(v PatchTestFailedResponse) ServicePatchUserConflict {
+	var s ServicePatchUserConflict
+	s.SetPatchTestFailedResponse(v)
+	return s
+}
+
+func (*ServicePatchUserConflict) servicePatchUserRes() {}
+
+// ServicePatchUserOK is response for ServicePatchUser operation.
+type ServicePatchUserOK struct{}
+
+func (*ServicePatchUserOK) servicePatchUserRes() {}
+
+type ServicePatchUserReqApplicationJSONPatchJSON []JSONPatchOperation
+
+func (*ServicePatchUserReqApplicationJSONPatchJSON) servicePatchUserReq() {}
+
+// ServicePutUserOK is response for ServicePutUser operation.
+type ServicePutUserOK struct{}
+
+func (*ServicePutUserOK) servicePutUserRes() {}
+
+type ServiceWatchUsersOK struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s ServiceWatchUsersOK) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+func (*ServiceWatchUsersOK) serviceWatchUsersRes() {}
+
+// Ref: #/components/schemas/TooManyRequestsResponse
+type TooManyRequestsResponse struct {
+	Message TooManyRequestsResponseMessage `json:"message"`
+}
+
+// GetMessage returns the value of Message.
+func (s *TooManyRequestsResponse) GetMessage() TooManyRequestsResponseMessage {
+	return s.Message
+}
+
+// SetMessage sets the value of Message.
+func (s *TooManyRequestsResponse) SetMessage(val TooManyRequestsResponseMessage) {
+	s.Message = val
+}
+
+func (*TooManyRequestsResponse) changePasswordRes()                   {}
+func (*TooManyRequestsResponse) groupsAddGroupMemberRes()             {}
+func (*TooManyRequestsResponse) groupsCreateGroupRes()                {}
+func (*TooManyRequestsResponse) groupsDeleteGroupRes()                {}
+func (*TooManyRequestsResponse) groupsGetGroupRes()                   {}
+func (*TooManyRequestsResponse) groupsListGroupMembersRes()           {}
+func (*TooManyRequestsResponse) groupsListGroupsRes()                 {}
+func (*TooManyRequestsResponse) groupsPatchGroupRes()                 {}
+func (*TooManyRequestsResponse) groupsRemoveGroupMemberRes()          {}
+func (*TooManyRequestsResponse) organizationsCreateOrganizationRes()  {}
+func (*TooManyRequestsResponse) organizationsDeleteOrganizationRes()  {}
+func (*TooManyRequestsResponse) organizationsGetOrganizationRes()     {}
+func (*TooManyRequestsResponse) organizationsListOrganizationsRes()   {}
+func (*TooManyRequestsResponse) serviceBatchUsersRes()                {}
+func (*TooManyRequestsResponse) serviceCreateUserRes()                {}
+func (*TooManyRequestsResponse) serviceDeleteUserRes()                {}
+func (*TooManyRequestsResponse) serviceExportUsersRes()               {}
+func (*TooManyRequestsResponse) serviceGetUserGroupsRes()             {}
+func (*TooManyRequestsResponse) serviceGetUserRes()                   {}
+func (*TooManyRequestsResponse) serviceImportUsersRes()               {}
+func (*TooManyRequestsResponse) serviceListUsersRes()                 {}
+func (*TooManyRequestsResponse) servicePatchUserRes()                 {}
+func (*TooManyRequestsResponse) servicePutUserRes()                   {}
+func (*TooManyRequestsResponse) serviceWatchUsersRes()                {}
+func (*TooManyRequestsResponse) webhooksCreateWebhookRes()            {}
+func (*TooManyRequestsResponse) webhooksDeleteWebhookRes()            {}
+func (*TooManyRequestsResponse) webhooksGetWebhookRes()               {}
+func (*TooManyRequestsResponse) webhooksListWebhookDeliveriesRes()    {}
+func (*TooManyRequestsResponse) webhooksListWebhooksRes()             {}
+func (*TooManyRequestsResponse) webhooksPatchWebhookRes()             {}
+func (*TooManyRequestsResponse) webhooksRedeliverWebhookDeliveryRes() {}
+
+type TooManyRequestsResponseMessage string
+
+const (
+	TooManyRequestsResponseMessageTooManyRequests TooManyRequestsResponseMessage = "too many requests"
+)
+
+// AllValues returns all TooManyRequestsResponseMessage values.
+func (TooManyRequestsResponseMessage) AllValues() []TooManyRequestsResponseMessage {
+	return []TooManyRequestsResponseMessage{
+		TooManyRequestsResponseMessageTooManyRequests,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s TooManyRequestsResponseMessage) MarshalText() ([]byte, error) {
+	switch s {
+	case TooManyRequestsResponseMessageTooManyRequests:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *TooManyRequestsResponseMessage) UnmarshalText(data []byte) error {
+	switch TooManyRequestsResponseMessage(data) {
+	case TooManyRequestsResponseMessageTooManyRequests:
+		*s = TooManyRequestsResponseMessageTooManyRequests
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+type UUID uuid.UUID
+
+// User model all fields isn't required
+// - `id`: the uuid
+// - `username`: the user's name
+// - `password`: the user's password, returned like a base64 string
+// - `email`: the user's email
+// - `is_admin`: define user permissions
+// - `must_change_password`: read only, set for the administrator created on the first run
+// - `groups`: read only, groups of the user, directly or through nested groups, returned with
+// expand=groups.
+// Ref: #/components/schemas/User
+type User struct {
+	ID                 OptUUID   `json:"id" db:"id"`
+	Username           OptString `json:"username" db:"username"`
+	Password           OptString `json:"password" db:"password"`
+	Email              OptString `json:"email" db:"email"`
+	IsAdmin            OptBool   `json:"is_admin" db:"is_admin"`
+	MustChangePassword OptBool   `json:"must_change_password" db:"must_change_password"`
+	Groups             []Group   `json:"groups"`
+}
+
+// GetID returns the value of ID.
+func (s *User) GetID() OptUUID {
+	return s.ID
+}
+
+// GetUsername returns the value of Username.
+func (s *User) GetUsername() OptString {
+	return s.Username
+}
+
+// GetPassword returns the value of Password.
+func (s *User) GetPassword() OptString {
+	return s.Password
+}
+
+// GetEmail returns the value of Email.
+func (s *User) GetEmail() OptString {
+	return s.Email
+}
+
+// GetIsAdmin returns the value of IsAdmin.
+func (s *User) GetIsAdmin() OptBool {
+	return s.IsAdmin
+}
+
+// GetMustChangePassword returns the value of MustChangePassword.
+func (s *User) GetMustChangePassword() OptBool {
+	return s.MustChangePassword
+}
+
+// GetGroups returns the value of Groups.
+func (s *User) GetGroups() []Group {
+	return s.Groups
+}
+
+// SetID sets the value of ID.
+func (s *User) SetID(val OptUUID) {
+	s.ID = val
+}
+
+// SetUsername sets the value of Username.
+func (s *User) SetUsername(val OptString) {
+	s.Username = val
+}
+
+// SetPassword sets the value of Password.
+func (s *User) SetPassword(val OptString) {
+	s.Password = val
+}
+
+// SetEmail sets the value of Email.
+func (s *User) SetEmail(val OptString) {
+	s.Email = val
+}
+
+// SetIsAdmin sets the value of IsAdmin.
+func (s *User) SetIsAdmin(val OptBool) {
+	s.IsAdmin = val
+}
+
+// SetMustChangePassword sets the value of MustChangePassword.
+func (s *User) SetMustChangePassword(val OptBool) {
+	s.MustChangePassword = val
+}
+
+// SetGroups sets the value of Groups.
+func (s *User) SetGroups(val []Group) {
+	s.Groups = val
 }
 
 func (*User) serviceCreateUserRes() {}
 func (*User) serviceGetUserRes()    {}
+func (*User) servicePatchUserReq()  {}
+
+// Related resources of the user which can be expanded.
+// Ref: #/components/schemas/UserExpand
+type UserExpand string
+
+const (
+	UserExpandGroups UserExpand = "groups"
+)
+
+// AllValues returns all UserExpand values.
+func (UserExpand) AllValues() []UserExpand {
+	return []UserExpand{
+		UserExpandGroups,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s UserExpand) MarshalText() ([]byte, error) {
+	switch s {
+	case UserExpandGroups:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *UserExpand) UnmarshalText(data []byte) error {
+	switch UserExpand(data) {
+	case UserExpandGroups:
+		*s = UserExpandGroups
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Fields of the user which can be selected.
+// Ref: #/components/schemas/UserField
+type UserField string
+
+const (
+	UserFieldID                 UserField = "id"
+	UserFieldUsername           UserField = "username"
+	UserFieldEmail              UserField = "email"
+	UserFieldIsAdmin            UserField = "is_admin"
+	UserFieldMustChangePassword UserField = "must_change_password"
+)
+
+// AllValues returns all UserField values.
+func (UserField) AllValues() []UserField {
+	return []UserField{
+		UserFieldID,
+		UserFieldUsername,
+		UserFieldEmail,
+		UserFieldIsAdmin,
+		UserFieldMustChangePassword,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s UserField) MarshalText() ([]byte, error) {
+	switch s {
+	case UserFieldID:
+		return []byte(s), nil
+	case UserFieldUsername:
+		return []byte(s), nil
+	case UserFieldEmail:
+		return []byte(s), nil
+	case UserFieldIsAdmin:
+		return []byte(s), nil
+	case UserFieldMustChangePassword:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *UserField) UnmarshalText(data []byte) error {
+	switch UserField(data) {
+	case UserFieldID:
+		*s = UserFieldID
+		return nil
+	case UserFieldUsername:
+		*s = UserFieldUsername
+		return nil
+	case UserFieldEmail:
+		*s = UserFieldEmail
+		return nil
+	case UserFieldIsAdmin:
+		*s = UserFieldIsAdmin
+		return nil
+	case UserFieldMustChangePassword:
+		*s = UserFieldMustChangePassword
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// JSON Merge Patch (RFC 7396) of the user, absent fields are kept, null clears the field
+// - cleared `username`, `password` and `email` are invalid, cleared `is_admin` is false.
+// Ref: #/components/schemas/UserMergePatch
+type UserMergePatch struct {
+	Username OptNilString `json:"username"`
+	Password OptNilString `json:"password"`
+	Email    OptNilString `json:"email"`
+	IsAdmin  OptNilBool   `json:"is_admin"`
+}
+
+// GetUsername returns the value of Username.
+func (s *UserMergePatch) GetUsername() OptNilString {
+	return s.Username
+}
+
+// GetPassword returns the value of Password.
+func (s *UserMergePatch) GetPassword() OptNilString {
+	return s.Password
+}
+
+// GetEmail returns the value of Email.
+func (s *UserMergePatch) GetEmail() OptNilString {
+	return s.Email
+}
+
+// GetIsAdmin returns the value of IsAdmin.
+func (s *UserMergePatch) GetIsAdmin() OptNilBool {
+	return s.IsAdmin
+}
+
+// SetUsername sets the value of Username.
+func (s *UserMergePatch) SetUsername(val OptNilString) {
+	s.Username = val
+}
+
+// SetPassword sets the value of Password.
+func (s *UserMergePatch) SetPassword(val OptNilString) {
+	s.Password = val
+}
+
+// SetEmail sets the value of Email.
+func (s *UserMergePatch) SetEmail(val OptNilString) {
+	s.Email = val
+}
+
+// SetIsAdmin sets the value of IsAdmin.
+func (s *UserMergePatch) SetIsAdmin(val OptNilBool) {
+	s.IsAdmin = val
+}
+
+func (*UserMergePatch) servicePatchUserReq() {}
 
 // Ref: #/components/schemas/ValidationErrorMessage
 type ValidationErrorMessage string
 
 const (
-	ValidationErrorMessageBadParams       ValidationErrorMessage = "bad params"
-	ValidationErrorMessageInvalidUsername ValidationErrorMessage = "invalid username"
-	ValidationErrorMessageInvalidPassword ValidationErrorMessage = "invalid password"
-	ValidationErrorMessageInvalidEmail    ValidationErrorMessage = "invalid email"
+	ValidationErrorMessageBadParams               ValidationErrorMessage = "bad params"
+	ValidationErrorMessageInvalidUsername         ValidationErrorMessage = "invalid username"
+	ValidationErrorMessageInvalidPassword         ValidationErrorMessage = "invalid password"
+	ValidationErrorMessageInvalidEmail            ValidationErrorMessage = "invalid email"
+	ValidationErrorMessageInvalidGroupName        ValidationErrorMessage = "invalid group name"
+	ValidationErrorMessageInvalidOrganizationName ValidationErrorMessage = "invalid organization name"
+	ValidationErrorMessageInvalidMember           ValidationErrorMessage = "invalid member"
+	ValidationErrorMessageGroupMembershipCycle    ValidationErrorMessage = "group membership cycle"
+	ValidationErrorMessageInvalidWebhookURL       ValidationErrorMessage = "invalid webhook url"
+	ValidationErrorMessageInvalidPatch            ValidationErrorMessage = "invalid patch"
+	ValidationErrorMessagePasswordNotChanged      ValidationErrorMessage = "password not changed"
+	ValidationErrorMessageInvalidFields           ValidationErrorMessage = "invalid fields"
+	ValidationErrorMessageInvalidExpand           ValidationErrorMessage = "invalid expand"
 )
 
 // AllValues returns all ValidationErrorMessage values.
@@ -558,6 +2305,15 @@ func (ValidationErrorMessage) AllValues() []ValidationErrorMessage {
 		ValidationErrorMessageInvalidUsername,
 		ValidationErrorMessageInvalidPassword,
 		ValidationErrorMessageInvalidEmail,
+		ValidationErrorMessageInvalidGroupName,
+		ValidationErrorMessageInvalidOrganizationName,
+		ValidationErrorMessageInvalidMember,
+		ValidationErrorMessageGroupMembershipCycle,
+		ValidationErrorMessageInvalidWebhookURL,
+		ValidationErrorMessageInvalidPatch,
+		ValidationErrorMessagePasswordNotChanged,
+		ValidationErrorMessageInvalidFields,
+		ValidationErrorMessageInvalidExpand,
 	}
 }
 
@@ -572,6 +2328,24 @@ func (s ValidationErrorMessage) MarshalText() ([]byte, error) {
 		return []byte(s), nil
 	case ValidationErrorMessageInvalidEmail:
 		return []byte(s), nil
+	case ValidationErrorMessageInvalidGroupName:
+		return []byte(s), nil
+	case ValidationErrorMessageInvalidOrganizationName:
+		return []byte(s), nil
+	case ValidationErrorMessageInvalidMember:
+		return []byte(s), nil
+	case ValidationErrorMessageGroupMembershipCycle:
+		return []byte(s), nil
+	case ValidationErrorMessageInvalidWebhookURL:
+		return []byte(s), nil
+	case ValidationErrorMessageInvalidPatch:
+		return []byte(s), nil
+	case ValidationErrorMessagePasswordNotChanged:
+		return []byte(s), nil
+	case ValidationErrorMessageInvalidFields:
+		return []byte(s), nil
+	case ValidationErrorMessageInvalidExpand:
+		return []byte(s), nil
 	default:
 		return nil, errors.Errorf("invalid value: %q", s)
 	}
@@ -592,6 +2366,33 @@ func (s *ValidationErrorMessage) UnmarshalText(data []byte) error {
 	case ValidationErrorMessageInvalidEmail:
 		*s = ValidationErrorMessageInvalidEmail
 		return nil
+	case ValidationErrorMessageInvalidGroupName:
+		*s = ValidationErrorMessageInvalidGroupName
+		return nil
+	case ValidationErrorMessageInvalidOrganizationName:
+		*s = ValidationErrorMessageInvalidOrganizationName
+		return nil
+	case ValidationErrorMessageInvalidMember:
+		*s = ValidationErrorMessageInvalidMember
+		return nil
+	case ValidationErrorMessageGroupMembershipCycle:
+		*s = ValidationErrorMessageGroupMembershipCycle
+		return nil
+	case ValidationErrorMessageInvalidWebhookURL:
+		*s = ValidationErrorMessageInvalidWebhookURL
+		return nil
+	case ValidationErrorMessageInvalidPatch:
+		*s = ValidationErrorMessageInvalidPatch
+		return nil
+	case ValidationErrorMessagePasswordNotChanged:
+		*s = ValidationErrorMessagePasswordNotChanged
+		return nil
+	case ValidationErrorMessageInvalidFields:
+		*s = ValidationErrorMessageInvalidFields
+		return nil
+	case ValidationErrorMessageInvalidExpand:
+		*s = ValidationErrorMessageInvalidExpand
+		return nil
 	default:
 		return errors.Errorf("invalid value: %q", data)
 	}
@@ -612,8 +2413,356 @@ func (s *ValidationErrorResponse) SetMessage(val ValidationErrorMessage) {
 	s.Message = val
 }
 
-func (*ValidationErrorResponse) serviceCreateUserRes() {}
-func (*ValidationErrorResponse) serviceDeleteUserRes() {}
-func (*ValidationErrorResponse) serviceGetUserRes()    {}
-func (*ValidationErrorResponse) servicePatchUserRes()  {}
-func (*ValidationErrorResponse) servicePutUserRes()    {}
+func (*ValidationErrorResponse) changePasswordRes()                   {}
+func (*ValidationErrorResponse) groupsAddGroupMemberRes()             {}
+func (*ValidationErrorResponse) groupsCreateGroupRes()                {}
+func (*ValidationErrorResponse) groupsDeleteGroupRes()                {}
+func (*ValidationErrorResponse) groupsGetGroupRes()                   {}
+func (*ValidationErrorResponse) groupsListGroupMembersRes()           {}
+func (*ValidationErrorResponse) groupsPatchGroupRes()                 {}
+func (*ValidationErrorResponse) groupsRemoveGroupMemberRes()          {}
+func (*ValidationErrorResponse) organizationsCreateOrganizationRes()  {}
+func (*ValidationErrorResponse) organizationsDeleteOrganizationRes()  {}
+func (*ValidationErrorResponse) organizationsGetOrganizationRes()     {}
+func (*ValidationErrorResponse) serviceBatchUsersRes()                {}
+func (*ValidationErrorResponse) serviceCreateUserRes()                {}
+func (*ValidationErrorResponse) serviceDeleteUserRes()                {}
+func (*ValidationErrorResponse) serviceExportUsersRes()               {}
+func (*ValidationErrorResponse) serviceGetUserGroupsRes()             {}
+func (*ValidationErrorResponse) serviceGetUserRes()                   {}
+func (*ValidationErrorResponse) serviceImportUsersRes()               {}
+func (*ValidationErrorResponse) serviceListUsersRes()                 {}
+func (*ValidationErrorResponse) servicePatchUserRes()                 {}
+func (*ValidationErrorResponse) servicePutUserRes()                   {}
+func (*ValidationErrorResponse) serviceWatchUsersRes()                {}
+func (*ValidationErrorResponse) webhooksCreateWebhookRes()            {}
+func (*ValidationErrorResponse) webhooksDeleteWebhookRes()            {}
+func (*ValidationErrorResponse) webhooksGetWebhookRes()               {}
+func (*ValidationErrorResponse) webhooksListWebhookDeliveriesRes()    {}
+func (*ValidationErrorResponse) webhooksPatchWebhookRes()             {}
+func (*ValidationErrorResponse) webhooksRedeliverWebhookDeliveryRes() {}
+
+// Webhook subscription, all fields aren't required
+// - `id`: the uuid
+// - `url`: http or https URL events are posted to
+// - `events`: events delivered to the URL, every event if empty
+// - `secret`: key of the HMAC-SHA256 signature, generated if it isn't provided, returned only on
+// creation
+// - `enabled`: events are delivered only to enabled webhooks, a webhook that keeps failing is
+// disabled
+// - `failures`: read only, failed delivery attempts in a row.
+// Ref: #/components/schemas/Webhook
+type Webhook struct {
+	ID       OptUUID        `json:"id"`
+	URL      OptString      `json:"url"`
+	Events   []WebhookEvent `json:"events"`
+	Secret   OptString      `json:"secret"`
+	Enabled  OptBool        `json:"enabled"`
+	Failures OptInt32       `json:"failures"`
+}
+
+// GetID returns the value of ID.
+func (s *Webhook) GetID() OptUUID {
+	return s.ID
+}
+
+// GetURL returns the value of URL.
+func (s *Webhook) GetURL() OptString {
+	return s.URL
+}
+
+// GetEvents returns the value of Events.
+func (s *Webhook) GetEvents() []WebhookEvent {
+	return s.Events
+}
+
+// GetSecret returns the value of Secret.
+func (s *Webhook) GetSecret() OptString {
+	return s.Secret
+}
+
+// GetEnabled returns the value of Enabled.
+func (s *Webhook) GetEnabled() OptBool {
+	return s.Enabled
+}
+
+// GetFailures returns the value of Failures.
+func (s *Webhook) GetFailures() OptInt32 {
+	return s.Failures
+}
+
+// SetID sets the value of ID.
+func (s *Webhook) SetID(val OptUUID) {
+	s.ID = val
+}
+
+// SetURL sets the value of URL.
+func (s *Webhook) SetURL(val OptString) {
+	s.URL = val
+}
+
+// SetEvents sets the value of Events.
+func (s *Webhook) SetEvents(val []WebhookEvent) {
+	s.Events = val
+}
+
+// SetSecret sets the value of Secret.
+func (s *Webhook) SetSecret(val OptString) {
+	s.Secret = val
+}
+
+// SetEnabled sets the value of Enabled.
+func (s *Webhook) SetEnabled(val OptBool) {
+	s.Enabled = val
+}
+
+// SetFailures sets the value of Failures.
+func (s *Webhook) SetFailures(val OptInt32) {
+	s.Failures = val
+}
+
+func (*Webhook) webhooksCreateWebhookRes() {}
+func (*Webhook) webhooksGetWebhookRes()    {}
+
+// Delivery of an event to a webhook
+// - `id`: the uuid
+// - `event_id`: id of the event, the same for every attempt and redelivery
+// - `event`: the event
+// - `status`: pending until the event is delivered or attempts are exhausted
+// - `attempts`: number of attempts made
+// - `response_status`: http status of the last attempt, if the endpoint responded
+// - `error`: reason of the last failure
+// - `next_attempt_at`: time of the next attempt of a pending delivery
+// - `created_at`: time the event was queued
+// - `delivered_at`: time of the successful attempt.
+// Ref: #/components/schemas/WebhookDelivery
+type WebhookDelivery struct {
+	ID             UUID                  `json:"id"`
+	EventID        int64                 `json:"event_id"`
+	Event          WebhookEvent          `json:"event"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int32                 `json:"attempts"`
+	ResponseStatus OptInt32              `json:"response_status"`
+	Error          OptString             `json:"error"`
+	NextAttemptAt  OptDateTime           `json:"next_attempt_at"`
+	CreatedAt      time.Time             `json:"created_at"`
+	DeliveredAt    OptDateTime           `json:"delivered_at"`
+}
+
+// GetID returns the value of ID.
+func (s *WebhookDelivery) GetID() UUID {
+	return s.ID
+}
+
+// GetEventID returns the value of EventID.
+func (s *WebhookDelivery) GetEventID() int64 {
+	return s.EventID
+}
+
+// GetEvent returns the value of Event.
+func (s *WebhookDelivery) GetEvent() WebhookEvent {
+	return s.Event
+}
+
+// GetStatus returns the value of Status.
+func (s *WebhookDelivery) GetStatus() WebhookDeliveryStatus {
+	return s.Status
+}
+
+// GetAttempts returns the value of Attempts.
+func (s *WebhookDelivery) GetAttempts() int32 {
+	return s.Attempts
+}
+
+// GetResponseStatus returns the value of ResponseStatus.
+func (s *WebhookDelivery) GetResponseStatus() OptInt32 {
+	return s.ResponseStatus
+}
+
+// GetError returns the value of Error.
+func (s *WebhookDelivery) GetError() OptString {
+	return s.Error
+}
+
+// GetNextAttemptAt returns the value of NextAttemptAt.
+func (s *WebhookDelivery) GetNextAttemptAt() OptDateTime {
+	return s.NextAttemptAt
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *WebhookDelivery) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// GetDeliveredAt returns the value of DeliveredAt.
+func (s *WebhookDelivery) GetDeliveredAt() OptDateTime {
+	return s.DeliveredAt
+}
+
+// SetID sets the value of ID.
+func (s *WebhookDelivery) SetID(val UUID) {
+	s.ID = val
+}
+
+// SetEventID sets the value of EventID.
+func (s *WebhookDelivery) SetEventID(val int64) {
+	s.EventID = val
+}
+
+// SetEvent sets the value of Event.
+func (s *WebhookDelivery) SetEvent(val WebhookEvent) {
+	s.Event = val
+}
+
+// SetStatus sets the value of Status.
+func (s *WebhookDelivery) SetStatus(val WebhookDeliveryStatus) {
+	s.Status = val
+}
+
+// SetAttempts sets the value of Attempts.
+func (s *WebhookDelivery) SetAttempts(val int32) {
+	s.Attempts = val
+}
+
+// SetResponseStatus sets the value of ResponseStatus.
+func (s *WebhookDelivery) SetResponseStatus(val OptInt32) {
+	s.ResponseStatus = val
+}
+
+// SetError sets the value of Error.
+func (s *WebhookDelivery) SetError(val OptString) {
+	s.Error = val
+}
+
+// SetNextAttemptAt sets the value of NextAttemptAt.
+func (s *WebhookDelivery) SetNextAttemptAt(val OptDateTime) {
+	s.NextAttemptAt = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *WebhookDelivery) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+// SetDeliveredAt sets the value of DeliveredAt.
+func (s *WebhookDelivery) SetDeliveredAt(val OptDateTime) {
+	s.DeliveredAt = val
+}
+
+func (*WebhookDelivery) webhooksRedeliverWebhookDeliveryRes() {}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "failed"
+)
+
+// AllValues returns all WebhookDeliveryStatus values.
+func (WebhookDeliveryStatus) AllValues() []WebhookDeliveryStatus {
+	return []WebhookDeliveryStatus{
+		WebhookDeliveryStatusPending,
+		WebhookDeliveryStatusSucceeded,
+		WebhookDeliveryStatusFailed,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s WebhookDeliveryStatus) MarshalText() ([]byte, error) {
+	switch s {
+	case WebhookDeliveryStatusPending:
+		return []byte(s), nil
+	case WebhookDeliveryStatusSucceeded:
+		return []byte(s), nil
+	case WebhookDeliveryStatusFailed:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *WebhookDeliveryStatus) UnmarshalText(data []byte) error {
+	switch WebhookDeliveryStatus(data) {
+	case WebhookDeliveryStatusPending:
+		*s = WebhookDeliveryStatusPending
+		return nil
+	case WebhookDeliveryStatusSucceeded:
+		*s = WebhookDeliveryStatusSucceeded
+		return nil
+	case WebhookDeliveryStatusFailed:
+		*s = WebhookDeliveryStatusFailed
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Event of a user change.
+// Ref: #/components/schemas/WebhookEvent
+type WebhookEvent string
+
+const (
+	WebhookEventUserCreated WebhookEvent = "user.created"
+	WebhookEventUserUpdated WebhookEvent = "user.updated"
+	WebhookEventUserDeleted WebhookEvent = "user.deleted"
+)
+
+// AllValues returns all WebhookEvent values.
+func (WebhookEvent) AllValues() []WebhookEvent {
+	return []WebhookEvent{
+		WebhookEventUserCreated,
+		WebhookEventUserUpdated,
+		WebhookEventUserDeleted,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s WebhookEvent) MarshalText() ([]byte, error) {
+	switch s {
+	case WebhookEventUserCreated:
+		return []byte(s), nil
+	case WebhookEventUserUpdated:
+		return []byte(s), nil
+	case WebhookEventUserDeleted:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *WebhookEvent) UnmarshalText(data []byte) error {
+	switch WebhookEvent(data) {
+	case WebhookEventUserCreated:
+		*s = WebhookEventUserCreated
+		return nil
+	case WebhookEventUserUpdated:
+		*s = WebhookEventUserUpdated
+		return nil
+	case WebhookEventUserDeleted:
+		*s = WebhookEventUserDeleted
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// WebhooksDeleteWebhookOK is response for WebhooksDeleteWebhook operation.
+type WebhooksDeleteWebhookOK struct{}
+
+func (*WebhooksDeleteWebhookOK) webhooksDeleteWebhookRes() {}
+
+type WebhooksListWebhookDeliveriesOKApplicationJSON []WebhookDelivery
+
+func (*WebhooksListWebhookDeliveriesOKApplicationJSON) webhooksListWebhookDeliveriesRes() {}
+
+type WebhooksListWebhooksOKApplicationJSON []Webhook
+
+func (*WebhooksListWebhooksOKApplicationJSON) webhooksListWebhooksRes() {}
+
+// WebhooksPatchWebhookOK is response for WebhooksPatchWebhook operation.
+type WebhooksPatchWebhookOK struct{}
+
+func (*WebhooksPatchWebhookOK) webhooksPatchWebhookRes() {}
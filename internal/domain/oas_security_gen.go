@@ -34,13 +34,38 @@ func findAuthorization(h http.Header, prefix string) (string, bool) {
 }
 
 var operationRolesBasicAuth = map[string][]string{
-	HealthOperation:            []string{},
-	ServiceCreateUserOperation: []string{},
-	ServiceDeleteUserOperation: []string{},
-	ServiceGetUserOperation:    []string{},
-	ServiceListUsersOperation:  []string{},
-	ServicePatchUserOperation:  []string{},
-	ServicePutUserOperation:    []string{},
+	ChangePasswordOperation:                   []string{},
+	GroupsAddGroupMemberOperation:             []string{},
+	GroupsCreateGroupOperation:                []string{},
+	GroupsDeleteGroupOperation:                []string{},
+	GroupsGetGroupOperation:                   []string{},
+	GroupsListGroupMembersOperation:           []string{},
+	GroupsListGroupsOperation:                 []string{},
+	GroupsPatchGroupOperation:                 []string{},
+	GroupsRemoveGroupMemberOperation:          []string{},
+	HealthOperation:                           []string{},
+	OrganizationsCreateOrganizationOperation:  []string{},
+	OrganizationsDeleteOrganizationOperation:  []string{},
+	OrganizationsGetOrganizationOperation:     []string{},
+	OrganizationsListOrganizationsOperation:   []string{},
+	ServiceBatchUsersOperation:                []string{},
+	ServiceCreateUserOperation:                []string{},
+	ServiceDeleteUserOperation:                []string{},
+	ServiceExportUsersOperation:               []string{},
+	ServiceGetUserOperation:                   []string{},
+	ServiceGetUserGroupsOperation:             []string{},
+	ServiceImportUsersOperation:               []string{},
+	ServiceListUsersOperation:                 []string{},
+	ServicePatchUserOperation:                 []string{},
+	ServicePutUserOperation:                   []string{},
+	ServiceWatchUsersOperation:                []string{},
+	WebhooksCreateWebhookOperation:            []string{},
+	WebhooksDeleteWebhookOperation:            []string{},
+	WebhooksGetWebhookOperation:               []string{},
+	WebhooksListWebhookDeliveriesOperation:    []string{},
+	WebhooksListWebhooksOperation:             []string{},
+	WebhooksPatchWebhookOperation:             []string{},
+	WebhooksRedeliverWebhookDeliveryOperation: []string{},
 }
 
 func (s *Server) securityBasicAuth(ctx context.Context, operationName OperationName, req *http.Request) (context.Context, bool, error) {
@@ -8,10 +8,118 @@ import (
 
 // Handler handles operations described by OpenAPI v3 specification.
 type Handler interface {
+	// ChangePassword implements changePassword operation.
+	//
+	// Change the password of the authenticated user
+	// - password is validated like in createUser
+	// - new password must differ from the current one
+	// - clears must_change_password
+	// - allowed for every user.
+	//
+	// POST /me/password
+	ChangePassword(ctx context.Context, req *PasswordChange) (ChangePasswordRes, error)
+	// GroupsAddGroupMember implements Groups_addGroupMember operation.
+	//
+	// Add a user or a nested group to the group
+	// - exactly one of user_id, group_id must be provided, 400 otherwise
+	// - membership cycles are rejected with 400
+	// - admin permission required.
+	//
+	// POST /groups/{groupId}/members
+	GroupsAddGroupMember(ctx context.Context, req *GroupMember, params GroupsAddGroupMemberParams) (GroupsAddGroupMemberRes, error)
+	// GroupsCreateGroup implements Groups_createGroup operation.
+	//
+	// Create a group
+	// - name must be provided, 400 otherwise
+	// - admin permission required.
+	//
+	// POST /groups/
+	GroupsCreateGroup(ctx context.Context, req *Group) (GroupsCreateGroupRes, error)
+	// GroupsDeleteGroup implements Groups_deleteGroup operation.
+	//
+	// Delete Group, memberships of the group are removed too
+	// - admin permission required.
+	//
+	// DELETE /groups/{groupId}
+	GroupsDeleteGroup(ctx context.Context, params GroupsDeleteGroupParams) (GroupsDeleteGroupRes, error)
+	// GroupsGetGroup implements Groups_getGroup operation.
+	//
+	// Returns a Group if group with provided groupId exists, 404 otherwise.
+	//
+	// GET /groups/{groupId}
+	GroupsGetGroup(ctx context.Context, params GroupsGetGroupParams) (GroupsGetGroupRes, error)
+	// GroupsListGroupMembers implements Groups_listGroupMembers operation.
+	//
+	// Returns direct members of the group
+	// - admin permission required.
+	//
+	// GET /groups/{groupId}/members
+	GroupsListGroupMembers(ctx context.Context, params GroupsListGroupMembersParams) (GroupsListGroupMembersRes, error)
+	// GroupsListGroups implements Groups_listGroups operation.
+	//
+	// Returns a list of groups
+	// - admin permission required.
+	//
+	// GET /groups/
+	GroupsListGroups(ctx context.Context, params GroupsListGroupsParams) (GroupsListGroupsRes, error)
+	// GroupsPatchGroup implements Groups_patchGroup operation.
+	//
+	// Patch Group
+	// - one of the fields must be provided, except id
+	// - admin permission required.
+	//
+	// PATCH /groups/{groupId}
+	GroupsPatchGroup(ctx context.Context, req *Group, params GroupsPatchGroupParams) (GroupsPatchGroupRes, error)
+	// GroupsRemoveGroupMember implements Groups_removeGroupMember operation.
+	//
+	// Remove a user or a nested group from the group
+	// - admin permission required.
+	//
+	// DELETE /groups/{groupId}/members/{memberId}
+	GroupsRemoveGroupMember(ctx context.Context, params GroupsRemoveGroupMemberParams) (GroupsRemoveGroupMemberRes, error)
 	// Health implements health operation.
 	//
 	// GET /health
 	Health(ctx context.Context) error
+	// OrganizationsCreateOrganization implements Organizations_createOrganization operation.
+	//
+	// Create an organization
+	// - name must be provided, 400 otherwise
+	// - super admin permission required.
+	//
+	// POST /organizations/
+	OrganizationsCreateOrganization(ctx context.Context, req *Organization) (OrganizationsCreateOrganizationRes, error)
+	// OrganizationsDeleteOrganization implements Organizations_deleteOrganization operation.
+	//
+	// Delete Organization with all its users and groups
+	// - the default organization can't be deleted, 400 otherwise
+	// - super admin permission required.
+	//
+	// DELETE /organizations/{organizationId}
+	OrganizationsDeleteOrganization(ctx context.Context, params OrganizationsDeleteOrganizationParams) (OrganizationsDeleteOrganizationRes, error)
+	// OrganizationsGetOrganization implements Organizations_getOrganization operation.
+	//
+	// Returns an Organization if organization with provided organizationId exists, 404 otherwise.
+	//
+	// GET /organizations/{organizationId}
+	OrganizationsGetOrganization(ctx context.Context, params OrganizationsGetOrganizationParams) (OrganizationsGetOrganizationRes, error)
+	// OrganizationsListOrganizations implements Organizations_listOrganizations operation.
+	//
+	// Returns a list of organizations
+	// - super admin permission required.
+	//
+	// GET /organizations/
+	OrganizationsListOrganizations(ctx context.Context, params OrganizationsListOrganizationsParams) (OrganizationsListOrganizationsRes, error)
+	// ServiceBatchUsers implements Service_batchUsers operation.
+	//
+	// Execute create, patch, put and delete operations on users in a single request
+	// - every operation gets the status and body of the single user endpoint
+	// - with atomic=true operations run in a single transaction, the first failed
+	// operation rolls the batch back and the rest aren't executed (status 424)
+	// - admin permission required.
+	//
+	// POST /users:batch
+	ServiceBatchUsers(ctx context.Context, req []BatchOperation, params ServiceBatchUsersParams) (ServiceBatchUsersRes, error)
 	// ServiceCreateUser implements Service_createUser operation.
 	//
 	// Create a user
@@ -23,16 +131,47 @@ type Handler interface {
 	// ServiceDeleteUser implements Service_deleteUser operation.
 	//
 	// Delete User
+	// - the last administrator of the organization can't be demoted or deleted, 409 otherwise
 	// - admin permission required.
 	//
 	// DELETE /users/{userId}
 	ServiceDeleteUser(ctx context.Context, params ServiceDeleteUserParams) (ServiceDeleteUserRes, error)
+	// ServiceExportUsers implements Service_exportUsers operation.
+	//
+	// Export all users of the organization as a stream, password hashes are never exported
+	// - format is chosen by Accept: application/x-ndjson (default), text/csv or application/json (array)
+	// - every format is written while users are read, application/json is an array of users
+	// - users are ordered by id, pass the id of the last received user as cursor to resume
+	// - filters are the same as in listUsers
+	// - admin permission required.
+	//
+	// GET /users:export
+	ServiceExportUsers(ctx context.Context, params ServiceExportUsersParams) (ServiceExportUsersRes, error)
 	// ServiceGetUser implements Service_getUser operation.
 	//
 	// Returns a User if user with provided userId exists, 404 otherwise.
 	//
 	// GET /users/{userId}
 	ServiceGetUser(ctx context.Context, params ServiceGetUserParams) (ServiceGetUserRes, error)
+	// ServiceGetUserGroups implements Service_getUserGroups operation.
+	//
+	// Returns groups of the user, including groups inherited through nested groups
+	// - admin permission required.
+	//
+	// GET /users/{userId}/groups
+	ServiceGetUserGroups(ctx context.Context, params ServiceGetUserGroupsParams) (ServiceGetUserGroupsRes, error)
+	// ServiceImportUsers implements Service_importUsers operation.
+	//
+	// Import users from CSV (header: username,email,password,is_admin)
+	// or NDJSON (one User per line) body, the body is processed as a stream
+	// - every row is validated like in createUser
+	// - on_conflict defines what to do with existing username or email, fail by default, fail stops at
+	// the first conflict and rolls back the whole import
+	// - dry_run validates rows without writing them, rows are checked against previous rows of the body
+	// - admin permission required.
+	//
+	// POST /users:import
+	ServiceImportUsers(ctx context.Context, req ServiceImportUsersReq, params ServiceImportUsersParams) (ServiceImportUsersRes, error)
 	// ServiceListUsers implements Service_listUsers operation.
 	//
 	// Returns a list of all users.
@@ -42,19 +181,90 @@ type Handler interface {
 	// ServicePatchUser implements Service_patchUser operation.
 	//
 	// Patch User
-	// - one of the fields must be provided, except id
+	// - application/json: one of the fields must be provided, except id
+	// - application/merge-patch+json (RFC 7396): null clears the field
+	// - application/json-patch+json (RFC 6902): operations on the stored user without password,
+	// password can be added or replaced, failed test operation rejects the patch with 409
+	// - patches are applied to the stored user, changed fields are validated like application/json ones
+	// - the last administrator of the organization can't be demoted or deleted, 409 otherwise
 	// - admin permission required.
 	//
 	// PATCH /users/{userId}
-	ServicePatchUser(ctx context.Context, req *User, params ServicePatchUserParams) (ServicePatchUserRes, error)
+	ServicePatchUser(ctx context.Context, req ServicePatchUserReq, params ServicePatchUserParams) (ServicePatchUserRes, error)
 	// ServicePutUser implements Service_putUser operation.
 	//
 	// Put a new User params
 	// - all fields must be provided, except id
+	// - the last administrator of the organization can't be demoted or deleted, 409 otherwise
 	// - admin permission required.
 	//
 	// PUT /users/{userId}
 	ServicePutUser(ctx context.Context, req *User, params ServicePutUserParams) (ServicePutUserRes, error)
+	// ServiceWatchUsers implements Service_watchUsers operation.
+	//
+	// Stream changes of users of the organization as server-sent events
+	// - every event is user.created, user.updated or user.deleted with the outbox
+	// event as data, the id of the event is its id in the outbox
+	// - reconnect with Last-Event-ID to receive the events missed since it, the event
+	// log is bounded, a reset event means missed events are lost and the list must be reloaded
+	// - the stream is closed after a change of the password or deletion of the authenticated user
+	// - comments are sent as heartbeats while there are no changes
+	// - can be used by all users.
+	//
+	// GET /users:watch
+	ServiceWatchUsers(ctx context.Context, params ServiceWatchUsersParams) (ServiceWatchUsersRes, error)
+	// WebhooksCreateWebhook implements Webhooks_createWebhook operation.
+	//
+	// Register a webhook
+	// - url must be provided, 400 otherwise
+	// - the secret is returned only in this response
+	// - admin permission required.
+	//
+	// POST /webhooks/
+	WebhooksCreateWebhook(ctx context.Context, req *Webhook) (WebhooksCreateWebhookRes, error)
+	// WebhooksDeleteWebhook implements Webhooks_deleteWebhook operation.
+	//
+	// Delete Webhook with its delivery log
+	// - admin permission required.
+	//
+	// DELETE /webhooks/{webhookId}
+	WebhooksDeleteWebhook(ctx context.Context, params WebhooksDeleteWebhookParams) (WebhooksDeleteWebhookRes, error)
+	// WebhooksGetWebhook implements Webhooks_getWebhook operation.
+	//
+	// Returns a Webhook if webhook with provided webhookId exists, 404 otherwise.
+	//
+	// GET /webhooks/{webhookId}
+	WebhooksGetWebhook(ctx context.Context, params WebhooksGetWebhookParams) (WebhooksGetWebhookRes, error)
+	// WebhooksListWebhookDeliveries implements Webhooks_listWebhookDeliveries operation.
+	//
+	// Returns deliveries of the webhook, the latest events first
+	// - admin permission required.
+	//
+	// GET /webhooks/{webhookId}/deliveries
+	WebhooksListWebhookDeliveries(ctx context.Context, params WebhooksListWebhookDeliveriesParams) (WebhooksListWebhookDeliveriesRes, error)
+	// WebhooksListWebhooks implements Webhooks_listWebhooks operation.
+	//
+	// Returns a list of webhooks, secrets are never returned
+	// - admin permission required.
+	//
+	// GET /webhooks/
+	WebhooksListWebhooks(ctx context.Context, params WebhooksListWebhooksParams) (WebhooksListWebhooksRes, error)
+	// WebhooksPatchWebhook implements Webhooks_patchWebhook operation.
+	//
+	// Patch Webhook
+	// - one of url, events, secret, enabled must be provided
+	// - enabling a webhook resets its failures
+	// - admin permission required.
+	//
+	// PATCH /webhooks/{webhookId}
+	WebhooksPatchWebhook(ctx context.Context, req *Webhook, params WebhooksPatchWebhookParams) (WebhooksPatchWebhookRes, error)
+	// WebhooksRedeliverWebhookDelivery implements Webhooks_redeliverWebhookDelivery operation.
+	//
+	// Queue the delivery again, attempts start over
+	// - admin permission required.
+	//
+	// POST /webhooks/{webhookId}/deliveries/{deliveryId}/redeliver
+	WebhooksRedeliverWebhookDelivery(ctx context.Context, params WebhooksRedeliverWebhookDeliveryParams) (WebhooksRedeliverWebhookDeliveryRes, error)
 }
 
 // Server implements http server based on OpenAPI v3 specification and
@@ -13,6 +13,102 @@ type UnimplementedHandler struct{}
 
 var _ Handler = UnimplementedHandler{}
 
+// ChangePassword implements changePassword operation.
+//
+// Change the password of the authenticated user
+// - password is validated like in createUser
+// - new password must differ from the current one
+// - clears must_change_password
+// - allowed for every user.
+//
+// POST /me/password
+func (UnimplementedHandler) ChangePassword(ctx context.Context, req *PasswordChange) (r ChangePasswordRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GroupsAddGroupMember implements Groups_addGroupMember operation.
+//
+// Add a user or a nested group to the group
+// - exactly one of user_id, group_id must be provided, 400 otherwise
+// - membership cycles are rejected with 400
+// - admin permission required.
+//
+// POST /groups/{groupId}/members
+func (UnimplementedHandler) GroupsAddGroupMember(ctx context.Context, req *GroupMember, params GroupsAddGroupMemberParams) (r GroupsAddGroupMemberRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GroupsCreateGroup implements Groups_createGroup operation.
+//
+// Create a group
+// - name must be provided, 400 otherwise
+// - admin permission required.
+//
+// POST /groups/
+func (UnimplementedHandler) GroupsCreateGroup(ctx context.Context, req *Group) (r GroupsCreateGroupRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GroupsDeleteGroup implements Groups_deleteGroup operation.
+//
+// Delete Group, memberships of the group are removed too
+// - admin permission required.
+//
+// DELETE /groups/{groupId}
+func (UnimplementedHandler) GroupsDeleteGroup(ctx context.Context, params GroupsDeleteGroupParams) (r GroupsDeleteGroupRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GroupsGetGroup implements Groups_getGroup operation.
+//
+// Returns a Group if group with provided groupId exists, 404 otherwise.
+//
+// GET /groups/{groupId}
+func (UnimplementedHandler) GroupsGetGroup(ctx context.Context, params GroupsGetGroupParams) (r GroupsGetGroupRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GroupsListGroupMembers implements Groups_listGroupMembers operation.
+//
+// Returns direct members of the group
+// - admin permission required.
+//
+// GET /groups/{groupId}/members
+func (UnimplementedHandler) GroupsListGroupMembers(ctx context.Context, params GroupsListGroupMembersParams) (r GroupsListGroupMembersRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GroupsListGroups implements Groups_listGroups operation.
+//
+// Returns a list of groups
+// - admin permission required.
+//
+// GET /groups/
+func (UnimplementedHandler) GroupsListGroups(ctx context.Context, params GroupsListGroupsParams) (r GroupsListGroupsRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GroupsPatchGroup implements Groups_patchGroup operation.
+//
+// Patch Group
+// - one of the fields must be provided, except id
+// - admin permission required.
+//
+// PATCH /groups/{groupId}
+func (UnimplementedHandler) GroupsPatchGroup(ctx context.Context, req *Group, params GroupsPatchGroupParams) (r GroupsPatchGroupRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GroupsRemoveGroupMember implements Groups_removeGroupMember operation.
+//
+// Remove a user or a nested group from the group
+// - admin permission required.
+//
+// DELETE /groups/{groupId}/members/{memberId}
+func (UnimplementedHandler) GroupsRemoveGroupMember(ctx context.Context, params GroupsRemoveGroupMemberParams) (r GroupsRemoveGroupMemberRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // Health implements health operation.
 //
 // GET /health
@@ -20,6 +116,60 @@ func (UnimplementedHandler) Health(ctx context.Context) error {
 	return ht.ErrNotImplemented
 }
 
+// OrganizationsCreateOrganization implements Organizations_createOrganization operation.
+//
+// Create an organization
+// - name must be provided, 400 otherwise
+// - super admin permission required.
+//
+// POST /organizations/
+func (UnimplementedHandler) OrganizationsCreateOrganization(ctx context.Context, req *Organization) (r OrganizationsCreateOrganizationRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// OrganizationsDeleteOrganization implements Organizations_deleteOrganization operation.
+//
+// Delete Organization with all its users and groups
+// - the default organization can't be deleted, 400 otherwise
+// - super admin permission required.
+//
+// DELETE /organizations/{organizationId}
+func (UnimplementedHandler) OrganizationsDeleteOrganization(ctx context.Context, params OrganizationsDeleteOrganizationParams) (r OrganizationsDeleteOrganizationRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// OrganizationsGetOrganization implements Organizations_getOrganization operation.
+//
+// Returns an Organization if organization with provided organizationId exists, 404 otherwise.
+//
+// GET /organizations/{organizationId}
+func (UnimplementedHandler) OrganizationsGetOrganization(ctx context.Context, params OrganizationsGetOrganizationParams) (r OrganizationsGetOrganizationRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// OrganizationsListOrganizations implements Organizations_listOrganizations operation.
+//
+// Returns a list of organizations
+// - super admin permission required.
+//
+// GET /organizations/
+func (UnimplementedHandler) OrganizationsListOrganizations(ctx context.Context, params OrganizationsListOrganizationsParams) (r OrganizationsListOrganizationsRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// ServiceBatchUsers implements Service_batchUsers operation.
+//
+// Execute create, patch, put and delete operations on users in a single request
+// - every operation gets the status and body of the single user endpoint
+// - with atomic=true operations run in a single transaction, the first failed
+// operation rolls the batch back and the rest aren't executed (status 424)
+// - admin permission required.
+//
+// POST /users:batch
+func (UnimplementedHandler) ServiceBatchUsers(ctx context.Context, req []BatchOperation, params ServiceBatchUsersParams) (r ServiceBatchUsersRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // ServiceCreateUser implements Service_createUser operation.
 //
 // Create a user
@@ -34,6 +184,7 @@ func (UnimplementedHandler) ServiceCreateUser(ctx context.Context, req *User) (r
 // ServiceDeleteUser implements Service_deleteUser operation.
 //
 // Delete User
+// - the last administrator of the organization can't be demoted or deleted, 409 otherwise
 // - admin permission required.
 //
 // DELETE /users/{userId}
@@ -41,6 +192,20 @@ func (UnimplementedHandler) ServiceDeleteUser(ctx context.Context, params Servic
 	return r, ht.ErrNotImplemented
 }
 
+// ServiceExportUsers implements Service_exportUsers operation.
+//
+// Export all users of the organization as a stream, password hashes are never exported
+// - format is chosen by Accept: application/x-ndjson (default), text/csv or application/json (array)
+// - every format is written while users are read, application/json is an array of users
+// - users are ordered by id, pass the id of the last received user as cursor to resume
+// - filters are the same as in listUsers
+// - admin permission required.
+//
+// GET /users:export
+func (UnimplementedHandler) ServiceExportUsers(ctx context.Context, params ServiceExportUsersParams) (r ServiceExportUsersRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // ServiceGetUser implements Service_getUser operation.
 //
 // Returns a User if user with provided userId exists, 404 otherwise.
@@ -50,6 +215,31 @@ func (UnimplementedHandler) ServiceGetUser(ctx context.Context, params ServiceGe
 	return r, ht.ErrNotImplemented
 }
 
+// ServiceGetUserGroups implements Service_getUserGroups operation.
+//
+// Returns groups of the user, including groups inherited through nested groups
+// - admin permission required.
+//
+// GET /users/{userId}/groups
+func (UnimplementedHandler) ServiceGetUserGroups(ctx context.Context, params ServiceGetUserGroupsParams) (r ServiceGetUserGroupsRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// ServiceImportUsers implements Service_importUsers operation.
+//
+// Import users from CSV (header: username,email,password,is_admin)
+// or NDJSON (one User per line) body, the body is processed as a stream
+// - every row is validated like in createUser
+// - on_conflict defines what to do with existing username or email, fail by default, fail stops at
+// the first conflict and rolls back the whole import
+// - dry_run validates rows without writing them, rows are checked against previous rows of the body
+// - admin permission required.
+//
+// POST /users:import
+func (UnimplementedHandler) ServiceImportUsers(ctx context.Context, req ServiceImportUsersReq, params ServiceImportUsersParams) (r ServiceImportUsersRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // ServiceListUsers implements Service_listUsers operation.
 //
 // Returns a list of all users.
@@ -62,11 +252,16 @@ func (UnimplementedHandler) ServiceListUsers(ctx context.Context, params Service
 // ServicePatchUser implements Service_patchUser operation.
 //
 // Patch User
-// - one of the fields must be provided, except id
+// - application/json: one of the fields must be provided, except id
+// - application/merge-patch+json (RFC 7396): null clears the field
+// - application/json-patch+json (RFC 6902): operations on the stored user without password,
+// password can be added or replaced, failed test operation rejects the patch with 409
+// - patches are applied to the stored user, changed fields are validated like application/json ones
+// - the last administrator of the organization can't be demoted or deleted, 409 otherwise
 // - admin permission required.
 //
 // PATCH /users/{userId}
-func (UnimplementedHandler) ServicePatchUser(ctx context.Context, req *User, params ServicePatchUserParams) (r ServicePatchUserRes, _ error) {
+func (UnimplementedHandler) ServicePatchUser(ctx context.Context, req ServicePatchUserReq, params ServicePatchUserParams) (r ServicePatchUserRes, _ error) {
 	return r, ht.ErrNotImplemented
 }
 
@@ -74,9 +269,99 @@ func (UnimplementedHandler) ServicePatchUser(ctx context.Context, req *User, par
 //
 // Put a new User params
 // - all fields must be provided, except id
+// - the last administrator of the organization can't be demoted or deleted, 409 otherwise
 // - admin permission required.
 //
 // PUT /users/{userId}
 func (UnimplementedHandler) ServicePutUser(ctx context.Context, req *User, params ServicePutUserParams) (r ServicePutUserRes, _ error) {
 	return r, ht.ErrNotImplemented
 }
+
+// ServiceWatchUsers implements Service_watchUsers operation.
+//
+// Stream changes of users of the organization as server-sent events
+// - every event is user.created, user.updated or user.deleted with the outbox
+// event as data, the id of the event is its id in the outbox
+// - reconnect with Last-Event-ID to receive the events missed since it, the event
+// log is bounded, a reset event means missed events are lost and the list must be reloaded
+// - the stream is closed after a change of the password or deletion of the authenticated user
+// - comments are sent as heartbeats while there are no changes
+// - can be used by all users.
+//
+// GET /users:watch
+func (UnimplementedHandler) ServiceWatchUsers(ctx context.Context, params ServiceWatchUsersParams) (r ServiceWatchUsersRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// WebhooksCreateWebhook implements Webhooks_createWebhook operation.
+//
+// Register a webhook
+// - url must be provided, 400 otherwise
+// - the secret is returned only in this response
+// - admin permission required.
+//
+// POST /webhooks/
+func (UnimplementedHandler) WebhooksCreateWebhook(ctx context.Context, req *Webhook) (r WebhooksCreateWebhookRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// WebhooksDeleteWebhook implements Webhooks_deleteWebhook operation.
+//
+// Delete Webhook with its delivery log
+// - admin permission required.
+//
+// DELETE /webhooks/{webhookId}
+func (UnimplementedHandler) WebhooksDeleteWebhook(ctx context.Context, params WebhooksDeleteWebhookParams) (r WebhooksDeleteWebhookRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// WebhooksGetWebhook implements Webhooks_getWebhook operation.
+//
+// Returns a Webhook if webhook with provided webhookId exists, 404 otherwise.
+//
+// GET /webhooks/{webhookId}
+func (UnimplementedHandler) WebhooksGetWebhook(ctx context.Context, params WebhooksGetWebhookParams) (r WebhooksGetWebhookRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// WebhooksListWebhookDeliveries implements Webhooks_listWebhookDeliveries operation.
+//
+// Returns deliveries of the webhook, the latest events first
+// - admin permission required.
+//
+// GET /webhooks/{webhookId}/deliveries
+func (UnimplementedHandler) WebhooksListWebhookDeliveries(ctx context.Context, params WebhooksListWebhookDeliveriesParams) (r WebhooksListWebhookDeliveriesRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// WebhooksListWebhooks implements Webhooks_listWebhooks operation.
+//
+// Returns a list of webhooks, secrets are never returned
+// - admin permission required.
+//
+// GET /webhooks/
+func (UnimplementedHandler) WebhooksListWebhooks(ctx context.Context, params WebhooksListWebhooksParams) (r WebhooksListWebhooksRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// WebhooksPatchWebhook implements Webhooks_patchWebhook operation.
+//
+// Patch Webhook
+// - one of url, events, secret, enabled must be provided
+// - enabling a webhook resets its failures
+// - admin permission required.
+//
+// PATCH /webhooks/{webhookId}
+func (UnimplementedHandler) WebhooksPatchWebhook(ctx context.Context, req *Webhook, params WebhooksPatchWebhookParams) (r WebhooksPatchWebhookRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// WebhooksRedeliverWebhookDelivery implements Webhooks_redeliverWebhookDelivery operation.
+//
+// Queue the delivery again, attempts start over
+// - admin permission required.
+//
+// POST /webhooks/{webhookId}/deliveries/{deliveryId}/redeliver
+func (UnimplementedHandler) WebhooksRedeliverWebhookDelivery(ctx context.Context, params WebhooksRedeliverWebhookDeliveryParams) (r WebhooksRedeliverWebhookDeliveryRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
@@ -0,0 +1,585 @@
+// gRPC API of the user service, it mirrors operations of the Service namespace
+// of main.tsp and shares the business logic and the authorization with the REST API.
+//   - credentials are passed in `authorization` metadata as Basic auth,
+//     `organization/username` or just `username` for the default organization
+//   - `x-tenant-id` and `x-read-your-writes` metadata work as the headers of the REST API
+//   - errors of the REST API are returned as status codes: 400 is INVALID_ARGUMENT,
+//     401 UNAUTHENTICATED, 403 PERMISSION_DENIED, 404 NOT_FOUND, 409 ALREADY_EXISTS,
+//     429 RESOURCE_EXHAUSTED, 500 INTERNAL. Conflicts which aren't duplicates are
+//     FAILED_PRECONDITION for the last administrator and ABORTED for a failed patch test
+//   - health is served by the standard grpc.health.v1.Health service without credentials
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: user_service.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// User model all fields isn't required, the same as User of the REST API
+type User struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       *string                `protobuf:"bytes,1,opt,name=id,proto3,oneof" json:"id,omitempty"`
+	Username *string                `protobuf:"bytes,2,opt,name=username,proto3,oneof" json:"username,omitempty"`
+	// the user's password, returned like a base64 string
+	Password *string `protobuf:"bytes,3,opt,name=password,proto3,oneof" json:"password,omitempty"`
+	Email    *string `protobuf:"bytes,4,opt,name=email,proto3,oneof" json:"email,omitempty"`
+	IsAdmin  *bool   `protobuf:"varint,5,opt,name=is_admin,json=isAdmin,proto3,oneof" json:"is_admin,omitempty"`
+	// read only, set for the administrator created on the first run
+	MustChangePassword *bool `protobuf:"varint,6,opt,name=must_change_password,json=mustChangePassword,proto3,oneof" json:"must_change_password,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_user_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *User) GetId() string {
+	if x != nil && x.Id != nil {
+		return *x.Id
+	}
+	return ""
+}
+
+func (x *User) GetUsername() string {
+	if x != nil && x.Username != nil {
+		return *x.Username
+	}
+	return ""
+}
+
+func (x *User) GetPassword() string {
+	if x != nil && x.Password != nil {
+		return *x.Password
+	}
+	return ""
+}
+
+func (x *User) GetEmail() string {
+	if x != nil && x.Email != nil {
+		return *x.Email
+	}
+	return ""
+}
+
+func (x *User) GetIsAdmin() bool {
+	if x != nil && x.IsAdmin != nil {
+		return *x.IsAdmin
+	}
+	return false
+}
+
+func (x *User) GetMustChangePassword() bool {
+	if x != nil && x.MustChangePassword != nil {
+		return *x.MustChangePassword
+	}
+	return false
+}
+
+type ListUsersRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Offset  *int64                 `protobuf:"varint,1,opt,name=offset,proto3,oneof" json:"offset,omitempty"`
+	IsAdmin *bool                  `protobuf:"varint,2,opt,name=is_admin,json=isAdmin,proto3,oneof" json:"is_admin,omitempty"`
+	// case-insensitive substring of username or email
+	Search        *string `protobuf:"bytes,3,opt,name=search,proto3,oneof" json:"search,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersRequest) Reset() {
+	*x = ListUsersRequest{}
+	mi := &file_user_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersRequest) ProtoMessage() {}
+
+func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListUsersRequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListUsersRequest) GetOffset() int64 {
+	if x != nil && x.Offset != nil {
+		return *x.Offset
+	}
+	return 0
+}
+
+func (x *ListUsersRequest) GetIsAdmin() bool {
+	if x != nil && x.IsAdmin != nil {
+		return *x.IsAdmin
+	}
+	return false
+}
+
+func (x *ListUsersRequest) GetSearch() string {
+	if x != nil && x.Search != nil {
+		return *x.Search
+	}
+	return ""
+}
+
+type ListUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersResponse) Reset() {
+	*x = ListUsersResponse{}
+	mi := &file_user_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersResponse) ProtoMessage() {}
+
+func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListUsersResponse) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListUsersResponse) GetUsers() []*User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+type GetUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserRequest) Reset() {
+	*x = GetUserRequest{}
+	mi := &file_user_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserRequest) ProtoMessage() {}
+
+func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
+func (*GetUserRequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type CreateUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateUserRequest) Reset() {
+	*x = CreateUserRequest{}
+	mi := &file_user_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUserRequest) ProtoMessage() {}
+
+func (x *CreateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUserRequest.ProtoReflect.Descriptor instead.
+func (*CreateUserRequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateUserRequest) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type PatchUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	User          *User                  `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PatchUserRequest) Reset() {
+	*x = PatchUserRequest{}
+	mi := &file_user_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PatchUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatchUserRequest) ProtoMessage() {}
+
+func (x *PatchUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatchUserRequest.ProtoReflect.Descriptor instead.
+func (*PatchUserRequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PatchUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PatchUserRequest) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type PutUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	User          *User                  `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PutUserRequest) Reset() {
+	*x = PutUserRequest{}
+	mi := &file_user_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PutUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutUserRequest) ProtoMessage() {}
+
+func (x *PutUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutUserRequest.ProtoReflect.Descriptor instead.
+func (*PutUserRequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PutUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PutUserRequest) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type DeleteUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserRequest) Reset() {
+	*x = DeleteUserRequest{}
+	mi := &file_user_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserRequest) ProtoMessage() {}
+
+func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserRequest.ProtoReflect.Descriptor instead.
+func (*DeleteUserRequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+var File_user_service_proto protoreflect.FileDescriptor
+
+const file_user_service_proto_rawDesc = "" +
+	"\n" +
+	"\x12user_service.proto\x12\x0fuser_service.v1\x1a\x1bgoogle/protobuf/empty.proto\"\xa0\x02\n" +
+	"\x04User\x12\x13\n" +
+	"\x02id\x18\x01 \x01(\tH\x00R\x02id\x88\x01\x01\x12\x1f\n" +
+	"\busername\x18\x02 \x01(\tH\x01R\busername\x88\x01\x01\x12\x1f\n" +
+	"\bpassword\x18\x03 \x01(\tH\x02R\bpassword\x88\x01\x01\x12\x19\n" +
+	"\x05email\x18\x04 \x01(\tH\x03R\x05email\x88\x01\x01\x12\x1e\n" +
+	"\bis_admin\x18\x05 \x01(\bH\x04R\aisAdmin\x88\x01\x01\x125\n" +
+	"\x14must_change_password\x18\x06 \x01(\bH\x05R\x12mustChangePassword\x88\x01\x01B\x05\n" +
+	"\x03_idB\v\n" +
+	"\t_usernameB\v\n" +
+	"\t_passwordB\b\n" +
+	"\x06_emailB\v\n" +
+	"\t_is_adminB\x17\n" +
+	"\x15_must_change_password\"\x8f\x01\n" +
+	"\x10ListUsersRequest\x12\x1b\n" +
+	"\x06offset\x18\x01 \x01(\x03H\x00R\x06offset\x88\x01\x01\x12\x1e\n" +
+	"\bis_admin\x18\x02 \x01(\bH\x01R\aisAdmin\x88\x01\x01\x12\x1b\n" +
+	"\x06search\x18\x03 \x01(\tH\x02R\x06search\x88\x01\x01B\t\n" +
+	"\a_offsetB\v\n" +
+	"\t_is_adminB\t\n" +
+	"\a_search\"@\n" +
+	"\x11ListUsersResponse\x12+\n" +
+	"\x05users\x18\x01 \x03(\v2\x15.user_service.v1.UserR\x05users\")\n" +
+	"\x0eGetUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\">\n" +
+	"\x11CreateUserRequest\x12)\n" +
+	"\x04user\x18\x01 \x01(\v2\x15.user_service.v1.UserR\x04user\"V\n" +
+	"\x10PatchUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12)\n" +
+	"\x04user\x18\x02 \x01(\v2\x15.user_service.v1.UserR\x04user\"T\n" +
+	"\x0ePutUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12)\n" +
+	"\x04user\x18\x02 \x01(\v2\x15.user_service.v1.UserR\x04user\",\n" +
+	"\x11DeleteUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId2\xc3\x03\n" +
+	"\vUserService\x12R\n" +
+	"\tListUsers\x12!.user_service.v1.ListUsersRequest\x1a\".user_service.v1.ListUsersResponse\x12A\n" +
+	"\aGetUser\x12\x1f.user_service.v1.GetUserRequest\x1a\x15.user_service.v1.User\x12G\n" +
+	"\n" +
+	"CreateUser\x12\".user_service.v1.CreateUserRequest\x1a\x15.user_service.v1.User\x12F\n" +
+	"\tPatchUser\x12!.user_service.v1.PatchUserRequest\x1a\x16.google.protobuf.Empty\x12B\n" +
+	"\aPutUser\x12\x1f.user_service.v1.PutUserRequest\x1a\x16.google.protobuf.Empty\x12H\n" +
+	"\n" +
+	"DeleteUser\x12\".user_service.v1.DeleteUserRequest\x1a\x16.google.protobuf.EmptyB2Z0github.com/liriquew/test_task/internal/domain/pbb\x06proto3"
+
+var (
+	file_user_service_proto_rawDescOnce sync.Once
+	file_user_service_proto_rawDescData []byte
+)
+
+func file_user_service_proto_rawDescGZIP() []byte {
+	file_user_service_proto_rawDescOnce.Do(func() {
+		file_user_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_user_service_proto_rawDesc), len(file_user_service_proto_rawDesc)))
+	})
+	return file_user_service_proto_rawDescData
+}
+
+var file_user_service_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_user_service_proto_goTypes = []any{
+	(*User)(nil),              // 0: user_service.v1.User
+	(*ListUsersRequest)(nil),  // 1: user_service.v1.ListUsersRequest
+	(*ListUsersResponse)(nil), // 2: user_service.v1.ListUsersResponse
+	(*GetUserRequest)(nil),    // 3: user_service.v1.GetUserRequest
+	(*CreateUserRequest)(nil), // 4: user_service.v1.CreateUserRequest
+	(*PatchUserRequest)(nil),  // 5: user_service.v1.PatchUserRequest
+	(*PutUserRequest)(nil),    // 6: user_service.v1.PutUserRequest
+	(*DeleteUserRequest)(nil), // 7: user_service.v1.DeleteUserRequest
+	(*emptypb.Empty)(nil),     // 8: google.protobuf.Empty
+}
+var file_user_service_proto_depIdxs = []int32{
+	0,  // 0: user_service.v1.ListUsersResponse.users:type_name -> user_service.v1.User
+	0,  // 1: user_service.v1.CreateUserRequest.user:type_name -> user_service.v1.User
+	0,  // 2: user_service.v1.PatchUserRequest.user:type_name -> user_service.v1.User
+	0,  // 3: user_service.v1.PutUserRequest.user:type_name -> user_service.v1.User
+	1,  // 4: user_service.v1.UserService.ListUsers:input_type -> user_service.v1.ListUsersRequest
+	3,  // 5: user_service.v1.UserService.GetUser:input_type -> user_service.v1.GetUserRequest
+	4,  // 6: user_service.v1.UserService.CreateUser:input_type -> user_service.v1.CreateUserRequest
+	5,  // 7: user_service.v1.UserService.PatchUser:input_type -> user_service.v1.PatchUserRequest
+	6,  // 8: user_service.v1.UserService.PutUser:input_type -> user_service.v1.PutUserRequest
+	7,  // 9: user_service.v1.UserService.DeleteUser:input_type -> user_service.v1.DeleteUserRequest
+	2,  // 10: user_service.v1.UserService.ListUsers:output_type -> user_service.v1.ListUsersResponse
+	0,  // 11: user_service.v1.UserService.GetUser:output_type -> user_service.v1.User
+	0,  // 12: user_service.v1.UserService.CreateUser:output_type -> user_service.v1.User
+	8,  // 13: user_service.v1.UserService.PatchUser:output_type -> google.protobuf.Empty
+	8,  // 14: user_service.v1.UserService.PutUser:output_type -> google.protobuf.Empty
+	8,  // 15: user_service.v1.UserService.DeleteUser:output_type -> google.protobuf.Empty
+	10, // [10:16] is the sub-list for method output_type
+	4,  // [4:10] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_user_service_proto_init() }
+func file_user_service_proto_init() {
+	if File_user_service_proto != nil {
+		return
+	}
+	file_user_service_proto_msgTypes[0].OneofWrappers = []any{}
+	file_user_service_proto_msgTypes[1].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_user_service_proto_rawDesc), len(file_user_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_user_service_proto_goTypes,
+		DependencyIndexes: file_user_service_proto_depIdxs,
+		MessageInfos:      file_user_service_proto_msgTypes,
+	}.Build()
+	File_user_service_proto = out.File
+	file_user_service_proto_goTypes = nil
+	file_user_service_proto_depIdxs = nil
+}
@@ -0,0 +1,379 @@
+// Package idempotency replays responses to retried requests with the Idempotency-Key header.
+// The first request with a key claims it, its response is stored for the ttl and retries
+// with the key get the stored response instead of running the request again. Keys are
+// claimed after the authentication and the rate limit and scoped to the user, so clients
+// can't see responses of each other. Only final responses are stored
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"hash"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/lib/config"
+	"github.com/liriquew/test_task/internal/lib/tenant"
+	"github.com/liriquew/test_task/internal/repository"
+	"github.com/liriquew/test_task/internal/service"
+	"github.com/liriquew/test_task/pkg/logger/sl"
+	"github.com/ogen-go/ogen/middleware"
+	"github.com/ogen-go/ogen/ogenerrors"
+)
+
+const (
+	// Header is the key chosen by the client, the same for every retry of a request
+	Header = "Idempotency-Key"
+	// ReplayedHeader is set on stored responses
+	ReplayedHeader = "Idempotent-Replayed"
+)
+
+// maximum length of a key
+const maxKeyLength = 255
+
+// methods of requests with keys, keys of other requests are ignored
+var methods = map[string]bool{
+	http.MethodPost:   true,
+	http.MethodPatch:  true,
+	http.MethodPut:    true,
+	http.MethodDelete: true,
+}
+
+// Store keeps keys and responses of their requests
+type Store interface {
+	ClaimIdempotencyKey(ctx context.Context, record repository.IdempotencyRecord, now time.Time) (*repository.IdempotencyRecord, error)
+	CompleteIdempotencyKey(ctx context.Context, record repository.IdempotencyRecord) error
+	ReleaseIdempotencyKey(ctx context.Context, scope, key string) error
+	DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int64, error)
+}
+
+// Error is returned by the middleware for a key claimed by another request,
+// ErrorHandler writes it
+type Error struct {
+	Record *repository.IdempotencyRecord
+	// Fingerprint of the request, empty if the key is in progress
+	Fingerprint string
+}
+
+func (e *Error) Error() string {
+	return "idempotency key is claimed"
+}
+
+type Handler struct {
+	log   *slog.Logger
+	store Store
+
+	ttl         time.Duration
+	lockTimeout time.Duration
+	interval    time.Duration
+	maxBodySize int64
+}
+
+func New(log *slog.Logger, store Store, cfg config.IdempotencyConfig) *Handler {
+	h := &Handler{
+		log:         log.With(slog.String("component", "idempotency")),
+		store:       store,
+		ttl:         cfg.TTL,
+		lockTimeout: cfg.LockTimeout,
+		interval:    cfg.Interval,
+		maxBodySize: cfg.MaxBodySize,
+	}
+	if h.ttl <= 0 {
+		h.ttl = 24 * time.Hour
+	}
+	if h.lockTimeout <= 0 {
+		h.lockTimeout = time.Minute
+	}
+	if h.interval <= 0 {
+		h.interval = time.Minute
+	}
+	if h.maxBodySize <= 0 {
+		h.maxBodySize = 32 << 20
+	}
+
+	return h
+}
+
+// Wrap keeps the key of the request in its context for the middleware, which claims the key,
+// and stores the response of the claimed key. The body of the request is limited and hashed
+// while the handler reads it, the hash is completed when the response is stored
+func (h *Handler) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(Header)
+		if key == "" || !methods[r.Method] {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if len(key) > maxKeyLength {
+			writeError(w, http.StatusBadRequest, "idempotency key is longer than 255 characters")
+			return
+		}
+
+		hash := sha256.New()
+		for _, part := range []string{r.Method, r.URL.RequestURI(), r.Header.Get("Content-Type")} {
+			hash.Write([]byte(part))
+			hash.Write([]byte{0})
+		}
+		body := http.MaxBytesReader(w, r.Body, h.maxBodySize)
+		r.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.TeeReader(body, hash), body}
+
+		req := &request{key: key, body: r.Body, hash: hash}
+		rec := &recorder{ResponseWriter: w, req: req}
+		// the key is stored or released even if next panics
+		defer h.complete(r.Context(), req, rec)
+
+		next.ServeHTTP(rec, r.WithContext(context.WithValue(r.Context(), requestKey{}, req)))
+	})
+}
+
+// Middleware claims the key of the request kept by Wrap, it runs after the authentication and
+// other middlewares, so a request rejected by them doesn't claim the key. A request whose key
+// is in progress gets 409, a request which reuses the key of another request gets 422, the
+// errors and stored responses are written by ErrorHandler. Requests without the key in the
+// context and unauthenticated requests aren't affected
+func (h *Handler) Middleware() middleware.Middleware {
+	return func(
+		req middleware.Request,
+		next middleware.Next,
+	) (middleware.Response, error) {
+		r, ok := req.Context.Value(requestKey{}).(*request)
+		principal, authenticated := req.Context.Value(service.CurrentUser{}).(service.Principal)
+		if !ok || !authenticated {
+			return next(req)
+		}
+
+		// the authentication scopes the request to the organization of the principal
+		tenantID, _ := tenant.FromContext(req.Context)
+		now := time.Now()
+		record := repository.IdempotencyRecord{
+			Scope:     scope(principal, tenantID),
+			Key:       r.key,
+			ExpiresAt: now.Add(h.lockTimeout),
+		}
+
+		stored, err := h.store.ClaimIdempotencyKey(req.Context, record, now)
+		if err != nil {
+			h.log.Warn("error while claiming idempotency key", sl.Err(err))
+			return middleware.Response{}, err
+		}
+		if stored != nil {
+			if stored.InProgress() {
+				return middleware.Response{}, &Error{Record: stored}
+			}
+			fingerprint, err := r.fingerprint()
+			if err != nil {
+				return middleware.Response{}, err
+			}
+			return middleware.Response{}, &Error{Record: stored, Fingerprint: fingerprint}
+		}
+
+		r.claimed = &record
+		return next(req)
+	}
+}
+
+// complete stores the response of the claimed key, the key is released if the response
+// isn't final or the body of the request exceeds the limit
+func (h *Handler) complete(ctx context.Context, req *request, rec *recorder) {
+	if req.claimed == nil {
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	record := *req.claimed
+
+	completed := false
+	defer func() {
+		if completed {
+			return
+		}
+		if err := h.store.ReleaseIdempotencyKey(ctx, record.Scope, record.Key); err != nil {
+			h.log.Warn("error while releasing idempotency key", sl.Err(err))
+		}
+	}()
+
+	if rec.status == 0 {
+		rec.status, rec.header = http.StatusOK, rec.ResponseWriter.Header().Clone()
+	}
+	if !final(rec.status) {
+		return
+	}
+	fingerprint, err := req.fingerprint()
+	if err != nil {
+		return
+	}
+
+	// the quota of the request isn't the quota of a retry
+	for name := range rec.header {
+		if strings.HasPrefix(name, "Ratelimit-") || name == "Retry-After" {
+			delete(rec.header, name)
+		}
+	}
+	header, err := json.Marshal(rec.header)
+	if err != nil {
+		h.log.Warn("error while encoding response header", sl.Err(err))
+		return
+	}
+	record.Fingerprint = fingerprint
+	record.Status = int32(rec.status)
+	record.Header = string(header)
+	record.Body = rec.body.Bytes()
+	record.ExpiresAt = time.Now().Add(h.ttl)
+
+	if err := h.store.CompleteIdempotencyKey(ctx, record); err != nil {
+		h.log.Warn("error while storing idempotent response", sl.Err(err))
+		return
+	}
+	completed = true
+}
+
+// final reports whether a response with the status is stored: successes and client errors
+// which a retry gets again. Server errors, failed authentication, denied permissions and
+// limited requests may succeed on retry, the key is released
+func final(status int) bool {
+	switch status {
+	case http.StatusBadRequest, http.StatusNotFound, http.StatusConflict,
+		http.StatusGone, http.StatusUnprocessableEntity:
+		return true
+	}
+	return status >= 200 && status < 300
+}
+
+// Run deletes expired keys every interval until ctx is done
+func (h *Handler) Run(ctx context.Context) {
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := h.store.DeleteExpiredIdempotencyKeys(ctx, time.Now()); err != nil && ctx.Err() == nil {
+			h.log.Warn("error while deleting expired idempotency keys", sl.Err(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ErrorHandler writes Error as the stored response or 409 and 422,
+// a body over the limit is 413, other errors are handled by next
+func ErrorHandler(next ogenerrors.ErrorHandler) ogenerrors.ErrorHandler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request, err error) {
+		var replayed *Error
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.As(err, &replayed):
+			replay(w, replayed)
+		case errors.As(err, &tooLarge):
+			writeError(w, http.StatusRequestEntityTooLarge, "request body is too large")
+		default:
+			next(ctx, w, r, err)
+		}
+	}
+}
+
+// replay writes the stored response if it's the response of the same request
+func replay(w http.ResponseWriter, e *Error) {
+	stored := e.Record
+	if stored.InProgress() {
+		w.Header().Set("Retry-After", "1")
+		writeError(w, http.StatusConflict, "request with the idempotency key is in progress")
+		return
+	}
+	if stored.Fingerprint != e.Fingerprint {
+		writeError(w, http.StatusUnprocessableEntity, "idempotency key is reused with another request")
+		return
+	}
+
+	var header http.Header
+	if err := json.Unmarshal([]byte(stored.Header), &header); err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+	for name, values := range header {
+		w.Header()[name] = values
+	}
+	w.Header().Set(ReplayedHeader, "true")
+	w.WriteHeader(int(stored.Status))
+	w.Write(stored.Body)
+}
+
+// scope is the organization and the id of the user, and the organization of the request,
+// which a super-admin chooses
+func scope(principal service.Principal, tenantID domain.UUID) string {
+	return uuid.UUID(principal.TenantID).String() + "/" + uuid.UUID(principal.ID).String() +
+		"/" + uuid.UUID(tenantID).String()
+}
+
+// writeError writes the error the same way as ogen does
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error_message": message})
+}
+
+type requestKey struct{}
+
+// request is the state of a request with a key, kept in its context by Wrap
+type request struct {
+	key  string
+	body io.Reader
+	hash hash.Hash
+	// claimed is the record of the key claimed by the middleware, nil if it isn't claimed
+	claimed *repository.IdempotencyRecord
+}
+
+// fingerprint reads the rest of the body and returns the hash of the method, the url,
+// the content type and the body of the request
+func (r *request) fingerprint() (string, error) {
+	if _, err := io.Copy(io.Discard, r.body); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(r.hash.Sum(nil)), nil
+}
+
+// recorder keeps the response written to the client,
+// the body is kept only if the key is claimed
+type recorder struct {
+	http.ResponseWriter
+	req    *request
+	status int
+	header http.Header
+	body   bytes.Buffer
+}
+
+func (r *recorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+		r.header = r.ResponseWriter.Header().Clone()
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.WriteHeader(http.StatusOK)
+	}
+	if r.req.claimed != nil {
+		r.body.Write(b)
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *recorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
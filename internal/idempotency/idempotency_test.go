@@ -0,0 +1,272 @@
+package idempotency_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/idempotency"
+	"github.com/liriquew/test_task/internal/lib/config"
+	"github.com/liriquew/test_task/internal/repository/memory"
+	"github.com/liriquew/test_task/internal/service"
+	"github.com/ogen-go/ogen/middleware"
+	"github.com/ogen-go/ogen/ogenerrors"
+	"github.com/stretchr/testify/require"
+)
+
+func stubLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+// counter responds with the number of requests it has handled and the status of X-Status,
+// requests with X-Block wait until release is closed
+type counter struct {
+	calls   atomic.Int32
+	release chan struct{}
+}
+
+func (c *counter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	calls := c.calls.Add(1)
+	if r.Header.Get("X-Block") != "" {
+		<-c.release
+	}
+
+	body, _ := io.ReadAll(r.Body)
+	status := http.StatusCreated
+	if s := r.Header.Get("X-Status"); s != "" {
+		status, _ = strconv.Atoi(s)
+	}
+
+	w.Header().Set("Location", "/users/"+string(body))
+	w.Header().Set("RateLimit-Remaining", "1")
+	w.WriteHeader(status)
+	w.Write([]byte{byte('0' + calls)})
+}
+
+// serve runs next the way the ogen server does: the username of basic auth is
+// the principal, then the middleware runs, its errors are written by ErrorHandler
+func serve(h *idempotency.Handler, next http.Handler) http.Handler {
+	errorHandler := idempotency.ErrorHandler(ogenerrors.DefaultErrorHandler)
+	mw := h.Middleware()
+
+	return h.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if username, _, ok := r.BasicAuth(); ok {
+			id := uuid.NewSHA1(uuid.Nil, []byte(username))
+			ctx = service.WithPrincipal(ctx, service.Principal{ID: domain.UUID(id)}, true)
+		}
+
+		_, err := mw(middleware.Request{Context: ctx, Raw: r}, func(req middleware.Request) (middleware.Response, error) {
+			next.ServeHTTP(w, r.WithContext(req.Context))
+			return middleware.Response{}, nil
+		})
+		if err != nil {
+			errorHandler(ctx, w, r, err)
+		}
+	}))
+}
+
+func TestWrap(t *testing.T) {
+	next := &counter{release: make(chan struct{})}
+	h := idempotency.New(stubLogger(), memory.New(), config.IdempotencyConfig{MaxBodySize: 16})
+	srv := httptest.NewServer(serve(h, next))
+	defer srv.Close()
+
+	do := func(method, key, body string, header ...string) (*http.Response, string) {
+		t.Helper()
+
+		req, err := http.NewRequest(method, srv.URL+"/users/", strings.NewReader(body))
+		require.NoError(t, err)
+		req.SetBasicAuth("administrator", "Passw0rdAdmin")
+		if key != "" {
+			req.Header.Set(idempotency.Header, key)
+		}
+		for i := 0; i < len(header); i += 2 {
+			req.Header.Set(header[i], header[i+1])
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		b, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp, string(b)
+	}
+
+	t.Run("Replay", func(t *testing.T) {
+		resp, body := do(http.MethodPost, "replay", "1")
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		require.Empty(t, resp.Header.Get(idempotency.ReplayedHeader))
+		first := body
+
+		resp, body = do(http.MethodPost, "replay", "1")
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		require.Equal(t, "true", resp.Header.Get(idempotency.ReplayedHeader))
+		require.Equal(t, "/users/1", resp.Header.Get("Location"))
+		require.Empty(t, resp.Header.Get("RateLimit-Remaining"))
+		require.Equal(t, first, body)
+
+		// client errors are replayed as well
+		resp, _ = do(http.MethodPost, "conflict", "1", "X-Status", "409")
+		require.Equal(t, http.StatusConflict, resp.StatusCode)
+		resp, _ = do(http.MethodPost, "conflict", "1", "X-Status", "409")
+		require.Equal(t, "true", resp.Header.Get(idempotency.ReplayedHeader))
+	})
+
+	t.Run("Another request", func(t *testing.T) {
+		do(http.MethodPost, "another", "1")
+
+		resp, _ := do(http.MethodPost, "another", "2")
+		require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+		resp, _ = do(http.MethodPut, "another", "1")
+		require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+
+	t.Run("Scope", func(t *testing.T) {
+		do(http.MethodPost, "scope", "1")
+
+		calls := next.calls.Load()
+		resp, _ := do(http.MethodPost, "scope", "1", "Authorization", "Basic b3RoZXI6b3RoZXI=")
+		require.Empty(t, resp.Header.Get(idempotency.ReplayedHeader))
+		require.Equal(t, calls+1, next.calls.Load())
+	})
+
+	t.Run("Server error", func(t *testing.T) {
+		resp, _ := do(http.MethodPost, "error", "1", "X-Status", "500")
+		require.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+
+		// the key is released, the retry runs the request
+		resp, _ = do(http.MethodPost, "error", "1", "X-Status", "500")
+		require.Empty(t, resp.Header.Get(idempotency.ReplayedHeader))
+		resp, _ = do(http.MethodPost, "error", "1")
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		require.Empty(t, resp.Header.Get(idempotency.ReplayedHeader))
+	})
+
+	t.Run("Not final", func(t *testing.T) {
+		for _, status := range []string{"401", "403", "429"} {
+			calls := next.calls.Load()
+			resp, _ := do(http.MethodPost, "not-final-"+status, "1", "X-Status", status)
+			require.Equal(t, status, strconv.Itoa(resp.StatusCode))
+			resp, _ = do(http.MethodPost, "not-final-"+status, "1")
+			require.Equal(t, http.StatusCreated, resp.StatusCode)
+			require.Equal(t, calls+2, next.calls.Load())
+		}
+	})
+
+	t.Run("Unauthenticated", func(t *testing.T) {
+		calls := next.calls.Load()
+		for range 2 {
+			req, err := http.NewRequest(http.MethodPost, srv.URL+"/users/", strings.NewReader("1"))
+			require.NoError(t, err)
+			req.Header.Set(idempotency.Header, "anonymous")
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+			require.Empty(t, resp.Header.Get(idempotency.ReplayedHeader))
+		}
+		require.Equal(t, calls+2, next.calls.Load())
+	})
+
+	t.Run("Large body", func(t *testing.T) {
+		large := strings.Repeat("1", 17)
+
+		// the body over the limit isn't stored
+		calls := next.calls.Load()
+		do(http.MethodPost, "large", large)
+		do(http.MethodPost, "large", large)
+		require.Equal(t, calls+2, next.calls.Load())
+
+		do(http.MethodPost, "stored", "1")
+		resp, _ := do(http.MethodPost, "stored", large)
+		require.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+	})
+
+	t.Run("In progress", func(t *testing.T) {
+		calls := next.calls.Load()
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			resp, _ := do(http.MethodPost, "concurrent", "1", "X-Block", "1")
+			require.Equal(t, http.StatusCreated, resp.StatusCode)
+		}()
+		require.Eventually(t, func() bool {
+			return next.calls.Load() == calls+1
+		}, time.Second, 10*time.Millisecond)
+
+		resp, _ := do(http.MethodPost, "concurrent", "1")
+		require.Equal(t, http.StatusConflict, resp.StatusCode)
+		require.NotEmpty(t, resp.Header.Get("Retry-After"))
+
+		close(next.release)
+		<-done
+
+		resp, _ = do(http.MethodPost, "concurrent", "1")
+		require.Equal(t, "true", resp.Header.Get(idempotency.ReplayedHeader))
+		require.Equal(t, calls+1, next.calls.Load())
+	})
+
+	t.Run("Without key", func(t *testing.T) {
+		calls := next.calls.Load()
+		do(http.MethodPost, "", "1")
+		do(http.MethodPost, "", "1")
+		// keys of reads are ignored
+		do(http.MethodGet, "get", "")
+		do(http.MethodGet, "get", "")
+		require.Equal(t, calls+4, next.calls.Load())
+
+		resp, _ := do(http.MethodPost, strings.Repeat("k", 256), "1")
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+// expiringStore counts deleted keys
+type expiringStore struct {
+	*memory.Repository
+	deleted atomic.Int64
+}
+
+func (s *expiringStore) DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int64, error) {
+	n, err := s.Repository.DeleteExpiredIdempotencyKeys(ctx, now)
+	s.deleted.Add(n)
+	return n, err
+}
+
+func TestRun(t *testing.T) {
+	store := &expiringStore{Repository: memory.New()}
+	h := idempotency.New(stubLogger(), store, config.IdempotencyConfig{
+		TTL:      time.Millisecond,
+		Interval: 10 * time.Millisecond,
+	})
+
+	var calls atomic.Int32
+	handler := serve(h, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	req := httptest.NewRequest(http.MethodPost, "/users/", nil)
+	req.SetBasicAuth("administrator", "Passw0rdAdmin")
+	req.Header.Set(idempotency.Header, "expired")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go h.Run(ctx)
+
+	require.Eventually(t, func() bool {
+		return store.deleted.Load() == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// the expired key runs the request again
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	require.Equal(t, int32(2), calls.Load())
+}
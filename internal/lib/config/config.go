@@ -2,17 +2,103 @@ package config
 
 import (
 	"os"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
 type AppConfig struct {
-	API     ServiceConfig `yaml:"service" env-required:"true"`
-	Storage StorageConfig `yaml:"storage" env-required:"true"`
+	Env         string            `yaml:"env" env:"ENV" env-default:"local"`
+	API         ServiceConfig     `yaml:"service" env-required:"true"`
+	Storage     StorageConfig     `yaml:"storage" env-required:"true"`
+	Bootstrap   BootstrapConfig   `yaml:"bootstrap"`
+	Outbox      OutboxConfig      `yaml:"outbox"`
+	Webhooks    WebhooksConfig    `yaml:"webhooks"`
+	Watch       WatchConfig       `yaml:"watch"`
+	GRPC        GRPCConfig        `yaml:"grpc"`
+	GraphQL     GraphQLConfig     `yaml:"graphql"`
+	SCIM        SCIMConfig        `yaml:"scim"`
+	Idempotency IdempotencyConfig `yaml:"idempotency"`
+	RateLimit   RateLimitConfig   `yaml:"rate_limit"`
+	AuthCache   AuthCacheConfig   `yaml:"auth_cache"`
+	Metrics     MetricsConfig     `yaml:"metrics"`
+}
+
+// OutboxConfig is the relay of user change events. Events are written to the outbox
+// regardless, they pile up in the database until the relay is enabled.
+// The relay also runs while webhooks are enabled, see WebhooksConfig
+type OutboxConfig struct {
+	Enabled bool `yaml:"enabled" env:"OUTBOX_ENABLED"`
+	// stdout, file or webhook
+	Publisher string `yaml:"publisher" env:"OUTBOX_PUBLISHER" env-default:"stdout"`
+	// file the file publisher appends events to as json lines
+	Path string `yaml:"path" env:"OUTBOX_PATH"`
+	// URL the webhook publisher posts events to
+	URL string `yaml:"url" env:"OUTBOX_URL"`
+	// timeout of a single webhook request
+	Timeout time.Duration `yaml:"timeout" env-default:"5s"`
+	// how often the outbox is polled
+	Interval time.Duration `yaml:"interval" env-default:"1s"`
+	// events published per transaction
+	BatchSize int `yaml:"batch_size" env-default:"100"`
+}
+
+// WebhooksConfig is the delivery of user events to webhooks registered by organizations.
+// The outbox relay passes events to webhooks, it runs while webhooks are enabled
+type WebhooksConfig struct {
+	Enabled bool `yaml:"enabled" env:"WEBHOOKS_ENABLED"`
+	// timeout of a single delivery attempt
+	Timeout time.Duration `yaml:"timeout" env-default:"5s"`
+	// how often due deliveries are polled
+	Interval time.Duration `yaml:"interval" env-default:"1s"`
+	// deliveries attempted at once
+	BatchSize int `yaml:"batch_size" env-default:"10"`
+	// a delivery is failed after max_attempts, retries wait backoff doubled
+	// after every attempt up to max_backoff
+	MaxAttempts int           `yaml:"max_attempts" env-default:"8"`
+	Backoff     time.Duration `yaml:"backoff" env-default:"10s"`
+	MaxBackoff  time.Duration `yaml:"max_backoff" env-default:"1h"`
+	// a webhook is disabled after disable_after failed attempts in a row, negative never disables it
+	DisableAfter int `yaml:"disable_after" env-default:"50"`
+	// webhooks are delivered to public addresses only, allow_private_networks allows
+	// loopback and private ones, for development
+	AllowPrivateNetworks bool `yaml:"allow_private_networks"`
+}
+
+// WatchConfig is the stream of user changes of GET /users:watch. Postgres notifies
+// watchers of changes, other storages are polled every interval
+type WatchConfig struct {
+	// how often the log of changes is polled, postgres is polled as well in case of a missed notification
+	Interval time.Duration `yaml:"interval" env-default:"1s"`
+	// comments are sent every heartbeat, so proxies don't close idle streams
+	Heartbeat time.Duration `yaml:"heartbeat" env-default:"15s"`
+	// latest events kept in the log, streams resume from them
+	LogSize int `yaml:"log_size" env-default:"10000"`
+	// events buffered for a stream, a stream that falls behind by more is closed
+	// and resumes from the log on reconnection
+	Buffer int `yaml:"buffer" env-default:"256"`
+}
+
+// environments
+const (
+	EnvLocal = "local"
+	// production refuses to start while admin:admin of earlier versions signs in
+	EnvProduction = "production"
+)
+
+// BootstrapConfig is the administrator of the default organization created
+// on the first run, it must change the password on the first login
+type BootstrapConfig struct {
+	Username string `yaml:"username" env:"BOOTSTRAP_ADMIN_USERNAME" env-default:"administrator"`
+	Email    string `yaml:"email" env:"BOOTSTRAP_ADMIN_EMAIL" env-default:"admin@admin.ru"`
+	// a random password is generated and logged once if it is empty
+	Password string `yaml:"password" env:"BOOTSTRAP_ADMIN_PASSWORD" json:"-"`
 }
 
 type AppTestConfig struct {
 	API ServiceConfig `yaml:"service" env-required:"true"`
+	// the administrator the service was started with
+	Bootstrap BootstrapConfig `yaml:"bootstrap"`
 }
 
 type ServiceConfig struct {
@@ -20,12 +106,132 @@ type ServiceConfig struct {
 	Host string `yaml:"host" env-required:"true"`
 }
 
+// GRPCConfig is the gRPC API served alongside the REST API on the host of the service
+type GRPCConfig struct {
+	Enabled bool `yaml:"enabled" env:"GRPC_ENABLED"`
+	Port    int  `yaml:"port" env:"GRPC_PORT" env-default:"9090"`
+}
+
+// GraphQLConfig limits queries of /graphql, deeper or more complex queries are rejected
+type GraphQLConfig struct {
+	MaxDepth      int `yaml:"max_depth" env-default:"10"`
+	MaxComplexity int `yaml:"max_complexity" env-default:"1000"`
+	// maximum size of the body of a request in bytes, a larger one gets 413
+	MaxBodySize int64 `yaml:"max_body_size" env-default:"1048576"`
+}
+
+// SCIMConfig is the provisioning of users and groups by SCIM 2.0 under /scim/v2.
+// The identity provider authenticates by the bearer token and provisions the organization
+type SCIMConfig struct {
+	Enabled bool   `yaml:"enabled" env:"SCIM_ENABLED"`
+	Token   string `yaml:"token" env:"SCIM_TOKEN" json:"-"`
+	// name of the organization
+	Organization string `yaml:"organization" env:"SCIM_ORGANIZATION" env-default:"default"`
+}
+
+// IdempotencyConfig is the replay of responses to retried requests with the Idempotency-Key header
+type IdempotencyConfig struct {
+	// responses are replayed for ttl after the first request
+	TTL time.Duration `yaml:"ttl" env:"IDEMPOTENCY_TTL" env-default:"24h"`
+	// a request in progress holds its key for lock_timeout at most, it must be longer than
+	// the longest request, a retry after it runs the request again
+	LockTimeout time.Duration `yaml:"lock_timeout" env-default:"1m"`
+	// how often expired keys are deleted
+	Interval time.Duration `yaml:"interval" env-default:"1m"`
+	// maximum size of the body of a request with a key in bytes, a larger one gets 413
+	MaxBodySize int64 `yaml:"max_body_size" env-default:"33554432"`
+}
+
+// RateLimitConfig is the limit of requests per user and operation. A quota is a bucket of
+// burst requests refilled with rate requests per second, unset quotas are defaults
+type RateLimitConfig struct {
+	Enabled bool `yaml:"enabled" env:"RATE_LIMIT_ENABLED"`
+	// quota of every operation of a user without admin permissions
+	User RateLimitQuota `yaml:"user"`
+	// quota of every operation of an admin
+	Admin RateLimitQuota `yaml:"admin"`
+	// quota of failed authentications of a client ip, every attempt costs a bcrypt comparison
+	Anonymous RateLimitQuota `yaml:"anonymous"`
+	// quota of authentications of a username from a client ip, taken before the credentials
+	// are checked, so concurrent attempts can't pass the check of anonymous at once.
+	// Successful ones are counted too, it must allow requests of the user quota
+	Attempts RateLimitQuota `yaml:"attempts"`
+	// the client ip is the first address of X-Forwarded-For, only behind a proxy which sets it
+	TrustForwardedFor bool `yaml:"trust_forwarded_for"`
+}
+
+type RateLimitQuota struct {
+	// requests per second
+	Rate  float64 `yaml:"rate"`
+	Burst int     `yaml:"burst"`
+}
+
+// AuthCacheConfig is the cache of successful authentications, a cached request skips the
+// lookup of the user and the bcrypt comparison
+type AuthCacheConfig struct {
+	Enabled bool `yaml:"enabled" env:"AUTH_CACHE_ENABLED"`
+	// an entry is used for ttl at most, it bounds staleness of changes missed by the cache
+	TTL time.Duration `yaml:"ttl" env-default:"1m"`
+	// maximum number of entries, the least recently used one is evicted
+	Size int `yaml:"size" env-default:"10000"`
+}
+
+// MetricsConfig is the export of metrics in the Prometheus format on the port of the REST API
+type MetricsConfig struct {
+	Enabled bool   `yaml:"enabled" env:"METRICS_ENABLED"`
+	Path    string `yaml:"path" env-default:"/metrics"`
+}
+
+// storage drivers
+const (
+	DriverPostgres = "postgres"
+	// memory driver keeps data in the process, it is lost on restart
+	DriverMemory = "memory"
+	// sqlite driver keeps data in the file of storage.path
+	DriverSQLite = "sqlite"
+)
+
 type StorageConfig struct {
-	Username string `yaml:"username" env-required:"true"`
-	Password string `yaml:"password" env-required:"true"`
-	Host     string `yaml:"host" env-required:"true"`
-	Port     int    `yaml:"port" env-required:"true"`
-	DBName   string `yaml:"db_name" env-required:"true"`
+	Driver string `yaml:"driver" env-default:"postgres"`
+	// apply pending migrations on startup, the service doesn't start on an outdated schema
+	AutoMigrate bool `yaml:"auto_migrate"`
+
+	// postgres connection, ignored by the memory driver
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	DBName   string `yaml:"db_name"`
+
+	// postgres connection options, see libpq sslmode
+	SSLMode     string `yaml:"sslmode" env-default:"disable"`
+	SSLRootCert string `yaml:"sslrootcert"`
+	SSLCert     string `yaml:"sslcert"`
+	SSLKey      string `yaml:"sslkey"`
+	// ApplicationName is shown in pg_stat_activity
+	ApplicationName  string        `yaml:"application_name" env-default:"user_service"`
+	ConnectTimeout   time.Duration `yaml:"connect_timeout" env-default:"5s"`
+	StatementTimeout time.Duration `yaml:"statement_timeout"`
+
+	// postgres read replicas, connection strings are used as is and may hold passwords.
+	// Lists, reads of users by id and exports go to healthy replicas, everything else to the primary
+	Replicas []string `yaml:"replicas" json:"-"`
+	// interval of replica health checks
+	ReplicaCheckInterval time.Duration `yaml:"replica_check_interval" env-default:"5s"`
+
+	// sqlite database file
+	Path string `yaml:"path"`
+
+	// connection pool of postgres and sqlite, zero keeps the default of database/sql
+	MaxOpenConns    int           `yaml:"max_open_conns" env-default:"25"`
+	MaxIdleConns    int           `yaml:"max_idle_conns" env-default:"10"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" env-default:"30m"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time" env-default:"5m"`
+
+	// deadline of a single repository call, zero disables it
+	QueryTimeout time.Duration `yaml:"query_timeout" env-default:"5s"`
+	// attempts to ping the database on startup, with exponential backoff between them
+	PingAttempts int `yaml:"ping_attempts" env-default:"5"`
 }
 
 func MustLoad() AppConfig {
@@ -0,0 +1,17 @@
+package consistency
+
+import "context"
+
+type primaryKey struct{}
+
+// WithPrimary returns ctx whose reads go to the primary database,
+// so the request sees its own writes that replicas may not have replayed yet
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// PrimaryRequired reports whether reads of ctx must go to the primary
+func PrimaryRequired(ctx context.Context) bool {
+	required, _ := ctx.Value(primaryKey{}).(bool)
+	return required
+}
@@ -0,0 +1,57 @@
+// Package netguard keeps requests to URLs chosen by clients away from internal networks:
+// loopback, private, link-local (cloud metadata included), shared and unspecified addresses
+package netguard
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+	"syscall"
+)
+
+var ErrForbiddenAddress = errors.New("address isn't public")
+
+// shared address space of carrier-grade NAT, some clouds serve metadata from it
+var shared = netip.MustParsePrefix("100.64.0.0/10")
+
+// Public reports whether addr is a public unicast address
+func Public(addr netip.Addr) bool {
+	addr = addr.Unmap()
+
+	return addr.IsValid() &&
+		!addr.IsLoopback() &&
+		!addr.IsPrivate() &&
+		!addr.IsLinkLocalUnicast() &&
+		!addr.IsUnspecified() &&
+		!addr.IsMulticast() &&
+		!shared.Contains(addr)
+}
+
+// PublicHost reports whether host may be public: a name other than localhost or a public address.
+// A name is checked again by Control when it's resolved
+func PublicHost(host string) bool {
+	if addr, err := netip.ParseAddr(strings.Trim(host, "[]")); err == nil {
+		return Public(addr)
+	}
+
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	return host != "" && host != "localhost" && !strings.HasSuffix(host, ".localhost")
+}
+
+// Control is the control function of net.Dialer, it rejects connections to addresses
+// which aren't public. It runs after the name is resolved, so a name can't point to
+// an internal address
+func Control(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil || !Public(addr) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, host)
+	}
+
+	return nil
+}
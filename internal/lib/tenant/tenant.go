@@ -0,0 +1,29 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+
+	domain "github.com/liriquew/test_task/internal/domain"
+)
+
+// Default is the organization created by migrations,
+// admins of the default organization are super-admins
+var Default = domain.UUID{}
+
+// ErrNoTenant is returned by storages when ctx isn't scoped to an organization
+var ErrNoTenant = errors.New("organization of the request isn't set")
+
+type tenantKey struct{}
+
+// WithTenant returns ctx scoped to the organization
+func WithTenant(ctx context.Context, id domain.UUID) context.Context {
+	return context.WithValue(ctx, tenantKey{}, id)
+}
+
+// FromContext returns the organization ctx is scoped to, ok is false if none.
+// There is no fallback, Default is the organization of super-admins
+func FromContext(ctx context.Context) (id domain.UUID, ok bool) {
+	id, ok = ctx.Value(tenantKey{}).(domain.UUID)
+	return id, ok
+}
@@ -0,0 +1,134 @@
+// Package migrator applies migrations embedded into the binary
+// to the database of the storage driver
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	_ "github.com/lib/pq"
+	"github.com/liriquew/test_task/internal/lib/config"
+	"github.com/liriquew/test_task/internal/repository"
+	"github.com/liriquew/test_task/migrations"
+	"github.com/pressly/goose/v3"
+	"github.com/pressly/goose/v3/lock"
+	_ "modernc.org/sqlite"
+)
+
+var (
+	// ErrNoMigrations is returned for drivers without a schema
+	ErrNoMigrations = errors.New("storage driver has no migrations")
+	// ErrSchemaBehind is returned when the database misses migrations of the binary
+	ErrSchemaBehind = errors.New("database schema is behind, run migrate up")
+)
+
+type Migrator struct {
+	provider *goose.Provider
+}
+
+// New opens a dedicated connection to the database of cfg. Migrations of postgres
+// run under a session advisory lock, so replicas started at once apply them one by one.
+// goose_db_version table is shared with the goose cli
+func New(cfg config.StorageConfig) (*Migrator, error) {
+	var (
+		dialect goose.Dialect
+		db      *sql.DB
+		fsys    fs.FS
+		opts    []goose.ProviderOption
+		err     error
+	)
+
+	switch cfg.Driver {
+	case config.DriverPostgres, "":
+		dialect = goose.DialectPostgres
+		fsys = migrations.Postgres
+
+		locker, err := lock.NewPostgresSessionLocker()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, goose.WithSessionLocker(locker))
+
+		db, err = sql.Open("postgres", repository.DSN(cfg))
+		if err != nil {
+			return nil, err
+		}
+	case config.DriverSQLite:
+		dialect = goose.DialectSQLite3
+		if fsys, err = fs.Sub(migrations.SQLite, "sqlite"); err != nil {
+			return nil, err
+		}
+
+		// foreign keys stay disabled, see migrations/sqlite
+		if db, err = sql.Open("sqlite", cfg.Path); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrNoMigrations, cfg.Driver)
+	}
+
+	provider, err := goose.NewProvider(dialect, db, fsys, opts...)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &Migrator{
+		provider: provider,
+	}, nil
+}
+
+func (m *Migrator) Close() error {
+	return m.provider.Close()
+}
+
+// Up applies all pending migrations
+func (m *Migrator) Up(ctx context.Context) ([]*goose.MigrationResult, error) {
+	return m.provider.Up(ctx)
+}
+
+// Down rolls back the latest migration
+func (m *Migrator) Down(ctx context.Context) (*goose.MigrationResult, error) {
+	return m.provider.Down(ctx)
+}
+
+// Redo rolls back the latest migration and applies it again
+func (m *Migrator) Redo(ctx context.Context) ([]*goose.MigrationResult, error) {
+	down, err := m.provider.Down(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	up, err := m.provider.UpByOne(ctx)
+	if err != nil {
+		return []*goose.MigrationResult{down}, err
+	}
+
+	return []*goose.MigrationResult{down, up}, nil
+}
+
+// Status returns all migrations of the binary with their state in the database
+func (m *Migrator) Status(ctx context.Context) ([]*goose.MigrationStatus, error) {
+	return m.provider.Status(ctx)
+}
+
+// CheckSchema returns ErrSchemaBehind when some migrations aren't applied
+func (m *Migrator) CheckSchema(ctx context.Context) error {
+	pending, err := m.provider.HasPending(ctx)
+	if err != nil {
+		return err
+	}
+	if !pending {
+		return nil
+	}
+
+	current, target, err := m.provider.GetVersions(ctx)
+	if err != nil {
+		return err
+	}
+
+	return fmt.Errorf("%w: version %d, binary requires %d", ErrSchemaBehind, current, target)
+}
@@ -0,0 +1,51 @@
+package migrator_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/liriquew/test_task/internal/lib/config"
+	"github.com/liriquew/test_task/internal/migrator"
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrator(t *testing.T) {
+	ctx := context.Background()
+
+	m, err := migrator.New(config.StorageConfig{
+		Driver: config.DriverSQLite,
+		Path:   filepath.Join(t.TempDir(), "users.db"),
+	})
+	require.NoError(t, err)
+	defer m.Close()
+
+	require.ErrorIs(t, m.CheckSchema(ctx), migrator.ErrSchemaBehind)
+
+	applied, err := m.Up(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, applied)
+	require.NoError(t, m.CheckSchema(ctx))
+
+	res, err := m.Redo(ctx)
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	require.Equal(t, res[0].Source.Version, res[1].Source.Version)
+
+	down, err := m.Down(ctx)
+	require.NoError(t, err)
+	require.ErrorIs(t, m.CheckSchema(ctx), migrator.ErrSchemaBehind)
+
+	status, err := m.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, status, len(applied))
+	require.Equal(t, down.Source.Version, status[len(status)-1].Source.Version)
+	require.Equal(t, goose.StatePending, status[len(status)-1].State)
+	require.Equal(t, goose.StateApplied, status[0].State)
+}
+
+func TestNoMigrations(t *testing.T) {
+	_, err := migrator.New(config.StorageConfig{Driver: config.DriverMemory})
+	require.ErrorIs(t, err, migrator.ErrNoMigrations)
+}
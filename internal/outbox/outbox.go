@@ -0,0 +1,136 @@
+// Package outbox publishes events of user changes written to the outbox
+// by the repository. Delivery is at-least-once: an event is deleted from
+// the outbox only after it is published, consumers must deduplicate by event id
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/liriquew/test_task/internal/lib/config"
+	"github.com/liriquew/test_task/internal/repository"
+	"github.com/liriquew/test_task/pkg/logger/sl"
+)
+
+// Store is the outbox of the repository
+type Store interface {
+	RelayEvents(ctx context.Context, limit int, publish func(repository.OutboxEvent) error) (int, error)
+}
+
+// Publisher delivers events to consumers, Publish returns nil only
+// when the event is delivered
+type Publisher interface {
+	Publish(ctx context.Context, event repository.OutboxEvent) error
+	Close() error
+}
+
+// publishers
+const (
+	PublisherStdout  = "stdout"
+	PublisherFile    = "file"
+	PublisherWebhook = "webhook"
+)
+
+// NewPublisher returns the publisher of cfg.Publisher
+func NewPublisher(cfg config.OutboxConfig) (Publisher, error) {
+	switch cfg.Publisher {
+	case PublisherStdout, "":
+		return NewStdoutPublisher(), nil
+	case PublisherFile:
+		return NewFilePublisher(cfg.Path)
+	case PublisherWebhook:
+		return NewWebhookPublisher(cfg.URL, cfg.Timeout)
+	default:
+		return nil, fmt.Errorf("unknown outbox publisher %q", cfg.Publisher)
+	}
+}
+
+// Relay moves events from the outbox to the publisher. Events are published
+// in the order they were written and a failed event blocks the ones after it,
+// so consumers see changes of a user in order
+type Relay struct {
+	log       *slog.Logger
+	store     Store
+	publisher Publisher
+
+	interval  time.Duration
+	batchSize int
+}
+
+func NewRelay(log *slog.Logger, store Store, publisher Publisher, cfg config.OutboxConfig) *Relay {
+	r := &Relay{
+		log:       log.With(slog.String("component", "outbox")),
+		store:     store,
+		publisher: publisher,
+		interval:  cfg.Interval,
+		batchSize: cfg.BatchSize,
+	}
+	if r.interval <= 0 {
+		r.interval = time.Second
+	}
+	if r.batchSize <= 0 {
+		r.batchSize = 100
+	}
+
+	return r
+}
+
+// Run polls the outbox every interval until ctx is done,
+// a failed event is retried on the next poll
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.Drain(ctx); err != nil && ctx.Err() == nil {
+			r.log.Warn("error while relaying outbox events", sl.Err(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Drain publishes events until the outbox is empty or publishing fails
+func (r *Relay) Drain(ctx context.Context) error {
+	for {
+		n, err := r.store.RelayEvents(ctx, r.batchSize, func(event repository.OutboxEvent) error {
+			return r.publisher.Publish(ctx, event)
+		})
+		if err != nil {
+			return err
+		}
+		if n < r.batchSize {
+			return nil
+		}
+	}
+}
+
+// Publishers publishes every event to all of them, an event is published
+// when all of them published it, so a failed one makes the others receive it again
+type Publishers []Publisher
+
+func (p Publishers) Publish(ctx context.Context, event repository.OutboxEvent) error {
+	for _, publisher := range p {
+		if err := publisher.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p Publishers) Close() error {
+	errs := make([]error, 0, len(p))
+	for _, publisher := range p {
+		errs = append(errs, publisher.Close())
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,170 @@
+package outbox_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/lib/config"
+	"github.com/liriquew/test_task/internal/lib/tenant"
+	"github.com/liriquew/test_task/internal/outbox"
+	"github.com/liriquew/test_task/internal/repository"
+	"github.com/liriquew/test_task/internal/repository/memory"
+	"github.com/stretchr/testify/require"
+)
+
+func stubLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func newUser(username string) *domain.User {
+	return &domain.User{
+		Username: domain.NewOptString(username),
+		Password: domain.NewOptString("password"),
+		Email:    domain.NewOptString(username + "@mail.ru"),
+		IsAdmin:  domain.NewOptBool(false),
+	}
+}
+
+// flakyPublisher fails every event until fail is cleared
+type flakyPublisher struct {
+	fail   atomic.Bool
+	events []repository.OutboxEvent
+}
+
+func (p *flakyPublisher) Publish(_ context.Context, event repository.OutboxEvent) error {
+	if p.fail.Load() {
+		return errors.New("unavailable")
+	}
+	p.events = append(p.events, event)
+	return nil
+}
+
+func (p *flakyPublisher) Close() error {
+	return nil
+}
+
+func TestRelay(t *testing.T) {
+	ctx := tenant.WithTenant(context.Background(), tenant.Default)
+	repo := memory.New()
+
+	id, err := repo.CreateUser(ctx, newUser("username1"))
+	require.NoError(t, err)
+	require.NoError(t, repo.UpdateUser(ctx, &domain.User{
+		ID:      domain.NewOptUUID(*id),
+		IsAdmin: domain.NewOptBool(true),
+	}))
+
+	publisher := &flakyPublisher{}
+	relay := outbox.NewRelay(stubLogger(), repo, publisher, config.OutboxConfig{BatchSize: 1})
+
+	// nothing is lost while the publisher is down
+	publisher.fail.Store(true)
+	require.Error(t, relay.Drain(ctx))
+	require.Empty(t, publisher.events)
+
+	require.NoError(t, repo.DeleteUser(ctx, *id))
+
+	publisher.fail.Store(false)
+	require.NoError(t, relay.Drain(ctx))
+	require.Len(t, publisher.events, 3)
+	for i, event := range publisher.events {
+		require.Equal(t, []string{
+			repository.EventUserCreated,
+			repository.EventUserUpdated,
+			repository.EventUserDeleted,
+		}[i], event.Type)
+	}
+
+	// published events are not passed again
+	require.NoError(t, relay.Drain(ctx))
+	require.Len(t, publisher.events, 3)
+}
+
+func TestFilePublisher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+
+	publisher, err := outbox.NewPublisher(config.OutboxConfig{
+		Publisher: outbox.PublisherFile,
+		Path:      path,
+	})
+	require.NoError(t, err)
+
+	for i := range 2 {
+		require.NoError(t, publisher.Publish(context.Background(), repository.OutboxEvent{
+			ID:      int64(i + 1),
+			Type:    repository.EventUserDeleted,
+			Payload: json.RawMessage(`{}`),
+		}))
+	}
+	require.NoError(t, publisher.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	lines := bufio.NewScanner(bytes.NewReader(data))
+	var ids []int64
+	for lines.Scan() {
+		var event repository.OutboxEvent
+		require.NoError(t, json.Unmarshal(lines.Bytes(), &event))
+		ids = append(ids, event.ID)
+	}
+	require.Equal(t, []int64{1, 2}, ids)
+}
+
+func TestWebhookPublisher(t *testing.T) {
+	var status atomic.Int32
+	status.Store(http.StatusInternalServerError)
+
+	received := make(chan repository.OutboxEvent, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event repository.OutboxEvent
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+		require.Equal(t, strconv.FormatInt(event.ID, 10), r.Header.Get(outbox.EventIDHeader))
+		require.Equal(t, event.Type, r.Header.Get(outbox.EventTypeHeader))
+
+		w.WriteHeader(int(status.Load()))
+		if status.Load() == http.StatusNoContent {
+			received <- event
+		}
+	}))
+	defer srv.Close()
+
+	publisher, err := outbox.NewPublisher(config.OutboxConfig{
+		Publisher: outbox.PublisherWebhook,
+		URL:       srv.URL,
+		Timeout:   time.Second,
+	})
+	require.NoError(t, err)
+	defer publisher.Close()
+
+	event := repository.OutboxEvent{
+		ID:      7,
+		Type:    repository.EventUserCreated,
+		Payload: json.RawMessage(`{"username":"username1"}`),
+	}
+
+	require.Error(t, publisher.Publish(context.Background(), event))
+
+	status.Store(http.StatusNoContent)
+	require.NoError(t, publisher.Publish(context.Background(), event))
+	got := <-received
+	require.Equal(t, event.ID, got.ID)
+	require.JSONEq(t, string(event.Payload), string(got.Payload))
+
+	_, err = outbox.NewPublisher(config.OutboxConfig{Publisher: outbox.PublisherWebhook})
+	require.Error(t, err)
+}
@@ -0,0 +1,123 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/liriquew/test_task/internal/repository"
+)
+
+// WriterPublisher writes events to w as json lines
+type WriterPublisher struct {
+	mu sync.Mutex
+	w  io.Writer
+	// closer is the file of the writer, nil for stdout
+	closer io.Closer
+}
+
+func NewStdoutPublisher() *WriterPublisher {
+	return &WriterPublisher{w: os.Stdout}
+}
+
+// NewFilePublisher appends events to the file of path, the file is created if it doesn't exist
+func NewFilePublisher(path string) (*WriterPublisher, error) {
+	if path == "" {
+		return nil, fmt.Errorf("outbox file publisher: empty path")
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("outbox file publisher: %w", err)
+	}
+
+	return &WriterPublisher{w: f, closer: f}, nil
+}
+
+func (p *WriterPublisher) Publish(_ context.Context, event repository.OutboxEvent) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	_, err = p.w.Write(append(line, '\n'))
+	return err
+}
+
+func (p *WriterPublisher) Close() error {
+	if p.closer == nil {
+		return nil
+	}
+
+	return p.closer.Close()
+}
+
+// headers of webhook requests
+const (
+	// EventIDHeader identifies the event, a redelivered event has the same id
+	EventIDHeader   = "X-Event-ID"
+	EventTypeHeader = "X-Event-Type"
+)
+
+// WebhookPublisher posts every event as json to the URL,
+// an event is delivered when the response status is 2xx
+type WebhookPublisher struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookPublisher(rawURL string, timeout time.Duration) (*WebhookPublisher, error) {
+	if _, err := url.ParseRequestURI(rawURL); err != nil {
+		return nil, fmt.Errorf("outbox webhook publisher: %w", err)
+	}
+
+	return &WebhookPublisher{
+		url:    rawURL,
+		client: &http.Client{Timeout: timeout},
+	}, nil
+}
+
+func (p *WebhookPublisher) Publish(ctx context.Context, event repository.OutboxEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventIDHeader, strconv.FormatInt(event.ID, 10))
+	req.Header.Set(EventTypeHeader, event.Type)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// drain the body, so the connection is reused
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d to event %d", resp.StatusCode, event.ID)
+	}
+
+	return nil
+}
+
+func (p *WebhookPublisher) Close() error {
+	p.client.CloseIdleConnections()
+	return nil
+}
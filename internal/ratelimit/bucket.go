@@ -0,0 +1,62 @@
+package ratelimit
+
+import (
+	"math"
+	"time"
+)
+
+// Quota is a bucket of Burst requests refilled with Rate requests per second
+type Quota struct {
+	Rate  float64
+	Burst int
+}
+
+// Result is the state of a bucket after a take
+type Result struct {
+	Allowed   bool
+	Remaining int
+	// RetryAfter is the time until the taken tokens are available, zero if allowed
+	RetryAfter time.Duration
+	// Reset is the time until the bucket is full
+	Reset time.Duration
+}
+
+// Bucket is the token bucket of a key. A shared store keeps buckets of keys
+// and takes tokens from them the same way under its own lock
+type Bucket struct {
+	Tokens  float64
+	Updated time.Time
+}
+
+// NewBucket returns the full bucket of the quota
+func NewBucket(quota Quota, now time.Time) Bucket {
+	return Bucket{
+		Tokens:  float64(quota.Burst),
+		Updated: now,
+	}
+}
+
+// Take refills the bucket for the time since its update and takes n tokens if there
+// are enough of them. n = 0 only reports whether a request would be allowed
+func (b *Bucket) Take(quota Quota, n int, now time.Time) Result {
+	if elapsed := now.Sub(b.Updated); elapsed > 0 {
+		b.Tokens = math.Min(float64(quota.Burst), b.Tokens+elapsed.Seconds()*quota.Rate)
+		b.Updated = now
+	}
+
+	need := float64(max(n, 1))
+	res := Result{Allowed: b.Tokens >= need}
+	if res.Allowed {
+		b.Tokens -= float64(n)
+	} else {
+		res.RetryAfter = seconds((need - b.Tokens) / quota.Rate)
+	}
+	res.Remaining = int(b.Tokens)
+	res.Reset = seconds((float64(quota.Burst) - b.Tokens) / quota.Rate)
+
+	return res
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
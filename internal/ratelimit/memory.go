@@ -0,0 +1,61 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// how often full buckets are dropped from the memory store
+const sweepInterval = time.Minute
+
+// MemoryStore keeps buckets in the memory of the process, every instance
+// of the service limits its own requests
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*memoryBucket
+	swept   time.Time
+}
+
+type memoryBucket struct {
+	Bucket
+	// the bucket is full at full, it's the same as a missing bucket
+	full time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets: map[string]*memoryBucket{},
+	}
+}
+
+func (s *MemoryStore) Take(ctx context.Context, key string, quota Quota, n int, now time.Time) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.swept) >= sweepInterval {
+		s.sweep(now)
+	}
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &memoryBucket{Bucket: NewBucket(quota, now)}
+	}
+
+	res := b.Take(quota, n, now)
+	b.full = now.Add(res.Reset)
+	if !ok && n > 0 {
+		s.buckets[key] = b
+	}
+
+	return res, nil
+}
+
+func (s *MemoryStore) sweep(now time.Time) {
+	for key, b := range s.buckets {
+		if !b.full.After(now) {
+			delete(s.buckets, key)
+		}
+	}
+	s.swept = now
+}
@@ -0,0 +1,240 @@
+// Package ratelimit limits requests with token buckets. Requests of a user are limited
+// per operation by the quota of the role of the user, failed authentications are
+// limited per client ip and all authentications per client ip and username before the
+// credentials are checked, so a client guessing passwords doesn't keep bcrypt busy.
+// Buckets are kept by a Store, the memory store limits every instance of the service
+// separately, a shared store limits all of them
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/lib/config"
+	"github.com/liriquew/test_task/internal/service"
+	"github.com/liriquew/test_task/pkg/logger/sl"
+	"github.com/ogen-go/ogen/middleware"
+	"github.com/ogen-go/ogen/ogenerrors"
+)
+
+// Store keeps buckets of keys
+type Store interface {
+	// Take takes n tokens from the bucket of the key, a new bucket is full.
+	// n = 0 only reports whether a request would be allowed
+	Take(ctx context.Context, key string, quota Quota, n int, now time.Time) (Result, error)
+}
+
+// Error is returned by the authenticator to a client over its quota,
+// ErrorHandler writes it as 429
+type Error struct {
+	Quota  Quota
+	Result Result
+}
+
+func (e *Error) Error() string {
+	return "too many requests"
+}
+
+type Limiter struct {
+	log   *slog.Logger
+	store Store
+
+	user      Quota
+	admin     Quota
+	anonymous Quota
+	attempts  Quota
+
+	trustForwardedFor bool
+}
+
+func New(log *slog.Logger, store Store, cfg config.RateLimitConfig) *Limiter {
+	return &Limiter{
+		log:               log.With(slog.String("component", "ratelimit")),
+		store:             store,
+		user:              quota(cfg.User, Quota{Rate: 10, Burst: 20}),
+		admin:             quota(cfg.Admin, Quota{Rate: 50, Burst: 100}),
+		anonymous:         quota(cfg.Anonymous, Quota{Rate: 0.2, Burst: 10}),
+		attempts:          quota(cfg.Attempts, Quota{Rate: 50, Burst: 100}),
+		trustForwardedFor: cfg.TrustForwardedFor,
+	}
+}
+
+func quota(cfg config.RateLimitQuota, def Quota) Quota {
+	if cfg.Rate <= 0 || cfg.Burst <= 0 {
+		return def
+	}
+
+	return Quota{Rate: cfg.Rate, Burst: cfg.Burst}
+}
+
+type (
+	clientIPKey struct{}
+	headerKey   struct{}
+)
+
+// WithClientIP keeps the ip of the client in ctx for the authenticator
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPKey{}, ip)
+}
+
+// Wrap keeps the client ip and the header of the response in the context of the request,
+// the middleware reports the quota in the header
+func (l *Limiter) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := WithClientIP(r.Context(), l.clientIP(r))
+		ctx = context.WithValue(ctx, headerKey{}, w.Header())
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func (l *Limiter) clientIP(r *http.Request) string {
+	if l.trustForwardedFor {
+		first, _, _ := strings.Cut(r.Header.Get("X-Forwarded-For"), ",")
+		if ip := strings.TrimSpace(first); ip != "" {
+			return ip
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// Middleware limits requests of the authenticated user per operation, admins have
+// their own quota. Health isn't limited. Requests are allowed if the store fails
+func (l *Limiter) Middleware() middleware.Middleware {
+	return func(
+		req middleware.Request,
+		next middleware.Next,
+	) (middleware.Response, error) {
+		principal, ok := req.Context.Value(service.CurrentUser{}).(service.Principal)
+		if !ok || req.OperationName == domain.HealthOperation {
+			return next(req)
+		}
+
+		quota := l.user
+		if isAdmin, _ := req.Context.Value(service.IsAdmin{}).(bool); isAdmin {
+			quota = l.admin
+		}
+
+		key := "user:" + uuid.UUID(principal.TenantID).String() + "/" +
+			uuid.UUID(principal.ID).String() + ":" + req.OperationName
+		res, err := l.store.Take(req.Context, key, quota, 1, time.Now())
+		if err != nil {
+			l.log.Warn("error while taking rate limit token", sl.Err(err))
+			return next(req)
+		}
+
+		if header, ok := req.Context.Value(headerKey{}).(http.Header); ok {
+			SetHeaders(header, quota, res)
+		}
+		if !res.Allowed {
+			return middleware.Response{
+				Type: &domain.TooManyRequestsResponse{
+					Message: domain.TooManyRequestsResponseMessageTooManyRequests,
+				},
+			}, nil
+		}
+
+		return next(req)
+	}
+}
+
+// Authenticator limits failed authentications of sec per client ip and every
+// authentication per client ip and username. A client over a quota gets Error before
+// its credentials are checked. Requests without the client ip in the context aren't limited
+func (l *Limiter) Authenticator(sec domain.SecurityHandler) domain.SecurityHandler {
+	return &authenticator{limiter: l, next: sec}
+}
+
+type authenticator struct {
+	limiter *Limiter
+	next    domain.SecurityHandler
+}
+
+func (a *authenticator) HandleBasicAuth(
+	ctx context.Context,
+	operationName domain.OperationName,
+	t domain.BasicAuth,
+) (context.Context, error) {
+	ip, _ := ctx.Value(clientIPKey{}).(string)
+	if ip == "" {
+		return a.next.HandleBasicAuth(ctx, operationName, t)
+	}
+
+	l := a.limiter
+	key := "ip:" + ip
+	res, err := l.store.Take(ctx, key, l.anonymous, 0, time.Now())
+	if err != nil {
+		l.log.Warn("error while checking rate limit of authentication", sl.Err(err))
+	} else if !res.Allowed {
+		return ctx, &Error{Quota: l.anonymous, Result: res}
+	}
+
+	// a failure is known only after bcrypt, so concurrent attempts all pass the check
+	// above, every attempt of the username is charged before the credentials are checked
+	attemptKey := "auth:" + ip + ":" + strings.ToLower(t.Username)
+	res, err = l.store.Take(ctx, attemptKey, l.attempts, 1, time.Now())
+	if err != nil {
+		l.log.Warn("error while taking rate limit token of authentication", sl.Err(err))
+	} else if !res.Allowed {
+		return ctx, &Error{Quota: l.attempts, Result: res}
+	}
+
+	authCtx, err := a.next.HandleBasicAuth(ctx, operationName, t)
+	if errors.Is(err, service.ErrUnauthorized) {
+		if _, err := l.store.Take(ctx, key, l.anonymous, 1, time.Now()); err != nil {
+			l.log.Warn("error while taking rate limit token of authentication", sl.Err(err))
+		}
+	}
+
+	return authCtx, err
+}
+
+// ErrorHandler writes Error as 429, other errors are handled by next
+func ErrorHandler(next ogenerrors.ErrorHandler) ogenerrors.ErrorHandler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request, err error) {
+		var limited *Error
+		if !errors.As(err, &limited) {
+			next(ctx, w, r, err)
+			return
+		}
+
+		SetHeaders(w.Header(), limited.Quota, limited.Result)
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusTooManyRequests)
+
+		res := domain.TooManyRequestsResponse{
+			Message: domain.TooManyRequestsResponseMessageTooManyRequests,
+		}
+		b, _ := res.MarshalJSON()
+		w.Write(b)
+	}
+}
+
+// SetHeaders reports the quota and the state of its bucket in RateLimit headers,
+// Retry-After is set if the request isn't allowed
+func SetHeaders(header http.Header, quota Quota, res Result) {
+	header.Set("RateLimit-Limit", strconv.Itoa(quota.Burst))
+	header.Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+	header.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(res.Reset)))
+	if !res.Allowed {
+		header.Set("Retry-After", strconv.Itoa(max(ceilSeconds(res.RetryAfter), 1)))
+	}
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,217 @@
+package ratelimit_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/lib/config"
+	"github.com/liriquew/test_task/internal/ratelimit"
+	"github.com/liriquew/test_task/internal/service"
+	"github.com/ogen-go/ogen/middleware"
+	"github.com/ogen-go/ogen/ogenerrors"
+	"github.com/stretchr/testify/require"
+)
+
+func stubLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestBucket(t *testing.T) {
+	quota := ratelimit.Quota{Rate: 2, Burst: 3}
+	now := time.Now()
+	b := ratelimit.NewBucket(quota, now)
+
+	for i := 2; i >= 0; i-- {
+		res := b.Take(quota, 1, now)
+		require.True(t, res.Allowed)
+		require.Equal(t, i, res.Remaining)
+	}
+
+	res := b.Take(quota, 1, now)
+	require.False(t, res.Allowed)
+	require.Equal(t, 500*time.Millisecond, res.RetryAfter)
+	require.Equal(t, 1500*time.Millisecond, res.Reset)
+
+	// n = 0 doesn't take tokens
+	now = now.Add(500 * time.Millisecond)
+	require.True(t, b.Take(quota, 0, now).Allowed)
+	require.True(t, b.Take(quota, 0, now).Allowed)
+	require.True(t, b.Take(quota, 1, now).Allowed)
+	require.False(t, b.Take(quota, 0, now).Allowed)
+
+	// the bucket isn't refilled over the burst
+	now = now.Add(time.Hour)
+	res = b.Take(quota, 1, now)
+	require.Equal(t, 2, res.Remaining)
+}
+
+// authenticated runs the middleware for a request of the user
+func authenticated(
+	t *testing.T,
+	limiter *ratelimit.Limiter,
+	id domain.UUID,
+	isAdmin bool,
+	operation string,
+) (http.Header, any) {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	var ctx context.Context
+	limiter.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx = r.Context()
+	})).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	ctx = context.WithValue(ctx, service.CurrentUser{}, service.Principal{ID: id})
+	ctx = context.WithValue(ctx, service.IsAdmin{}, isAdmin)
+	res, err := limiter.Middleware()(
+		middleware.Request{Context: ctx, OperationName: operation},
+		func(req middleware.Request) (middleware.Response, error) {
+			return middleware.Response{Type: "ok"}, nil
+		},
+	)
+	require.NoError(t, err)
+
+	return w.Header(), res.Type
+}
+
+func TestMiddleware(t *testing.T) {
+	limiter := ratelimit.New(stubLogger(), ratelimit.NewMemoryStore(), config.RateLimitConfig{
+		User:  config.RateLimitQuota{Rate: 0.001, Burst: 2},
+		Admin: config.RateLimitQuota{Rate: 0.001, Burst: 3},
+	})
+	tooMany := &domain.TooManyRequestsResponse{
+		Message: domain.TooManyRequestsResponseMessageTooManyRequests,
+	}
+
+	header, res := authenticated(t, limiter, domain.UUID{1}, false, domain.ServiceListUsersOperation)
+	require.Equal(t, "ok", res)
+	require.Equal(t, "2", header.Get("RateLimit-Limit"))
+	require.Equal(t, "1", header.Get("RateLimit-Remaining"))
+	require.Empty(t, header.Get("Retry-After"))
+
+	_, res = authenticated(t, limiter, domain.UUID{1}, false, domain.ServiceListUsersOperation)
+	require.Equal(t, "ok", res)
+	header, res = authenticated(t, limiter, domain.UUID{1}, false, domain.ServiceListUsersOperation)
+	require.Equal(t, tooMany, res)
+	require.Equal(t, "0", header.Get("RateLimit-Remaining"))
+	require.NotEmpty(t, header.Get("Retry-After"))
+
+	// operations and users have their own buckets
+	_, res = authenticated(t, limiter, domain.UUID{1}, false, domain.ServiceGetUserOperation)
+	require.Equal(t, "ok", res)
+	_, res = authenticated(t, limiter, domain.UUID{2}, false, domain.ServiceListUsersOperation)
+	require.Equal(t, "ok", res)
+
+	// admins have their own quota
+	for range 3 {
+		header, res = authenticated(t, limiter, domain.UUID{3}, true, domain.ServiceListUsersOperation)
+		require.Equal(t, "ok", res)
+	}
+	require.Equal(t, "3", header.Get("RateLimit-Limit"))
+	_, res = authenticated(t, limiter, domain.UUID{3}, true, domain.ServiceListUsersOperation)
+	require.Equal(t, tooMany, res)
+
+	// health isn't limited
+	for range 3 {
+		_, res = authenticated(t, limiter, domain.UUID{1}, false, domain.HealthOperation)
+		require.Equal(t, "ok", res)
+	}
+}
+
+type stubSecurity struct {
+	calls int
+}
+
+func (s *stubSecurity) HandleBasicAuth(
+	ctx context.Context,
+	operationName domain.OperationName,
+	t domain.BasicAuth,
+) (context.Context, error) {
+	s.calls++
+	if t.Password != "Passw0rdAdmin" {
+		return ctx, service.ErrUnauthorized
+	}
+	return ctx, nil
+}
+
+func TestAuthenticator(t *testing.T) {
+	limiter := ratelimit.New(stubLogger(), ratelimit.NewMemoryStore(), config.RateLimitConfig{
+		Anonymous: config.RateLimitQuota{Rate: 0.001, Burst: 2},
+	})
+	sec := &stubSecurity{}
+	auth := limiter.Authenticator(sec)
+
+	login := func(ip, password string) error {
+		ctx := ratelimit.WithClientIP(context.Background(), ip)
+		_, err := auth.HandleBasicAuth(ctx, domain.ServiceListUsersOperation, domain.BasicAuth{
+			Username: "administrator",
+			Password: password,
+		})
+		return err
+	}
+
+	// successful authentications aren't counted
+	for range 3 {
+		require.NoError(t, login("10.0.0.1", "Passw0rdAdmin"))
+	}
+	require.ErrorIs(t, login("10.0.0.1", "wrong"), service.ErrUnauthorized)
+	require.ErrorIs(t, login("10.0.0.1", "wrong"), service.ErrUnauthorized)
+
+	// the credentials aren't checked over the quota, even the right ones
+	calls := sec.calls
+	err := login("10.0.0.1", "Passw0rdAdmin")
+	var limited *ratelimit.Error
+	require.ErrorAs(t, err, &limited)
+	require.Equal(t, calls, sec.calls)
+
+	require.NoError(t, login("10.0.0.2", "Passw0rdAdmin"))
+	// requests without the client ip aren't limited
+	require.NoError(t, login("", "Passw0rdAdmin"))
+
+	w := httptest.NewRecorder()
+	ratelimit.ErrorHandler(ogenerrors.DefaultErrorHandler)(
+		context.Background(), w, httptest.NewRequest(http.MethodGet, "/", nil),
+		&ogenerrors.SecurityError{Security: "BasicAuth", Err: err},
+	)
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	require.Equal(t, "2", w.Header().Get("RateLimit-Limit"))
+	require.NotEmpty(t, w.Header().Get("Retry-After"))
+	require.JSONEq(t, `{"message":"too many requests"}`, w.Body.String())
+}
+
+func TestAuthenticatorAttempts(t *testing.T) {
+	limiter := ratelimit.New(stubLogger(), ratelimit.NewMemoryStore(), config.RateLimitConfig{
+		Attempts: config.RateLimitQuota{Rate: 0.001, Burst: 2},
+	})
+	sec := &stubSecurity{}
+	auth := limiter.Authenticator(sec)
+
+	login := func(ip, username string) error {
+		ctx := ratelimit.WithClientIP(context.Background(), ip)
+		_, err := auth.HandleBasicAuth(ctx, domain.ServiceListUsersOperation, domain.BasicAuth{
+			Username: username,
+			Password: "wrong",
+		})
+		return err
+	}
+
+	require.ErrorIs(t, login("10.0.0.1", "administrator"), service.ErrUnauthorized)
+	require.ErrorIs(t, login("10.0.0.1", "Administrator"), service.ErrUnauthorized)
+
+	// attempts are charged before the credentials are checked
+	calls := sec.calls
+	var limited *ratelimit.Error
+	require.ErrorAs(t, login("10.0.0.1", "administrator"), &limited)
+	require.Equal(t, 2, limited.Quota.Burst)
+	require.Equal(t, calls, sec.calls)
+
+	// the bucket is kept per client ip and username
+	require.ErrorIs(t, login("10.0.0.1", "username1"), service.ErrUnauthorized)
+	require.ErrorIs(t, login("10.0.0.2", "administrator"), service.ErrUnauthorized)
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	domain "github.com/liriquew/test_task/internal/domain"
+)
+
+// adminsLock is the class of advisory locks of admins, one lock per organization
+const adminsLock = 7_270_300
+
+// LockAdmins returns ids of users with admin permissions, granted directly
+// or through an admin group. The advisory lock of the organization is held until
+// the end of the transaction, so concurrent demotions are serialized, whether
+// permissions are granted directly or by groups. Rows of direct admins are locked too
+func (s *Repository) LockAdmins(ctx context.Context) ([]domain.UUID, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	query := `SELECT pg_advisory_xact_lock($1, hashtext($2))`
+
+	if _, err := s.q.ExecContext(ctx, query, adminsLock, tenantID(ctx)); err != nil {
+		return nil, err
+	}
+
+	var direct []uuid.UUID
+
+	query = `
+		SELECT id FROM users
+		WHERE tenant_id = $1 AND is_admin
+		ORDER BY id
+		FOR UPDATE
+	`
+
+	if err := s.q.SelectContext(ctx, &direct, query, tenantID(ctx)); err != nil {
+		return nil, err
+	}
+
+	var granted []uuid.UUID
+
+	// members of admin groups, including members of nested groups
+	query = `
+		WITH RECURSIVE admin_groups(id) AS (
+			SELECT id FROM groups
+			WHERE tenant_id = $1 AND is_admin
+			UNION
+			SELECT gm.member_group_id FROM group_members gm
+			JOIN admin_groups ON gm.group_id = admin_groups.id
+			WHERE gm.member_group_id IS NOT NULL
+		)
+		SELECT DISTINCT gm.user_id FROM group_members gm
+		JOIN admin_groups ON gm.group_id = admin_groups.id
+		WHERE gm.user_id IS NOT NULL
+	`
+
+	if err := s.q.SelectContext(ctx, &granted, query, tenantID(ctx)); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[uuid.UUID]bool, len(direct)+len(granted))
+	res := make([]domain.UUID, 0, len(direct)+len(granted))
+	for _, id := range append(direct, granted...) {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		res = append(res, domain.UUID(id))
+	}
+
+	return res, nil
+}
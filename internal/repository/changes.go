@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// ChangesChannel is notified on commit of a transaction that appended events
+const ChangesChannel = "user_changes"
+
+// ListChanges returns up to limit events of the log of changes of all organizations
+// with ids greater than after, in id order
+func (s *Repository) ListChanges(ctx context.Context, after int64, limit int) ([]OutboxEvent, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT * FROM user_changes
+		WHERE id > $1
+		ORDER BY id
+		LIMIT $2
+	`
+
+	var events []OutboxEvent
+	if err := s.q.SelectContext(ctx, &events, query, after, limit); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// LastChangeID returns the id of the latest event of the log, 0 if it is empty
+func (s *Repository) LastChangeID(ctx context.Context) (int64, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	var id int64
+	if err := s.q.GetContext(ctx, &id, `SELECT COALESCE(max(id), 0) FROM user_changes`); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// PruneChanges deletes events of the log except the latest keep ones,
+// returns the number of deleted events
+func (s *Repository) PruneChanges(ctx context.Context, keep int) (int64, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	query := `
+		DELETE FROM user_changes
+		WHERE id <= (
+			SELECT id FROM user_changes
+			ORDER BY id DESC
+			OFFSET $1
+			LIMIT 1
+		)
+	`
+
+	res, err := s.q.ExecContext(ctx, query, keep)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// interval of pings of the listener connection, a broken connection
+// is noticed by a ping and reestablished
+const listenerPingInterval = 90 * time.Second
+
+// ListenChanges calls notify on every notification of ChangesChannel until ctx is done.
+// Notifications sent while the connection was lost are missed, notify is called
+// after the connection is reestablished as well
+func (s *Repository) ListenChanges(ctx context.Context, notify func()) error {
+	listener := pq.NewListener(s.dsn, time.Second, time.Minute, nil)
+	defer listener.Close()
+
+	if err := listener.Listen(ChangesChannel); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(listenerPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-listener.Notify:
+			// nil after reconnection
+			notify()
+		case <-ticker.C:
+			go listener.Ping()
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/liriquew/test_task/internal/lib/config"
+)
+
+// ConfigurePool applies pool limits of cfg, zero values keep the defaults of database/sql
+func ConfigurePool(db *sql.DB, cfg config.StorageConfig) {
+	if cfg.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(cfg.MaxOpenConns)
+	}
+	if cfg.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(cfg.MaxIdleConns)
+	}
+	if cfg.ConnMaxLifetime > 0 {
+		db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	}
+	if cfg.ConnMaxIdleTime > 0 {
+		db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+	}
+}
+
+const (
+	pingBackoff    = 500 * time.Millisecond
+	maxPingBackoff = 10 * time.Second
+)
+
+// Ping checks the connection up to attempts times, the delay between
+// attempts doubles, so the service waits for a database started along with it
+func Ping(ctx context.Context, db *sql.DB, attempts int) error {
+	backoff := pingBackoff
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = db.PingContext(ctx); err == nil {
+			return nil
+		}
+		if attempt >= attempts {
+			return fmt.Errorf("error while try to ping db, %d attempts: %w", attempt, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("error while try to ping db: %w", err)
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, maxPingBackoff)
+	}
+}
+
+// queryContext bounds a single repository call with the query timeout
+func (s *Repository) queryContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.timeout <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, s.timeout)
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/liriquew/test_task/internal/lib/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDSN(t *testing.T) {
+	dsn := DSN(config.StorageConfig{
+		Username:         "user",
+		Password:         "p@ss/word",
+		Host:             "localhost",
+		Port:             5432,
+		DBName:           "users_db",
+		SSLMode:          "verify-full",
+		SSLRootCert:      "/etc/ssl/ca.pem",
+		ApplicationName:  "user service",
+		ConnectTimeout:   1500 * time.Millisecond,
+		StatementTimeout: 10 * time.Second,
+	})
+
+	parsed, err := url.Parse(dsn)
+	require.NoError(t, err)
+
+	password, _ := parsed.User.Password()
+	assert.Equal(t, "p@ss/word", password)
+	assert.Equal(t, "localhost:5432", parsed.Host)
+	assert.Equal(t, "/users_db", parsed.Path)
+
+	params := parsed.Query()
+	assert.Equal(t, "verify-full", params.Get("sslmode"))
+	assert.Equal(t, "/etc/ssl/ca.pem", params.Get("sslrootcert"))
+	assert.Equal(t, "user service", params.Get("application_name"))
+	assert.Equal(t, "2", params.Get("connect_timeout"))
+	assert.Equal(t, "10000", params.Get("statement_timeout"))
+	assert.False(t, params.Has("sslcert"))
+	assert.False(t, params.Has("sslkey"))
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	domain "github.com/liriquew/test_task/internal/domain"
+)
+
+// rows fetched from the export cursor per round trip
+const exportFetchSize = 1000
+
+// ExportUsers passes every user matching the filter to fn ordered by id,
+// starting after the cursor id if it is set. Rows are read through a server-side
+// cursor in a read only transaction, so the export sees a consistent snapshot
+// and doesn't hold the whole table in memory. Passwords are never selected.
+// The query timeout bounds every round trip, not the whole export.
+// The export reads from a replica if there is a healthy one, it falls back
+// to the primary only if the replica fails before the first user is passed to fn
+func (s *Repository) ExportUsers(
+	ctx context.Context,
+	filter UserFilter,
+	cursor domain.OptUUID,
+	fn func(domain.User) error,
+) error {
+	if rep := s.replica(ctx); rep != nil {
+		started := false
+		err := rep.repo.exportUsers(ctx, filter, cursor, func(user domain.User) error {
+			started = true
+			return fn(user)
+		})
+		if started || !unavailable(ctx, err) {
+			return err
+		}
+		rep.healthy.Store(false)
+	}
+
+	return s.exportUsers(ctx, filter, cursor, fn)
+}
+
+func (s *Repository) exportUsers(
+	ctx context.Context,
+	filter UserFilter,
+	cursor domain.OptUUID,
+	fn func(domain.User) error,
+) error {
+	tx, err := s.beginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelRepeatableRead,
+		ReadOnly:  true,
+	})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		DECLARE users_export NO SCROLL CURSOR FOR
+		SELECT id, username, email, is_admin, tenant_id FROM users
+		WHERE tenant_id = $1%s%s
+		ORDER BY id
+	`
+
+	conditions, args := filter.conditions([]any{tenantID(ctx)})
+	after := ""
+	if cursor.IsSet() {
+		args = append(args, UUID(cursor.Value))
+		after = fmt.Sprintf(" AND id > $%d", len(args))
+	}
+	query = fmt.Sprintf(query, conditions, after)
+
+	declareCtx, cancel := s.queryContext(ctx)
+	_, err = tx.ExecContext(declareCtx, query, args...)
+	cancel()
+	if err != nil {
+		return err
+	}
+
+	fetch := fmt.Sprintf(`FETCH %d FROM users_export`, exportFetchSize)
+	users := make([]DBUser, 0, exportFetchSize)
+	for {
+		users = users[:0]
+		fetchCtx, cancel := s.queryContext(ctx)
+		err := tx.SelectContext(fetchCtx, &users, fetch)
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		for _, dbUser := range users {
+			user := ConvertDBUserToUser(dbUser)
+			user.Password.Reset()
+
+			if err := fn(user); err != nil {
+				return err
+			}
+		}
+
+		if len(users) < exportFetchSize {
+			break
+		}
+	}
+
+	// read only transaction, commit just releases the cursor
+	return tx.Commit()
+}
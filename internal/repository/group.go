@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	domain "github.com/liriquew/test_task/internal/domain"
+)
+
+type DBGroup struct {
+	ID          uuid.UUID `db:"id"`
+	Name        string    `db:"name"`
+	Description string    `db:"description"`
+	IsAdmin     bool      `db:"is_admin"`
+	TenantID    uuid.UUID `db:"tenant_id"`
+}
+
+// member is either a user or a nested group, the other one is NULL
+type DBGroupMember struct {
+	UserID  uuid.NullUUID `db:"user_id"`
+	GroupID uuid.NullUUID `db:"member_group_id"`
+}
+
+// ConvertDBGroupToGroup преобразует DBGroup в Group.
+func ConvertDBGroupToGroup(dbGroup DBGroup) domain.Group {
+	return domain.Group{
+		ID:          domain.NewOptUUID(domain.UUID(dbGroup.ID)),
+		Name:        domain.NewOptString(dbGroup.Name),
+		Description: domain.NewOptString(dbGroup.Description),
+		IsAdmin:     domain.NewOptBool(dbGroup.IsAdmin),
+	}
+}
+
+// ConvertDBGroupMemberToGroupMember преобразует DBGroupMember в GroupMember.
+func ConvertDBGroupMemberToGroupMember(dbMember DBGroupMember) domain.GroupMember {
+	member := domain.GroupMember{}
+
+	if dbMember.UserID.Valid {
+		member.UserID = domain.NewOptUUID(domain.UUID(dbMember.UserID.UUID))
+	}
+	if dbMember.GroupID.Valid {
+		member.GroupID = domain.NewOptUUID(domain.UUID(dbMember.GroupID.UUID))
+	}
+
+	return member
+}
+
+func nullUUID(id domain.OptUUID) uuid.NullUUID {
+	return uuid.NullUUID{
+		UUID:  uuid.UUID(id.Value),
+		Valid: id.IsSet(),
+	}
+}
@@ -0,0 +1,369 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	domain "github.com/liriquew/test_task/internal/domain"
+)
+
+const (
+	groupNameConstraint       = "groups_tenant_name_key"
+	memberUserConstraint      = "group_members_user_key"
+	memberGroupConstraint     = "group_members_group_key"
+	memberUserFKConstraint    = "group_members_user_id_fkey"
+	memberGroupIDFKConstraint = "group_members_group_id_fkey"
+	memberNestedFKConstraint  = "group_members_member_group_id_fkey"
+)
+
+func (s *Repository) ListGroups(ctx context.Context, offset int64) ([]domain.Group, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	var groups []DBGroup
+
+	query := `
+		SELECT * FROM groups
+		WHERE tenant_id = $1
+		ORDER BY id
+		OFFSET $2
+		LIMIT 10
+	`
+
+	if err := s.q.SelectContext(ctx, &groups, query, tenantID(ctx), offset); err != nil {
+		return nil, err
+	}
+
+	res := make([]domain.Group, 0, len(groups))
+	for _, group := range groups {
+		res = append(res, ConvertDBGroupToGroup(group))
+	}
+
+	return res, nil
+}
+
+func (s *Repository) CreateGroup(ctx context.Context, group *domain.Group) (*domain.UUID, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO groups (name, description, is_admin, tenant_id) VALUES
+		($1, $2, $3, $4) RETURNING id
+	`
+
+	var id uuid.UUID
+	err := s.q.QueryRowContext(ctx, query,
+		group.Name.Value,
+		group.Description.Value,
+		group.IsAdmin.Value,
+		tenantID(ctx),
+	).Scan(&id)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			if pqErr.Code == "23505" && pqErr.Constraint == groupNameConstraint {
+				return nil, ErrGroupNameExists
+			}
+		}
+		return nil, err
+	}
+
+	res := domain.UUID(id)
+
+	return &res, nil
+}
+
+func (s *Repository) GetGroupById(ctx context.Context, id domain.UUID) (*domain.Group, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT * FROM groups
+		WHERE id = $1 AND tenant_id = $2
+	`
+
+	group := DBGroup{}
+	err := s.q.GetContext(ctx, &group, query, UUID(id), tenantID(ctx))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrGroupNotFound
+		}
+		return nil, err
+	}
+
+	res := ConvertDBGroupToGroup(group)
+
+	return &res, nil
+}
+
+func (s *Repository) UpdateGroup(ctx context.Context, group *domain.Group) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE groups SET %s WHERE id=$%d AND tenant_id=$%d
+	`
+
+	queryParams, args, err := s.buildGroupUpdate(group)
+	if err != nil {
+		return err
+	}
+	args = append(args, UUID(group.ID.Value), tenantID(ctx))
+
+	query = fmt.Sprintf(query, queryParams, len(args)-1, len(args))
+
+	result, err := s.q.ExecContext(ctx, query, args...)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			if pqErr.Code == "23505" && pqErr.Constraint == groupNameConstraint {
+				return ErrGroupNameExists
+			}
+		}
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrGroupNotFound
+	}
+
+	return nil
+}
+
+func (s *Repository) DeleteGroup(ctx context.Context, id domain.UUID) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	query := `
+		DELETE FROM groups
+		WHERE id=$1 AND tenant_id=$2
+	`
+
+	_, err := s.q.ExecContext(ctx, query, UUID(id), tenantID(ctx))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *Repository) ListGroupMembers(ctx context.Context, groupID domain.UUID) ([]domain.GroupMember, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	var members []DBGroupMember
+
+	query := `
+		SELECT gm.user_id, gm.member_group_id FROM group_members gm
+		JOIN groups g ON g.id = gm.group_id
+		WHERE gm.group_id = $1 AND g.tenant_id = $2
+		ORDER BY gm.user_id, gm.member_group_id
+	`
+
+	if err := s.q.SelectContext(ctx, &members, query, UUID(groupID), tenantID(ctx)); err != nil {
+		return nil, err
+	}
+
+	res := make([]domain.GroupMember, 0, len(members))
+	for _, member := range members {
+		res = append(res, ConvertDBGroupMemberToGroupMember(member))
+	}
+
+	return res, nil
+}
+
+func (s *Repository) AddGroupMember(ctx context.Context, groupID domain.UUID, member *domain.GroupMember) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	tx, err := s.beginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// group and member must belong to the same organization
+	groupExists := `SELECT EXISTS (SELECT 1 FROM groups WHERE id = $1 AND tenant_id = $2)`
+	userExists := `SELECT EXISTS (SELECT 1 FROM users WHERE id = $1 AND tenant_id = $2)`
+
+	var exists bool
+	if err := tx.GetContext(ctx, &exists, groupExists, UUID(groupID), tenantID(ctx)); err != nil {
+		return err
+	}
+	if !exists {
+		return ErrGroupNotFound
+	}
+
+	if member.UserID.IsSet() {
+		if err := tx.GetContext(ctx, &exists, userExists, UUID(member.UserID.Value), tenantID(ctx)); err != nil {
+			return err
+		}
+		if !exists {
+			return ErrNotFound
+		}
+	}
+
+	if member.GroupID.IsSet() {
+		if err := tx.GetContext(ctx, &exists, groupExists, UUID(member.GroupID.Value), tenantID(ctx)); err != nil {
+			return err
+		}
+		if !exists {
+			return ErrGroupNotFound
+		}
+
+		// nested groups are added one at a time, otherwise two concurrent
+		// inserts (a into b and b into a) can both pass the cycle check
+		if _, err := tx.ExecContext(ctx, `LOCK TABLE group_members IN SHARE ROW EXCLUSIVE MODE`); err != nil {
+			return err
+		}
+
+		// the group must not be reachable from the nested group
+		query := `
+			WITH RECURSIVE nested(id) AS (
+				SELECT $1::uuid
+				UNION
+				SELECT gm.member_group_id FROM group_members gm
+				JOIN nested ON gm.group_id = nested.id
+				WHERE gm.member_group_id IS NOT NULL
+			)
+			SELECT EXISTS (SELECT 1 FROM nested WHERE id = $2)
+		`
+
+		var cycle bool
+		if err := tx.GetContext(ctx, &cycle, query, UUID(member.GroupID.Value), UUID(groupID)); err != nil {
+			return err
+		}
+		if cycle {
+			return ErrGroupCycle
+		}
+	}
+
+	query := `
+		INSERT INTO group_members (group_id, user_id, member_group_id) VALUES
+		($1, $2, $3)
+	`
+
+	_, err = tx.ExecContext(ctx, query,
+		UUID(groupID),
+		nullUUID(member.UserID),
+		nullUUID(member.GroupID),
+	)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code {
+			case "23505":
+				switch pqErr.Constraint {
+				case memberUserConstraint, memberGroupConstraint:
+					return ErrMemberExists
+				}
+			case "23503":
+				switch pqErr.Constraint {
+				case memberUserFKConstraint:
+					return ErrNotFound
+				case memberGroupIDFKConstraint, memberNestedFKConstraint:
+					return ErrGroupNotFound
+				}
+			}
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *Repository) RemoveGroupMember(ctx context.Context, groupID, memberID domain.UUID) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	query := `
+		DELETE FROM group_members gm
+		USING groups g
+		WHERE g.id = gm.group_id AND g.tenant_id = $3
+		AND gm.group_id = $1 AND (gm.user_id = $2 OR gm.member_group_id = $2)
+	`
+
+	result, err := s.q.ExecContext(ctx, query, UUID(groupID), UUID(memberID), tenantID(ctx))
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrMemberNotFound
+	}
+
+	return nil
+}
+
+// GetUserGroups returns groups the user is a member of,
+// directly or through nested groups
+func (s *Repository) GetUserGroups(ctx context.Context, userID domain.UUID) ([]domain.Group, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	var groups []DBGroup
+
+	// UNION (not UNION ALL) drops already visited groups,
+	// so the recursion stops even on a broken (cyclic) graph
+	query := `
+		WITH RECURSIVE user_groups(id) AS (
+			SELECT group_id FROM group_members
+			WHERE user_id = $1
+			UNION
+			SELECT gm.group_id FROM group_members gm
+			JOIN user_groups ON gm.member_group_id = user_groups.id
+		)
+		SELECT g.* FROM groups g
+		JOIN user_groups ON g.id = user_groups.id
+		WHERE g.tenant_id = $2
+		ORDER BY g.name
+	`
+
+	if err := s.q.SelectContext(ctx, &groups, query, UUID(userID), tenantID(ctx)); err != nil {
+		return nil, err
+	}
+
+	res := make([]domain.Group, 0, len(groups))
+	for _, group := range groups {
+		res = append(res, ConvertDBGroupToGroup(group))
+	}
+
+	return res, nil
+}
+
+func (s *Repository) buildGroupUpdate(group *domain.Group) (queryParams string, args []any, err error) {
+	sb := strings.Builder{}
+
+	if group.Name.IsSet() {
+		args = append(args, group.Name.Value)
+		sb.WriteString(fmt.Sprintf("name=$%d, ", len(args)))
+	}
+	if group.Description.IsSet() {
+		args = append(args, group.Description.Value)
+		sb.WriteString(fmt.Sprintf("description=$%d, ", len(args)))
+	}
+	if group.IsAdmin.IsSet() {
+		args = append(args, group.IsAdmin.Value)
+		sb.WriteString(fmt.Sprintf("is_admin=$%d, ", len(args)))
+	}
+
+	if len(args) == 0 {
+		return "", nil, ErrEmptyUpdate
+	}
+
+	queryParams = sb.String()
+	// remove last ", "
+	queryParams = queryParams[:len(queryParams)-2]
+
+	return
+}
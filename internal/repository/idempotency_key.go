@@ -0,0 +1,23 @@
+package repository
+
+import "time"
+
+// IdempotencyRecord is a request with the Idempotency-Key header and its response
+type IdempotencyRecord struct {
+	// Scope is the hash of credentials of the request, clients can't see responses of each other
+	Scope string `db:"scope"`
+	Key   string `db:"request_key"`
+	// Fingerprint is the hash of the request, a key is replayed only for the same request
+	Fingerprint string `db:"fingerprint"`
+	// Status of the response, zero while the request is in progress
+	Status int32 `db:"status"`
+	// Header of the response as json
+	Header    string    `db:"header"`
+	Body      []byte    `db:"body"`
+	ExpiresAt time.Time `db:"expires_at"`
+}
+
+// InProgress reports whether the request of the key has no response yet
+func (r IdempotencyRecord) InProgress() bool {
+	return r.Status == 0
+}
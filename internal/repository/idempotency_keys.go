@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// ClaimIdempotencyKey stores the record of a request in progress unless the key of the scope
+// is stored and not expired at now, the stored record is returned then and nil otherwise.
+// Concurrent requests with the same key are serialized by the primary key, only one claims it
+func (s *Repository) ClaimIdempotencyKey(ctx context.Context, record IdempotencyRecord, now time.Time) (*IdempotencyRecord, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO idempotency_keys (scope, request_key, fingerprint, expires_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (scope, request_key) DO UPDATE
+		SET fingerprint = EXCLUDED.fingerprint, status = 0, header = '', body = NULL,
+			expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= $5
+	`
+
+	// the stored key may expire and be deleted between the statements
+	for {
+		res, err := s.q.ExecContext(ctx, query, record.Scope, record.Key, record.Fingerprint, record.ExpiresAt, now)
+		if err != nil {
+			return nil, err
+		}
+		if claimed, err := res.RowsAffected(); err != nil || claimed == 1 {
+			return nil, err
+		}
+
+		var stored IdempotencyRecord
+		err = s.q.GetContext(ctx, &stored, `
+			SELECT * FROM idempotency_keys WHERE scope = $1 AND request_key = $2
+		`, record.Scope, record.Key)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return &stored, nil
+	}
+}
+
+// CompleteIdempotencyKey stores the fingerprint of the request and the response of the claimed key
+func (s *Repository) CompleteIdempotencyKey(ctx context.Context, record IdempotencyRecord) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE idempotency_keys
+		SET fingerprint = $1, status = $2, header = $3, body = $4, expires_at = $5
+		WHERE scope = $6 AND request_key = $7 AND status = 0
+	`
+
+	_, err := s.q.ExecContext(ctx, query,
+		record.Fingerprint, record.Status, record.Header, record.Body, record.ExpiresAt,
+		record.Scope, record.Key,
+	)
+	return err
+}
+
+// ReleaseIdempotencyKey deletes the claimed key without a response, so the request can be retried
+func (s *Repository) ReleaseIdempotencyKey(ctx context.Context, scope, key string) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	_, err := s.q.ExecContext(ctx, `
+		DELETE FROM idempotency_keys WHERE scope = $1 AND request_key = $2 AND status = 0
+	`, scope, key)
+	return err
+}
+
+// DeleteExpiredIdempotencyKeys deletes keys expired at now, returns the number of deleted keys
+func (s *Repository) DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int64, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	res, err := s.q.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE expires_at <= $1`, now)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	domain "github.com/liriquew/test_task/internal/domain"
+)
+
+// ImportUsers writes a batch of users in a single transaction, a failed row
+// doesn't abort the batch
+func (s *Repository) ImportUsers(
+	ctx context.Context,
+	users []domain.User,
+	onConflict domain.ServiceImportUsersOnConflict,
+) ([]domain.ImportRowResult, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	tx, err := s.beginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	results := make([]domain.ImportRowResult, 0, len(users))
+	for i := range users {
+		// savepoint keeps the transaction usable after a constraint violation
+		if _, err := tx.ExecContext(ctx, `SAVEPOINT import_row`); err != nil {
+			return nil, err
+		}
+
+		res, err := importUser(ctx, tx, &users[i], onConflict)
+		if err != nil {
+			if !errors.Is(err, ErrUsernameExists) && !errors.Is(err, ErrEmailExists) {
+				return nil, err
+			}
+			if _, err := tx.ExecContext(ctx, `ROLLBACK TO SAVEPOINT import_row`); err != nil {
+				return nil, err
+			}
+
+			res = domain.ImportRowResult{
+				Status: domain.ImportRowResultStatusFailed,
+				Error:  domain.NewOptString(err.Error()),
+			}
+		}
+
+		if _, err := tx.ExecContext(ctx, `RELEASE SAVEPOINT import_row`); err != nil {
+			return nil, err
+		}
+
+		results = append(results, res)
+	}
+
+	return results, tx.Commit()
+}
+
+func importUser(
+	ctx context.Context,
+	tx queryer,
+	user *domain.User,
+	onConflict domain.ServiceImportUsersOnConflict,
+) (domain.ImportRowResult, error) {
+	query := `
+		INSERT INTO users (username, email, password, is_admin, tenant_id) VALUES
+		($1, $2, $3, $4, $5)
+	`
+	args := []any{
+		user.Username.Value,
+		user.Email.Value,
+		user.Password.Value,
+		user.IsAdmin.Value,
+		tenantID(ctx),
+	}
+	switch onConflict {
+	case domain.ServiceImportUsersOnConflictSkip:
+		query += ` ON CONFLICT DO NOTHING`
+	case domain.ServiceImportUsersOnConflictUpdate:
+		// existing user is matched by username, email of another user is still a conflict,
+		// is_admin is kept unless the row has it
+		query += `
+			ON CONFLICT (tenant_id, lower(username)) DO UPDATE SET
+			email = EXCLUDED.email, password = EXCLUDED.password,
+			is_admin = CASE WHEN $6 THEN EXCLUDED.is_admin ELSE users.is_admin END
+		`
+		args = append(args, user.IsAdmin.IsSet())
+	}
+	// xmax is zero only for a freshly inserted row
+	query += ` RETURNING id, xmax = 0`
+
+	var (
+		id       uuid.UUID
+		inserted bool
+	)
+	err := tx.QueryRowContext(ctx, query, args...).Scan(&id, &inserted)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ImportRowResult{
+				Status: domain.ImportRowResultStatusSkipped,
+			}, nil
+		}
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code {
+			case "23505":
+				switch pqErr.Constraint {
+				case usernameConstraint:
+					return domain.ImportRowResult{}, ErrUsernameExists
+				case emailConstraint:
+					return domain.ImportRowResult{}, ErrEmailExists
+				}
+			}
+		}
+		return domain.ImportRowResult{}, err
+	}
+
+	res := domain.ImportRowResult{
+		Status: domain.ImportRowResultStatusCreated,
+		ID:     domain.NewOptUUID(domain.UUID(id)),
+	}
+	event := NewUserEvent(ctx, EventUserCreated, domain.UUID(id), user)
+	if !inserted {
+		res.Status = domain.ImportRowResultStatusUpdated
+		event = NewUserEvent(ctx, EventUserUpdated, domain.UUID(id), ImportUpdate(user))
+	}
+
+	if err := appendEvent(ctx, tx, event); err != nil {
+		return domain.ImportRowResult{}, err
+	}
+
+	return res, nil
+}
+
+// ImportUpdate returns the fields an import changes in an existing user,
+// is_admin is changed only if the row has it
+func ImportUpdate(user *domain.User) *domain.User {
+	return &domain.User{
+		Email:    user.Email,
+		Password: user.Password,
+		IsAdmin:  user.IsAdmin,
+	}
+}
@@ -0,0 +1,50 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/liriquew/test_task/internal/repository"
+)
+
+// ListChanges returns up to limit events of the log of changes of all organizations
+// with ids greater than after, in id order
+func (r *Repository) ListChanges(_ context.Context, after int64, limit int) ([]repository.OutboxEvent, error) {
+	var events []repository.OutboxEvent
+	r.read(func(d *data) error {
+		i, _ := slices.BinarySearchFunc(d.changes, after+1, func(e repository.OutboxEvent, id int64) int {
+			return cmp.Compare(e.ID, id)
+		})
+		events = slices.Clone(d.changes[i:min(i+limit, len(d.changes))])
+		return nil
+	})
+
+	return events, nil
+}
+
+// LastChangeID returns the id of the latest event of the log, 0 if it is empty
+func (r *Repository) LastChangeID(_ context.Context) (int64, error) {
+	var id int64
+	r.read(func(d *data) error {
+		if len(d.changes) != 0 {
+			id = d.changes[len(d.changes)-1].ID
+		}
+		return nil
+	})
+
+	return id, nil
+}
+
+// PruneChanges deletes events of the log except the latest keep ones,
+// returns the number of deleted events
+func (r *Repository) PruneChanges(_ context.Context, keep int) (int64, error) {
+	var pruned int
+	r.write(func(d *data) error {
+		pruned = max(len(d.changes)-keep, 0)
+		d.changes = slices.Clone(d.changes[pruned:])
+		return nil
+	})
+
+	return int64(pruned), nil
+}
@@ -0,0 +1,391 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/lib/tenant"
+	"github.com/liriquew/test_task/internal/repository"
+)
+
+// errInvalidMember mirrors the check constraint of group_members table
+var errInvalidMember = errors.New("member must be either a user or a group")
+
+func (r *Repository) ListGroups(ctx context.Context, offset int64) ([]domain.Group, error) {
+	tenantID, ok := tenant.FromContext(ctx)
+	if !ok {
+		return nil, tenant.ErrNoTenant
+	}
+
+	var res []domain.Group
+
+	err := r.read(func(d *data) error {
+		groups := []domain.Group{}
+		for _, g := range d.groups {
+			if g.tenant == tenantID {
+				groups = append(groups, g.Group)
+			}
+		}
+		slices.SortFunc(groups, func(a, b domain.Group) int {
+			return compareIDs(a.ID.Value, b.ID.Value)
+		})
+		res = page(groups, offset)
+
+		return nil
+	})
+
+	return res, err
+}
+
+func (r *Repository) CreateGroup(ctx context.Context, g *domain.Group) (*domain.UUID, error) {
+	tenantID, ok := tenant.FromContext(ctx)
+	if !ok {
+		return nil, tenant.ErrNoTenant
+	}
+
+	id := newID()
+
+	err := r.write(func(d *data) error {
+		if d.groupNameTaken(tenantID, id, g.Name.Value) {
+			return repository.ErrGroupNameExists
+		}
+
+		d.groups[id] = group{
+			Group: domain.Group{
+				ID:          domain.NewOptUUID(id),
+				Name:        domain.NewOptString(g.Name.Value),
+				Description: domain.NewOptString(g.Description.Value),
+				IsAdmin:     domain.NewOptBool(g.IsAdmin.Value),
+			},
+			tenant: tenantID,
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &id, nil
+}
+
+func (r *Repository) GetGroupById(ctx context.Context, id domain.UUID) (*domain.Group, error) {
+	tenantID, ok := tenant.FromContext(ctx)
+	if !ok {
+		return nil, tenant.ErrNoTenant
+	}
+
+	var res domain.Group
+
+	err := r.read(func(d *data) error {
+		g, ok := d.tenantGroup(tenantID, id)
+		if !ok {
+			return repository.ErrGroupNotFound
+		}
+		res = g.Group
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (r *Repository) UpdateGroup(ctx context.Context, g *domain.Group) error {
+	tenantID, ok := tenant.FromContext(ctx)
+	if !ok {
+		return tenant.ErrNoTenant
+	}
+
+	if !g.Name.IsSet() && !g.Description.IsSet() && !g.IsAdmin.IsSet() {
+		return repository.ErrEmptyUpdate
+	}
+
+	return r.write(func(d *data) error {
+		stored, ok := d.tenantGroup(tenantID, g.ID.Value)
+		if !ok {
+			return repository.ErrGroupNotFound
+		}
+
+		if g.Name.IsSet() {
+			if d.groupNameTaken(stored.tenant, g.ID.Value, g.Name.Value) {
+				return repository.ErrGroupNameExists
+			}
+			stored.Name.SetTo(g.Name.Value)
+		}
+		if g.Description.IsSet() {
+			stored.Description.SetTo(g.Description.Value)
+		}
+		if g.IsAdmin.IsSet() {
+			stored.IsAdmin.SetTo(g.IsAdmin.Value)
+		}
+		d.groups[g.ID.Value] = stored
+
+		return nil
+	})
+}
+
+func (r *Repository) DeleteGroup(ctx context.Context, id domain.UUID) error {
+	tenantID, ok := tenant.FromContext(ctx)
+	if !ok {
+		return tenant.ErrNoTenant
+	}
+
+	return r.write(func(d *data) error {
+		if _, ok := d.tenantGroup(tenantID, id); !ok {
+			return nil
+		}
+
+		d.deleteGroup(id)
+
+		return nil
+	})
+}
+
+func (r *Repository) ListGroupMembers(ctx context.Context, groupID domain.UUID) ([]domain.GroupMember, error) {
+	tenantID, ok := tenant.FromContext(ctx)
+	if !ok {
+		return nil, tenant.ErrNoTenant
+	}
+
+	res := []domain.GroupMember{}
+
+	err := r.read(func(d *data) error {
+		if _, ok := d.tenantGroup(tenantID, groupID); !ok {
+			return nil
+		}
+
+		for _, m := range d.members {
+			if m.group == groupID {
+				res = append(res, domain.GroupMember{
+					UserID:  m.user,
+					GroupID: m.nested,
+				})
+			}
+		}
+
+		// users first, the same as NULLS LAST of postgres
+		slices.SortFunc(res, func(a, b domain.GroupMember) int {
+			if a.UserID.IsSet() != b.UserID.IsSet() {
+				if a.UserID.IsSet() {
+					return -1
+				}
+				return 1
+			}
+			if c := compareIDs(a.UserID.Value, b.UserID.Value); c != 0 {
+				return c
+			}
+			return compareIDs(a.GroupID.Value, b.GroupID.Value)
+		})
+
+		return nil
+	})
+
+	return res, err
+}
+
+func (r *Repository) AddGroupMember(ctx context.Context, groupID domain.UUID, m *domain.GroupMember) error {
+	tenantID, ok := tenant.FromContext(ctx)
+	if !ok {
+		return tenant.ErrNoTenant
+	}
+
+	if m.UserID.IsSet() == m.GroupID.IsSet() {
+		return errInvalidMember
+	}
+
+	return r.write(func(d *data) error {
+		// group and member must belong to the same organization
+		if _, ok := d.tenantGroup(tenantID, groupID); !ok {
+			return repository.ErrGroupNotFound
+		}
+
+		if m.UserID.IsSet() {
+			if u, ok := d.users[m.UserID.Value]; !ok || u.tenant != tenantID {
+				return repository.ErrNotFound
+			}
+		}
+
+		if m.GroupID.IsSet() {
+			if _, ok := d.tenantGroup(tenantID, m.GroupID.Value); !ok {
+				return repository.ErrGroupNotFound
+			}
+			// the group must not be reachable from the nested group
+			if slices.Contains(d.nestedGroups(m.GroupID.Value), groupID) {
+				return repository.ErrGroupCycle
+			}
+		}
+
+		for _, existing := range d.members {
+			if existing.group == groupID && existing.user == m.UserID && existing.nested == m.GroupID {
+				return repository.ErrMemberExists
+			}
+		}
+
+		d.members = append(d.members, member{
+			group:  groupID,
+			user:   m.UserID,
+			nested: m.GroupID,
+		})
+
+		return nil
+	})
+}
+
+func (r *Repository) RemoveGroupMember(ctx context.Context, groupID, memberID domain.UUID) error {
+	tenantID, ok := tenant.FromContext(ctx)
+	if !ok {
+		return tenant.ErrNoTenant
+	}
+
+	return r.write(func(d *data) error {
+		if _, ok := d.tenantGroup(tenantID, groupID); !ok {
+			return repository.ErrMemberNotFound
+		}
+
+		n := len(d.members)
+		d.members = slices.DeleteFunc(d.members, func(m member) bool {
+			return m.group == groupID &&
+				(m.user.IsSet() && m.user.Value == memberID || m.nested.IsSet() && m.nested.Value == memberID)
+		})
+		if len(d.members) == n {
+			return repository.ErrMemberNotFound
+		}
+
+		return nil
+	})
+}
+
+// GetUserGroups returns groups the user is a member of,
+// directly or through nested groups
+func (r *Repository) GetUserGroups(ctx context.Context, userID domain.UUID) ([]domain.Group, error) {
+	tenantID, ok := tenant.FromContext(ctx)
+	if !ok {
+		return nil, tenant.ErrNoTenant
+	}
+
+	res := []domain.Group{}
+
+	err := r.read(func(d *data) error {
+		visited := map[domain.UUID]bool{}
+		queue := []domain.UUID{}
+		for _, m := range d.members {
+			if m.user.IsSet() && m.user.Value == userID && !visited[m.group] {
+				visited[m.group] = true
+				queue = append(queue, m.group)
+			}
+		}
+
+		// groups containing already found ones
+		for len(queue) > 0 {
+			id := queue[0]
+			queue = queue[1:]
+
+			for _, m := range d.members {
+				if m.nested.IsSet() && m.nested.Value == id && !visited[m.group] {
+					visited[m.group] = true
+					queue = append(queue, m.group)
+				}
+			}
+		}
+
+		for id := range visited {
+			if g, ok := d.tenantGroup(tenantID, id); ok {
+				res = append(res, g.Group)
+			}
+		}
+		slices.SortFunc(res, func(a, b domain.Group) int {
+			return cmp.Compare(a.Name.Value, b.Name.Value)
+		})
+
+		return nil
+	})
+
+	return res, err
+}
+
+// LockAdmins returns ids of users with admin permissions, granted directly
+// or through an admin group. Nothing is locked, transactions of
+// the memory repository are serialized anyway
+func (r *Repository) LockAdmins(ctx context.Context) ([]domain.UUID, error) {
+	tenantID, ok := tenant.FromContext(ctx)
+	if !ok {
+		return nil, tenant.ErrNoTenant
+	}
+
+	res := []domain.UUID{}
+
+	err := r.read(func(d *data) error {
+		for _, u := range d.users {
+			if u.tenant == tenantID && u.IsAdmin.Value {
+				res = append(res, u.ID.Value)
+			}
+		}
+		slices.SortFunc(res, compareIDs)
+
+		// members of admin groups, including members of nested groups
+		for _, g := range d.groups {
+			if g.tenant != tenantID || !g.IsAdmin.Value {
+				continue
+			}
+
+			for _, id := range d.nestedGroups(g.ID.Value) {
+				for _, m := range d.members {
+					if m.group == id && m.user.IsSet() && !slices.Contains(res, m.user.Value) {
+						res = append(res, m.user.Value)
+					}
+				}
+			}
+		}
+
+		return nil
+	})
+
+	return res, err
+}
+
+// nestedGroups returns the group and all groups nested into it
+func (d *data) nestedGroups(id domain.UUID) []domain.UUID {
+	res := []domain.UUID{id}
+	for i := 0; i < len(res); i++ {
+		for _, m := range d.members {
+			if m.group == res[i] && m.nested.IsSet() && !slices.Contains(res, m.nested.Value) {
+				res = append(res, m.nested.Value)
+			}
+		}
+	}
+
+	return res
+}
+
+func (d *data) tenantGroup(tenantID, id domain.UUID) (group, bool) {
+	g, ok := d.groups[id]
+	if !ok || g.tenant != tenantID {
+		return group{}, false
+	}
+
+	return g, true
+}
+
+func (d *data) groupNameTaken(tenantID, id domain.UUID, name string) bool {
+	for _, g := range d.groups {
+		if g.tenant == tenantID && g.ID.Value != id && g.Name.Value == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// deleteGroup removes the group with its memberships
+func (d *data) deleteGroup(id domain.UUID) {
+	delete(d.groups, id)
+	d.members = slices.DeleteFunc(d.members, func(m member) bool {
+		return m.group == id || m.nested.IsSet() && m.nested.Value == id
+	})
+}
@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/liriquew/test_task/internal/repository"
+)
+
+type idempotencyKey struct {
+	scope string
+	key   string
+}
+
+func (r *Repository) ClaimIdempotencyKey(_ context.Context, record repository.IdempotencyRecord, now time.Time) (*repository.IdempotencyRecord, error) {
+	var stored *repository.IdempotencyRecord
+
+	err := r.write(func(d *data) error {
+		id := idempotencyKey{scope: record.Scope, key: record.Key}
+		if existing, ok := d.idempotencyKeys[id]; ok && existing.ExpiresAt.After(now) {
+			stored = &existing
+			return nil
+		}
+
+		record.Status, record.Header, record.Body = 0, "", nil
+		d.idempotencyKeys[id] = record
+		return nil
+	})
+
+	return stored, err
+}
+
+func (r *Repository) CompleteIdempotencyKey(_ context.Context, record repository.IdempotencyRecord) error {
+	return r.write(func(d *data) error {
+		id := idempotencyKey{scope: record.Scope, key: record.Key}
+		if existing, ok := d.idempotencyKeys[id]; ok && existing.InProgress() {
+			d.idempotencyKeys[id] = record
+		}
+		return nil
+	})
+}
+
+func (r *Repository) ReleaseIdempotencyKey(_ context.Context, scope, key string) error {
+	return r.write(func(d *data) error {
+		id := idempotencyKey{scope: scope, key: key}
+		if existing, ok := d.idempotencyKeys[id]; ok && existing.InProgress() {
+			delete(d.idempotencyKeys, id)
+		}
+		return nil
+	})
+}
+
+func (r *Repository) DeleteExpiredIdempotencyKeys(_ context.Context, now time.Time) (int64, error) {
+	var deleted int64
+
+	err := r.write(func(d *data) error {
+		for id, record := range d.idempotencyKeys {
+			if !record.ExpiresAt.After(now) {
+				delete(d.idempotencyKeys, id)
+				deleted++
+			}
+		}
+		return nil
+	})
+
+	return deleted, err
+}
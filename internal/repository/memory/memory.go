@@ -0,0 +1,179 @@
+// Package memory keeps the data of the service in the process memory,
+// it needs no database and is used for local development and tests.
+// Semantics (errors, ordering, paging) follow the postgres repository
+package memory
+
+import (
+	"bytes"
+	"context"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/lib/tenant"
+	"github.com/liriquew/test_task/internal/repository"
+	"github.com/liriquew/test_task/internal/service"
+)
+
+// page size of listings, the same as LIMIT of the postgres queries
+const pageSize = 10
+
+var _ service.Repository = (*Repository)(nil)
+
+type user struct {
+	domain.User
+	tenant domain.UUID
+}
+
+type group struct {
+	domain.Group
+	tenant domain.UUID
+}
+
+// member is either a user or a nested group, the other one is not set
+type member struct {
+	group  domain.UUID
+	user   domain.OptUUID
+	nested domain.OptUUID
+}
+
+type data struct {
+	organizations map[domain.UUID]domain.Organization
+	users         map[domain.UUID]user
+	groups        map[domain.UUID]group
+	members       []member
+
+	outbox      []repository.OutboxEvent
+	lastEventID int64
+	// changes is the log of events, ordered by id
+	changes []repository.OutboxEvent
+
+	webhooks   map[domain.UUID]repository.DBWebhook
+	deliveries map[domain.UUID]repository.DBWebhookDelivery
+
+	idempotencyKeys map[idempotencyKey]repository.IdempotencyRecord
+}
+
+func (d *data) clone() *data {
+	return &data{
+		organizations: maps.Clone(d.organizations),
+		users:         maps.Clone(d.users),
+		groups:        maps.Clone(d.groups),
+		members:       slices.Clone(d.members),
+		outbox:        slices.Clone(d.outbox),
+		lastEventID:   d.lastEventID,
+		changes:       slices.Clone(d.changes),
+		webhooks:      maps.Clone(d.webhooks),
+		deliveries:    maps.Clone(d.deliveries),
+
+		idempotencyKeys: maps.Clone(d.idempotencyKeys),
+	}
+}
+
+type store struct {
+	mu   sync.RWMutex
+	data *data
+}
+
+type Repository struct {
+	store *store
+	// tx is a copy of the data changed by WithTx,
+	// the store is locked until the transaction ends
+	tx *data
+}
+
+// New returns a repository with the default organization, the same as created
+// by migrations, the administrator is created by the service on the first run
+func New() *Repository {
+	d := &data{
+		organizations: map[domain.UUID]domain.Organization{},
+		users:         map[domain.UUID]user{},
+		groups:        map[domain.UUID]group{},
+		webhooks:      map[domain.UUID]repository.DBWebhook{},
+		deliveries:    map[domain.UUID]repository.DBWebhookDelivery{},
+
+		idempotencyKeys: map[idempotencyKey]repository.IdempotencyRecord{},
+	}
+
+	d.organizations[tenant.Default] = domain.Organization{
+		ID:   domain.NewOptUUID(tenant.Default),
+		Name: domain.NewOptString("default"),
+	}
+
+	return &Repository{
+		store: &store{data: d},
+	}
+}
+
+func (r *Repository) Close() error {
+	return nil
+}
+
+// WithTx runs fn with a repository working on a copy of the data, the copy
+// replaces the data when fn returns nil. The store is locked for the whole
+// transaction, so transactions are serializable, options are ignored
+// and there is nothing to retry. Inside another WithTx fn joins the outer transaction
+func (r *Repository) WithTx(ctx context.Context, fn func(service.Repository) error, _ ...repository.TxOption) error {
+	if r.tx != nil {
+		return fn(r)
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	tx := r.store.data.clone()
+	if err := fn(&Repository{store: r.store, tx: tx}); err != nil {
+		return err
+	}
+	r.store.data = tx
+
+	return nil
+}
+
+// read runs fn with the data locked for reading
+func (r *Repository) read(fn func(*data) error) error {
+	if r.tx != nil {
+		return fn(r.tx)
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return fn(r.store.data)
+}
+
+// write runs fn with the data locked for writing,
+// fn must check everything before the first change
+func (r *Repository) write(fn func(*data) error) error {
+	if r.tx != nil {
+		return fn(r.tx)
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return fn(r.store.data)
+}
+
+func newID() domain.UUID {
+	return domain.UUID(uuid.New())
+}
+
+// compareIDs orders ids the same way as postgres orders uuid
+func compareIDs(a, b domain.UUID) int {
+	return bytes.Compare(a[:], b[:])
+}
+
+// page returns items of the page starting at offset
+func page[T any](items []T, offset int64) []T {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset >= int64(len(items)) {
+		return []T{}
+	}
+
+	return items[offset:min(offset+pageSize, int64(len(items)))]
+}
@@ -0,0 +1,15 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/liriquew/test_task/internal/repository/memory"
+	"github.com/liriquew/test_task/internal/repository/repotest"
+	"github.com/liriquew/test_task/internal/service"
+)
+
+func TestConformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) service.Repository {
+		return memory.New()
+	})
+}
@@ -0,0 +1,131 @@
+package memory
+
+import (
+	"context"
+	"slices"
+
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/repository"
+)
+
+// organizations aren't scoped by tenant, only super-admins can manage them
+
+func (r *Repository) ListOrganizations(ctx context.Context, offset int64) ([]domain.Organization, error) {
+	var res []domain.Organization
+
+	err := r.read(func(d *data) error {
+		orgs := make([]domain.Organization, 0, len(d.organizations))
+		for _, org := range d.organizations {
+			orgs = append(orgs, org)
+		}
+		slices.SortFunc(orgs, func(a, b domain.Organization) int {
+			return compareIDs(a.ID.Value, b.ID.Value)
+		})
+		res = page(orgs, offset)
+
+		return nil
+	})
+
+	return res, err
+}
+
+func (r *Repository) CreateOrganization(ctx context.Context, org *domain.Organization) (*domain.UUID, error) {
+	id := newID()
+
+	err := r.write(func(d *data) error {
+		if _, ok := d.findOrganization(org.Name.Value); ok {
+			return repository.ErrOrganizationNameExists
+		}
+
+		d.organizations[id] = domain.Organization{
+			ID:   domain.NewOptUUID(id),
+			Name: domain.NewOptString(org.Name.Value),
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &id, nil
+}
+
+func (r *Repository) GetOrganizationById(ctx context.Context, id domain.UUID) (*domain.Organization, error) {
+	var res domain.Organization
+
+	err := r.read(func(d *data) error {
+		org, ok := d.organizations[id]
+		if !ok {
+			return repository.ErrOrganizationNotFound
+		}
+		res = org
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (r *Repository) GetOrganizationByName(ctx context.Context, name string) (*domain.Organization, error) {
+	var res domain.Organization
+
+	err := r.read(func(d *data) error {
+		org, ok := d.findOrganization(name)
+		if !ok {
+			return repository.ErrOrganizationNotFound
+		}
+		res = org
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// DeleteOrganization removes the organization with its users, groups and webhooks
+func (r *Repository) DeleteOrganization(ctx context.Context, id domain.UUID) error {
+	return r.write(func(d *data) error {
+		delete(d.organizations, id)
+
+		for userID, u := range d.users {
+			if u.tenant == id {
+				delete(d.users, userID)
+			}
+		}
+		d.members = slices.DeleteFunc(d.members, func(m member) bool {
+			_, ok := d.users[m.user.Value]
+			return m.user.IsSet() && !ok
+		})
+
+		for groupID, g := range d.groups {
+			if g.tenant == id {
+				d.deleteGroup(groupID)
+			}
+		}
+
+		for webhookID, w := range d.webhooks {
+			if domain.UUID(w.TenantID) == id {
+				d.deleteWebhook(webhookID)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (d *data) findOrganization(name string) (domain.Organization, bool) {
+	for _, org := range d.organizations {
+		if org.Name.Value == name {
+			return org, true
+		}
+	}
+
+	return domain.Organization{}, false
+}
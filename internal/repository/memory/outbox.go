@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/liriquew/test_task/internal/repository"
+)
+
+// appendEvent writes the event to the outbox and to the log of changes of the data,
+// changed by the same write
+func (d *data) appendEvent(event repository.OutboxEvent) {
+	d.lastEventID++
+	event.ID = d.lastEventID
+	event.CreatedAt = time.Now().UTC()
+
+	d.outbox = append(d.outbox, event)
+	d.changes = append(d.changes, event)
+}
+
+// RelayEvents passes up to limit of the oldest outbox events to publish in order
+// and deletes the published ones. It stops at the first event publish fails on,
+// so events of a user are never published out of order, the event is passed again
+// by the next call. Returns the number of published events.
+// The data isn't locked while events are published, a single relay must work with the repository
+func (r *Repository) RelayEvents(_ context.Context, limit int, publish func(repository.OutboxEvent) error) (int, error) {
+	var events []repository.OutboxEvent
+	r.read(func(d *data) error {
+		events = slices.Clone(d.outbox[:min(limit, len(d.outbox))])
+		return nil
+	})
+
+	published := 0
+	var publishErr error
+	for _, event := range events {
+		if publishErr = publish(event); publishErr != nil {
+			break
+		}
+		published++
+	}
+
+	if published != 0 {
+		// events are appended in the order of changes, published ones are the oldest
+		last := events[published-1].ID
+		r.write(func(d *data) error {
+			d.outbox = slices.DeleteFunc(d.outbox, func(e repository.OutboxEvent) bool {
+				return e.ID <= last
+			})
+			return nil
+		})
+	}
+
+	return published, publishErr
+}
@@ -0,0 +1,379 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/lib/tenant"
+	"github.com/liriquew/test_task/internal/repository"
+)
+
+func (r *Repository) ListUsers(ctx context.Context, filter repository.UserFilter, offset int64) ([]domain.User, error) {
+	tenantID, ok := tenant.FromContext(ctx)
+	if !ok {
+		return nil, tenant.ErrNoTenant
+	}
+
+	var res []domain.User
+
+	err := r.read(func(d *data) error {
+		res = page(d.filterUsers(tenantID, filter), offset)
+		for i := range res {
+			filter.Fields.Project(&res[i])
+		}
+		return nil
+	})
+
+	return res, err
+}
+
+func (r *Repository) CreateUser(ctx context.Context, u *domain.User) (*domain.UUID, error) {
+	tenantID, ok := tenant.FromContext(ctx)
+	if !ok {
+		return nil, tenant.ErrNoTenant
+	}
+
+	id := newID()
+
+	err := r.write(func(d *data) error {
+		if err := d.checkUnique(tenantID, id, u.Username.Value, u.Email.Value); err != nil {
+			return err
+		}
+
+		created := user{
+			User: domain.User{
+				ID:       domain.NewOptUUID(id),
+				Username: domain.NewOptString(u.Username.Value),
+				Password: domain.NewOptString(u.Password.Value),
+				Email:    domain.NewOptString(u.Email.Value),
+				IsAdmin:  domain.NewOptBool(u.IsAdmin.Value),
+			},
+			tenant: tenantID,
+		}
+		setMustChangePassword(&created.User, u.MustChangePassword.Value)
+		d.users[id] = created
+		d.appendEvent(repository.NewUserEvent(ctx, repository.EventUserCreated, id, u))
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &id, nil
+}
+
+func (r *Repository) GetUserById(ctx context.Context, id domain.UUID) (*domain.User, error) {
+	return r.GetUserFieldsById(ctx, id, nil)
+}
+
+func (r *Repository) GetUserFieldsById(ctx context.Context, id domain.UUID, fields repository.UserFields) (*domain.User, error) {
+	tenantID, ok := tenant.FromContext(ctx)
+	if !ok {
+		return nil, tenant.ErrNoTenant
+	}
+
+	var res domain.User
+
+	err := r.read(func(d *data) error {
+		u, ok := d.users[id]
+		if !ok || u.tenant != tenantID {
+			return repository.ErrNotFound
+		}
+		res = u.User
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	fields.Project(&res)
+
+	return &res, nil
+}
+
+func (r *Repository) UpdateUser(ctx context.Context, u *domain.User) error {
+	tenantID, ok := tenant.FromContext(ctx)
+	if !ok {
+		return tenant.ErrNoTenant
+	}
+
+	if !u.Password.IsSet() && !u.Username.IsSet() && !u.Email.IsSet() && !u.IsAdmin.IsSet() && !u.MustChangePassword.IsSet() {
+		return repository.ErrEmptyUpdate
+	}
+
+	return r.write(func(d *data) error {
+		stored, ok := d.users[u.ID.Value]
+		if !ok || stored.tenant != tenantID {
+			return repository.ErrNotFound
+		}
+
+		if u.Password.IsSet() {
+			stored.Password.SetTo(u.Password.Value)
+		}
+		if u.Username.IsSet() {
+			stored.Username.SetTo(u.Username.Value)
+		}
+		if u.Email.IsSet() {
+			stored.Email.SetTo(u.Email.Value)
+		}
+		if u.IsAdmin.IsSet() {
+			stored.IsAdmin.SetTo(u.IsAdmin.Value)
+		}
+		if u.MustChangePassword.IsSet() {
+			setMustChangePassword(&stored.User, u.MustChangePassword.Value)
+		}
+
+		if err := d.checkUnique(stored.tenant, u.ID.Value, stored.Username.Value, stored.Email.Value); err != nil {
+			return err
+		}
+		d.users[u.ID.Value] = stored
+		d.appendEvent(repository.NewUserEvent(ctx, repository.EventUserUpdated, u.ID.Value, u))
+
+		return nil
+	})
+}
+
+func (r *Repository) DeleteUser(ctx context.Context, id domain.UUID) error {
+	tenantID, ok := tenant.FromContext(ctx)
+	if !ok {
+		return tenant.ErrNoTenant
+	}
+
+	return r.write(func(d *data) error {
+		if u, ok := d.users[id]; !ok || u.tenant != tenantID {
+			return nil
+		}
+
+		delete(d.users, id)
+		d.members = slices.DeleteFunc(d.members, func(m member) bool {
+			return m.user.IsSet() && m.user.Value == id
+		})
+		d.appendEvent(repository.NewUserEvent(ctx, repository.EventUserDeleted, id, nil))
+
+		return nil
+	})
+}
+
+func (r *Repository) GetUserByUsername(ctx context.Context, username string) (*domain.User, error) {
+	tenantID, ok := tenant.FromContext(ctx)
+	if !ok {
+		return nil, tenant.ErrNoTenant
+	}
+
+	var res *domain.User
+
+	err := r.read(func(d *data) error {
+		u, ok := d.findUser(tenantID, username)
+		if !ok {
+			return repository.ErrNotFound
+		}
+		res = &u.User
+
+		return nil
+	})
+
+	return res, err
+}
+
+// ImportUsers writes a batch of users at once, a failed row doesn't abort the batch
+func (r *Repository) ImportUsers(
+	ctx context.Context,
+	users []domain.User,
+	onConflict domain.ServiceImportUsersOnConflict,
+) ([]domain.ImportRowResult, error) {
+	tenantID, ok := tenant.FromContext(ctx)
+	if !ok {
+		return nil, tenant.ErrNoTenant
+	}
+
+	results := make([]domain.ImportRowResult, 0, len(users))
+
+	err := r.write(func(d *data) error {
+		for i := range users {
+			results = append(results, d.importUser(ctx, tenantID, &users[i], onConflict))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (d *data) importUser(
+	ctx context.Context,
+	tenantID domain.UUID,
+	u *domain.User,
+	onConflict domain.ServiceImportUsersOnConflict,
+) domain.ImportRowResult {
+	id := newID()
+	// existing user is matched by username, email of another user is still a conflict
+	if existing, ok := d.findUser(tenantID, u.Username.Value); ok && onConflict == domain.ServiceImportUsersOnConflictUpdate {
+		id = existing.ID.Value
+	}
+
+	err := d.checkUnique(tenantID, id, u.Username.Value, u.Email.Value)
+	if err != nil {
+		if onConflict == domain.ServiceImportUsersOnConflictSkip {
+			return domain.ImportRowResult{
+				Status: domain.ImportRowResultStatusSkipped,
+			}
+		}
+
+		return domain.ImportRowResult{
+			Status: domain.ImportRowResultStatusFailed,
+			Error:  domain.NewOptString(err.Error()),
+		}
+	}
+
+	res := domain.ImportRowResult{
+		Status: domain.ImportRowResultStatusCreated,
+		ID:     domain.NewOptUUID(id),
+	}
+
+	stored, updated := d.users[id]
+	if updated {
+		res.Status = domain.ImportRowResultStatusUpdated
+		d.appendEvent(repository.NewUserEvent(ctx, repository.EventUserUpdated, id, repository.ImportUpdate(u)))
+	} else {
+		d.appendEvent(repository.NewUserEvent(ctx, repository.EventUserCreated, id, u))
+		stored = user{
+			User: domain.User{
+				ID:       domain.NewOptUUID(id),
+				Username: domain.NewOptString(u.Username.Value),
+			},
+			tenant: tenantID,
+		}
+	}
+	stored.Email = domain.NewOptString(u.Email.Value)
+	stored.Password = domain.NewOptString(u.Password.Value)
+	// is_admin of an updated user is kept unless the row has it
+	if !updated || u.IsAdmin.IsSet() {
+		stored.IsAdmin = domain.NewOptBool(u.IsAdmin.Value)
+	}
+	d.users[id] = stored
+
+	return res
+}
+
+// ExportUsers passes every user matching the filter to fn ordered by id,
+// starting after the cursor id if it is set. fn is called on a snapshot
+// taken before the first call. Passwords are never exported
+func (r *Repository) ExportUsers(
+	ctx context.Context,
+	filter repository.UserFilter,
+	cursor domain.OptUUID,
+	fn func(domain.User) error,
+) error {
+	tenantID, ok := tenant.FromContext(ctx)
+	if !ok {
+		return tenant.ErrNoTenant
+	}
+
+	var users []domain.User
+
+	err := r.read(func(d *data) error {
+		users = d.filterUsers(tenantID, filter)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, u := range users {
+		if cursor.IsSet() && compareIDs(u.ID.Value, cursor.Value) <= 0 {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		u.Password.Reset()
+		if err := fn(u); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// filterUsers returns users of the organization matching the filter ordered by id
+func (d *data) filterUsers(tenantID domain.UUID, filter repository.UserFilter) []domain.User {
+	search := strings.ToLower(filter.Search.Value)
+
+	res := []domain.User{}
+	for _, u := range d.users {
+		if u.tenant != tenantID {
+			continue
+		}
+		if filter.IsAdmin.IsSet() && u.IsAdmin.Value != filter.IsAdmin.Value {
+			continue
+		}
+		if search != "" &&
+			!strings.Contains(strings.ToLower(u.Username.Value), search) &&
+			!strings.Contains(strings.ToLower(u.Email.Value), search) {
+			continue
+		}
+
+		res = append(res, u.User)
+	}
+
+	slices.SortFunc(res, func(a, b domain.User) int {
+		return compareIDs(a.ID.Value, b.ID.Value)
+	})
+
+	return res
+}
+
+// findUser returns the user of the organization with the username, case-insensitive
+func (d *data) findUser(tenantID domain.UUID, username string) (user, bool) {
+	username = strings.ToLower(username)
+	for _, u := range d.users {
+		if u.tenant == tenantID && strings.ToLower(u.Username.Value) == username {
+			return u, true
+		}
+	}
+
+	return user{}, false
+}
+
+// checkUnique returns an error when another user of the organization
+// has the same username or email, case-insensitive
+func (d *data) checkUnique(tenantID, id domain.UUID, username, email string) error {
+	username = strings.ToLower(username)
+	email = strings.ToLower(email)
+
+	emailTaken := false
+	for _, u := range d.users {
+		if u.tenant != tenantID || u.ID.Value == id {
+			continue
+		}
+		if strings.ToLower(u.Username.Value) == username {
+			return repository.ErrUsernameExists
+		}
+		if strings.ToLower(u.Email.Value) == email {
+			emailTaken = true
+		}
+	}
+
+	if emailTaken {
+		return repository.ErrEmailExists
+	}
+
+	return nil
+}
+
+// setMustChangePassword keeps the flag unset for users who don't have
+// to change the password, the same as it is read from the databases
+func setMustChangePassword(u *domain.User, value bool) {
+	if value {
+		u.MustChangePassword.SetTo(true)
+	} else {
+		u.MustChangePassword.Reset()
+	}
+}
@@ -0,0 +1,371 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/lib/tenant"
+	"github.com/liriquew/test_task/internal/repository"
+)
+
+func (r *Repository) ListWebhooks(ctx context.Context, offset int64) ([]domain.Webhook, error) {
+	tenantID, ok := tenant.FromContext(ctx)
+	if !ok {
+		return nil, tenant.ErrNoTenant
+	}
+
+	var res []domain.Webhook
+
+	err := r.read(func(d *data) error {
+		webhooks := []domain.Webhook{}
+		for _, w := range d.webhooks {
+			if domain.UUID(w.TenantID) == tenantID {
+				webhooks = append(webhooks, repository.ConvertDBWebhookToWebhook(w))
+			}
+		}
+		slices.SortFunc(webhooks, func(a, b domain.Webhook) int {
+			return compareIDs(a.ID.Value, b.ID.Value)
+		})
+		res = page(webhooks, offset)
+
+		return nil
+	})
+
+	return res, err
+}
+
+func (r *Repository) CreateWebhook(ctx context.Context, w *domain.Webhook) (*domain.UUID, error) {
+	tenantID, ok := tenant.FromContext(ctx)
+	if !ok {
+		return nil, tenant.ErrNoTenant
+	}
+
+	id := newID()
+
+	err := r.write(func(d *data) error {
+		d.webhooks[id] = repository.DBWebhook{
+			ID:        uuid.UUID(id),
+			TenantID:  uuid.UUID(tenantID),
+			URL:       w.URL.Value,
+			Events:    repository.WebhookEvents(w.Events),
+			Secret:    w.Secret.Value,
+			Enabled:   w.Enabled.Or(true),
+			CreatedAt: time.Now().UTC(),
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &id, nil
+}
+
+func (r *Repository) GetWebhookById(ctx context.Context, id domain.UUID) (*domain.Webhook, error) {
+	tenantID, ok := tenant.FromContext(ctx)
+	if !ok {
+		return nil, tenant.ErrNoTenant
+	}
+
+	var res domain.Webhook
+
+	err := r.read(func(d *data) error {
+		w, ok := d.tenantWebhook(tenantID, id)
+		if !ok {
+			return repository.ErrWebhookNotFound
+		}
+		res = repository.ConvertDBWebhookToWebhook(w)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (r *Repository) UpdateWebhook(ctx context.Context, w *domain.Webhook) error {
+	tenantID, ok := tenant.FromContext(ctx)
+	if !ok {
+		return tenant.ErrNoTenant
+	}
+
+	if !w.URL.IsSet() && w.Events == nil && !w.Secret.IsSet() && !w.Enabled.IsSet() {
+		return repository.ErrEmptyUpdate
+	}
+
+	return r.write(func(d *data) error {
+		stored, ok := d.tenantWebhook(tenantID, w.ID.Value)
+		if !ok {
+			return repository.ErrWebhookNotFound
+		}
+
+		if w.URL.IsSet() {
+			stored.URL = w.URL.Value
+		}
+		if w.Events != nil {
+			stored.Events = repository.WebhookEvents(w.Events)
+		}
+		if w.Secret.IsSet() {
+			stored.Secret = w.Secret.Value
+		}
+		if w.Enabled.IsSet() {
+			stored.Enabled = w.Enabled.Value
+			// an enabled webhook starts counting failures over
+			if w.Enabled.Value {
+				stored.Failures = 0
+			}
+		}
+		d.webhooks[w.ID.Value] = stored
+
+		return nil
+	})
+}
+
+func (r *Repository) DeleteWebhook(ctx context.Context, id domain.UUID) error {
+	tenantID, ok := tenant.FromContext(ctx)
+	if !ok {
+		return tenant.ErrNoTenant
+	}
+
+	return r.write(func(d *data) error {
+		if _, ok := d.tenantWebhook(tenantID, id); !ok {
+			return nil
+		}
+
+		d.deleteWebhook(id)
+
+		return nil
+	})
+}
+
+func (r *Repository) ListWebhookDeliveries(ctx context.Context, webhookID domain.UUID, offset int64) ([]domain.WebhookDelivery, error) {
+	tenantID, ok := tenant.FromContext(ctx)
+	if !ok {
+		return nil, tenant.ErrNoTenant
+	}
+
+	var res []domain.WebhookDelivery
+
+	err := r.read(func(d *data) error {
+		deliveries := []repository.DBWebhookDelivery{}
+		for _, delivery := range d.deliveries {
+			if domain.UUID(delivery.WebhookID) == webhookID && domain.UUID(delivery.TenantID) == tenantID {
+				deliveries = append(deliveries, delivery)
+			}
+		}
+		slices.SortFunc(deliveries, func(a, b repository.DBWebhookDelivery) int {
+			return cmp.Compare(b.EventID, a.EventID)
+		})
+
+		res = []domain.WebhookDelivery{}
+		for _, delivery := range page(deliveries, offset) {
+			res = append(res, repository.ConvertDBWebhookDeliveryToWebhookDelivery(delivery))
+		}
+
+		return nil
+	})
+
+	return res, err
+}
+
+func (r *Repository) RedeliverWebhookDelivery(ctx context.Context, webhookID, deliveryID domain.UUID) (*domain.WebhookDelivery, error) {
+	tenantID, ok := tenant.FromContext(ctx)
+	if !ok {
+		return nil, tenant.ErrNoTenant
+	}
+
+	var res domain.WebhookDelivery
+
+	err := r.write(func(d *data) error {
+		delivery, ok := d.deliveries[deliveryID]
+		if !ok || domain.UUID(delivery.WebhookID) != webhookID || domain.UUID(delivery.TenantID) != tenantID {
+			return repository.ErrDeliveryNotFound
+		}
+
+		delivery.Status = repository.DeliveryPending
+		delivery.Attempts = 0
+		delivery.ResponseStatus.Valid = false
+		delivery.Error.Valid = false
+		delivery.NextAttemptAt = time.Now().UTC()
+		delivery.DeliveredAt.Valid = false
+		d.deliveries[deliveryID] = delivery
+
+		res = repository.ConvertDBWebhookDeliveryToWebhookDelivery(delivery)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// webhook deliveries aren't scoped by tenant of ctx, they are made by the delivery worker
+
+// EnqueueDeliveries queues delivery of the event to enabled webhooks of its organization
+// subscribed to it. An event enqueued again is ignored, so the relay may pass it more than once
+func (r *Repository) EnqueueDeliveries(_ context.Context, event repository.OutboxEvent) (int, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return 0, err
+	}
+
+	enqueued := 0
+	err = r.write(func(d *data) error {
+		now := time.Now().UTC()
+
+		for _, w := range d.webhooks {
+			if w.TenantID != event.TenantID || !w.Enabled || !w.Subscribed(event.Type) {
+				continue
+			}
+			if d.enqueued(w.ID, event.ID) {
+				continue
+			}
+
+			id := newID()
+			d.deliveries[id] = repository.DBWebhookDelivery{
+				ID:            uuid.UUID(id),
+				WebhookID:     w.ID,
+				TenantID:      event.TenantID,
+				EventID:       event.ID,
+				EventType:     event.Type,
+				Payload:       payload,
+				Status:        repository.DeliveryPending,
+				NextAttemptAt: now,
+				CreatedAt:     now,
+			}
+			enqueued++
+		}
+
+		return nil
+	})
+
+	return enqueued, err
+}
+
+// ClaimDeliveries returns up to limit of pending deliveries due to enabled webhooks and
+// postpones their next attempt by lease, so other workers skip them while they are attempted.
+// A delivery whose attempt isn't completed is claimed again when the lease expires
+func (r *Repository) ClaimDeliveries(_ context.Context, limit int, lease time.Duration) ([]repository.DeliveryJob, error) {
+	var jobs []repository.DeliveryJob
+
+	err := r.write(func(d *data) error {
+		now := time.Now().UTC()
+
+		var due []repository.DBWebhookDelivery
+		for _, delivery := range d.deliveries {
+			w, ok := d.webhooks[domain.UUID(delivery.WebhookID)]
+			if ok && w.Enabled && delivery.Status == repository.DeliveryPending && !delivery.NextAttemptAt.After(now) {
+				due = append(due, delivery)
+			}
+		}
+		slices.SortFunc(due, func(a, b repository.DBWebhookDelivery) int {
+			return a.NextAttemptAt.Compare(b.NextAttemptAt)
+		})
+
+		for _, delivery := range due[:min(limit, len(due))] {
+			w := d.webhooks[domain.UUID(delivery.WebhookID)]
+			jobs = append(jobs, repository.DeliveryJob{
+				ID:        delivery.ID,
+				WebhookID: delivery.WebhookID,
+				EventID:   delivery.EventID,
+				EventType: delivery.EventType,
+				Payload:   delivery.Payload,
+				Attempts:  delivery.Attempts,
+				URL:       w.URL,
+				Secret:    w.Secret,
+			})
+
+			delivery.NextAttemptAt = now.Add(lease)
+			d.deliveries[domain.UUID(delivery.ID)] = delivery
+		}
+
+		return nil
+	})
+
+	return jobs, err
+}
+
+// CompleteDelivery records the attempt of the delivery. A succeeded attempt resets failures
+// of the webhook, a failed one counts, the webhook is disabled when failures reach
+// result.DisableAfter. Reports whether the webhook was disabled by this attempt
+func (r *Repository) CompleteDelivery(_ context.Context, result repository.DeliveryResult) (bool, error) {
+	disabled := false
+
+	err := r.write(func(d *data) error {
+		now := time.Now().UTC()
+
+		delivery, ok := d.deliveries[domain.UUID(result.ID)]
+		if ok && delivery.Status == repository.DeliveryPending {
+			delivery.Status = result.Status()
+			delivery.Attempts++
+			delivery.ResponseStatus.Int32 = result.ResponseStatus
+			delivery.ResponseStatus.Valid = result.ResponseStatus != 0
+			delivery.Error.String = result.Error
+			delivery.Error.Valid = result.Error != ""
+			delivery.NextAttemptAt = cmp.Or(result.NextAttemptAt, now)
+			delivery.DeliveredAt.Time = now
+			delivery.DeliveredAt.Valid = result.Succeeded
+			d.deliveries[domain.UUID(result.ID)] = delivery
+		}
+
+		w, ok := d.webhooks[domain.UUID(result.WebhookID)]
+		if !ok {
+			return nil
+		}
+		if result.Succeeded {
+			w.Failures = 0
+		} else {
+			w.Failures++
+			if result.DisableAfter > 0 && w.Failures >= result.DisableAfter {
+				w.Enabled = false
+			}
+			disabled = result.Disabled(w.Enabled, w.Failures)
+		}
+		d.webhooks[domain.UUID(result.WebhookID)] = w
+
+		return nil
+	})
+
+	return disabled, err
+}
+
+func (d *data) tenantWebhook(tenantID, id domain.UUID) (repository.DBWebhook, bool) {
+	w, ok := d.webhooks[id]
+	if !ok || domain.UUID(w.TenantID) != tenantID {
+		return repository.DBWebhook{}, false
+	}
+
+	return w, true
+}
+
+// deleteWebhook removes the webhook with its deliveries
+func (d *data) deleteWebhook(id domain.UUID) {
+	delete(d.webhooks, id)
+
+	for deliveryID, delivery := range d.deliveries {
+		if domain.UUID(delivery.WebhookID) == id {
+			delete(d.deliveries, deliveryID)
+		}
+	}
+}
+
+// enqueued reports whether the event is already queued to the webhook
+func (d *data) enqueued(webhookID uuid.UUID, eventID int64) bool {
+	for _, delivery := range d.deliveries {
+		if delivery.WebhookID == webhookID && delivery.EventID == eventID {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	domain "github.com/liriquew/test_task/internal/domain"
+)
+
+type DBOrganization struct {
+	ID   uuid.UUID `db:"id"`
+	Name string    `db:"name"`
+}
+
+// ConvertDBOrganizationToOrganization преобразует DBOrganization в Organization.
+func ConvertDBOrganizationToOrganization(dbOrg DBOrganization) domain.Organization {
+	return domain.Organization{
+		ID:   domain.NewOptUUID(domain.UUID(dbOrg.ID)),
+		Name: domain.NewOptString(dbOrg.Name),
+	}
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	domain "github.com/liriquew/test_task/internal/domain"
+)
+
+const (
+	organizationNameConstraint = "organizations_name_key"
+)
+
+// organizations aren't scoped by tenant, only super-admins can manage them
+
+func (s *Repository) ListOrganizations(ctx context.Context, offset int64) ([]domain.Organization, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	var orgs []DBOrganization
+
+	query := `
+		SELECT * FROM organizations
+		ORDER BY id
+		OFFSET $1
+		LIMIT 10
+	`
+
+	if err := s.q.SelectContext(ctx, &orgs, query, offset); err != nil {
+		return nil, err
+	}
+
+	res := make([]domain.Organization, 0, len(orgs))
+	for _, org := range orgs {
+		res = append(res, ConvertDBOrganizationToOrganization(org))
+	}
+
+	return res, nil
+}
+
+func (s *Repository) CreateOrganization(ctx context.Context, org *domain.Organization) (*domain.UUID, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO organizations (name) VALUES
+		($1) RETURNING id
+	`
+
+	var id uuid.UUID
+	err := s.q.QueryRowContext(ctx, query, org.Name.Value).Scan(&id)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			if pqErr.Code == "23505" && pqErr.Constraint == organizationNameConstraint {
+				return nil, ErrOrganizationNameExists
+			}
+		}
+		return nil, err
+	}
+
+	res := domain.UUID(id)
+
+	return &res, nil
+}
+
+func (s *Repository) GetOrganizationById(ctx context.Context, id domain.UUID) (*domain.Organization, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT * FROM organizations
+		WHERE id = $1
+	`
+
+	org := DBOrganization{}
+	err := s.q.GetContext(ctx, &org, query, UUID(id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+
+	res := ConvertDBOrganizationToOrganization(org)
+
+	return &res, nil
+}
+
+func (s *Repository) GetOrganizationByName(ctx context.Context, name string) (*domain.Organization, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT * FROM organizations
+		WHERE name = $1
+	`
+
+	org := DBOrganization{}
+	err := s.q.GetContext(ctx, &org, query, name)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+
+	res := ConvertDBOrganizationToOrganization(org)
+
+	return &res, nil
+}
+
+func (s *Repository) DeleteOrganization(ctx context.Context, id domain.UUID) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	query := `
+		DELETE FROM organizations
+		WHERE id=$1
+	`
+
+	_, err := s.q.ExecContext(ctx, query, UUID(id))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
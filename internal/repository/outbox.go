@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/lib/tenant"
+)
+
+// events of user changes
+const (
+	EventUserCreated = "user.created"
+	EventUserUpdated = "user.updated"
+	EventUserDeleted = "user.deleted"
+)
+
+// OutboxEvent is a change of a user written to the outbox
+// in the same transaction as the change itself
+type OutboxEvent struct {
+	ID        int64           `db:"id" json:"id"`
+	Type      string          `db:"event_type" json:"type"`
+	TenantID  uuid.UUID       `db:"tenant_id" json:"tenant_id"`
+	UserID    uuid.UUID       `db:"user_id" json:"user_id"`
+	Payload   json.RawMessage `db:"payload" json:"data"`
+	CreatedAt time.Time       `db:"created_at" json:"created_at"`
+}
+
+// UserEventData is the payload of user events, passwords are never written
+type UserEventData struct {
+	Username           *string `json:"username,omitempty"`
+	Email              *string `json:"email,omitempty"`
+	IsAdmin            *bool   `json:"is_admin,omitempty"`
+	MustChangePassword *bool   `json:"must_change_password,omitempty"`
+	// Changed lists fields of user.updated, a changed password is listed without the value
+	Changed []string `json:"changed,omitempty"`
+}
+
+// NewUserEvent returns the event of the user change in the organization of ctx,
+// user is the created user or the fields of the update, nil for deletion
+func NewUserEvent(ctx context.Context, eventType string, id domain.UUID, user *domain.User) OutboxEvent {
+	data := UserEventData{}
+
+	switch eventType {
+	case EventUserCreated:
+		data.Username = &user.Username.Value
+		data.Email = &user.Email.Value
+		data.IsAdmin = &user.IsAdmin.Value
+		data.MustChangePassword = &user.MustChangePassword.Value
+	case EventUserUpdated:
+		if user.Username.IsSet() {
+			data.Username = &user.Username.Value
+			data.Changed = append(data.Changed, "username")
+		}
+		if user.Email.IsSet() {
+			data.Email = &user.Email.Value
+			data.Changed = append(data.Changed, "email")
+		}
+		if user.Password.IsSet() {
+			data.Changed = append(data.Changed, "password")
+		}
+		if user.IsAdmin.IsSet() {
+			data.IsAdmin = &user.IsAdmin.Value
+			data.Changed = append(data.Changed, "is_admin")
+		}
+		if user.MustChangePassword.IsSet() {
+			data.MustChangePassword = &user.MustChangePassword.Value
+			data.Changed = append(data.Changed, "must_change_password")
+		}
+	}
+
+	// fields of UserEventData always marshal
+	payload, _ := json.Marshal(data)
+	// the change itself fails without the organization
+	tenantID, _ := tenant.FromContext(ctx)
+
+	return OutboxEvent{
+		Type:     eventType,
+		TenantID: uuid.UUID(tenantID),
+		UserID:   uuid.UUID(id),
+		Payload:  payload,
+	}
+}
+
+// appendEvent writes the event to the outbox, q must be the transaction of the change.
+// The event is copied to the log of changes on commit by the trigger of the outbox with
+// an id of the log, writers take the advisory lock only then, so ids of the log are
+// committed in order and a reader never skips an event. The notification wakes
+// watchers on commit, it has no payload, so a transaction notifies once
+func appendEvent(ctx context.Context, q queryer, event OutboxEvent) error {
+	query := `
+		INSERT INTO outbox (event_type, tenant_id, user_id, payload) VALUES
+		($1, $2, $3, $4)
+	`
+
+	_, err := q.ExecContext(ctx, query,
+		event.Type,
+		event.TenantID.String(),
+		event.UserID.String(),
+		string(event.Payload),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = q.ExecContext(ctx, `SELECT pg_notify($1, '')`, ChangesChannel)
+	return err
+}
+
+// outboxLock is the advisory lock of the relay
+const outboxLock = 7_270_100
+
+// RelayEvents passes up to limit of the oldest outbox events to publish in order
+// and deletes the published ones. It stops at the first event publish fails on,
+// so events of a user are never published out of order, the event is passed again
+// by the next call. Returns the number of published events.
+// Events are locked by an advisory lock, only one relay of all instances runs at a time,
+// the others return 0
+func (s *Repository) RelayEvents(ctx context.Context, limit int, publish func(OutboxEvent) error) (int, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var locked bool
+	if err := tx.GetContext(ctx, &locked, `SELECT pg_try_advisory_xact_lock($1)`, outboxLock); err != nil {
+		return 0, err
+	}
+	if !locked {
+		return 0, nil
+	}
+
+	query := `
+		SELECT * FROM outbox
+		ORDER BY id
+		LIMIT $1
+	`
+
+	var events []OutboxEvent
+	if err := tx.SelectContext(ctx, &events, query, limit); err != nil {
+		return 0, err
+	}
+
+	published := make([]int64, 0, len(events))
+	var publishErr error
+	for _, event := range events {
+		if publishErr = publish(event); publishErr != nil {
+			break
+		}
+		published = append(published, event.ID)
+	}
+
+	if len(published) != 0 {
+		// ids of transactions committed out of order may be lower than published ones
+		if _, err := tx.ExecContext(ctx, `DELETE FROM outbox WHERE id = ANY($1)`, pq.Array(published)); err != nil {
+			return 0, err
+		}
+		if err := tx.Commit(); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(published), publishErr
+}
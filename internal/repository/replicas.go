@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/liriquew/test_task/internal/lib/config"
+	"github.com/liriquew/test_task/internal/lib/consistency"
+)
+
+// replica is a read only standby of the primary database
+type replica struct {
+	// repo runs queries of the repository on the replica
+	repo    *Repository
+	healthy atomic.Bool
+}
+
+// replicas spreads reads between healthy standbys and checks them in the background
+type replicas struct {
+	list []*replica
+	next atomic.Uint64
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// openReplicas connects to the replicas of cfg, a replica that doesn't respond
+// doesn't fail the startup, it joins the rotation after a successful health check
+func openReplicas(ctx context.Context, cfg config.StorageConfig) (*replicas, error) {
+	if len(cfg.Replicas) == 0 {
+		return nil, nil
+	}
+
+	r := &replicas{stop: make(chan struct{})}
+	for _, dsn := range cfg.Replicas {
+		db, err := sqlx.Open("postgres", dsn)
+		if err != nil {
+			r.close()
+			return nil, err
+		}
+		ConfigurePool(db.DB, cfg)
+
+		r.list = append(r.list, &replica{
+			repo: &Repository{db: db, q: db, timeout: cfg.QueryTimeout},
+		})
+	}
+
+	interval := cfg.ReplicaCheckInterval
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	r.check(ctx, interval)
+	r.wg.Add(1)
+	go r.run(interval)
+
+	return r, nil
+}
+
+// run checks replicas every interval until close
+func (r *replicas) run(interval time.Duration) {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+			r.check(context.Background(), interval)
+		}
+	}
+}
+
+// check pings every replica, a replica is healthy if it responds within the interval
+func (r *replicas) check(ctx context.Context, interval time.Duration) {
+	for _, rep := range r.list {
+		pingCtx, cancel := context.WithTimeout(ctx, interval)
+		err := rep.repo.db.PingContext(pingCtx)
+		cancel()
+
+		rep.healthy.Store(err == nil)
+	}
+}
+
+// pick returns the next healthy replica, nil if there are none
+func (r *replicas) pick() *replica {
+	if r == nil {
+		return nil
+	}
+
+	start := r.next.Add(1)
+	for i := range uint64(len(r.list)) {
+		rep := r.list[(start+i)%uint64(len(r.list))]
+		if rep.healthy.Load() {
+			return rep
+		}
+	}
+
+	return nil
+}
+
+func (r *replicas) close() error {
+	if r == nil {
+		return nil
+	}
+
+	select {
+	case <-r.stop:
+	default:
+		close(r.stop)
+	}
+	r.wg.Wait()
+
+	var errs []error
+	for _, rep := range r.list {
+		errs = append(errs, rep.repo.db.Close())
+	}
+
+	return errors.Join(errs...)
+}
+
+// replica returns the replica for reads of ctx, nil if they go to the primary:
+// inside transactions, when ctx requires the primary or no replica is healthy
+func (s *Repository) replica(ctx context.Context) *replica {
+	if s.tx != nil || consistency.PrimaryRequired(ctx) {
+		return nil
+	}
+
+	return s.replicas.pick()
+}
+
+// onReplica runs read on a replica, the read is repeated on the primary
+// if the replica turns out to be unavailable, the replica leaves the rotation
+// until the next successful health check
+func onReplica[T any](ctx context.Context, s *Repository, read func(*Repository) (T, error)) (T, error) {
+	rep := s.replica(ctx)
+	if rep == nil {
+		return read(s)
+	}
+
+	res, err := read(rep.repo)
+	if !unavailable(ctx, err) {
+		return res, err
+	}
+	rep.healthy.Store(false)
+
+	return read(s)
+}
+
+// unavailable reports whether err means the database can't serve queries,
+// unlike errors of the query itself
+func unavailable(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	if errors.Is(err, sql.ErrNoRows) || errors.Is(err, ErrNotFound) {
+		return false
+	}
+	// the query timeout, a replica that stopped responding fails the health check
+	if errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		// connection exceptions and shutdown of the server,
+		// query_canceled is a statement timeout and would hit the primary the same way
+		switch pqErr.Code.Class() {
+		case "08":
+			return true
+		case "57":
+			return pqErr.Code != "57014"
+		default:
+			return false
+		}
+	}
+
+	// network errors and driver.ErrBadConn
+	return true
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/liriquew/test_task/internal/lib/consistency"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stubReplicas(healthy ...bool) *replicas {
+	r := &replicas{}
+	for _, h := range healthy {
+		rep := &replica{repo: &Repository{}}
+		rep.healthy.Store(h)
+		r.list = append(r.list, rep)
+	}
+	return r
+}
+
+func TestReplicaPick(t *testing.T) {
+	r := stubReplicas(true, false, true)
+
+	seen := map[*replica]int{}
+	for range 10 {
+		rep := r.pick()
+		require.NotNil(t, rep)
+		require.True(t, rep.healthy.Load())
+		seen[rep]++
+	}
+	assert.Len(t, seen, 2)
+
+	assert.Nil(t, stubReplicas(false, false).pick())
+	assert.Nil(t, (*replicas)(nil).pick())
+}
+
+func TestReplicaRouting(t *testing.T) {
+	ctx := context.Background()
+	primary := &Repository{replicas: stubReplicas(true)}
+	replicaRepo := primary.replicas.list[0].repo
+
+	read := func(ctx context.Context, s *Repository, fail error) (*Repository, error) {
+		var used []*Repository
+		_, err := onReplica(ctx, s, func(r *Repository) (struct{}, error) {
+			used = append(used, r)
+			if r != s {
+				return struct{}{}, fail
+			}
+			return struct{}{}, nil
+		})
+		return used[len(used)-1], err
+	}
+
+	used, err := read(ctx, primary, nil)
+	require.NoError(t, err)
+	assert.Same(t, replicaRepo, used)
+
+	used, err = read(consistency.WithPrimary(ctx), primary, nil)
+	require.NoError(t, err)
+	assert.Same(t, primary, used)
+
+	inTx := &Repository{replicas: primary.replicas, tx: &sqlx.Tx{}}
+	used, err = read(ctx, inTx, nil)
+	require.NoError(t, err)
+	assert.Same(t, inTx, used)
+
+	// errors of the query are returned as is
+	used, err = read(ctx, primary, ErrNotFound)
+	require.ErrorIs(t, err, ErrNotFound)
+	assert.Same(t, replicaRepo, used)
+
+	// unavailable replica falls back to the primary and leaves the rotation
+	used, err = read(ctx, primary, driver.ErrBadConn)
+	require.NoError(t, err)
+	assert.Same(t, primary, used)
+	assert.False(t, primary.replicas.list[0].healthy.Load())
+	assert.Nil(t, primary.replica(ctx))
+}
+
+func TestUnavailable(t *testing.T) {
+	ctx := context.Background()
+
+	cases := []struct {
+		err  error
+		want bool
+	}{
+		{err: nil, want: false},
+		{err: sql.ErrNoRows, want: false},
+		{err: fmt.Errorf("wrapped: %w", ErrNotFound), want: false},
+		{err: context.DeadlineExceeded, want: false},
+		{err: &pq.Error{Code: "23505"}, want: false},
+		{err: &pq.Error{Code: "57014"}, want: false},
+		{err: &pq.Error{Code: "57P01"}, want: true},
+		{err: &pq.Error{Code: "08006"}, want: true},
+		{err: driver.ErrBadConn, want: true},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.want, unavailable(ctx, c.err), "%v", c.err)
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.False(t, unavailable(canceled, driver.ErrBadConn))
+}
@@ -3,9 +3,15 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"fmt"
+	"math"
+	"net"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	domain "github.com/liriquew/test_task/internal/domain"
@@ -14,37 +20,91 @@ import (
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
 	_ "github.com/lib/pq"
+
+	"github.com/liriquew/test_task/internal/lib/tenant"
 )
 
 type Repository struct {
 	db *sqlx.DB
+	// q is db or the transaction of WithTx
+	q  queryer
+	tx *sqlx.Tx
+	// timeout bounds a single repository call
+	timeout time.Duration
+	// replicas serve reads that tolerate replication lag, nil without replicas
+	replicas *replicas
+	// dsn connects listeners of notifications
+	dsn string
 }
 
-func New(cfg config.StorageConfig) *Repository {
-	connStr := fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=disable",
-		cfg.Username,
-		cfg.Password,
-		cfg.Host,
-		cfg.Port,
-		cfg.DBName,
-	)
+// DSN returns the connection string of the postgres database of cfg
+func DSN(cfg config.StorageConfig) string {
+	params := url.Values{}
+	params.Set("sslmode", cfg.SSLMode)
+	if cfg.SSLRootCert != "" {
+		params.Set("sslrootcert", cfg.SSLRootCert)
+	}
+	if cfg.SSLCert != "" {
+		params.Set("sslcert", cfg.SSLCert)
+	}
+	if cfg.SSLKey != "" {
+		params.Set("sslkey", cfg.SSLKey)
+	}
+	if cfg.ApplicationName != "" {
+		params.Set("application_name", cfg.ApplicationName)
+	}
+	// libpq counts connect_timeout in seconds, less than 2 is treated as 2
+	if cfg.ConnectTimeout > 0 {
+		params.Set("connect_timeout", strconv.Itoa(int(math.Ceil(cfg.ConnectTimeout.Seconds()))))
+	}
+	// unknown parameters are sent to the server as run-time settings
+	if cfg.StatementTimeout > 0 {
+		params.Set("statement_timeout", strconv.FormatInt(cfg.StatementTimeout.Milliseconds(), 10))
+	}
+
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(cfg.Username, cfg.Password),
+		Host:     net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+		Path:     cfg.DBName,
+		RawQuery: params.Encode(),
+	}
+
+	return dsn.String()
+}
 
-	db, err := sqlx.Open("postgres", connStr)
+// New connects to the postgres database of cfg, the database is pinged
+// with backoff until it responds or attempts of cfg are exhausted
+func New(ctx context.Context, cfg config.StorageConfig) (*Repository, error) {
+	dsn := DSN(cfg)
+	db, err := sqlx.Open("postgres", dsn)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
+	ConfigurePool(db.DB, cfg)
 
-	if err := db.Ping(); err != nil {
-		panic(fmt.Errorf("error while try to ping db: %w", err))
+	if err := Ping(ctx, db.DB, cfg.PingAttempts); err != nil {
+		db.Close()
+		return nil, err
 	}
 
-	return &Repository{
-		db: db,
+	replicas, err := openReplicas(ctx, cfg)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("replicas: %w", err)
 	}
+
+	return &Repository{
+		db:       db,
+		q:        db,
+		timeout:  cfg.QueryTimeout,
+		replicas: replicas,
+		dsn:      dsn,
+	}, nil
 }
 
 func (r *Repository) Close() error {
-	return r.Close()
+	return errors.Join(r.replicas.close(), r.db.Close())
 }
 
 var (
@@ -52,29 +112,109 @@ var (
 	ErrUsernameExists = errors.New("user with this username already exists")
 	ErrEmailExists    = errors.New("user with this email already exists")
 
+	ErrGroupNotFound   = errors.New("group not found")
+	ErrGroupNameExists = errors.New("group with this name already exists")
+	ErrMemberNotFound  = errors.New("member not found")
+	ErrMemberExists    = errors.New("member already in the group")
+	ErrGroupCycle      = errors.New("group membership cycle")
+
+	ErrOrganizationNotFound   = errors.New("organization not found")
+	ErrOrganizationNameExists = errors.New("organization with this name already exists")
+
+	ErrWebhookNotFound  = errors.New("webhook not found")
+	ErrDeliveryNotFound = errors.New("webhook delivery not found")
+
 	ErrEmptyUpdate = errors.New("empty fields, nothing to update")
 )
 
 const (
-	usernameConstraint = "users_username_key"
-	emailConstraint    = "users_email_key"
+	// unique indexes on lower(username), lower(email)
+	usernameConstraint = "users_tenant_lower_username_key"
+	emailConstraint    = "users_tenant_lower_email_key"
 )
 
 func UUID(id domain.UUID) string {
 	return uuid.UUID(id).String()
 }
 
-func (s *Repository) ListUsers(ctx context.Context, offset int64) ([]domain.User, error) {
+// TenantArg is the organization a query is scoped to,
+// the query fails with tenant.ErrNoTenant if ctx has none
+type TenantArg struct {
+	id domain.UUID
+	ok bool
+}
+
+// Tenant returns the argument of the organization of ctx
+func Tenant(ctx context.Context) TenantArg {
+	id, ok := tenant.FromContext(ctx)
+	return TenantArg{id: id, ok: ok}
+}
+
+func (t TenantArg) Value() (driver.Value, error) {
+	if !t.ok {
+		return nil, tenant.ErrNoTenant
+	}
+	return UUID(t.id), nil
+}
+
+// tenantID returns the organization queries are scoped to
+func tenantID(ctx context.Context) TenantArg {
+	return Tenant(ctx)
+}
+
+// UserFilter narrows users in listing and export
+type UserFilter struct {
+	IsAdmin domain.OptBool
+	// case-insensitive substring of username or email
+	Search domain.OptString
+	// Fields project listed users, export selects all of them
+	Fields UserFields
+}
+
+// conditions returns filter conditions to be appended to WHERE clause
+func (f UserFilter) conditions(args []any) (string, []any) {
+	sb := strings.Builder{}
+
+	if f.IsAdmin.IsSet() {
+		args = append(args, f.IsAdmin.Value)
+		sb.WriteString(fmt.Sprintf(" AND COALESCE(is_admin, false)=$%d", len(args)))
+	}
+	if f.Search.Value != "" {
+		args = append(args, "%"+likeEscaper.Replace(f.Search.Value)+"%")
+		sb.WriteString(fmt.Sprintf(" AND (username ILIKE $%d OR email ILIKE $%d)", len(args), len(args)))
+	}
+
+	return sb.String(), args
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// ListUsers reads from a replica if there is a healthy one
+func (s *Repository) ListUsers(ctx context.Context, filter UserFilter, offset int64) ([]domain.User, error) {
+	return onReplica(ctx, s, func(r *Repository) ([]domain.User, error) {
+		return r.listUsers(ctx, filter, offset)
+	})
+}
+
+func (s *Repository) listUsers(ctx context.Context, filter UserFilter, offset int64) ([]domain.User, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
 	var users []DBUser
 
 	query := `
-		SELECT * FROM users
+		SELECT %s FROM users
+		WHERE tenant_id = $1%s
 		ORDER BY id
-		OFFSET $1
+		OFFSET $%d
 		LIMIT 10
 	`
 
-	if err := s.db.SelectContext(ctx, &users, query, offset); err != nil {
+	conditions, args := filter.conditions([]any{tenantID(ctx)})
+	args = append(args, offset)
+	query = fmt.Sprintf(query, filter.Fields.SelectList(), conditions, len(args))
+
+	if err := s.q.SelectContext(ctx, &users, query, args...); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return []domain.User{}, nil
 		}
@@ -84,24 +224,37 @@ func (s *Repository) ListUsers(ctx context.Context, offset int64) ([]domain.User
 
 	res := make([]domain.User, 0, len(users))
 	for _, user := range users {
-		res = append(res, ConvertDBUserToUser(user))
+		u := ConvertDBUserToUser(user)
+		filter.Fields.Project(&u)
+		res = append(res, u)
 	}
 
 	return res, nil
 }
 
 func (s *Repository) CreateUser(ctx context.Context, user *domain.User) (*domain.UUID, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
 	query := `
-		INSERT INTO users (username, email, password, is_admin) VALUES
-		($1, $2, $3, $4) RETURNING id
+		INSERT INTO users (username, email, password, is_admin, must_change_password, tenant_id) VALUES
+		($1, $2, $3, $4, $5, $6) RETURNING id
 	`
 
+	tx, err := s.beginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
 	var id uuid.UUID
-	err := s.db.QueryRowContext(ctx, query,
+	err = tx.QueryRowContext(ctx, query,
 		user.Username.Value,
 		user.Email.Value,
 		user.Password.Value,
 		user.IsAdmin.Value,
+		user.MustChangePassword.Value,
+		tenantID(ctx),
 	).Scan(&id)
 	if err != nil {
 		if pqErr, ok := err.(*pq.Error); ok {
@@ -119,18 +272,38 @@ func (s *Repository) CreateUser(ctx context.Context, user *domain.User) (*domain
 	}
 
 	res := domain.UUID(id)
+	if err := appendEvent(ctx, tx, NewUserEvent(ctx, EventUserCreated, res, user)); err != nil {
+		return nil, err
+	}
 
-	return &res, nil
+	return &res, tx.Commit()
 }
 
+// GetUserById reads from a replica if there is a healthy one, use consistency.WithPrimary
+// to read the user right after it was changed
 func (s *Repository) GetUserById(ctx context.Context, id domain.UUID) (*domain.User, error) {
+	return s.GetUserFieldsById(ctx, id, nil)
+}
+
+// GetUserFieldsById is GetUserById which selects only the fields
+func (s *Repository) GetUserFieldsById(ctx context.Context, id domain.UUID, fields UserFields) (*domain.User, error) {
+	return onReplica(ctx, s, func(r *Repository) (*domain.User, error) {
+		return r.getUserById(ctx, id, fields)
+	})
+}
+
+func (s *Repository) getUserById(ctx context.Context, id domain.UUID, fields UserFields) (*domain.User, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
 	query := `
-		SELECT * FROM users
-		WHERE id = $1
+		SELECT %s FROM users
+		WHERE id = $1 AND tenant_id = $2
 	`
+	query = fmt.Sprintf(query, fields.SelectList())
 
 	user := DBUser{}
-	err := s.db.GetContext(ctx, &user, query, UUID(id))
+	err := s.q.GetContext(ctx, &user, query, UUID(id), tenantID(ctx))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrNotFound
@@ -139,24 +312,34 @@ func (s *Repository) GetUserById(ctx context.Context, id domain.UUID) (*domain.U
 	}
 
 	res := ConvertDBUserToUser(user)
+	fields.Project(&res)
 
 	return &res, nil
 }
 
 func (s *Repository) UpdateUser(ctx context.Context, user *domain.User) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
 	query := `
-		UPDATE users SET %s WHERE id=$%d
+		UPDATE users SET %s WHERE id=$%d AND tenant_id=$%d
 	`
 
 	queryParams, args, err := s.buildUpdate(user)
 	if err != nil {
 		return err
 	}
-	args = append(args, UUID(user.ID.Value))
+	args = append(args, UUID(user.ID.Value), tenantID(ctx))
+
+	query = fmt.Sprintf(query, queryParams, len(args)-1, len(args))
 
-	query = fmt.Sprintf(query, queryParams, len(args))
+	tx, err := s.beginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
 
-	result, err := s.db.ExecContext(ctx, query, args...)
+	result, err := tx.ExecContext(ctx, query, args...)
 	if err != nil {
 		if pqErr, ok := err.(*pq.Error); ok {
 			switch pqErr.Code {
@@ -173,38 +356,67 @@ func (s *Repository) UpdateUser(ctx context.Context, user *domain.User) error {
 	}
 
 	rowsAffected, err := result.RowsAffected()
-	if err == nil {
+	if err != nil {
 		return err
 	}
 	if rowsAffected == 0 {
 		return ErrNotFound
 	}
 
-	return nil
+	if err := appendEvent(ctx, tx, NewUserEvent(ctx, EventUserUpdated, user.ID.Value, user)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (s *Repository) DeleteUser(ctx context.Context, id domain.UUID) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
 	query := `
 		DELETE FROM users
-		WHERE id=$1
+		WHERE id=$1 AND tenant_id=$2
 	`
 
-	_, err := s.db.ExecContext(ctx, query, UUID(id))
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback()
 
-	return nil
+	result, err := tx.ExecContext(ctx, query, UUID(id), tenantID(ctx))
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	// deletion of a missing user is not an error and not an event
+	if rowsAffected == 0 {
+		return nil
+	}
+
+	if err := appendEvent(ctx, tx, NewUserEvent(ctx, EventUserDeleted, id, nil)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (s *Repository) GetUserByUsername(ctx context.Context, username string) (*domain.User, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
 	query := `
 		SELECT * FROM users
-		WHERE username=$1
+		WHERE lower(username)=lower($1) AND tenant_id=$2
 	`
 
 	user := DBUser{}
-	err := s.db.GetContext(ctx, &user, query, username)
+	err := s.q.GetContext(ctx, &user, query, username, tenantID(ctx))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrNotFound
@@ -236,6 +448,10 @@ func (s *Repository) buildUpdate(user *domain.User) (queryParams string, args []
 		args = append(args, user.IsAdmin.Value)
 		sb.WriteString(fmt.Sprintf("is_admin=$%d, ", len(args)))
 	}
+	if user.MustChangePassword.IsSet() {
+		args = append(args, user.MustChangePassword.Value)
+		sb.WriteString(fmt.Sprintf("must_change_password=$%d, ", len(args)))
+	}
 
 	if len(args) == 0 {
 		return "", nil, ErrEmptyUpdate
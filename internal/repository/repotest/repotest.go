@@ -0,0 +1,1280 @@
+// Package repotest is the conformance suite of service.Repository implementations,
+// every implementation must pass it to be used by the service
+package repotest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/lib/tenant"
+	"github.com/liriquew/test_task/internal/repository"
+	"github.com/liriquew/test_task/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+// passwords are stored as base64 of bcrypt hash, always 80 characters
+var password = strings.Repeat("p", 80)
+
+// Run runs the suite against the repository returned by newRepo. Every test
+// works in its own organization, so the same repository may be returned each time
+func Run(t *testing.T, newRepo func(t *testing.T) service.Repository) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, repo service.Repository)
+	}{
+		{"Users", testUsers},
+		{"ListUsers", testListUsers},
+		{"ImportUsers", testImportUsers},
+		{"ExportUsers", testExportUsers},
+		{"Groups", testGroups},
+		{"GroupMembers", testGroupMembers},
+		{"LockAdmins", testLockAdmins},
+		{"Organizations", testOrganizations},
+		{"WithTx", testWithTx},
+		{"Outbox", testOutbox},
+		{"Webhooks", testWebhooks},
+		{"WebhookDeliveries", testWebhookDeliveries},
+		{"Changes", testChanges},
+		{"IdempotencyKeys", testIdempotencyKeys},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.fn(t, newRepo(t))
+		})
+	}
+}
+
+// newTenant creates an organization, deleted with everything in it after the test
+func newTenant(t *testing.T, repo service.Repository) context.Context {
+	t.Helper()
+
+	id, err := repo.CreateOrganization(context.Background(), &domain.Organization{
+		Name: domain.NewOptString("repotest-" + uuid.NewString()),
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, repo.DeleteOrganization(context.Background(), *id))
+	})
+
+	return tenant.WithTenant(context.Background(), *id)
+}
+
+func newUser(username string) *domain.User {
+	return &domain.User{
+		Username: domain.NewOptString(username),
+		Password: domain.NewOptString(password),
+		Email:    domain.NewOptString(username + "@mail.ru"),
+		IsAdmin:  domain.NewOptBool(false),
+	}
+}
+
+func createUser(t *testing.T, ctx context.Context, repo service.Repository, user *domain.User) domain.UUID {
+	t.Helper()
+
+	id, err := repo.CreateUser(ctx, user)
+	require.NoError(t, err)
+	user.ID.SetTo(*id)
+
+	return *id
+}
+
+func createGroup(t *testing.T, ctx context.Context, repo service.Repository, name string, isAdmin bool) domain.UUID {
+	t.Helper()
+
+	id, err := repo.CreateGroup(ctx, &domain.Group{
+		Name:    domain.NewOptString(name),
+		IsAdmin: domain.NewOptBool(isAdmin),
+	})
+	require.NoError(t, err)
+
+	return *id
+}
+
+func compareIDs(a, b domain.UUID) int {
+	return bytes.Compare(a[:], b[:])
+}
+
+func userIDs(users []domain.User) []domain.UUID {
+	ids := make([]domain.UUID, 0, len(users))
+	for _, u := range users {
+		ids = append(ids, u.ID.Value)
+	}
+
+	return ids
+}
+
+func groupNames(groups []domain.Group) []string {
+	names := make([]string, 0, len(groups))
+	for _, g := range groups {
+		names = append(names, g.Name.Value)
+	}
+
+	return names
+}
+
+func testUsers(t *testing.T, repo service.Repository) {
+	ctx := newTenant(t, repo)
+	other := newTenant(t, repo)
+
+	user := newUser("username1")
+	id := createUser(t, ctx, repo, user)
+
+	got, err := repo.GetUserById(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, user, got)
+
+	_, err = repo.GetUserById(other, id)
+	require.ErrorIs(t, err, repository.ErrNotFound)
+
+	got, err = repo.GetUserFieldsById(ctx, id, repository.UserFields{"username", "is_admin"})
+	require.NoError(t, err)
+	require.Equal(t, &domain.User{Username: user.Username, IsAdmin: user.IsAdmin}, got)
+	_, err = repo.GetUserFieldsById(other, id, repository.UserFields{"id"})
+	require.ErrorIs(t, err, repository.ErrNotFound)
+	_, err = repo.GetUserById(ctx, domain.UUID(uuid.New()))
+	require.ErrorIs(t, err, repository.ErrNotFound)
+
+	got, err = repo.GetUserByUsername(ctx, "USERNAME1")
+	require.NoError(t, err)
+	require.Equal(t, id, got.ID.Value)
+	_, err = repo.GetUserByUsername(other, "username1")
+	require.ErrorIs(t, err, repository.ErrNotFound)
+
+	// uniqueness is case-insensitive and scoped to the organization
+	dup := newUser("USERNAME1")
+	dup.Email.SetTo("another@mail.ru")
+	_, err = repo.CreateUser(ctx, dup)
+	require.ErrorIs(t, err, repository.ErrUsernameExists)
+
+	dup = newUser("username2")
+	dup.Email.SetTo("USERNAME1@mail.ru")
+	_, err = repo.CreateUser(ctx, dup)
+	require.ErrorIs(t, err, repository.ErrEmailExists)
+
+	createUser(t, other, repo, newUser("username1"))
+
+	t.Run("Update", func(t *testing.T) {
+		second := newUser("username2")
+		createUser(t, ctx, repo, second)
+
+		err := repo.UpdateUser(ctx, &domain.User{ID: domain.NewOptUUID(id)})
+		require.ErrorIs(t, err, repository.ErrEmptyUpdate)
+
+		err = repo.UpdateUser(ctx, &domain.User{
+			ID:    domain.NewOptUUID(domain.UUID(uuid.New())),
+			Email: domain.NewOptString("new@mail.ru"),
+		})
+		require.ErrorIs(t, err, repository.ErrNotFound)
+
+		err = repo.UpdateUser(other, &domain.User{
+			ID:    domain.NewOptUUID(id),
+			Email: domain.NewOptString("new@mail.ru"),
+		})
+		require.ErrorIs(t, err, repository.ErrNotFound)
+
+		err = repo.UpdateUser(ctx, &domain.User{
+			ID:       domain.NewOptUUID(id),
+			Username: domain.NewOptString("Username2"),
+		})
+		require.ErrorIs(t, err, repository.ErrUsernameExists)
+
+		err = repo.UpdateUser(ctx, &domain.User{
+			ID:    domain.NewOptUUID(id),
+			Email: domain.NewOptString("Username2@mail.ru"),
+		})
+		require.ErrorIs(t, err, repository.ErrEmailExists)
+
+		// only set fields are changed
+		err = repo.UpdateUser(ctx, &domain.User{
+			ID:      domain.NewOptUUID(id),
+			Email:   domain.NewOptString("new@mail.ru"),
+			IsAdmin: domain.NewOptBool(true),
+		})
+		require.NoError(t, err)
+
+		got, err := repo.GetUserById(ctx, id)
+		require.NoError(t, err)
+		require.Equal(t, &domain.User{
+			ID:       domain.NewOptUUID(id),
+			Username: domain.NewOptString("username1"),
+			Password: domain.NewOptString(password),
+			Email:    domain.NewOptString("new@mail.ru"),
+			IsAdmin:  domain.NewOptBool(true),
+		}, got)
+
+		// changing the case of own username isn't a conflict
+		err = repo.UpdateUser(ctx, &domain.User{
+			ID:       domain.NewOptUUID(id),
+			Username: domain.NewOptString("UserName1"),
+		})
+		require.NoError(t, err)
+
+		// must_change_password is returned only when it is set
+		flagged := newUser("username3")
+		flagged.MustChangePassword.SetTo(true)
+		flaggedID := createUser(t, ctx, repo, flagged)
+
+		got, err = repo.GetUserByUsername(ctx, "username3")
+		require.NoError(t, err)
+		require.Equal(t, domain.NewOptBool(true), got.MustChangePassword)
+
+		err = repo.UpdateUser(ctx, &domain.User{
+			ID:                 domain.NewOptUUID(flaggedID),
+			MustChangePassword: domain.NewOptBool(false),
+		})
+		require.NoError(t, err)
+
+		got, err = repo.GetUserById(ctx, flaggedID)
+		require.NoError(t, err)
+		require.False(t, got.MustChangePassword.IsSet())
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, repo.DeleteUser(ctx, id))
+
+		_, err := repo.GetUserById(ctx, id)
+		require.ErrorIs(t, err, repository.ErrNotFound)
+
+		// deleting a missing user isn't an error
+		require.NoError(t, repo.DeleteUser(ctx, id))
+	})
+}
+
+func testListUsers(t *testing.T, repo service.Repository) {
+	ctx := newTenant(t, repo)
+	other := newTenant(t, repo)
+	createUser(t, other, repo, newUser("listuser00"))
+
+	var ids []domain.UUID
+	for i := range 11 {
+		user := newUser(fmt.Sprintf("listuser%02d", i))
+		user.IsAdmin.SetTo(i%3 == 0)
+		ids = append(ids, createUser(t, ctx, repo, user))
+	}
+	ids = append(ids, createUser(t, ctx, repo, newUser("list_user")))
+	slices.SortFunc(ids, compareIDs)
+
+	t.Run("Paging", func(t *testing.T) {
+		first, err := repo.ListUsers(ctx, repository.UserFilter{}, 0)
+		require.NoError(t, err)
+		require.Equal(t, ids[:10], userIDs(first))
+
+		second, err := repo.ListUsers(ctx, repository.UserFilter{}, 10)
+		require.NoError(t, err)
+		require.Equal(t, ids[10:], userIDs(second))
+
+		last, err := repo.ListUsers(ctx, repository.UserFilter{}, 12)
+		require.NoError(t, err)
+		require.Empty(t, last)
+	})
+
+	t.Run("Fields", func(t *testing.T) {
+		users, err := repo.ListUsers(ctx, repository.UserFilter{
+			Search: domain.NewOptString("list_user"),
+			Fields: repository.UserFields{"id", "email"},
+		}, 0)
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+		require.Equal(t, domain.User{
+			ID:    users[0].ID,
+			Email: domain.NewOptString("list_user@mail.ru"),
+		}, users[0])
+		require.True(t, users[0].ID.IsSet())
+	})
+
+	t.Run("Filter", func(t *testing.T) {
+		admins, err := repo.ListUsers(ctx, repository.UserFilter{
+			IsAdmin: domain.NewOptBool(true),
+		}, 0)
+		require.NoError(t, err)
+		require.Len(t, admins, 4)
+		for _, u := range admins {
+			require.True(t, u.IsAdmin.Value)
+		}
+
+		found, err := repo.ListUsers(ctx, repository.UserFilter{
+			Search: domain.NewOptString("LISTUSER1"),
+		}, 0)
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		require.Equal(t, "listuser10", found[0].Username.Value)
+
+		// wildcards of LIKE are matched literally
+		found, err = repo.ListUsers(ctx, repository.UserFilter{
+			Search: domain.NewOptString("_"),
+		}, 0)
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		require.Equal(t, "list_user", found[0].Username.Value)
+
+		found, err = repo.ListUsers(ctx, repository.UserFilter{
+			Search:  domain.NewOptString("@MAIL"),
+			IsAdmin: domain.NewOptBool(false),
+		}, 0)
+		require.NoError(t, err)
+		require.Len(t, found, 8)
+	})
+}
+
+func testImportUsers(t *testing.T, repo service.Repository) {
+	ctx := newTenant(t, repo)
+	existing := newUser("import1")
+	id := createUser(t, ctx, repo, existing)
+
+	dupUsername := newUser("IMPORT1")
+	dupUsername.Email.SetTo("another@mail.ru")
+	dupEmail := newUser("import9")
+	dupEmail.Email.SetTo("Import1@mail.ru")
+
+	t.Run("Fail", func(t *testing.T) {
+		res, err := repo.ImportUsers(ctx, []domain.User{
+			*newUser("import2"),
+			*dupUsername,
+			*dupEmail,
+		}, domain.ServiceImportUsersOnConflictFail)
+		require.NoError(t, err)
+		require.Len(t, res, 3)
+
+		require.Equal(t, domain.ImportRowResultStatusCreated, res[0].Status)
+		got, err := repo.GetUserByUsername(ctx, "import2")
+		require.NoError(t, err)
+		require.Equal(t, res[0].ID.Value, got.ID.Value)
+
+		require.Equal(t, domain.ImportRowResult{
+			Status: domain.ImportRowResultStatusFailed,
+			Error:  domain.NewOptString(repository.ErrUsernameExists.Error()),
+		}, res[1])
+		require.Equal(t, domain.ImportRowResult{
+			Status: domain.ImportRowResultStatusFailed,
+			Error:  domain.NewOptString(repository.ErrEmailExists.Error()),
+		}, res[2])
+	})
+
+	t.Run("Skip", func(t *testing.T) {
+		res, err := repo.ImportUsers(ctx, []domain.User{
+			*dupUsername,
+			*dupEmail,
+		}, domain.ServiceImportUsersOnConflictSkip)
+		require.NoError(t, err)
+		require.Equal(t, []domain.ImportRowResult{
+			{Status: domain.ImportRowResultStatusSkipped},
+			{Status: domain.ImportRowResultStatusSkipped},
+		}, res)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		update := newUser("import1")
+		update.Email.SetTo("import1new@mail.ru")
+		update.IsAdmin.SetTo(true)
+		// email of another user is still a conflict
+		conflict := newUser("import1")
+		conflict.Email.SetTo("import2@mail.ru")
+
+		res, err := repo.ImportUsers(ctx, []domain.User{
+			*update,
+			*conflict,
+		}, domain.ServiceImportUsersOnConflictUpdate)
+		require.NoError(t, err)
+		require.Equal(t, []domain.ImportRowResult{
+			{Status: domain.ImportRowResultStatusUpdated, ID: domain.NewOptUUID(id)},
+			{
+				Status: domain.ImportRowResultStatusFailed,
+				Error:  domain.NewOptString(repository.ErrEmailExists.Error()),
+			},
+		}, res)
+
+		got, err := repo.GetUserById(ctx, id)
+		require.NoError(t, err)
+		require.Equal(t, "import1", got.Username.Value)
+		require.Equal(t, "import1new@mail.ru", got.Email.Value)
+		require.True(t, got.IsAdmin.Value)
+
+		// a row without is_admin keeps it
+		update.Email.SetTo("import1newer@mail.ru")
+		update.IsAdmin.Reset()
+		res, err = repo.ImportUsers(ctx, []domain.User{*update}, domain.ServiceImportUsersOnConflictUpdate)
+		require.NoError(t, err)
+		require.Equal(t, domain.ImportRowResultStatusUpdated, res[0].Status)
+
+		got, err = repo.GetUserById(ctx, id)
+		require.NoError(t, err)
+		require.Equal(t, "import1newer@mail.ru", got.Email.Value)
+		require.True(t, got.IsAdmin.Value)
+	})
+}
+
+func testExportUsers(t *testing.T, repo service.Repository) {
+	ctx := newTenant(t, repo)
+
+	var ids []domain.UUID
+	for i := range 3 {
+		ids = append(ids, createUser(t, ctx, repo, newUser(fmt.Sprintf("export%d", i))))
+	}
+	slices.SortFunc(ids, compareIDs)
+
+	export := func(filter repository.UserFilter, cursor domain.OptUUID) []domain.User {
+		var users []domain.User
+		err := repo.ExportUsers(ctx, filter, cursor, func(u domain.User) error {
+			users = append(users, u)
+			return nil
+		})
+		require.NoError(t, err)
+
+		return users
+	}
+
+	users := export(repository.UserFilter{}, domain.OptUUID{})
+	require.Equal(t, ids, userIDs(users))
+	for _, u := range users {
+		require.False(t, u.Password.IsSet())
+	}
+
+	users = export(repository.UserFilter{}, domain.NewOptUUID(ids[0]))
+	require.Equal(t, ids[1:], userIDs(users))
+
+	users = export(repository.UserFilter{
+		Search: domain.NewOptString("EXPORT1"),
+	}, domain.OptUUID{})
+	require.Len(t, users, 1)
+	require.Equal(t, "export1", users[0].Username.Value)
+
+	// error of fn stops the export
+	errStop := errors.New("stop")
+	calls := 0
+	err := repo.ExportUsers(ctx, repository.UserFilter{}, domain.OptUUID{}, func(domain.User) error {
+		calls++
+		return errStop
+	})
+	require.ErrorIs(t, err, errStop)
+	require.Equal(t, 1, calls)
+}
+
+func testGroups(t *testing.T, repo service.Repository) {
+	ctx := newTenant(t, repo)
+	other := newTenant(t, repo)
+
+	id := createGroup(t, ctx, repo, "group1", false)
+
+	got, err := repo.GetGroupById(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, &domain.Group{
+		ID:          domain.NewOptUUID(id),
+		Name:        domain.NewOptString("group1"),
+		Description: domain.NewOptString(""),
+		IsAdmin:     domain.NewOptBool(false),
+	}, got)
+
+	_, err = repo.GetGroupById(other, id)
+	require.ErrorIs(t, err, repository.ErrGroupNotFound)
+
+	_, err = repo.CreateGroup(ctx, &domain.Group{Name: domain.NewOptString("group1")})
+	require.ErrorIs(t, err, repository.ErrGroupNameExists)
+	createGroup(t, other, repo, "group1", false)
+
+	second := createGroup(t, ctx, repo, "group2", false)
+
+	t.Run("Update", func(t *testing.T) {
+		err := repo.UpdateGroup(ctx, &domain.Group{ID: domain.NewOptUUID(id)})
+		require.ErrorIs(t, err, repository.ErrEmptyUpdate)
+
+		err = repo.UpdateGroup(other, &domain.Group{
+			ID:          domain.NewOptUUID(id),
+			Description: domain.NewOptString("description"),
+		})
+		require.ErrorIs(t, err, repository.ErrGroupNotFound)
+
+		err = repo.UpdateGroup(ctx, &domain.Group{
+			ID:   domain.NewOptUUID(second),
+			Name: domain.NewOptString("group1"),
+		})
+		require.ErrorIs(t, err, repository.ErrGroupNameExists)
+
+		err = repo.UpdateGroup(ctx, &domain.Group{
+			ID:          domain.NewOptUUID(id),
+			Description: domain.NewOptString("description"),
+			IsAdmin:     domain.NewOptBool(true),
+		})
+		require.NoError(t, err)
+
+		got, err := repo.GetGroupById(ctx, id)
+		require.NoError(t, err)
+		require.Equal(t, "group1", got.Name.Value)
+		require.Equal(t, "description", got.Description.Value)
+		require.True(t, got.IsAdmin.Value)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		for i := range 9 {
+			createGroup(t, ctx, repo, fmt.Sprintf("list%d", i), false)
+		}
+
+		first, err := repo.ListGroups(ctx, 0)
+		require.NoError(t, err)
+		require.Len(t, first, 10)
+		require.True(t, slices.IsSortedFunc(first, func(a, b domain.Group) int {
+			return compareIDs(a.ID.Value, b.ID.Value)
+		}))
+
+		second, err := repo.ListGroups(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, second, 1)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, repo.DeleteGroup(ctx, second))
+
+		_, err := repo.GetGroupById(ctx, second)
+		require.ErrorIs(t, err, repository.ErrGroupNotFound)
+
+		require.NoError(t, repo.DeleteGroup(ctx, second))
+	})
+}
+
+func testGroupMembers(t *testing.T, repo service.Repository) {
+	ctx := newTenant(t, repo)
+	other := newTenant(t, repo)
+
+	a := createGroup(t, ctx, repo, "a", false)
+	b := createGroup(t, ctx, repo, "b", false)
+	c := createGroup(t, ctx, repo, "c", false)
+	user := createUser(t, ctx, repo, newUser("member1"))
+	stranger := createUser(t, other, repo, newUser("member2"))
+
+	add := func(group domain.UUID, member domain.GroupMember) error {
+		return repo.AddGroupMember(ctx, group, &member)
+	}
+	userMember := func(id domain.UUID) domain.GroupMember {
+		return domain.GroupMember{UserID: domain.NewOptUUID(id)}
+	}
+	groupMember := func(id domain.UUID) domain.GroupMember {
+		return domain.GroupMember{GroupID: domain.NewOptUUID(id)}
+	}
+
+	// c is nested into b, b is nested into a
+	require.NoError(t, add(a, groupMember(b)))
+	require.NoError(t, add(b, groupMember(c)))
+	require.NoError(t, add(a, userMember(user)))
+	require.NoError(t, add(c, userMember(user)))
+
+	require.ErrorIs(t, add(a, userMember(user)), repository.ErrMemberExists)
+	require.ErrorIs(t, add(a, groupMember(b)), repository.ErrMemberExists)
+	require.ErrorIs(t, add(c, groupMember(a)), repository.ErrGroupCycle)
+	require.ErrorIs(t, add(a, groupMember(a)), repository.ErrGroupCycle)
+
+	require.ErrorIs(t, add(a, userMember(stranger)), repository.ErrNotFound)
+	require.ErrorIs(t, add(a, userMember(domain.UUID(uuid.New()))), repository.ErrNotFound)
+	require.ErrorIs(t, add(a, groupMember(domain.UUID(uuid.New()))), repository.ErrGroupNotFound)
+	require.ErrorIs(t, add(domain.UUID(uuid.New()), userMember(user)), repository.ErrGroupNotFound)
+
+	members, err := repo.ListGroupMembers(ctx, a)
+	require.NoError(t, err)
+	require.Equal(t, []domain.GroupMember{userMember(user), groupMember(b)}, members)
+
+	members, err = repo.ListGroupMembers(other, a)
+	require.NoError(t, err)
+	require.Empty(t, members)
+
+	groups, err := repo.GetUserGroups(ctx, user)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b", "c"}, groupNames(groups))
+
+	t.Run("Remove", func(t *testing.T) {
+		require.ErrorIs(t, repo.RemoveGroupMember(ctx, b, user), repository.ErrMemberNotFound)
+		require.ErrorIs(t, repo.RemoveGroupMember(other, a, user), repository.ErrMemberNotFound)
+
+		require.NoError(t, repo.RemoveGroupMember(ctx, b, c))
+		groups, err := repo.GetUserGroups(ctx, user)
+		require.NoError(t, err)
+		require.Equal(t, []string{"a", "c"}, groupNames(groups))
+
+		// memberships of a deleted user are removed
+		require.NoError(t, repo.DeleteUser(ctx, user))
+		members, err := repo.ListGroupMembers(ctx, a)
+		require.NoError(t, err)
+		require.Equal(t, []domain.GroupMember{groupMember(b)}, members)
+
+		// and of a deleted group
+		require.NoError(t, repo.DeleteGroup(ctx, b))
+		members, err = repo.ListGroupMembers(ctx, a)
+		require.NoError(t, err)
+		require.Empty(t, members)
+	})
+}
+
+func testLockAdmins(t *testing.T, repo service.Repository) {
+	ctx := newTenant(t, repo)
+
+	admins, err := repo.LockAdmins(ctx)
+	require.NoError(t, err)
+	require.Empty(t, admins)
+
+	admin := newUser("admin1")
+	admin.IsAdmin.SetTo(true)
+	direct := createUser(t, ctx, repo, admin)
+	granted := createUser(t, ctx, repo, newUser("admin2"))
+	createUser(t, ctx, repo, newUser("user1"))
+
+	// permissions of an admin group are granted to members of nested groups
+	adminGroup := createGroup(t, ctx, repo, "admins", true)
+	nested := createGroup(t, ctx, repo, "nested", false)
+	require.NoError(t, repo.AddGroupMember(ctx, adminGroup, &domain.GroupMember{
+		GroupID: domain.NewOptUUID(nested),
+	}))
+	require.NoError(t, repo.AddGroupMember(ctx, nested, &domain.GroupMember{
+		UserID: domain.NewOptUUID(granted),
+	}))
+	require.NoError(t, repo.AddGroupMember(ctx, adminGroup, &domain.GroupMember{
+		UserID: domain.NewOptUUID(direct),
+	}))
+
+	err = repo.WithTx(ctx, func(repo service.Repository) error {
+		admins, err = repo.LockAdmins(ctx)
+		return err
+	})
+	require.NoError(t, err)
+	require.Equal(t, []domain.UUID{direct, granted}, admins)
+}
+
+func testOrganizations(t *testing.T, repo service.Repository) {
+	ctx := context.Background()
+
+	def, err := repo.GetOrganizationById(ctx, tenant.Default)
+	require.NoError(t, err)
+	require.Equal(t, "default", def.Name.Value)
+
+	name := "repotest-" + uuid.NewString()
+	id, err := repo.CreateOrganization(ctx, &domain.Organization{Name: domain.NewOptString(name)})
+	require.NoError(t, err)
+
+	_, err = repo.CreateOrganization(ctx, &domain.Organization{Name: domain.NewOptString(name)})
+	require.ErrorIs(t, err, repository.ErrOrganizationNameExists)
+
+	org, err := repo.GetOrganizationByName(ctx, name)
+	require.NoError(t, err)
+	require.Equal(t, &domain.Organization{
+		ID:   domain.NewOptUUID(*id),
+		Name: domain.NewOptString(name),
+	}, org)
+
+	_, err = repo.GetOrganizationByName(ctx, "repotest-"+uuid.NewString())
+	require.ErrorIs(t, err, repository.ErrOrganizationNotFound)
+	_, err = repo.GetOrganizationById(ctx, domain.UUID(uuid.New()))
+	require.ErrorIs(t, err, repository.ErrOrganizationNotFound)
+
+	orgs, err := repo.ListOrganizations(ctx, 0)
+	require.NoError(t, err)
+	require.NotEmpty(t, orgs)
+	require.LessOrEqual(t, len(orgs), 10)
+	require.True(t, slices.IsSortedFunc(orgs, func(a, b domain.Organization) int {
+		return compareIDs(a.ID.Value, b.ID.Value)
+	}))
+
+	// users and groups are deleted with the organization
+	orgCtx := tenant.WithTenant(ctx, *id)
+	user := createUser(t, orgCtx, repo, newUser("user1"))
+	group := createGroup(t, orgCtx, repo, "group1", false)
+
+	require.NoError(t, repo.DeleteOrganization(ctx, *id))
+
+	_, err = repo.GetOrganizationById(ctx, *id)
+	require.ErrorIs(t, err, repository.ErrOrganizationNotFound)
+	_, err = repo.GetUserById(orgCtx, user)
+	require.ErrorIs(t, err, repository.ErrNotFound)
+	_, err = repo.GetGroupById(orgCtx, group)
+	require.ErrorIs(t, err, repository.ErrGroupNotFound)
+}
+
+func testWithTx(t *testing.T, repo service.Repository) {
+	ctx := newTenant(t, repo)
+	errRollback := errors.New("rollback")
+
+	t.Run("Rollback", func(t *testing.T) {
+		err := repo.WithTx(ctx, func(tx service.Repository) error {
+			createUser(t, ctx, tx, newUser("rollback1"))
+
+			// changes are visible inside the transaction
+			_, err := tx.GetUserByUsername(ctx, "rollback1")
+			require.NoError(t, err)
+
+			return errRollback
+		})
+		require.ErrorIs(t, err, errRollback)
+
+		_, err = repo.GetUserByUsername(ctx, "rollback1")
+		require.ErrorIs(t, err, repository.ErrNotFound)
+	})
+
+	t.Run("Commit", func(t *testing.T) {
+		err := repo.WithTx(ctx, func(tx service.Repository) error {
+			createUser(t, ctx, tx, newUser("commit1"))
+			return nil
+		})
+		require.NoError(t, err)
+
+		_, err = repo.GetUserByUsername(ctx, "commit1")
+		require.NoError(t, err)
+	})
+
+	t.Run("Nested", func(t *testing.T) {
+		// nested transaction joins the outer one
+		err := repo.WithTx(ctx, func(tx service.Repository) error {
+			err := tx.WithTx(ctx, func(tx service.Repository) error {
+				createUser(t, ctx, tx, newUser("nested1"))
+				return nil
+			})
+			require.NoError(t, err)
+
+			return errRollback
+		})
+		require.ErrorIs(t, err, errRollback)
+
+		_, err = repo.GetUserByUsername(ctx, "nested1")
+		require.ErrorIs(t, err, repository.ErrNotFound)
+	})
+
+	t.Run("Repository errors", func(t *testing.T) {
+		createUser(t, ctx, repo, newUser("unique1"))
+
+		err := repo.WithTx(ctx, func(tx service.Repository) error {
+			_, err := tx.CreateUser(ctx, newUser("unique1"))
+			return err
+		})
+		require.ErrorIs(t, err, repository.ErrUsernameExists)
+	})
+}
+
+// outboxStore is implemented by repositories with the transactional outbox
+type outboxStore interface {
+	RelayEvents(ctx context.Context, limit int, publish func(repository.OutboxEvent) error) (int, error)
+}
+
+// drainEvents relays every event of the outbox and returns the ones of the organization,
+// events of parallel tests are relayed as well
+func drainEvents(t *testing.T, store outboxStore, tenantID domain.UUID) []repository.OutboxEvent {
+	t.Helper()
+
+	var events []repository.OutboxEvent
+	for {
+		n, err := store.RelayEvents(context.Background(), 100, func(e repository.OutboxEvent) error {
+			if e.TenantID == uuid.UUID(tenantID) {
+				events = append(events, e)
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		if n == 0 {
+			return events
+		}
+	}
+}
+
+func testOutbox(t *testing.T, repo service.Repository) {
+	store, ok := repo.(outboxStore)
+	if !ok {
+		t.Skip("repository has no outbox")
+	}
+
+	ctx := newTenant(t, repo)
+	tenantID, _ := tenant.FromContext(ctx)
+	drainEvents(t, store, tenantID)
+
+	user := newUser("outbox1")
+	id := createUser(t, ctx, repo, user)
+	require.NoError(t, repo.UpdateUser(ctx, &domain.User{
+		ID:       domain.NewOptUUID(id),
+		Email:    domain.NewOptString("changed1@mail.ru"),
+		Password: domain.NewOptString(password),
+	}))
+
+	// rolled back changes and failed writes have no events
+	err := repo.WithTx(ctx, func(tx service.Repository) error {
+		createUser(t, ctx, tx, newUser("outbox2"))
+		return errors.New("rollback")
+	})
+	require.Error(t, err)
+	_, err = repo.CreateUser(ctx, newUser("outbox1"))
+	require.ErrorIs(t, err, repository.ErrUsernameExists)
+
+	require.NoError(t, repo.DeleteUser(ctx, id))
+	require.NoError(t, repo.DeleteUser(ctx, id))
+
+	// a failed publish stops the relay, the event is passed again
+	_, err = store.RelayEvents(context.Background(), 100, func(e repository.OutboxEvent) error {
+		if e.TenantID == uuid.UUID(tenantID) {
+			return errors.New("publish failed")
+		}
+		return nil
+	})
+	require.Error(t, err)
+
+	events := drainEvents(t, store, tenantID)
+	types := make([]string, 0, len(events))
+	for _, e := range events {
+		require.Equal(t, uuid.UUID(id), e.UserID)
+		require.False(t, e.CreatedAt.IsZero())
+		types = append(types, e.Type)
+	}
+	require.Equal(t, []string{
+		repository.EventUserCreated,
+		repository.EventUserUpdated,
+		repository.EventUserDeleted,
+	}, types)
+
+	require.JSONEq(t, `{
+		"username": "outbox1",
+		"email": "outbox1@mail.ru",
+		"is_admin": false,
+		"must_change_password": false
+	}`, string(events[0].Payload))
+	require.JSONEq(t, `{
+		"email": "changed1@mail.ru",
+		"changed": ["email", "password"]
+	}`, string(events[1].Payload))
+	require.JSONEq(t, `{}`, string(events[2].Payload))
+
+	imported := newUser("outbox3")
+	_, err = repo.ImportUsers(ctx, []domain.User{*imported}, domain.ServiceImportUsersOnConflictUpdate)
+	require.NoError(t, err)
+	_, err = repo.ImportUsers(ctx, []domain.User{*imported}, domain.ServiceImportUsersOnConflictUpdate)
+	require.NoError(t, err)
+
+	events = drainEvents(t, store, tenantID)
+	require.Len(t, events, 2)
+	require.Equal(t, repository.EventUserCreated, events[0].Type)
+	require.Equal(t, repository.EventUserUpdated, events[1].Type)
+	require.JSONEq(t, `{
+		"email": "outbox3@mail.ru",
+		"is_admin": false,
+		"changed": ["email", "password", "is_admin"]
+	}`, string(events[1].Payload))
+}
+
+func createWebhook(t *testing.T, ctx context.Context, repo service.Repository, events ...domain.WebhookEvent) domain.UUID {
+	t.Helper()
+
+	id, err := repo.CreateWebhook(ctx, &domain.Webhook{
+		URL:    domain.NewOptString("https://example.com/hooks"),
+		Events: events,
+		Secret: domain.NewOptString("secret"),
+	})
+	require.NoError(t, err)
+
+	return *id
+}
+
+func testWebhooks(t *testing.T, repo service.Repository) {
+	ctx := newTenant(t, repo)
+
+	id := createWebhook(t, ctx, repo, domain.WebhookEventUserCreated)
+
+	webhook, err := repo.GetWebhookById(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com/hooks", webhook.URL.Value)
+	require.Equal(t, []domain.WebhookEvent{domain.WebhookEventUserCreated}, webhook.Events)
+	require.True(t, webhook.Enabled.Value)
+	require.Equal(t, int32(0), webhook.Failures.Value)
+	// the secret is never read back
+	require.False(t, webhook.Secret.IsSet())
+
+	// webhooks of other organizations are not visible
+	_, err = repo.GetWebhookById(newTenant(t, repo), id)
+	require.ErrorIs(t, err, repository.ErrWebhookNotFound)
+
+	t.Run("Update", func(t *testing.T) {
+		require.NoError(t, repo.UpdateWebhook(ctx, &domain.Webhook{
+			ID:      domain.NewOptUUID(id),
+			URL:     domain.NewOptString("http://example.com/users"),
+			Events:  []domain.WebhookEvent{},
+			Enabled: domain.NewOptBool(false),
+		}))
+
+		webhook, err := repo.GetWebhookById(ctx, id)
+		require.NoError(t, err)
+		require.Equal(t, "http://example.com/users", webhook.URL.Value)
+		require.Empty(t, webhook.Events)
+		require.False(t, webhook.Enabled.Value)
+
+		err = repo.UpdateWebhook(ctx, &domain.Webhook{ID: domain.NewOptUUID(id)})
+		require.ErrorIs(t, err, repository.ErrEmptyUpdate)
+
+		err = repo.UpdateWebhook(ctx, &domain.Webhook{
+			ID:      domain.NewOptUUID(domain.UUID(uuid.New())),
+			Enabled: domain.NewOptBool(true),
+		})
+		require.ErrorIs(t, err, repository.ErrWebhookNotFound)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		ids := []domain.UUID{id}
+		for range 10 {
+			ids = append(ids, createWebhook(t, ctx, repo))
+		}
+		slices.SortFunc(ids, compareIDs)
+
+		first, err := repo.ListWebhooks(ctx, 0)
+		require.NoError(t, err)
+		second, err := repo.ListWebhooks(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, first, 10)
+		require.Len(t, second, 1)
+
+		var listed []domain.UUID
+		for _, webhook := range append(first, second...) {
+			listed = append(listed, webhook.ID.Value)
+		}
+		require.Equal(t, ids, listed)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, repo.DeleteWebhook(ctx, id))
+		require.NoError(t, repo.DeleteWebhook(ctx, id))
+
+		_, err := repo.GetWebhookById(ctx, id)
+		require.ErrorIs(t, err, repository.ErrWebhookNotFound)
+	})
+}
+
+// webhookStore is implemented by repositories delivering webhooks
+type webhookStore interface {
+	EnqueueDeliveries(ctx context.Context, event repository.OutboxEvent) (int, error)
+	ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]repository.DeliveryJob, error)
+	CompleteDelivery(ctx context.Context, result repository.DeliveryResult) (bool, error)
+}
+
+// claimDeliveries claims every due delivery and returns the ones of webhooks,
+// deliveries of parallel tests are claimed as well
+func claimDeliveries(t *testing.T, store webhookStore, webhooks ...domain.UUID) []repository.DeliveryJob {
+	t.Helper()
+
+	jobs, err := store.ClaimDeliveries(context.Background(), 1000, time.Minute)
+	require.NoError(t, err)
+
+	return slices.DeleteFunc(jobs, func(job repository.DeliveryJob) bool {
+		return !slices.Contains(webhooks, domain.UUID(job.WebhookID))
+	})
+}
+
+func testWebhookDeliveries(t *testing.T, repo service.Repository) {
+	store, ok := repo.(webhookStore)
+	if !ok {
+		t.Skip("repository has no webhooks")
+	}
+
+	ctx := newTenant(t, repo)
+	orgID, _ := tenant.FromContext(ctx)
+	tenantID := uuid.UUID(orgID)
+
+	all := createWebhook(t, ctx, repo)
+	created := createWebhook(t, ctx, repo, domain.WebhookEventUserCreated)
+	disabled := createWebhook(t, ctx, repo)
+	require.NoError(t, repo.UpdateWebhook(ctx, &domain.Webhook{
+		ID:      domain.NewOptUUID(disabled),
+		Enabled: domain.NewOptBool(false),
+	}))
+	// webhooks of other organizations don't receive events
+	other := createWebhook(t, newTenant(t, repo), repo)
+
+	event := repository.OutboxEvent{
+		ID:       1,
+		Type:     repository.EventUserUpdated,
+		TenantID: tenantID,
+		UserID:   uuid.New(),
+		Payload:  []byte(`{"changed":["email"]}`),
+	}
+
+	n, err := store.EnqueueDeliveries(context.Background(), event)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	// the relay may pass an event again
+	n, err = store.EnqueueDeliveries(context.Background(), event)
+	require.NoError(t, err)
+	require.Equal(t, 0, n)
+
+	event.ID, event.Type = 2, repository.EventUserCreated
+	n, err = store.EnqueueDeliveries(context.Background(), event)
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+
+	jobs := claimDeliveries(t, store, all, created, disabled, other)
+	require.Len(t, jobs, 3)
+	for _, job := range jobs {
+		require.Equal(t, "https://example.com/hooks", job.URL)
+		require.Equal(t, "secret", job.Secret)
+		require.Zero(t, job.Attempts)
+		require.Contains(t, []domain.UUID{all, created}, domain.UUID(job.WebhookID))
+	}
+	// claimed deliveries are leased
+	require.Empty(t, claimDeliveries(t, store, all, created))
+
+	deliveries, err := repo.ListWebhookDeliveries(ctx, all, 0)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+	// the latest events first
+	require.Equal(t, int64(2), deliveries[0].EventID)
+	require.Equal(t, domain.WebhookEventUserCreated, deliveries[0].Event)
+	require.Equal(t, domain.WebhookDeliveryStatusPending, deliveries[0].Status)
+
+	var payload repository.OutboxEvent
+	require.NoError(t, json.Unmarshal(jobs[0].Payload, &payload))
+	require.Equal(t, tenantID, payload.TenantID)
+
+	t.Run("Complete", func(t *testing.T) {
+		for _, job := range jobs {
+			if domain.UUID(job.WebhookID) != created {
+				continue
+			}
+
+			// a failed attempt is retried at the next attempt time
+			disabledBy, err := store.CompleteDelivery(context.Background(), repository.DeliveryResult{
+				ID:             job.ID,
+				WebhookID:      job.WebhookID,
+				ResponseStatus: 500,
+				Error:          "webhook responded with status 500",
+				NextAttemptAt:  time.Now().Add(-time.Second),
+				DisableAfter:   2,
+			})
+			require.NoError(t, err)
+			require.False(t, disabledBy)
+
+			retried := claimDeliveries(t, store, created)
+			require.Len(t, retried, 1)
+			require.Equal(t, int32(1), retried[0].Attempts)
+
+			_, err = store.CompleteDelivery(context.Background(), repository.DeliveryResult{
+				ID:             job.ID,
+				WebhookID:      job.WebhookID,
+				Succeeded:      true,
+				ResponseStatus: 204,
+			})
+			require.NoError(t, err)
+		}
+
+		deliveries, err := repo.ListWebhookDeliveries(ctx, created, 0)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		require.Equal(t, domain.WebhookDeliveryStatusSucceeded, deliveries[0].Status)
+		require.Equal(t, int32(2), deliveries[0].Attempts)
+		require.Equal(t, int32(204), deliveries[0].ResponseStatus.Value)
+		require.False(t, deliveries[0].Error.IsSet())
+		require.False(t, deliveries[0].NextAttemptAt.IsSet())
+		require.True(t, deliveries[0].DeliveredAt.IsSet())
+
+		// a success resets failures
+		webhook, err := repo.GetWebhookById(ctx, created)
+		require.NoError(t, err)
+		require.Equal(t, int32(0), webhook.Failures.Value)
+	})
+
+	t.Run("Disable", func(t *testing.T) {
+		var last repository.DeliveryJob
+		failures := 0
+		for _, job := range jobs {
+			if domain.UUID(job.WebhookID) != all {
+				continue
+			}
+			last = job
+			failures++
+
+			// attempts are exhausted, the second failure in a row disables the webhook
+			disabledBy, err := store.CompleteDelivery(context.Background(), repository.DeliveryResult{
+				ID:           job.ID,
+				WebhookID:    job.WebhookID,
+				Error:        "connection refused",
+				DisableAfter: 2,
+			})
+			require.NoError(t, err)
+			require.Equal(t, failures == 2, disabledBy)
+		}
+
+		webhook, err := repo.GetWebhookById(ctx, all)
+		require.NoError(t, err)
+		require.False(t, webhook.Enabled.Value)
+		require.Equal(t, int32(2), webhook.Failures.Value)
+
+		deliveries, err := repo.ListWebhookDeliveries(ctx, all, 0)
+		require.NoError(t, err)
+		for _, delivery := range deliveries {
+			require.Equal(t, domain.WebhookDeliveryStatusFailed, delivery.Status)
+			require.Equal(t, "connection refused", delivery.Error.Value)
+			require.False(t, delivery.ResponseStatus.IsSet())
+		}
+
+		// a redelivery waits until the webhook is enabled
+		redelivered, err := repo.RedeliverWebhookDelivery(ctx, all, domain.UUID(last.ID))
+		require.NoError(t, err)
+		require.Equal(t, domain.WebhookDeliveryStatusPending, redelivered.Status)
+		require.Zero(t, redelivered.Attempts)
+		require.Empty(t, claimDeliveries(t, store, all))
+
+		require.NoError(t, repo.UpdateWebhook(ctx, &domain.Webhook{
+			ID:      domain.NewOptUUID(all),
+			Enabled: domain.NewOptBool(true),
+		}))
+		webhook, err = repo.GetWebhookById(ctx, all)
+		require.NoError(t, err)
+		require.Equal(t, int32(0), webhook.Failures.Value)
+
+		retried := claimDeliveries(t, store, all)
+		require.Len(t, retried, 1)
+		require.Equal(t, last.ID, retried[0].ID)
+
+		_, err = repo.RedeliverWebhookDelivery(ctx, created, domain.UUID(last.ID))
+		require.ErrorIs(t, err, repository.ErrDeliveryNotFound)
+		_, err = repo.RedeliverWebhookDelivery(newTenant(t, repo), all, domain.UUID(last.ID))
+		require.ErrorIs(t, err, repository.ErrDeliveryNotFound)
+	})
+}
+
+type changeLog interface {
+	ListChanges(ctx context.Context, after int64, limit int) ([]repository.OutboxEvent, error)
+	LastChangeID(ctx context.Context) (int64, error)
+	PruneChanges(ctx context.Context, keep int) (int64, error)
+}
+
+func testChanges(t *testing.T, repo service.Repository) {
+	store, ok := repo.(changeLog)
+	if !ok {
+		t.Skip("repository has no log of changes")
+	}
+
+	ctx := newTenant(t, repo)
+	orgID, _ := tenant.FromContext(ctx)
+	tenantID := uuid.UUID(orgID)
+
+	last, err := store.LastChangeID(ctx)
+	require.NoError(t, err)
+
+	id := createUser(t, ctx, repo, newUser("changes1"))
+	require.NoError(t, repo.UpdateUser(ctx, &domain.User{
+		ID:    domain.NewOptUUID(id),
+		Email: domain.NewOptString("changed1@mail.ru"),
+	}))
+	// rolled back changes aren't logged
+	err = repo.WithTx(ctx, func(tx service.Repository) error {
+		createUser(t, ctx, tx, newUser("changes2"))
+		return errors.New("rollback")
+	})
+	require.Error(t, err)
+	require.NoError(t, repo.DeleteUser(ctx, id))
+
+	// events of parallel tests are logged as well
+	logged, err := store.ListChanges(ctx, last, 1000)
+	require.NoError(t, err)
+	var events []repository.OutboxEvent
+	for i, e := range logged {
+		if i > 0 {
+			require.Greater(t, e.ID, logged[i-1].ID)
+		}
+		if e.TenantID == tenantID {
+			events = append(events, e)
+		}
+	}
+	require.Len(t, events, 3)
+	require.Equal(t, repository.EventUserCreated, events[0].Type)
+	require.Equal(t, repository.EventUserUpdated, events[1].Type)
+	require.Equal(t, repository.EventUserDeleted, events[2].Type)
+	require.Equal(t, uuid.UUID(id), events[1].UserID)
+	require.JSONEq(t, `{"email": "changed1@mail.ru", "changed": ["email"]}`, string(events[1].Payload))
+
+	latest, err := store.LastChangeID(ctx)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, latest, events[2].ID)
+
+	// the latest events are kept
+	_, err = store.PruneChanges(ctx, 1)
+	require.NoError(t, err)
+	pruned, err := store.ListChanges(ctx, events[0].ID-1, 1)
+	require.NoError(t, err)
+	require.NotEqual(t, events[0].ID, pruned[0].ID)
+
+	latest, err = store.LastChangeID(ctx)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, latest, events[2].ID)
+}
+
+type idempotencyStore interface {
+	ClaimIdempotencyKey(ctx context.Context, record repository.IdempotencyRecord, now time.Time) (*repository.IdempotencyRecord, error)
+	CompleteIdempotencyKey(ctx context.Context, record repository.IdempotencyRecord) error
+	ReleaseIdempotencyKey(ctx context.Context, scope, key string) error
+	DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int64, error)
+}
+
+func testIdempotencyKeys(t *testing.T, repo service.Repository) {
+	store, ok := repo.(idempotencyStore)
+	if !ok {
+		t.Skip("repository has no idempotency keys")
+	}
+
+	ctx := context.Background()
+	// keys aren't scoped to organizations, the scope is unique to the test
+	now := time.Now().Truncate(time.Millisecond)
+	record := repository.IdempotencyRecord{
+		Scope:       uuid.NewString(),
+		Key:         "key1",
+		Fingerprint: "fingerprint1",
+		ExpiresAt:   now.Add(time.Minute),
+	}
+
+	stored, err := store.ClaimIdempotencyKey(ctx, record, now)
+	require.NoError(t, err)
+	require.Nil(t, stored)
+
+	// the key is in progress
+	other := record
+	other.Fingerprint = "fingerprint2"
+	stored, err = store.ClaimIdempotencyKey(ctx, other, now)
+	require.NoError(t, err)
+	require.Equal(t, "fingerprint1", stored.Fingerprint)
+	require.True(t, stored.InProgress())
+
+	// the released key is claimed again
+	require.NoError(t, store.ReleaseIdempotencyKey(ctx, record.Scope, record.Key))
+	stored, err = store.ClaimIdempotencyKey(ctx, record, now)
+	require.NoError(t, err)
+	require.Nil(t, stored)
+
+	// the fingerprint is set with the response
+	completed := record
+	completed.Fingerprint = "fingerprint3"
+	completed.Status = 201
+	completed.Header = `{"Location":["/users/1"]}`
+	completed.Body = []byte(`{"id":1}`)
+	completed.ExpiresAt = now.Add(time.Hour)
+	require.NoError(t, store.CompleteIdempotencyKey(ctx, completed))
+	// completed keys aren't released
+	require.NoError(t, store.ReleaseIdempotencyKey(ctx, record.Scope, record.Key))
+
+	stored, err = store.ClaimIdempotencyKey(ctx, record, now.Add(time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, int32(201), stored.Status)
+	require.Equal(t, "fingerprint3", stored.Fingerprint)
+	require.Equal(t, completed.Header, stored.Header)
+	require.Equal(t, completed.Body, stored.Body)
+	require.True(t, completed.ExpiresAt.Equal(stored.ExpiresAt))
+
+	// the expired key is claimed by another request
+	stored, err = store.ClaimIdempotencyKey(ctx, other, now.Add(2*time.Hour))
+	require.NoError(t, err)
+	require.Nil(t, stored)
+	stored, err = store.ClaimIdempotencyKey(ctx, record, now)
+	require.NoError(t, err)
+	require.Equal(t, "fingerprint2", stored.Fingerprint)
+	require.True(t, stored.InProgress())
+
+	_, err = store.DeleteExpiredIdempotencyKeys(ctx, now.Add(2*time.Minute))
+	require.NoError(t, err)
+	stored, err = store.ClaimIdempotencyKey(ctx, record, now)
+	require.NoError(t, err)
+	require.Nil(t, stored)
+}
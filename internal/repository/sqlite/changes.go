@@ -0,0 +1,67 @@
+package sqlite
+
+import (
+	"context"
+
+	"github.com/liriquew/test_task/internal/repository"
+)
+
+// ListChanges returns up to limit events of the log of changes of all organizations
+// with ids greater than after, in id order.
+// Transactions of sqlite are serialized, ids are committed in order
+func (s *Repository) ListChanges(ctx context.Context, after int64, limit int) ([]repository.OutboxEvent, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	// the driver returns TEXT as a string, json.RawMessage is scanned from bytes only
+	query := `
+		SELECT id, event_type, tenant_id, user_id, CAST(payload AS BLOB) AS payload, created_at
+		FROM user_changes
+		WHERE id > ?
+		ORDER BY id
+		LIMIT ?
+	`
+
+	var events []repository.OutboxEvent
+	if err := s.q.SelectContext(ctx, &events, query, after, limit); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// LastChangeID returns the id of the latest event of the log, 0 if it is empty
+func (s *Repository) LastChangeID(ctx context.Context) (int64, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	var id int64
+	if err := s.q.GetContext(ctx, &id, `SELECT COALESCE(max(id), 0) FROM user_changes`); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// PruneChanges deletes events of the log except the latest keep ones,
+// returns the number of deleted events
+func (s *Repository) PruneChanges(ctx context.Context, keep int) (int64, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	query := `
+		DELETE FROM user_changes
+		WHERE id <= (
+			SELECT id FROM user_changes
+			ORDER BY id DESC
+			LIMIT 1 OFFSET ?
+		)
+	`
+
+	res, err := s.q.ExecContext(ctx, query, keep)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
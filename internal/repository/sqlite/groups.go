@@ -0,0 +1,405 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/repository"
+)
+
+func (s *Repository) ListGroups(ctx context.Context, offset int64) ([]domain.Group, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	var groups []repository.DBGroup
+
+	query := `
+		SELECT * FROM groups
+		WHERE tenant_id = ?
+		ORDER BY id
+		LIMIT 10
+		OFFSET ?
+	`
+
+	if err := s.q.SelectContext(ctx, &groups, query, tenantID(ctx), offset); err != nil {
+		return nil, err
+	}
+
+	res := make([]domain.Group, 0, len(groups))
+	for _, group := range groups {
+		res = append(res, repository.ConvertDBGroupToGroup(group))
+	}
+
+	return res, nil
+}
+
+func (s *Repository) CreateGroup(ctx context.Context, group *domain.Group) (*domain.UUID, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO groups (name, description, is_admin, tenant_id) VALUES
+		(?, ?, ?, ?) RETURNING id
+	`
+
+	var id uuid.UUID
+	err := s.q.QueryRowContext(ctx, query,
+		group.Name.Value,
+		group.Description.Value,
+		group.IsAdmin.Value,
+		tenantID(ctx),
+	).Scan(&id)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, repository.ErrGroupNameExists
+		}
+		return nil, err
+	}
+
+	res := domain.UUID(id)
+
+	return &res, nil
+}
+
+func (s *Repository) GetGroupById(ctx context.Context, id domain.UUID) (*domain.Group, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT * FROM groups
+		WHERE id = ? AND tenant_id = ?
+	`
+
+	group := repository.DBGroup{}
+	err := s.q.GetContext(ctx, &group, query, repository.UUID(id), tenantID(ctx))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrGroupNotFound
+		}
+		return nil, err
+	}
+
+	res := repository.ConvertDBGroupToGroup(group)
+
+	return &res, nil
+}
+
+func (s *Repository) UpdateGroup(ctx context.Context, group *domain.Group) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE groups SET %s WHERE id = ? AND tenant_id = ?
+	`
+
+	queryParams, args, err := buildGroupUpdate(group)
+	if err != nil {
+		return err
+	}
+	args = append(args, repository.UUID(group.ID.Value), tenantID(ctx))
+
+	query = fmt.Sprintf(query, queryParams)
+
+	result, err := s.q.ExecContext(ctx, query, args...)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return repository.ErrGroupNameExists
+		}
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return repository.ErrGroupNotFound
+	}
+
+	return nil
+}
+
+func (s *Repository) DeleteGroup(ctx context.Context, id domain.UUID) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	query := `
+		DELETE FROM groups
+		WHERE id = ? AND tenant_id = ?
+	`
+
+	_, err := s.q.ExecContext(ctx, query, repository.UUID(id), tenantID(ctx))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *Repository) ListGroupMembers(ctx context.Context, groupID domain.UUID) ([]domain.GroupMember, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	var members []repository.DBGroupMember
+
+	// NULLS LAST is the default of postgres, users go first
+	query := `
+		SELECT gm.user_id, gm.member_group_id FROM group_members gm
+		JOIN groups g ON g.id = gm.group_id
+		WHERE gm.group_id = ? AND g.tenant_id = ?
+		ORDER BY gm.user_id NULLS LAST, gm.member_group_id NULLS LAST
+	`
+
+	if err := s.q.SelectContext(ctx, &members, query, repository.UUID(groupID), tenantID(ctx)); err != nil {
+		return nil, err
+	}
+
+	res := make([]domain.GroupMember, 0, len(members))
+	for _, member := range members {
+		res = append(res, repository.ConvertDBGroupMemberToGroupMember(member))
+	}
+
+	return res, nil
+}
+
+func (s *Repository) AddGroupMember(ctx context.Context, groupID domain.UUID, member *domain.GroupMember) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	tx, err := s.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// group and member must belong to the same organization
+	groupExists := `SELECT EXISTS (SELECT 1 FROM groups WHERE id = ? AND tenant_id = ?)`
+	userExists := `SELECT EXISTS (SELECT 1 FROM users WHERE id = ? AND tenant_id = ?)`
+
+	var exists bool
+	if err := tx.GetContext(ctx, &exists, groupExists, repository.UUID(groupID), tenantID(ctx)); err != nil {
+		return err
+	}
+	if !exists {
+		return repository.ErrGroupNotFound
+	}
+
+	if member.UserID.IsSet() {
+		if err := tx.GetContext(ctx, &exists, userExists, repository.UUID(member.UserID.Value), tenantID(ctx)); err != nil {
+			return err
+		}
+		if !exists {
+			return repository.ErrNotFound
+		}
+	}
+
+	if member.GroupID.IsSet() {
+		if err := tx.GetContext(ctx, &exists, groupExists, repository.UUID(member.GroupID.Value), tenantID(ctx)); err != nil {
+			return err
+		}
+		if !exists {
+			return repository.ErrGroupNotFound
+		}
+
+		// the group must not be reachable from the nested group,
+		// the transaction holds the write lock, so the check can't race
+		query := `
+			WITH RECURSIVE nested(id) AS (
+				SELECT ?
+				UNION
+				SELECT gm.member_group_id FROM group_members gm
+				JOIN nested ON gm.group_id = nested.id
+				WHERE gm.member_group_id IS NOT NULL
+			)
+			SELECT EXISTS (SELECT 1 FROM nested WHERE id = ?)
+		`
+
+		var cycle bool
+		err := tx.GetContext(ctx, &cycle, query,
+			repository.UUID(member.GroupID.Value),
+			repository.UUID(groupID),
+		)
+		if err != nil {
+			return err
+		}
+		if cycle {
+			return repository.ErrGroupCycle
+		}
+	}
+
+	query := `
+		INSERT INTO group_members (group_id, user_id, member_group_id) VALUES
+		(?, ?, ?)
+	`
+
+	_, err = tx.ExecContext(ctx, query,
+		repository.UUID(groupID),
+		nullUUID(member.UserID),
+		nullUUID(member.GroupID),
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return repository.ErrMemberExists
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *Repository) RemoveGroupMember(ctx context.Context, groupID, memberID domain.UUID) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	query := `
+		DELETE FROM group_members
+		WHERE group_id = ? AND (user_id = ? OR member_group_id = ?)
+		AND group_id IN (SELECT id FROM groups WHERE tenant_id = ?)
+	`
+
+	result, err := s.q.ExecContext(ctx, query,
+		repository.UUID(groupID),
+		repository.UUID(memberID),
+		repository.UUID(memberID),
+		tenantID(ctx),
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return repository.ErrMemberNotFound
+	}
+
+	return nil
+}
+
+// GetUserGroups returns groups the user is a member of,
+// directly or through nested groups
+func (s *Repository) GetUserGroups(ctx context.Context, userID domain.UUID) ([]domain.Group, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	var groups []repository.DBGroup
+
+	// UNION (not UNION ALL) drops already visited groups,
+	// so the recursion stops even on a broken (cyclic) graph
+	query := `
+		WITH RECURSIVE user_groups(id) AS (
+			SELECT group_id FROM group_members
+			WHERE user_id = ?
+			UNION
+			SELECT gm.group_id FROM group_members gm
+			JOIN user_groups ON gm.member_group_id = user_groups.id
+		)
+		SELECT g.* FROM groups g
+		JOIN user_groups ON g.id = user_groups.id
+		WHERE g.tenant_id = ?
+		ORDER BY g.name
+	`
+
+	if err := s.q.SelectContext(ctx, &groups, query, repository.UUID(userID), tenantID(ctx)); err != nil {
+		return nil, err
+	}
+
+	res := make([]domain.Group, 0, len(groups))
+	for _, group := range groups {
+		res = append(res, repository.ConvertDBGroupToGroup(group))
+	}
+
+	return res, nil
+}
+
+// LockAdmins returns ids of users with admin permissions, granted directly
+// or through an admin group. Rows can't be locked in sqlite, concurrent
+// demotions are serialized by the write lock of WithTx
+func (s *Repository) LockAdmins(ctx context.Context) ([]domain.UUID, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	var direct []uuid.UUID
+
+	query := `
+		SELECT id FROM users
+		WHERE tenant_id = ? AND is_admin
+		ORDER BY id
+	`
+
+	if err := s.q.SelectContext(ctx, &direct, query, tenantID(ctx)); err != nil {
+		return nil, err
+	}
+
+	var granted []uuid.UUID
+
+	// members of admin groups, including members of nested groups
+	query = `
+		WITH RECURSIVE admin_groups(id) AS (
+			SELECT id FROM groups
+			WHERE tenant_id = ? AND is_admin
+			UNION
+			SELECT gm.member_group_id FROM group_members gm
+			JOIN admin_groups ON gm.group_id = admin_groups.id
+			WHERE gm.member_group_id IS NOT NULL
+		)
+		SELECT DISTINCT gm.user_id FROM group_members gm
+		JOIN admin_groups ON gm.group_id = admin_groups.id
+		WHERE gm.user_id IS NOT NULL
+	`
+
+	if err := s.q.SelectContext(ctx, &granted, query, tenantID(ctx)); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[uuid.UUID]bool, len(direct)+len(granted))
+	res := make([]domain.UUID, 0, len(direct)+len(granted))
+	for _, id := range append(direct, granted...) {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		res = append(res, domain.UUID(id))
+	}
+
+	return res, nil
+}
+
+func buildGroupUpdate(group *domain.Group) (queryParams string, args []any, err error) {
+	var columns []string
+
+	if group.Name.IsSet() {
+		args = append(args, group.Name.Value)
+		columns = append(columns, "name = ?")
+	}
+	if group.Description.IsSet() {
+		args = append(args, group.Description.Value)
+		columns = append(columns, "description = ?")
+	}
+	if group.IsAdmin.IsSet() {
+		args = append(args, group.IsAdmin.Value)
+		columns = append(columns, "is_admin = ?")
+	}
+
+	if len(args) == 0 {
+		return "", nil, repository.ErrEmptyUpdate
+	}
+
+	return strings.Join(columns, ", "), args, nil
+}
+
+// nullUUID stores an unset id as NULL, ids are stored as text
+func nullUUID(id domain.OptUUID) any {
+	if !id.IsSet() {
+		return nil
+	}
+
+	return repository.UUID(id.Value)
+}
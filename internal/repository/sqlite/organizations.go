@@ -0,0 +1,126 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/repository"
+)
+
+// organizations aren't scoped by tenant, only super-admins can manage them
+
+func (s *Repository) ListOrganizations(ctx context.Context, offset int64) ([]domain.Organization, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	var orgs []repository.DBOrganization
+
+	query := `
+		SELECT * FROM organizations
+		ORDER BY id
+		LIMIT 10
+		OFFSET ?
+	`
+
+	if err := s.q.SelectContext(ctx, &orgs, query, offset); err != nil {
+		return nil, err
+	}
+
+	res := make([]domain.Organization, 0, len(orgs))
+	for _, org := range orgs {
+		res = append(res, repository.ConvertDBOrganizationToOrganization(org))
+	}
+
+	return res, nil
+}
+
+func (s *Repository) CreateOrganization(ctx context.Context, org *domain.Organization) (*domain.UUID, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO organizations (name) VALUES
+		(?) RETURNING id
+	`
+
+	var id uuid.UUID
+	err := s.q.QueryRowContext(ctx, query, org.Name.Value).Scan(&id)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, repository.ErrOrganizationNameExists
+		}
+		return nil, err
+	}
+
+	res := domain.UUID(id)
+
+	return &res, nil
+}
+
+func (s *Repository) GetOrganizationById(ctx context.Context, id domain.UUID) (*domain.Organization, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT * FROM organizations
+		WHERE id = ?
+	`
+
+	org := repository.DBOrganization{}
+	err := s.q.GetContext(ctx, &org, query, repository.UUID(id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+
+	res := repository.ConvertDBOrganizationToOrganization(org)
+
+	return &res, nil
+}
+
+func (s *Repository) GetOrganizationByName(ctx context.Context, name string) (*domain.Organization, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT * FROM organizations
+		WHERE name = ?
+	`
+
+	org := repository.DBOrganization{}
+	err := s.q.GetContext(ctx, &org, query, name)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+
+	res := repository.ConvertDBOrganizationToOrganization(org)
+
+	return &res, nil
+}
+
+// DeleteOrganization removes the organization, its users and groups
+// are removed by foreign keys, enabled for every connection
+func (s *Repository) DeleteOrganization(ctx context.Context, id domain.UUID) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	query := `
+		DELETE FROM organizations
+		WHERE id = ?
+	`
+
+	_, err := s.q.ExecContext(ctx, query, repository.UUID(id))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
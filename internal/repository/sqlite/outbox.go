@@ -0,0 +1,89 @@
+package sqlite
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/liriquew/test_task/internal/repository"
+)
+
+// appendEvent writes the event to the outbox and to the log of changes,
+// q must be the transaction of the change
+func appendEvent(ctx context.Context, q queryer, event repository.OutboxEvent) error {
+	query := `
+		INSERT INTO outbox (event_type, tenant_id, user_id, payload, created_at) VALUES
+		(?, ?, ?, ?, ?)
+		RETURNING id
+	`
+
+	createdAt := time.Now().UTC()
+	var id int64
+	err := q.QueryRowContext(ctx, query,
+		event.Type,
+		event.TenantID.String(),
+		event.UserID.String(),
+		string(event.Payload),
+		createdAt,
+	).Scan(&id)
+	if err != nil {
+		return err
+	}
+
+	query = `
+		INSERT INTO user_changes (id, event_type, tenant_id, user_id, payload, created_at) VALUES
+		(?, ?, ?, ?, ?, ?)
+	`
+
+	_, err = q.ExecContext(ctx, query,
+		id,
+		event.Type,
+		event.TenantID.String(),
+		event.UserID.String(),
+		string(event.Payload),
+		createdAt,
+	)
+	return err
+}
+
+// RelayEvents passes up to limit of the oldest outbox events to publish in order
+// and deletes the published ones. It stops at the first event publish fails on,
+// so events of a user are never published out of order, the event is passed again
+// by the next call. Returns the number of published events.
+// Events are published without a transaction, a transaction would hold the write
+// lock of the database, so a single relay must work with the file
+func (s *Repository) RelayEvents(ctx context.Context, limit int, publish func(repository.OutboxEvent) error) (int, error) {
+	// the driver returns TEXT as a string, json.RawMessage is scanned from bytes only
+	query := `
+		SELECT id, event_type, tenant_id, user_id, CAST(payload AS BLOB) AS payload, created_at
+		FROM outbox
+		ORDER BY id
+		LIMIT ?
+	`
+
+	var events []repository.OutboxEvent
+	if err := s.db.SelectContext(ctx, &events, query, limit); err != nil {
+		return 0, err
+	}
+
+	published := make([]int64, 0, len(events))
+	var publishErr error
+	for _, event := range events {
+		if publishErr = publish(event); publishErr != nil {
+			break
+		}
+		published = append(published, event.ID)
+	}
+
+	if len(published) != 0 {
+		query, args, err := sqlx.In(`DELETE FROM outbox WHERE id IN (?)`, published)
+		if err != nil {
+			return 0, err
+		}
+		if _, err := s.db.ExecContext(ctx, query, args...); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(published), publishErr
+}
@@ -0,0 +1,139 @@
+// Package sqlite keeps the data of the service in a sqlite database,
+// it is used where postgres can't be run. Migrations are in migrations/sqlite
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/liriquew/test_task/internal/lib/config"
+	"github.com/liriquew/test_task/internal/repository"
+	"github.com/liriquew/test_task/internal/service"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+var _ service.Repository = (*Repository)(nil)
+
+type Repository struct {
+	db *sqlx.DB
+	// q is db or the transaction of WithTx
+	q  queryer
+	tx *sqlx.Tx
+	// timeout bounds a single repository call
+	timeout time.Duration
+}
+
+// New opens the database file of cfg.Path. Transactions take the write lock
+// when they begin (sqlite allows a single writer), concurrent writers wait
+// for it up to busy_timeout. WAL lets readers work while a transaction is open.
+// Options of the postgres connection are ignored, pool limits and timeouts apply
+func New(ctx context.Context, cfg config.StorageConfig) (*Repository, error) {
+	dsn := fmt.Sprintf("file:%s?%s", cfg.Path, strings.Join([]string{
+		"_pragma=foreign_keys(1)",
+		"_pragma=journal_mode(WAL)",
+		"_pragma=busy_timeout(5000)",
+		"_txlock=immediate",
+	}, "&"))
+
+	db, err := sqlx.Open("sqlite", dsn)
+	if err != nil {
+		return nil, err
+	}
+	repository.ConfigurePool(db.DB, cfg)
+
+	if err := repository.Ping(ctx, db.DB, cfg.PingAttempts); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &Repository{
+		db:      db,
+		q:       db,
+		timeout: cfg.QueryTimeout,
+	}, nil
+}
+
+// queryContext bounds a single repository call with the query timeout
+func (s *Repository) queryContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.timeout <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, s.timeout)
+}
+
+func (r *Repository) Close() error {
+	return r.db.Close()
+}
+
+const (
+	// unique indexes on lower(username), lower(email),
+	// sqlite reports names of expression indexes in errors
+	usernameConstraint = "users_tenant_lower_username_key"
+	emailConstraint    = "users_tenant_lower_email_key"
+)
+
+// tenantID returns the organization queries are scoped to
+func tenantID(ctx context.Context) repository.TenantArg {
+	return repository.Tenant(ctx)
+}
+
+func isUniqueViolation(err error) bool {
+	var sqliteErr *sqlite.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE
+}
+
+// userConflict translates unique violations of users table to repository errors
+func userConflict(err error) error {
+	if isUniqueViolation(err) {
+		switch {
+		case strings.Contains(err.Error(), usernameConstraint):
+			return repository.ErrUsernameExists
+		case strings.Contains(err.Error(), emailConstraint):
+			return repository.ErrEmailExists
+		}
+	}
+
+	return err
+}
+
+// isBusy reports that the database stayed locked longer than busy_timeout
+func isBusy(err error) bool {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+
+	// extended codes keep the primary one in the lower byte
+	switch sqliteErr.Code() & 0xff {
+	case sqlite3.SQLITE_BUSY, sqlite3.SQLITE_LOCKED:
+		return true
+	}
+
+	return false
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// filterConditions returns filter conditions to be appended to WHERE clause
+func filterConditions(f repository.UserFilter, args []any) (string, []any) {
+	sb := strings.Builder{}
+
+	if f.IsAdmin.IsSet() {
+		args = append(args, f.IsAdmin.Value)
+		sb.WriteString(" AND COALESCE(is_admin, 0) = ?")
+	}
+	if f.Search.Value != "" {
+		// LIKE of sqlite is case-insensitive for ASCII
+		pattern := "%" + likeEscaper.Replace(f.Search.Value) + "%"
+		args = append(args, pattern, pattern)
+		sb.WriteString(` AND (username LIKE ? ESCAPE '\' OR email LIKE ? ESCAPE '\')`)
+	}
+
+	return sb.String(), args
+}
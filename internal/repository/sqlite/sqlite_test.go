@@ -0,0 +1,44 @@
+package sqlite_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/liriquew/test_task/internal/lib/config"
+	"github.com/liriquew/test_task/internal/migrator"
+	"github.com/liriquew/test_task/internal/repository/repotest"
+	"github.com/liriquew/test_task/internal/repository/sqlite"
+	"github.com/liriquew/test_task/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+// migrate applies migrations/sqlite to a new database file
+func migrate(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "users.db")
+
+	m, err := migrator.New(config.StorageConfig{
+		Driver: config.DriverSQLite,
+		Path:   path,
+	})
+	require.NoError(t, err)
+	defer m.Close()
+
+	_, err = m.Up(context.Background())
+	require.NoError(t, err)
+
+	return path
+}
+
+func TestConformance(t *testing.T) {
+	repo, err := sqlite.New(context.Background(), config.StorageConfig{
+		Driver: config.DriverSQLite,
+		Path:   migrate(t),
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { repo.Close() })
+
+	repotest.Run(t, func(t *testing.T) service.Repository {
+		return repo
+	})
+}
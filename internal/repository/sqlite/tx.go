@@ -0,0 +1,106 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/liriquew/test_task/internal/repository"
+	"github.com/liriquew/test_task/internal/service"
+)
+
+// queryer is implemented by both *sqlx.DB and *sqlx.Tx,
+// so the same queries run inside and outside of a transaction
+type queryer interface {
+	sqlx.ExtContext
+	GetContext(ctx context.Context, dest any, query string, args ...any) error
+	SelectContext(ctx context.Context, dest any, query string, args ...any) error
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// WithTx runs fn with a repository bound to a single transaction,
+// the transaction is committed when fn returns nil and rolled back otherwise.
+// Transactions of sqlite are serializable, so isolation is ignored, the whole
+// transaction is retried when the database stays locked longer than busy_timeout.
+// Inside another WithTx fn joins the outer transaction and options are ignored
+func (s *Repository) WithTx(ctx context.Context, fn func(service.Repository) error, opts ...repository.TxOption) error {
+	if s.tx != nil {
+		return fn(s)
+	}
+
+	txOpts, retries := repository.TxSettings(opts...)
+
+	for attempt := 0; ; attempt++ {
+		err := s.runTx(ctx, &sql.TxOptions{ReadOnly: txOpts.ReadOnly}, fn)
+		if err == nil || !isBusy(err) || attempt >= retries {
+			return err
+		}
+
+		if err := repository.TxBackoff(ctx, attempt); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Repository) runTx(ctx context.Context, opts *sql.TxOptions, fn func(service.Repository) error) error {
+	tx, err := s.db.BeginTxx(ctx, opts)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(&Repository{db: s.db, q: tx, tx: tx, timeout: s.timeout}); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// tx is a transaction of a single repository method,
+// inside WithTx it is a savepoint of the outer transaction
+type tx struct {
+	*sqlx.Tx
+	nested bool
+	done   bool
+}
+
+// beginTx starts a transaction, inside WithTx a savepoint is created instead
+func (s *Repository) beginTx(ctx context.Context) (*tx, error) {
+	if s.tx != nil {
+		if _, err := s.tx.ExecContext(ctx, `SAVEPOINT repository_tx`); err != nil {
+			return nil, err
+		}
+		return &tx{Tx: s.tx, nested: true}, nil
+	}
+
+	t, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tx{Tx: t}, nil
+}
+
+func (t *tx) Commit() error {
+	if !t.nested {
+		return t.Tx.Commit()
+	}
+
+	t.done = true
+	_, err := t.Tx.Exec(`RELEASE SAVEPOINT repository_tx`)
+	return err
+}
+
+// Rollback is safe to defer, it does nothing after Commit
+func (t *tx) Rollback() error {
+	if !t.nested {
+		return t.Tx.Rollback()
+	}
+	if t.done {
+		return nil
+	}
+
+	t.done = true
+	_, err := t.Tx.Exec(`ROLLBACK TO SAVEPOINT repository_tx`)
+	return err
+}
@@ -0,0 +1,423 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/repository"
+)
+
+func (s *Repository) ListUsers(ctx context.Context, filter repository.UserFilter, offset int64) ([]domain.User, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	var users []repository.DBUser
+
+	query := `
+		SELECT %s FROM users
+		WHERE tenant_id = ?%s
+		ORDER BY id
+		LIMIT 10
+		OFFSET ?
+	`
+
+	conditions, args := filterConditions(filter, []any{tenantID(ctx)})
+	args = append(args, offset)
+	query = fmt.Sprintf(query, filter.Fields.SelectList(), conditions)
+
+	if err := s.q.SelectContext(ctx, &users, query, args...); err != nil {
+		return nil, err
+	}
+
+	res := make([]domain.User, 0, len(users))
+	for _, user := range users {
+		u := repository.ConvertDBUserToUser(user)
+		filter.Fields.Project(&u)
+		res = append(res, u)
+	}
+
+	return res, nil
+}
+
+func (s *Repository) CreateUser(ctx context.Context, user *domain.User) (*domain.UUID, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO users (username, email, password, is_admin, must_change_password, tenant_id) VALUES
+		(?, ?, ?, ?, ?, ?) RETURNING id
+	`
+
+	tx, err := s.beginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var id uuid.UUID
+	err = tx.QueryRowContext(ctx, query,
+		user.Username.Value,
+		user.Email.Value,
+		user.Password.Value,
+		user.IsAdmin.Value,
+		user.MustChangePassword.Value,
+		tenantID(ctx),
+	).Scan(&id)
+	if err != nil {
+		return nil, userConflict(err)
+	}
+
+	res := domain.UUID(id)
+	if err := appendEvent(ctx, tx, repository.NewUserEvent(ctx, repository.EventUserCreated, res, user)); err != nil {
+		return nil, err
+	}
+
+	return &res, tx.Commit()
+}
+
+func (s *Repository) GetUserById(ctx context.Context, id domain.UUID) (*domain.User, error) {
+	return s.GetUserFieldsById(ctx, id, nil)
+}
+
+func (s *Repository) GetUserFieldsById(ctx context.Context, id domain.UUID, fields repository.UserFields) (*domain.User, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT %s FROM users
+		WHERE id = ? AND tenant_id = ?
+	`
+	query = fmt.Sprintf(query, fields.SelectList())
+
+	user := repository.DBUser{}
+	err := s.q.GetContext(ctx, &user, query, repository.UUID(id), tenantID(ctx))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, err
+	}
+
+	res := repository.ConvertDBUserToUser(user)
+	fields.Project(&res)
+
+	return &res, nil
+}
+
+func (s *Repository) UpdateUser(ctx context.Context, user *domain.User) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE users SET %s WHERE id = ? AND tenant_id = ?
+	`
+
+	queryParams, args, err := buildUpdate(user)
+	if err != nil {
+		return err
+	}
+	args = append(args, repository.UUID(user.ID.Value), tenantID(ctx))
+
+	query = fmt.Sprintf(query, queryParams)
+
+	tx, err := s.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return userConflict(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	if err := appendEvent(ctx, tx, repository.NewUserEvent(ctx, repository.EventUserUpdated, user.ID.Value, user)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *Repository) DeleteUser(ctx context.Context, id domain.UUID) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	query := `
+		DELETE FROM users
+		WHERE id = ? AND tenant_id = ?
+	`
+
+	tx, err := s.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, query, repository.UUID(id), tenantID(ctx))
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	// deletion of a missing user is not an error and not an event
+	if rowsAffected == 0 {
+		return nil
+	}
+
+	if err := appendEvent(ctx, tx, repository.NewUserEvent(ctx, repository.EventUserDeleted, id, nil)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *Repository) GetUserByUsername(ctx context.Context, username string) (*domain.User, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT * FROM users
+		WHERE lower(username) = lower(?) AND tenant_id = ?
+	`
+
+	user := repository.DBUser{}
+	err := s.q.GetContext(ctx, &user, query, username, tenantID(ctx))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, err
+	}
+
+	res := repository.ConvertDBUserToUser(user)
+
+	return &res, nil
+}
+
+func buildUpdate(user *domain.User) (queryParams string, args []any, err error) {
+	var columns []string
+
+	if user.Password.IsSet() {
+		args = append(args, user.Password.Value)
+		columns = append(columns, "password = ?")
+	}
+	if user.Username.IsSet() {
+		args = append(args, user.Username.Value)
+		columns = append(columns, "username = ?")
+	}
+	if user.Email.IsSet() {
+		args = append(args, user.Email.Value)
+		columns = append(columns, "email = ?")
+	}
+	if user.IsAdmin.IsSet() {
+		args = append(args, user.IsAdmin.Value)
+		columns = append(columns, "is_admin = ?")
+	}
+	if user.MustChangePassword.IsSet() {
+		args = append(args, user.MustChangePassword.Value)
+		columns = append(columns, "must_change_password = ?")
+	}
+
+	if len(args) == 0 {
+		return "", nil, repository.ErrEmptyUpdate
+	}
+
+	return strings.Join(columns, ", "), args, nil
+}
+
+// ImportUsers writes a batch of users in a single transaction, a failed row
+// doesn't abort the batch
+func (s *Repository) ImportUsers(
+	ctx context.Context,
+	users []domain.User,
+	onConflict domain.ServiceImportUsersOnConflict,
+) ([]domain.ImportRowResult, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	tx, err := s.beginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	results := make([]domain.ImportRowResult, 0, len(users))
+	for i := range users {
+		// savepoint drops changes of a failed row
+		if _, err := tx.ExecContext(ctx, `SAVEPOINT import_row`); err != nil {
+			return nil, err
+		}
+
+		res, err := importUser(ctx, tx, &users[i], onConflict)
+		if err != nil {
+			if !errors.Is(err, repository.ErrUsernameExists) && !errors.Is(err, repository.ErrEmailExists) {
+				return nil, err
+			}
+			if _, err := tx.ExecContext(ctx, `ROLLBACK TO SAVEPOINT import_row`); err != nil {
+				return nil, err
+			}
+
+			res = domain.ImportRowResult{
+				Status: domain.ImportRowResultStatusFailed,
+				Error:  domain.NewOptString(err.Error()),
+			}
+		}
+
+		if _, err := tx.ExecContext(ctx, `RELEASE SAVEPOINT import_row`); err != nil {
+			return nil, err
+		}
+
+		results = append(results, res)
+	}
+
+	return results, tx.Commit()
+}
+
+func importUser(
+	ctx context.Context,
+	tx queryer,
+	user *domain.User,
+	onConflict domain.ServiceImportUsersOnConflict,
+) (domain.ImportRowResult, error) {
+	// sqlite has no way to tell an inserted row from an updated one in RETURNING,
+	// so an existing user is looked up first, the transaction holds the write lock
+	if onConflict == domain.ServiceImportUsersOnConflictUpdate {
+		query := `
+			SELECT id FROM users
+			WHERE lower(username) = lower(?) AND tenant_id = ?
+		`
+
+		var id uuid.UUID
+		err := tx.GetContext(ctx, &id, query, user.Username.Value, tenantID(ctx))
+		if err == nil {
+			// email of another user is still a conflict, is_admin is kept unless the row has it
+			query = `
+				UPDATE users SET email = ?, password = ?,
+				is_admin = CASE WHEN ? THEN ? ELSE is_admin END
+				WHERE id = ?
+			`
+			_, err := tx.ExecContext(ctx, query,
+				user.Email.Value,
+				user.Password.Value,
+				user.IsAdmin.IsSet(),
+				user.IsAdmin.Value,
+				id.String(),
+			)
+			if err != nil {
+				return domain.ImportRowResult{}, userConflict(err)
+			}
+
+			event := repository.NewUserEvent(ctx, repository.EventUserUpdated, domain.UUID(id), repository.ImportUpdate(user))
+			if err := appendEvent(ctx, tx, event); err != nil {
+				return domain.ImportRowResult{}, err
+			}
+
+			return domain.ImportRowResult{
+				Status: domain.ImportRowResultStatusUpdated,
+				ID:     domain.NewOptUUID(domain.UUID(id)),
+			}, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return domain.ImportRowResult{}, err
+		}
+	}
+
+	query := `
+		INSERT INTO users (username, email, password, is_admin, tenant_id) VALUES
+		(?, ?, ?, ?, ?)
+	`
+	if onConflict == domain.ServiceImportUsersOnConflictSkip {
+		query += ` ON CONFLICT DO NOTHING`
+	}
+	query += ` RETURNING id`
+
+	var id uuid.UUID
+	err := tx.QueryRowContext(ctx, query,
+		user.Username.Value,
+		user.Email.Value,
+		user.Password.Value,
+		user.IsAdmin.Value,
+		tenantID(ctx),
+	).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ImportRowResult{
+				Status: domain.ImportRowResultStatusSkipped,
+			}, nil
+		}
+		return domain.ImportRowResult{}, userConflict(err)
+	}
+
+	if err := appendEvent(ctx, tx, repository.NewUserEvent(ctx, repository.EventUserCreated, domain.UUID(id), user)); err != nil {
+		return domain.ImportRowResult{}, err
+	}
+
+	return domain.ImportRowResult{
+		Status: domain.ImportRowResultStatusCreated,
+		ID:     domain.NewOptUUID(domain.UUID(id)),
+	}, nil
+}
+
+// ExportUsers passes every user matching the filter to fn ordered by id,
+// starting after the cursor id if it is set. Rows are read by a single
+// statement, which sees a consistent snapshot in WAL mode and doesn't hold
+// the whole table in memory. Passwords are never selected. The query timeout
+// doesn't apply, the statement lasts as long as the export
+func (s *Repository) ExportUsers(
+	ctx context.Context,
+	filter repository.UserFilter,
+	cursor domain.OptUUID,
+	fn func(domain.User) error,
+) error {
+	query := `
+		SELECT id, username, email, is_admin, tenant_id FROM users
+		WHERE tenant_id = ?%s%s
+		ORDER BY id
+	`
+
+	conditions, args := filterConditions(filter, []any{tenantID(ctx)})
+	after := ""
+	if cursor.IsSet() {
+		args = append(args, repository.UUID(cursor.Value))
+		after = " AND id > ?"
+	}
+	query = fmt.Sprintf(query, conditions, after)
+
+	rows, err := s.q.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var dbUser repository.DBUser
+		if err := rows.StructScan(&dbUser); err != nil {
+			return err
+		}
+
+		user := repository.ConvertDBUserToUser(dbUser)
+		user.Password.Reset()
+
+		if err := fn(user); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
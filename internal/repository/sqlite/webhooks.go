@@ -0,0 +1,401 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/repository"
+)
+
+func (s *Repository) ListWebhooks(ctx context.Context, offset int64) ([]domain.Webhook, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	var webhooks []repository.DBWebhook
+
+	query := `
+		SELECT * FROM webhooks
+		WHERE tenant_id = ?
+		ORDER BY id
+		LIMIT 10
+		OFFSET ?
+	`
+
+	if err := s.q.SelectContext(ctx, &webhooks, query, tenantID(ctx), offset); err != nil {
+		return nil, err
+	}
+
+	res := make([]domain.Webhook, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		res = append(res, repository.ConvertDBWebhookToWebhook(webhook))
+	}
+
+	return res, nil
+}
+
+func (s *Repository) CreateWebhook(ctx context.Context, webhook *domain.Webhook) (*domain.UUID, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO webhooks (url, events, secret, enabled, tenant_id, created_at) VALUES
+		(?, ?, ?, ?, ?, ?) RETURNING id
+	`
+
+	var id uuid.UUID
+	err := s.q.QueryRowContext(ctx, query,
+		webhook.URL.Value,
+		repository.WebhookEvents(webhook.Events),
+		webhook.Secret.Value,
+		webhook.Enabled.Or(true),
+		tenantID(ctx),
+		time.Now().UTC(),
+	).Scan(&id)
+	if err != nil {
+		return nil, err
+	}
+
+	res := domain.UUID(id)
+
+	return &res, nil
+}
+
+func (s *Repository) GetWebhookById(ctx context.Context, id domain.UUID) (*domain.Webhook, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT * FROM webhooks
+		WHERE id = ? AND tenant_id = ?
+	`
+
+	webhook := repository.DBWebhook{}
+	err := s.q.GetContext(ctx, &webhook, query, repository.UUID(id), tenantID(ctx))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrWebhookNotFound
+		}
+		return nil, err
+	}
+
+	res := repository.ConvertDBWebhookToWebhook(webhook)
+
+	return &res, nil
+}
+
+func (s *Repository) UpdateWebhook(ctx context.Context, webhook *domain.Webhook) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE webhooks SET %s WHERE id = ? AND tenant_id = ?
+	`
+
+	queryParams, args, err := buildWebhookUpdate(webhook)
+	if err != nil {
+		return err
+	}
+	args = append(args, repository.UUID(webhook.ID.Value), tenantID(ctx))
+
+	query = fmt.Sprintf(query, queryParams)
+
+	result, err := s.q.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return repository.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+func (s *Repository) DeleteWebhook(ctx context.Context, id domain.UUID) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	query := `
+		DELETE FROM webhooks
+		WHERE id = ? AND tenant_id = ?
+	`
+
+	_, err := s.q.ExecContext(ctx, query, repository.UUID(id), tenantID(ctx))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *Repository) ListWebhookDeliveries(ctx context.Context, webhookID domain.UUID, offset int64) ([]domain.WebhookDelivery, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	var deliveries []repository.DBWebhookDelivery
+
+	query := `
+		SELECT * FROM webhook_deliveries
+		WHERE webhook_id = ? AND tenant_id = ?
+		ORDER BY event_id DESC
+		LIMIT 10
+		OFFSET ?
+	`
+
+	if err := s.q.SelectContext(ctx, &deliveries, query, repository.UUID(webhookID), tenantID(ctx), offset); err != nil {
+		return nil, err
+	}
+
+	res := make([]domain.WebhookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		res = append(res, repository.ConvertDBWebhookDeliveryToWebhookDelivery(delivery))
+	}
+
+	return res, nil
+}
+
+func (s *Repository) RedeliverWebhookDelivery(ctx context.Context, webhookID, deliveryID domain.UUID) (*domain.WebhookDelivery, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE webhook_deliveries
+		SET status = 'pending', attempts = 0, response_status = NULL, error = NULL,
+			next_attempt_at = ?, delivered_at = NULL
+		WHERE id = ? AND webhook_id = ? AND tenant_id = ?
+		RETURNING *
+	`
+
+	delivery := repository.DBWebhookDelivery{}
+	err := s.q.GetContext(ctx, &delivery, query,
+		time.Now().UTC(),
+		repository.UUID(deliveryID),
+		repository.UUID(webhookID),
+		tenantID(ctx),
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrDeliveryNotFound
+		}
+		return nil, err
+	}
+
+	res := repository.ConvertDBWebhookDeliveryToWebhookDelivery(delivery)
+
+	return &res, nil
+}
+
+// webhook deliveries aren't scoped by tenant of ctx, they are made by the delivery worker
+
+// EnqueueDeliveries queues delivery of the event to enabled webhooks of its organization
+// subscribed to it. An event enqueued again is ignored, so the relay may pass it more than once
+func (s *Repository) EnqueueDeliveries(ctx context.Context, event repository.OutboxEvent) (int, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := s.beginTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	// events are an array literal, subscriptions are matched here
+	var webhooks []repository.DBWebhook
+	query := `SELECT * FROM webhooks WHERE tenant_id = ? AND enabled`
+	if err := tx.SelectContext(ctx, &webhooks, query, event.TenantID.String()); err != nil {
+		return 0, err
+	}
+
+	query = `
+		INSERT INTO webhook_deliveries
+		(webhook_id, tenant_id, event_id, event_type, payload, next_attempt_at, created_at) VALUES
+		(?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (webhook_id, event_id) DO NOTHING
+	`
+
+	now := time.Now().UTC()
+	enqueued := 0
+	for _, webhook := range webhooks {
+		if !webhook.Subscribed(event.Type) {
+			continue
+		}
+
+		result, err := tx.ExecContext(ctx, query,
+			webhook.ID.String(),
+			event.TenantID.String(),
+			event.ID,
+			event.Type,
+			string(payload),
+			now,
+			now,
+		)
+		if err != nil {
+			return 0, err
+		}
+
+		n, err := result.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		enqueued += int(n)
+	}
+
+	return enqueued, tx.Commit()
+}
+
+// ClaimDeliveries returns up to limit of pending deliveries due to enabled webhooks and
+// postpones their next attempt by lease, so other workers skip them while they are attempted.
+// A delivery whose attempt isn't completed is claimed again when the lease expires
+func (s *Repository) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]repository.DeliveryJob, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	tx, err := s.beginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		SELECT d.id, d.webhook_id, d.event_id, d.event_type, d.payload, d.attempts, w.url, w.secret
+		FROM webhook_deliveries d
+		JOIN webhooks w ON w.id = d.webhook_id
+		WHERE d.status = 'pending' AND d.next_attempt_at <= ? AND w.enabled
+		ORDER BY d.next_attempt_at
+		LIMIT ?
+	`
+
+	now := time.Now().UTC()
+
+	var jobs []repository.DeliveryJob
+	if err := tx.SelectContext(ctx, &jobs, query, now, limit); err != nil {
+		return nil, err
+	}
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		ids = append(ids, job.ID.String())
+	}
+
+	query, args, err := sqlx.In(`UPDATE webhook_deliveries SET next_attempt_at = ? WHERE id IN (?)`, now.Add(lease), ids)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return nil, err
+	}
+
+	return jobs, tx.Commit()
+}
+
+// CompleteDelivery records the attempt of the delivery. A succeeded attempt resets failures
+// of the webhook, a failed one counts, the webhook is disabled when failures reach
+// result.DisableAfter. Reports whether the webhook was disabled by this attempt
+func (s *Repository) CompleteDelivery(ctx context.Context, result repository.DeliveryResult) (bool, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	tx, err := s.beginTx(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE webhook_deliveries
+		SET status = ?, attempts = attempts + 1, response_status = ?, error = ?,
+			next_attempt_at = ?, delivered_at = ?
+		WHERE id = ? AND status = 'pending'
+	`
+
+	args := append(result.Values(time.Now()), result.ID.String())
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return false, err
+	}
+
+	if result.Succeeded {
+		if _, err := tx.ExecContext(ctx, `UPDATE webhooks SET failures = 0 WHERE id = ?`, result.WebhookID.String()); err != nil {
+			return false, err
+		}
+		return false, tx.Commit()
+	}
+
+	query = `
+		UPDATE webhooks
+		SET failures = failures + 1, enabled = enabled AND (? = 0 OR failures + 1 < ?)
+		WHERE id = ?
+		RETURNING enabled, failures
+	`
+
+	var enabled bool
+	var failures int32
+	err = tx.QueryRowContext(ctx, query,
+		result.DisableAfter,
+		result.DisableAfter,
+		result.WebhookID.String(),
+	).Scan(&enabled, &failures)
+	if err != nil {
+		// the webhook was deleted during the attempt
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return result.Disabled(enabled, failures), tx.Commit()
+}
+
+func buildWebhookUpdate(webhook *domain.Webhook) (queryParams string, args []any, err error) {
+	sb := strings.Builder{}
+
+	if webhook.URL.IsSet() {
+		args = append(args, webhook.URL.Value)
+		sb.WriteString("url = ?, ")
+	}
+	if webhook.Events != nil {
+		args = append(args, repository.WebhookEvents(webhook.Events))
+		sb.WriteString("events = ?, ")
+	}
+	if webhook.Secret.IsSet() {
+		args = append(args, webhook.Secret.Value)
+		sb.WriteString("secret = ?, ")
+	}
+	if webhook.Enabled.IsSet() {
+		args = append(args, webhook.Enabled.Value)
+		sb.WriteString("enabled = ?, ")
+		// an enabled webhook starts counting failures over
+		if webhook.Enabled.Value {
+			sb.WriteString("failures = 0, ")
+		}
+	}
+
+	if len(args) == 0 {
+		return "", nil, repository.ErrEmptyUpdate
+	}
+
+	queryParams = sb.String()
+	// remove last ", "
+	queryParams = queryParams[:len(queryParams)-2]
+
+	return
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// queryer is implemented by both *sqlx.DB and *sqlx.Tx,
+// so the same queries run inside and outside of a transaction
+type queryer interface {
+	sqlx.ExtContext
+	GetContext(ctx context.Context, dest any, query string, args ...any) error
+	SelectContext(ctx context.Context, dest any, query string, args ...any) error
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// default number of WithTx retries on serialization failure
+const defaultTxRetries = 3
+
+type txOptions struct {
+	sql.TxOptions
+	retries int
+}
+
+// TxOption configures transaction of WithTx
+type TxOption func(*txOptions)
+
+// WithIsolation sets isolation level of the transaction, read committed by default
+func WithIsolation(level sql.IsolationLevel) TxOption {
+	return func(o *txOptions) {
+		o.Isolation = level
+	}
+}
+
+// ReadOnly starts a read only transaction
+func ReadOnly() TxOption {
+	return func(o *txOptions) {
+		o.ReadOnly = true
+	}
+}
+
+// WithRetries sets how many times fn is retried on serialization failure
+func WithRetries(n int) TxOption {
+	return func(o *txOptions) {
+		o.retries = n
+	}
+}
+
+// WithTx runs fn with a repository bound to a single transaction,
+// the transaction is committed when fn returns nil and rolled back otherwise.
+// On serialization failure (SQLSTATE 40001) the whole transaction is retried,
+// so fn must not have side effects outside of the repository and must return
+// repository errors as is. Inside another WithTx fn joins the outer transaction
+// and options are ignored
+func (s *Repository) WithTx(ctx context.Context, fn func(*Repository) error, opts ...TxOption) error {
+	if s.tx != nil {
+		return fn(s)
+	}
+
+	txOpts, retries := TxSettings(opts...)
+
+	for attempt := 0; ; attempt++ {
+		err := s.runTx(ctx, &txOpts, fn)
+		if err == nil || !isSerializationFailure(err) || attempt >= retries {
+			return err
+		}
+
+		if err := TxBackoff(ctx, attempt); err != nil {
+			return err
+		}
+	}
+}
+
+// TxSettings returns transaction options and the number of retries set by opts,
+// used by other implementations of WithTx
+func TxSettings(opts ...TxOption) (sql.TxOptions, int) {
+	o := txOptions{retries: defaultTxRetries}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o.TxOptions, o.retries
+}
+
+// TxBackoff waits before the next attempt of a failed transaction
+func TxBackoff(ctx context.Context, attempt int) error {
+	// jitter spreads retries of conflicting transactions
+	backoff := time.Duration(attempt+1)*10*time.Millisecond +
+		time.Duration(rand.Int64N(int64(10*time.Millisecond)))
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(backoff):
+		return nil
+	}
+}
+
+func (s *Repository) runTx(ctx context.Context, opts *sql.TxOptions, fn func(*Repository) error) error {
+	tx, err := s.db.BeginTxx(ctx, opts)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(&Repository{db: s.db, q: tx, tx: tx, timeout: s.timeout}); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func isSerializationFailure(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "40001"
+}
+
+// tx is a transaction of a single repository method,
+// inside WithTx it is a savepoint of the outer transaction
+type tx struct {
+	*sqlx.Tx
+	nested bool
+	done   bool
+}
+
+// beginTx starts a transaction, opts are ignored inside WithTx
+func (s *Repository) beginTx(ctx context.Context, opts *sql.TxOptions) (*tx, error) {
+	if s.tx != nil {
+		if _, err := s.tx.ExecContext(ctx, `SAVEPOINT repository_tx`); err != nil {
+			return nil, err
+		}
+		return &tx{Tx: s.tx, nested: true}, nil
+	}
+
+	t, err := s.db.BeginTxx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tx{Tx: t}, nil
+}
+
+func (t *tx) Commit() error {
+	if !t.nested {
+		return t.Tx.Commit()
+	}
+
+	t.done = true
+	_, err := t.Tx.Exec(`RELEASE SAVEPOINT repository_tx`)
+	return err
+}
+
+// Rollback is safe to defer, it does nothing after Commit
+func (t *tx) Rollback() error {
+	if !t.nested {
+		return t.Tx.Rollback()
+	}
+	if t.done {
+		return nil
+	}
+
+	t.done = true
+	_, err := t.Tx.Exec(`ROLLBACK TO SAVEPOINT repository_tx`)
+	return err
+}
@@ -2,6 +2,8 @@ package repository
 
 import (
 	"database/sql"
+	"slices"
+	"strings"
 
 	"github.com/google/uuid"
 	domain "github.com/liriquew/test_task/internal/domain"
@@ -15,6 +17,9 @@ type DBUser struct {
 	Password string       `db:"password"`
 	Email    string       `db:"email"`
 	IsAdmin  sql.NullBool `db:"is_admin"`
+	TenantID uuid.UUID    `db:"tenant_id"`
+
+	MustChangePassword bool `db:"must_change_password"`
 }
 
 // ConvertUserToDBUser преобразует User в DBUser.
@@ -32,6 +37,7 @@ func ConvertUserToDBUser(u domain.User) DBUser {
 			Valid: true,
 		}
 	}
+	dbUser.MustChangePassword = u.MustChangePassword.Value
 
 	return dbUser
 }
@@ -48,6 +54,65 @@ func ConvertDBUserToUser(dbUser DBUser) domain.User {
 	if dbUser.IsAdmin.Valid {
 		user.IsAdmin = domain.NewOptBool(dbUser.IsAdmin.Bool)
 	}
+	// the flag is omitted from responses of users who don't have to change the password
+	if dbUser.MustChangePassword {
+		user.MustChangePassword = domain.NewOptBool(true)
+	}
 
 	return user
 }
+
+// UserFields are the columns of users selected by reads, all of them if empty.
+// Unknown fields are ignored
+type UserFields []string
+
+// userColumns are the columns which can be selected by UserFields
+var userColumns = map[string]bool{
+	"id":                   true,
+	"username":             true,
+	"password":             true,
+	"email":                true,
+	"is_admin":             true,
+	"must_change_password": true,
+}
+
+// SelectList returns the select list of the fields
+func (f UserFields) SelectList() string {
+	columns := make([]string, 0, len(f))
+	for _, field := range f {
+		if userColumns[field] && !slices.Contains(columns, field) {
+			columns = append(columns, field)
+		}
+	}
+	if len(columns) == 0 {
+		return "*"
+	}
+
+	return strings.Join(columns, ", ")
+}
+
+// Project resets the fields of the user which aren't selected
+func (f UserFields) Project(u *domain.User) {
+	if f.SelectList() == "*" {
+		return
+	}
+
+	if !slices.Contains(f, "id") {
+		u.ID.Reset()
+	}
+	if !slices.Contains(f, "username") {
+		u.Username.Reset()
+	}
+	if !slices.Contains(f, "password") {
+		u.Password.Reset()
+	}
+	if !slices.Contains(f, "email") {
+		u.Email.Reset()
+	}
+	if !slices.Contains(f, "is_admin") {
+		u.IsAdmin.Reset()
+	}
+	if !slices.Contains(f, "must_change_password") {
+		u.MustChangePassword.Reset()
+	}
+}
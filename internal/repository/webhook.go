@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"database/sql"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	domain "github.com/liriquew/test_task/internal/domain"
+)
+
+// statuses of webhook deliveries
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed"
+)
+
+type DBWebhook struct {
+	ID       uuid.UUID `db:"id"`
+	TenantID uuid.UUID `db:"tenant_id"`
+	URL      string    `db:"url"`
+	// events delivered to the webhook, every event if empty.
+	// sqlite keeps the array literal of postgres as text
+	Events    pq.StringArray `db:"events"`
+	Secret    string         `db:"secret"`
+	Enabled   bool           `db:"enabled"`
+	Failures  int32          `db:"failures"`
+	CreatedAt time.Time      `db:"created_at"`
+}
+
+// Subscribed reports whether events of eventType are delivered to the webhook
+func (w DBWebhook) Subscribed(eventType string) bool {
+	return len(w.Events) == 0 || slices.Contains(w.Events, eventType)
+}
+
+type DBWebhookDelivery struct {
+	ID        uuid.UUID `db:"id"`
+	WebhookID uuid.UUID `db:"webhook_id"`
+	TenantID  uuid.UUID `db:"tenant_id"`
+	EventID   int64     `db:"event_id"`
+	EventType string    `db:"event_type"`
+	// Payload is the outbox event as json, the body of every attempt
+	Payload        []byte         `db:"payload"`
+	Status         string         `db:"status"`
+	Attempts       int32          `db:"attempts"`
+	ResponseStatus sql.NullInt32  `db:"response_status"`
+	Error          sql.NullString `db:"error"`
+	NextAttemptAt  time.Time      `db:"next_attempt_at"`
+	CreatedAt      time.Time      `db:"created_at"`
+	DeliveredAt    sql.NullTime   `db:"delivered_at"`
+}
+
+// DeliveryJob is a pending delivery claimed by the delivery worker
+type DeliveryJob struct {
+	ID        uuid.UUID `db:"id"`
+	WebhookID uuid.UUID `db:"webhook_id"`
+	EventID   int64     `db:"event_id"`
+	EventType string    `db:"event_type"`
+	Payload   []byte    `db:"payload"`
+	// Attempts made before this one
+	Attempts int32  `db:"attempts"`
+	URL      string `db:"url"`
+	Secret   string `db:"secret"`
+}
+
+// DeliveryResult is the outcome of an attempt of a DeliveryJob
+type DeliveryResult struct {
+	ID        uuid.UUID
+	WebhookID uuid.UUID
+	Succeeded bool
+	// ResponseStatus is zero when the endpoint didn't respond
+	ResponseStatus int32
+	Error          string
+	// NextAttemptAt is the time of the retry of a failed attempt,
+	// zero when attempts are exhausted and the delivery is failed
+	NextAttemptAt time.Time
+	// DisableAfter failed attempts in a row the webhook is disabled, zero never disables it
+	DisableAfter int32
+}
+
+// Status of the delivery after the attempt
+func (r DeliveryResult) Status() string {
+	switch {
+	case r.Succeeded:
+		return DeliverySucceeded
+	case r.NextAttemptAt.IsZero():
+		return DeliveryFailed
+	default:
+		return DeliveryPending
+	}
+}
+
+// Values returns status, response_status, error, next_attempt_at and delivered_at
+// of the delivery after the attempt
+func (r DeliveryResult) Values(now time.Time) []any {
+	next := r.NextAttemptAt
+	if next.IsZero() {
+		next = now
+	}
+
+	return []any{
+		r.Status(),
+		sql.NullInt32{Int32: r.ResponseStatus, Valid: r.ResponseStatus != 0},
+		sql.NullString{String: r.Error, Valid: r.Error != ""},
+		next.UTC(),
+		sql.NullTime{Time: now.UTC(), Valid: r.Succeeded},
+	}
+}
+
+// Disabled reports whether the failed attempt disabled the webhook,
+// enabled and failures are the state of the webhook after the attempt
+func (r DeliveryResult) Disabled(enabled bool, failures int32) bool {
+	return r.DisableAfter > 0 && !enabled && failures == r.DisableAfter
+}
+
+// ConvertDBWebhookToWebhook преобразует DBWebhook в Webhook, секрет не возвращается.
+func ConvertDBWebhookToWebhook(dbWebhook DBWebhook) domain.Webhook {
+	events := make([]domain.WebhookEvent, 0, len(dbWebhook.Events))
+	for _, event := range dbWebhook.Events {
+		events = append(events, domain.WebhookEvent(event))
+	}
+
+	return domain.Webhook{
+		ID:       domain.NewOptUUID(domain.UUID(dbWebhook.ID)),
+		URL:      domain.NewOptString(dbWebhook.URL),
+		Events:   events,
+		Enabled:  domain.NewOptBool(dbWebhook.Enabled),
+		Failures: domain.NewOptInt32(dbWebhook.Failures),
+	}
+}
+
+// ConvertDBWebhookDeliveryToWebhookDelivery преобразует DBWebhookDelivery в WebhookDelivery.
+func ConvertDBWebhookDeliveryToWebhookDelivery(dbDelivery DBWebhookDelivery) domain.WebhookDelivery {
+	delivery := domain.WebhookDelivery{
+		ID:        domain.UUID(dbDelivery.ID),
+		EventID:   dbDelivery.EventID,
+		Event:     domain.WebhookEvent(dbDelivery.EventType),
+		Status:    domain.WebhookDeliveryStatus(dbDelivery.Status),
+		Attempts:  dbDelivery.Attempts,
+		CreatedAt: dbDelivery.CreatedAt,
+	}
+
+	if dbDelivery.ResponseStatus.Valid {
+		delivery.ResponseStatus = domain.NewOptInt32(dbDelivery.ResponseStatus.Int32)
+	}
+	if dbDelivery.Error.Valid {
+		delivery.Error = domain.NewOptString(dbDelivery.Error.String)
+	}
+	if dbDelivery.Status == DeliveryPending {
+		delivery.NextAttemptAt = domain.NewOptDateTime(dbDelivery.NextAttemptAt)
+	}
+	if dbDelivery.DeliveredAt.Valid {
+		delivery.DeliveredAt = domain.NewOptDateTime(dbDelivery.DeliveredAt.Time)
+	}
+
+	return delivery
+}
+
+// WebhookEvents returns events of the webhook as stored
+func WebhookEvents(events []domain.WebhookEvent) pq.StringArray {
+	res := make(pq.StringArray, 0, len(events))
+	for _, event := range events {
+		res = append(res, string(event))
+	}
+
+	return res
+}
@@ -0,0 +1,344 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	domain "github.com/liriquew/test_task/internal/domain"
+)
+
+func (s *Repository) ListWebhooks(ctx context.Context, offset int64) ([]domain.Webhook, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	var webhooks []DBWebhook
+
+	query := `
+		SELECT * FROM webhooks
+		WHERE tenant_id = $1
+		ORDER BY id
+		OFFSET $2
+		LIMIT 10
+	`
+
+	if err := s.q.SelectContext(ctx, &webhooks, query, tenantID(ctx), offset); err != nil {
+		return nil, err
+	}
+
+	res := make([]domain.Webhook, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		res = append(res, ConvertDBWebhookToWebhook(webhook))
+	}
+
+	return res, nil
+}
+
+// CreateWebhook registers the webhook, it is enabled unless webhook.Enabled is false
+func (s *Repository) CreateWebhook(ctx context.Context, webhook *domain.Webhook) (*domain.UUID, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO webhooks (url, events, secret, enabled, tenant_id) VALUES
+		($1, $2, $3, $4, $5) RETURNING id
+	`
+
+	var id uuid.UUID
+	err := s.q.QueryRowContext(ctx, query,
+		webhook.URL.Value,
+		WebhookEvents(webhook.Events),
+		webhook.Secret.Value,
+		webhook.Enabled.Or(true),
+		tenantID(ctx),
+	).Scan(&id)
+	if err != nil {
+		return nil, err
+	}
+
+	res := domain.UUID(id)
+
+	return &res, nil
+}
+
+func (s *Repository) GetWebhookById(ctx context.Context, id domain.UUID) (*domain.Webhook, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	query := `
+		SELECT * FROM webhooks
+		WHERE id = $1 AND tenant_id = $2
+	`
+
+	webhook := DBWebhook{}
+	err := s.q.GetContext(ctx, &webhook, query, UUID(id), tenantID(ctx))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrWebhookNotFound
+		}
+		return nil, err
+	}
+
+	res := ConvertDBWebhookToWebhook(webhook)
+
+	return &res, nil
+}
+
+// UpdateWebhook updates set fields of the webhook, enabling the webhook resets its failures
+func (s *Repository) UpdateWebhook(ctx context.Context, webhook *domain.Webhook) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE webhooks SET %s WHERE id=$%d AND tenant_id=$%d
+	`
+
+	queryParams, args, err := s.buildWebhookUpdate(webhook)
+	if err != nil {
+		return err
+	}
+	args = append(args, UUID(webhook.ID.Value), tenantID(ctx))
+
+	query = fmt.Sprintf(query, queryParams, len(args)-1, len(args))
+
+	result, err := s.q.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// DeleteWebhook removes the webhook with its deliveries
+func (s *Repository) DeleteWebhook(ctx context.Context, id domain.UUID) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	query := `
+		DELETE FROM webhooks
+		WHERE id=$1 AND tenant_id=$2
+	`
+
+	_, err := s.q.ExecContext(ctx, query, UUID(id), tenantID(ctx))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ListWebhookDeliveries returns deliveries of the webhook, the latest events first
+func (s *Repository) ListWebhookDeliveries(ctx context.Context, webhookID domain.UUID, offset int64) ([]domain.WebhookDelivery, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	var deliveries []DBWebhookDelivery
+
+	query := `
+		SELECT * FROM webhook_deliveries
+		WHERE webhook_id = $1 AND tenant_id = $2
+		ORDER BY event_id DESC
+		OFFSET $3
+		LIMIT 10
+	`
+
+	if err := s.q.SelectContext(ctx, &deliveries, query, UUID(webhookID), tenantID(ctx), offset); err != nil {
+		return nil, err
+	}
+
+	res := make([]domain.WebhookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		res = append(res, ConvertDBWebhookDeliveryToWebhookDelivery(delivery))
+	}
+
+	return res, nil
+}
+
+// RedeliverWebhookDelivery queues the delivery again, attempts start over.
+// A delivery to a disabled webhook waits until the webhook is enabled
+func (s *Repository) RedeliverWebhookDelivery(ctx context.Context, webhookID, deliveryID domain.UUID) (*domain.WebhookDelivery, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE webhook_deliveries
+		SET status = 'pending', attempts = 0, response_status = NULL, error = NULL,
+			next_attempt_at = now(), delivered_at = NULL
+		WHERE id = $1 AND webhook_id = $2 AND tenant_id = $3
+		RETURNING *
+	`
+
+	delivery := DBWebhookDelivery{}
+	err := s.q.GetContext(ctx, &delivery, query, UUID(deliveryID), UUID(webhookID), tenantID(ctx))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrDeliveryNotFound
+		}
+		return nil, err
+	}
+
+	res := ConvertDBWebhookDeliveryToWebhookDelivery(delivery)
+
+	return &res, nil
+}
+
+// webhook deliveries aren't scoped by tenant of ctx, they are made by the delivery worker
+
+// EnqueueDeliveries queues delivery of the event to enabled webhooks of its organization
+// subscribed to it. An event enqueued again is ignored, so the relay may pass it more than once
+func (s *Repository) EnqueueDeliveries(ctx context.Context, event OutboxEvent) (int, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return 0, err
+	}
+
+	query := `
+		INSERT INTO webhook_deliveries (webhook_id, tenant_id, event_id, event_type, payload)
+		SELECT id, tenant_id, $1, $2, $3 FROM webhooks
+		WHERE tenant_id = $4 AND enabled AND (cardinality(events) = 0 OR $2::text = ANY(events))
+		ON CONFLICT (webhook_id, event_id) DO NOTHING
+	`
+
+	result, err := s.q.ExecContext(ctx, query, event.ID, event.Type, string(payload), event.TenantID.String())
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := result.RowsAffected()
+
+	return int(n), err
+}
+
+// ClaimDeliveries returns up to limit of pending deliveries due to enabled webhooks and
+// postpones their next attempt by lease, so other workers skip them while they are attempted.
+// A delivery whose attempt isn't completed is claimed again when the lease expires
+func (s *Repository) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]DeliveryJob, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE webhook_deliveries d
+		SET next_attempt_at = now() + $2 * interval '1 millisecond'
+		FROM webhooks w
+		WHERE w.id = d.webhook_id AND d.id IN (
+			SELECT d.id FROM webhook_deliveries d
+			JOIN webhooks w ON w.id = d.webhook_id
+			WHERE d.status = 'pending' AND d.next_attempt_at <= now() AND w.enabled
+			ORDER BY d.next_attempt_at
+			LIMIT $1
+			FOR UPDATE OF d SKIP LOCKED
+		)
+		RETURNING d.id, d.webhook_id, d.event_id, d.event_type, d.payload, d.attempts, w.url, w.secret
+	`
+
+	var jobs []DeliveryJob
+	if err := s.q.SelectContext(ctx, &jobs, query, limit, lease.Milliseconds()); err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// CompleteDelivery records the attempt of the delivery. A succeeded attempt resets failures
+// of the webhook, a failed one counts, the webhook is disabled when failures reach
+// result.DisableAfter. Reports whether the webhook was disabled by this attempt
+func (s *Repository) CompleteDelivery(ctx context.Context, result DeliveryResult) (bool, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	tx, err := s.beginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $1, attempts = attempts + 1, response_status = $2, error = $3,
+			next_attempt_at = $4, delivered_at = $5
+		WHERE id = $6 AND status = 'pending'
+	`
+
+	args := append(result.Values(time.Now()), result.ID.String())
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return false, err
+	}
+
+	if result.Succeeded {
+		if _, err := tx.ExecContext(ctx, `UPDATE webhooks SET failures = 0 WHERE id = $1`, result.WebhookID.String()); err != nil {
+			return false, err
+		}
+		return false, tx.Commit()
+	}
+
+	query = `
+		UPDATE webhooks
+		SET failures = failures + 1, enabled = enabled AND ($2 = 0 OR failures + 1 < $2)
+		WHERE id = $1
+		RETURNING enabled, failures
+	`
+
+	var enabled bool
+	var failures int32
+	err = tx.QueryRowContext(ctx, query, result.WebhookID.String(), result.DisableAfter).Scan(&enabled, &failures)
+	if err != nil {
+		// the webhook was deleted during the attempt
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return result.Disabled(enabled, failures), tx.Commit()
+}
+
+func (s *Repository) buildWebhookUpdate(webhook *domain.Webhook) (queryParams string, args []any, err error) {
+	sb := strings.Builder{}
+
+	if webhook.URL.IsSet() {
+		args = append(args, webhook.URL.Value)
+		sb.WriteString(fmt.Sprintf("url=$%d, ", len(args)))
+	}
+	if webhook.Events != nil {
+		args = append(args, WebhookEvents(webhook.Events))
+		sb.WriteString(fmt.Sprintf("events=$%d, ", len(args)))
+	}
+	if webhook.Secret.IsSet() {
+		args = append(args, webhook.Secret.Value)
+		sb.WriteString(fmt.Sprintf("secret=$%d, ", len(args)))
+	}
+	if webhook.Enabled.IsSet() {
+		args = append(args, webhook.Enabled.Value)
+		sb.WriteString(fmt.Sprintf("enabled=$%d, ", len(args)))
+		// an enabled webhook starts counting failures over
+		if webhook.Enabled.Value {
+			sb.WriteString("failures=0, ")
+		}
+	}
+
+	if len(args) == 0 {
+		return "", nil, ErrEmptyUpdate
+	}
+
+	queryParams = sb.String()
+	// remove last ", "
+	queryParams = queryParams[:len(queryParams)-2]
+
+	return
+}
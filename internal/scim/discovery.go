@@ -0,0 +1,235 @@
+package scim
+
+import "net/http"
+
+// attribute is the definition of an attribute of RFC 7643 7
+type attribute struct {
+	Name          string      `json:"name"`
+	Type          string      `json:"type"`
+	MultiValued   bool        `json:"multiValued"`
+	Description   string      `json:"description,omitempty"`
+	Required      bool        `json:"required"`
+	CaseExact     bool        `json:"caseExact"`
+	Mutability    string      `json:"mutability"`
+	Returned      string      `json:"returned"`
+	Uniqueness    string      `json:"uniqueness"`
+	SubAttributes []attribute `json:"subAttributes,omitempty"`
+}
+
+type schema struct {
+	Schemas     []string    `json:"schemas"`
+	ID          string      `json:"id"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Attributes  []attribute `json:"attributes"`
+	Meta        *meta       `json:"meta"`
+}
+
+type resourceType struct {
+	Schemas     []string `json:"schemas"`
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Endpoint    string   `json:"endpoint"`
+	Description string   `json:"description"`
+	Schema      string   `json:"schema"`
+	Meta        *meta    `json:"meta"`
+}
+
+func stringAttribute(name, description string) attribute {
+	return attribute{
+		Name:        name,
+		Type:        "string",
+		Description: description,
+		Mutability:  "readWrite",
+		Returned:    "default",
+		Uniqueness:  "none",
+	}
+}
+
+// referenceAttributes are the sub-attributes of group memberships
+func referenceAttributes(mutability string, types ...string) []attribute {
+	value := stringAttribute("value", "Identifier of the resource")
+	value.Mutability = mutability
+
+	display := stringAttribute("display", "Name of the resource")
+	display.Mutability = "readOnly"
+
+	typ := stringAttribute("type", "Type of the resource: "+types[0]+" or "+types[1])
+	typ.Mutability = mutability
+
+	ref := attribute{
+		Name:       "$ref",
+		Type:       "reference",
+		Mutability: mutability,
+		Returned:   "default",
+		Uniqueness: "none",
+	}
+
+	return []attribute{value, display, typ, ref}
+}
+
+func userAttributes() []attribute {
+	userName := stringAttribute("userName", "Unique identifier of the user, alphanumeric, longer than 8 characters")
+	userName.Required = true
+	userName.Uniqueness = "server"
+
+	password := stringAttribute("password", "Password of the user, a random one is set if it isn't provided")
+	password.Mutability = "writeOnly"
+	password.Returned = "never"
+
+	active := attribute{
+		Name:        "active",
+		Type:        "boolean",
+		Description: "Users are always active, they are deprovisioned by deletion",
+		Mutability:  "readWrite",
+		Returned:    "default",
+		Uniqueness:  "none",
+	}
+
+	emails := stringAttribute("emails", "The email of the user, the primary or the first one is kept")
+	emails.Type = "complex"
+	emails.MultiValued = true
+	emails.SubAttributes = []attribute{
+		stringAttribute("value", "Email address"),
+		stringAttribute("type", "Type of the email"),
+		{Name: "primary", Type: "boolean", Mutability: "readWrite", Returned: "default", Uniqueness: "none"},
+	}
+
+	roles := stringAttribute("roles", "The admin role grants admin permissions")
+	roles.Type = "complex"
+	roles.MultiValued = true
+	roles.SubAttributes = []attribute{stringAttribute("value", "Name of the role")}
+
+	groups := stringAttribute("groups", "Groups of the user, they are changed by groups")
+	groups.Type = "complex"
+	groups.MultiValued = true
+	groups.Mutability = "readOnly"
+	groups.SubAttributes = referenceAttributes("readOnly", "direct", "indirect")
+
+	return []attribute{userName, password, active, emails, roles, groups}
+}
+
+func groupAttributes() []attribute {
+	displayName := stringAttribute("displayName", "Name of the group, 3 to 127 letters, digits, - and _")
+	displayName.Required = true
+	displayName.Uniqueness = "server"
+
+	members := stringAttribute("members", "Users and groups of the group")
+	members.Type = "complex"
+	members.MultiValued = true
+	members.SubAttributes = referenceAttributes("immutable", memberUser, memberGroup)
+
+	return []attribute{displayName, members}
+}
+
+func schemas(r *http.Request) []*schema {
+	return []*schema{
+		{
+			Schemas:     []string{schemaSchema},
+			ID:          SchemaUser,
+			Name:        "User",
+			Description: "User Account",
+			Attributes:  userAttributes(),
+			Meta:        &meta{ResourceType: "Schema", Location: location(r, "/Schemas/"+SchemaUser)},
+		},
+		{
+			Schemas:     []string{schemaSchema},
+			ID:          SchemaGroup,
+			Name:        "Group",
+			Description: "Group",
+			Attributes:  groupAttributes(),
+			Meta:        &meta{ResourceType: "Schema", Location: location(r, "/Schemas/"+SchemaGroup)},
+		},
+	}
+}
+
+func resourceTypes(r *http.Request) []*resourceType {
+	return []*resourceType{
+		{
+			Schemas:     []string{schemaResourceType},
+			ID:          "User",
+			Name:        "User",
+			Endpoint:    "/Users",
+			Description: "User Account",
+			Schema:      SchemaUser,
+			Meta:        &meta{ResourceType: "ResourceType", Location: location(r, "/ResourceTypes/User")},
+		},
+		{
+			Schemas:     []string{schemaResourceType},
+			ID:          "Group",
+			Name:        "Group",
+			Endpoint:    "/Groups",
+			Description: "Group",
+			Schema:      SchemaGroup,
+			Meta:        &meta{ResourceType: "ResourceType", Location: location(r, "/ResourceTypes/Group")},
+		},
+	}
+}
+
+// discoveryList is the list response of discovery endpoints, they aren't filtered or paged
+func discoveryList[T any](resources []T) map[string]any {
+	return map[string]any{
+		"schemas":      []string{schemaListResponse},
+		"totalResults": len(resources),
+		"startIndex":   1,
+		"itemsPerPage": len(resources),
+		"Resources":    resources,
+	}
+}
+
+func (h *Handler) serviceProviderConfig(_ http.ResponseWriter, r *http.Request) (int, any, error) {
+	supported := func(supported bool) map[string]bool {
+		return map[string]bool{"supported": supported}
+	}
+
+	return http.StatusOK, map[string]any{
+		"schemas":          []string{schemaServiceProviderConfig},
+		"documentationUri": "https://datatracker.ietf.org/doc/html/rfc7644",
+		"patch":            supported(true),
+		"bulk": map[string]any{
+			"supported":      false,
+			"maxOperations":  0,
+			"maxPayloadSize": 0,
+		},
+		"filter": map[string]any{
+			"supported":  true,
+			"maxResults": maxCount,
+		},
+		"changePassword": supported(true),
+		"sort":           supported(false),
+		"etag":           supported(false),
+		"authenticationSchemes": []map[string]any{{
+			"type":        "oauthbearertoken",
+			"name":        "OAuth Bearer Token",
+			"description": "Authentication by the bearer token of the configuration",
+			"primary":     true,
+		}},
+		"meta": &meta{ResourceType: "ServiceProviderConfig", Location: location(r, "/ServiceProviderConfig")},
+	}, nil
+}
+
+func (h *Handler) listResourceTypes(_ http.ResponseWriter, r *http.Request) (int, any, error) {
+	return http.StatusOK, discoveryList(resourceTypes(r)), nil
+}
+
+func (h *Handler) getResourceType(_ http.ResponseWriter, r *http.Request) (int, any, error) {
+	for _, res := range resourceTypes(r) {
+		if res.ID == r.PathValue("id") {
+			return http.StatusOK, res, nil
+		}
+	}
+	return 0, nil, notFound("ResourceType")
+}
+
+func (h *Handler) listSchemas(_ http.ResponseWriter, r *http.Request) (int, any, error) {
+	return http.StatusOK, discoveryList(schemas(r)), nil
+}
+
+func (h *Handler) getSchema(_ http.ResponseWriter, r *http.Request) (int, any, error) {
+	for _, res := range schemas(r) {
+		if res.ID == r.PathValue("id") {
+			return http.StatusOK, res, nil
+		}
+	}
+	return 0, nil, notFound("Schema")
+}
@@ -0,0 +1,478 @@
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidFilter is returned for filters and paths that can't be parsed
+var ErrInvalidFilter = errors.New("invalid filter")
+
+// Filter matches resources by the filter of RFC 7644 3.4.2.2, attributes of resources
+// are compared case-insensitively
+type Filter interface {
+	Match(resource map[string]any) bool
+}
+
+type (
+	andFilter struct{ left, right Filter }
+	orFilter  struct{ left, right Filter }
+	notFilter struct{ filter Filter }
+
+	// compareFilter compares values of the attribute, pr checks the attribute has a value
+	compareFilter struct {
+		path  attrPath
+		op    string
+		value any
+	}
+
+	// valueFilter matches resources with a value of the multi-valued attribute
+	// matched by the filter, attributes of the filter are sub-attributes of the value
+	valueFilter struct {
+		attr   string
+		filter Filter
+	}
+)
+
+// attrPath is an attribute with an optional sub-attribute, the schema URN is stripped
+type attrPath struct {
+	attr, sub string
+}
+
+func (f andFilter) Match(r map[string]any) bool { return f.left.Match(r) && f.right.Match(r) }
+func (f orFilter) Match(r map[string]any) bool  { return f.left.Match(r) || f.right.Match(r) }
+func (f notFilter) Match(r map[string]any) bool { return !f.filter.Match(r) }
+
+func (f valueFilter) Match(r map[string]any) bool {
+	for _, value := range values(r, f.attr) {
+		if value, ok := value.(map[string]any); ok && f.filter.Match(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f compareFilter) Match(r map[string]any) bool {
+	var leaves []any
+	if f.path.sub == "" {
+		leaves = values(r, f.path.attr)
+	} else {
+		for _, value := range values(r, f.path.attr) {
+			if value, ok := value.(map[string]any); ok {
+				leaves = append(leaves, values(value, f.path.sub)...)
+			}
+		}
+	}
+
+	switch f.op {
+	case "pr":
+		for _, leaf := range leaves {
+			if leaf != nil && leaf != "" {
+				return true
+			}
+		}
+		return false
+	case "ne":
+		return !compareFilter{path: f.path, op: "eq", value: f.value}.Match(r)
+	}
+
+	if f.value == nil && f.op == "eq" {
+		return !compareFilter{path: f.path, op: "pr"}.Match(r)
+	}
+	for _, leaf := range leaves {
+		if compare(leaf, f.op, f.value) {
+			return true
+		}
+	}
+	return false
+}
+
+// compare compares the value of the attribute with the value of the filter,
+// strings are compared case-insensitively
+func compare(leaf any, op string, value any) bool {
+	switch value := value.(type) {
+	case string:
+		leaf, ok := leaf.(string)
+		if !ok {
+			return false
+		}
+		leaf, value = strings.ToLower(leaf), strings.ToLower(value)
+
+		switch op {
+		case "eq":
+			return leaf == value
+		case "co":
+			return strings.Contains(leaf, value)
+		case "sw":
+			return strings.HasPrefix(leaf, value)
+		case "ew":
+			return strings.HasSuffix(leaf, value)
+		}
+		return compareOrdered(strings.Compare(leaf, value), op)
+	case bool:
+		leaf, ok := leaf.(bool)
+		return ok && op == "eq" && leaf == value
+	case float64:
+		leaf, ok := leaf.(float64)
+		if !ok {
+			return false
+		}
+		switch {
+		case leaf < value:
+			return compareOrdered(-1, op)
+		case leaf > value:
+			return compareOrdered(1, op)
+		}
+		return compareOrdered(0, op)
+	}
+
+	return false
+}
+
+func compareOrdered(cmp int, op string) bool {
+	switch op {
+	case "eq":
+		return cmp == 0
+	case "gt":
+		return cmp > 0
+	case "ge":
+		return cmp >= 0
+	case "lt":
+		return cmp < 0
+	case "le":
+		return cmp <= 0
+	}
+	return false
+}
+
+// values returns values of the attribute of the resource, a multi-valued attribute
+// returns all of its values
+func values(r map[string]any, attr string) []any {
+	for key, value := range r {
+		if !strings.EqualFold(key, attr) {
+			continue
+		}
+		if list, ok := value.([]any); ok {
+			return list
+		}
+		return []any{value}
+	}
+	return nil
+}
+
+// ParseFilter parses the filter of list requests
+func ParseFilter(filter string) (Filter, error) {
+	tokens, err := tokenize(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &filterParser{tokens: tokens}
+	f, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, fmt.Errorf("%w: unexpected %q", ErrInvalidFilter, p.peek().text)
+	}
+
+	return f, nil
+}
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenString
+	tokenOpen
+	tokenClose
+	tokenOpenBracket
+	tokenCloseBracket
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+func tokenize(s string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{tokenOpen, "("})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{tokenClose, ")"})
+			i++
+		case c == '[':
+			tokens = append(tokens, token{tokenOpenBracket, "["})
+			i++
+		case c == ']':
+			tokens = append(tokens, token{tokenCloseBracket, "]"})
+			i++
+		case c == '"':
+			// strings are json strings
+			j := i + 1
+			for ; j < len(s) && s[j] != '"'; j++ {
+				if s[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(s) {
+				return nil, fmt.Errorf("%w: unterminated string", ErrInvalidFilter)
+			}
+			var value string
+			if err := json.Unmarshal([]byte(s[i:j+1]), &value); err != nil {
+				return nil, fmt.Errorf("%w: %s", ErrInvalidFilter, err)
+			}
+			tokens = append(tokens, token{tokenString, value})
+			i = j + 1
+		default:
+			j := i
+			for j < len(s) && isWordChar(s[j]) {
+				j++
+			}
+			if j == i {
+				return nil, fmt.Errorf("%w: unexpected %q", ErrInvalidFilter, c)
+			}
+			tokens = append(tokens, token{tokenWord, s[i:j]})
+			i = j
+		}
+	}
+
+	return tokens, nil
+}
+
+// isWordChar reports whether c belongs to attribute paths, operators and literals
+func isWordChar(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' ||
+		strings.IndexByte(".:$-_+", c) >= 0
+}
+
+type filterParser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *filterParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *filterParser) peek() token {
+	if p.done() {
+		return token{kind: -1}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *filterParser) next() (token, error) {
+	if p.done() {
+		return token{}, fmt.Errorf("%w: unexpected end", ErrInvalidFilter)
+	}
+	p.pos++
+	return p.tokens[p.pos-1], nil
+}
+
+func (p *filterParser) keyword(word string) bool {
+	t := p.peek()
+	if t.kind == tokenWord && strings.EqualFold(t.text, word) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *filterParser) expect(kind tokenKind, text string) error {
+	t, err := p.next()
+	if err != nil {
+		return err
+	}
+	if t.kind != kind {
+		return fmt.Errorf("%w: expected %q, got %q", ErrInvalidFilter, text, t.text)
+	}
+	return nil
+}
+
+func (p *filterParser) parseOr() (Filter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orFilter{left, right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (Filter, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("and") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = andFilter{left, right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseNot() (Filter, error) {
+	if !p.keyword("not") {
+		return p.parsePrimary()
+	}
+
+	if err := p.expect(tokenOpen, "("); err != nil {
+		return nil, err
+	}
+	f, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(tokenClose, ")"); err != nil {
+		return nil, err
+	}
+	return notFilter{f}, nil
+}
+
+func (p *filterParser) parsePrimary() (Filter, error) {
+	t, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t.kind {
+	case tokenOpen:
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenClose, ")"); err != nil {
+			return nil, err
+		}
+		return f, nil
+	case tokenWord:
+	default:
+		return nil, fmt.Errorf("%w: unexpected %q", ErrInvalidFilter, t.text)
+	}
+
+	path, err := parseAttrPath(t.text)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek().kind == tokenOpenBracket {
+		p.pos++
+		if path.sub != "" {
+			return nil, fmt.Errorf("%w: filter of sub-attribute %q", ErrInvalidFilter, t.text)
+		}
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenCloseBracket, "]"); err != nil {
+			return nil, err
+		}
+		return valueFilter{attr: path.attr, filter: f}, nil
+	}
+
+	op, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	opName := strings.ToLower(op.text)
+	switch opName {
+	case "pr":
+		return compareFilter{path: path, op: opName}, nil
+	case "eq", "ne", "co", "sw", "ew", "gt", "ge", "lt", "le":
+	default:
+		return nil, fmt.Errorf("%w: unknown operator %q", ErrInvalidFilter, op.text)
+	}
+
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	return compareFilter{path: path, op: opName, value: value}, nil
+}
+
+func (p *filterParser) parseValue() (any, error) {
+	t, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t.kind {
+	case tokenString:
+		return t.text, nil
+	case tokenWord:
+		switch strings.ToLower(t.text) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		}
+		if number, err := strconv.ParseFloat(t.text, 64); err == nil {
+			return number, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: bad value %q", ErrInvalidFilter, t.text)
+}
+
+// parseAttrPath parses attribute paths of filters, paths prefixed by a schema
+// URN are supported for the core schemas only
+func parseAttrPath(s string) (attrPath, error) {
+	if strings.HasPrefix(strings.ToLower(s), "urn:") {
+		stripped := false
+		for _, schema := range []string{SchemaUser, SchemaGroup} {
+			if len(s) > len(schema) && strings.EqualFold(s[:len(schema)+1], schema+":") {
+				s, stripped = s[len(schema)+1:], true
+				break
+			}
+		}
+		if !stripped {
+			return attrPath{}, fmt.Errorf("%w: unknown schema of %q", ErrInvalidFilter, s)
+		}
+	}
+
+	attr, sub, _ := strings.Cut(s, ".")
+	if !validName(attr) || (sub != "" && !validName(sub)) {
+		return attrPath{}, fmt.Errorf("%w: bad attribute %q", ErrInvalidFilter, s)
+	}
+
+	return attrPath{attr: attr, sub: sub}, nil
+}
+
+// validName reports whether name is an attribute name of RFC 7643 2.1
+func validName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, c := range name {
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z':
+		case i == 0 && c == '$':
+			// $ref
+		case i > 0 && ('0' <= c && c <= '9' || c == '-' || c == '_'):
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,151 @@
+package scim
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilter(t *testing.T) {
+	user := map[string]any{
+		"schemas":  []any{SchemaUser},
+		"userName": "Username001",
+		"active":   true,
+		"emails": []any{
+			map[string]any{"value": "work@mail.ru", "type": "work", "primary": true},
+			map[string]any{"value": "home@gmail.com", "type": "home"},
+		},
+		"meta": map[string]any{"resourceType": "User"},
+	}
+
+	for _, tt := range []struct {
+		filter string
+		match  bool
+	}{
+		{`userName eq "username001"`, true},
+		{`USERNAME Eq "username001"`, true},
+		{`urn:ietf:params:scim:schemas:core:2.0:User:userName eq "username001"`, true},
+		{`userName ne "username001"`, false},
+		{`userName sw "user" and userName ew "001"`, true},
+		{`userName co "name0" or userName eq "x"`, true},
+		{`userName gt "username000" and userName lt "username002"`, true},
+		{`not (userName eq "username001")`, false},
+		{`active eq true`, true},
+		{`active eq false`, false},
+		{`title pr`, false},
+		{`title eq null`, true},
+		{`emails pr`, true},
+		{`emails.value ew "gmail.com"`, true},
+		{`emails[type eq "work" and value co "@mail"]`, true},
+		{`emails[type eq "home" and value co "@mail"]`, false},
+		{`meta.resourceType eq "User"`, true},
+		{`(userName eq "x" or active eq true) and not (emails[type eq "other"])`, true},
+	} {
+		t.Run(tt.filter, func(t *testing.T) {
+			f, err := ParseFilter(tt.filter)
+			require.NoError(t, err)
+			require.Equal(t, tt.match, f.Match(user))
+		})
+	}
+
+	for _, filter := range []string{
+		``,
+		`userName`,
+		`userName eq`,
+		`userName is "x"`,
+		`userName eq "x`,
+		`userName eq x`,
+		`(userName eq "x"`,
+		`userName eq "x" and`,
+		`emails[type eq "work"`,
+		`urn:unknown:userName eq "x"`,
+		`user.name.first eq "x"`,
+		`not userName eq "x"`,
+	} {
+		_, err := ParseFilter(filter)
+		require.ErrorIs(t, err, ErrInvalidFilter, filter)
+	}
+}
+
+func TestApplyPatch(t *testing.T) {
+	newGroup := func() map[string]any {
+		return map[string]any{
+			"displayName": "group001",
+			"members": []any{
+				map[string]any{"value": "1", "type": "User"},
+				map[string]any{"value": "2", "type": "User"},
+			},
+		}
+	}
+	values := func(group map[string]any) []string {
+		var values []string
+		for _, member := range group["members"].([]any) {
+			values = append(values, member.(map[string]any)["value"].(string))
+		}
+		return values
+	}
+	op := func(op, path, value string) PatchOp {
+		return PatchOp{Op: op, Path: path, Value: json.RawMessage(value)}
+	}
+
+	t.Run("Add", func(t *testing.T) {
+		group := newGroup()
+		require.NoError(t, applyPatch(group, op("add", "members", `[{"value":"2"},{"value":"3"}]`)))
+		require.Equal(t, []string{"1", "2", "3"}, values(group))
+
+		// a single value is added to the multi-valued attribute
+		require.NoError(t, applyPatch(group, op("Add", "members", `{"value":"4"}`)))
+		require.Equal(t, []string{"1", "2", "3", "4"}, values(group))
+
+		group = map[string]any{"displayName": "group001"}
+		require.NoError(t, applyPatch(group, op("add", "members", `{"value":"1"}`)))
+		require.Equal(t, []string{"1"}, values(group))
+	})
+
+	t.Run("Replace", func(t *testing.T) {
+		group := newGroup()
+		require.NoError(t, applyPatch(group, op("replace", "members", `[{"value":"3"}]`)))
+		require.Equal(t, []string{"3"}, values(group))
+
+		// attributes of the value without a path are paths
+		require.NoError(t, applyPatch(group, op("replace", "", `{"displayName":"group002","members[value eq \"3\"].type":"Group"}`)))
+		require.Equal(t, "group002", group["displayName"])
+		require.Equal(t, "Group", group["members"].([]any)[0].(map[string]any)["type"])
+
+		err := applyPatch(group, op("replace", `members[value eq "9"].type`, `"User"`))
+		require.Equal(t, "noTarget", err.(*Error).ScimType)
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		group := newGroup()
+		require.NoError(t, applyPatch(group, op("remove", `members[value eq "1"]`, ``)))
+		require.Equal(t, []string{"2"}, values(group))
+
+		// values of the value are removed as well
+		group = newGroup()
+		require.NoError(t, applyPatch(group, op("remove", "members", `[{"value":"2"}]`)))
+		require.Equal(t, []string{"1"}, values(group))
+
+		require.NoError(t, applyPatch(group, op("remove", "members", ``)))
+		require.NotContains(t, group, "members")
+
+		err := applyPatch(group, op("remove", "", ``))
+		require.Equal(t, "noTarget", err.(*Error).ScimType)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, tt := range []struct {
+			op       PatchOp
+			scimType string
+		}{
+			{op("move", "members", `[]`), "invalidSyntax"},
+			{op("add", "members[value eq", `[]`), "invalidPath"},
+			{op("add", "", `"value"`), "invalidValue"},
+			{op("add", "members", `{`), "invalidValue"},
+		} {
+			err := applyPatch(newGroup(), tt.op)
+			require.Equal(t, tt.scimType, err.(*Error).ScimType, tt.op)
+		}
+	})
+}
@@ -0,0 +1,401 @@
+package scim
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/repository"
+)
+
+// member types
+const (
+	memberUser  = "User"
+	memberGroup = "Group"
+)
+
+// groupResource is the group of RFC 7643 4.2, the display name is the name of the group.
+// Members are users and nested groups
+type groupResource struct {
+	Schemas     []string     `json:"schemas"`
+	ID          string       `json:"id,omitempty"`
+	DisplayName string       `json:"displayName"`
+	Members     []multiValue `json:"members,omitempty"`
+	Meta        *meta        `json:"meta,omitempty"`
+}
+
+func newGroupResource(r *http.Request, group *domain.Group) *groupResource {
+	id := uuid.UUID(group.ID.Value).String()
+
+	return &groupResource{
+		Schemas:     []string{SchemaGroup},
+		ID:          id,
+		DisplayName: group.Name.Value,
+		Meta: &meta{
+			ResourceType: "Group",
+			Location:     location(r, "/Groups/"+id),
+		},
+	}
+}
+
+// groupResource returns the resource of the group with its members
+func (h *Handler) groupResource(r *http.Request, group *domain.Group) (resource, error) {
+	members, err := h.store.ListGroupMembers(r.Context(), group.ID.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	res := newGroupResource(r, group)
+	for _, member := range members {
+		value := multiValue{Type: memberUser, Value: uuid.UUID(member.UserID.Value).String()}
+		if member.GroupID.IsSet() {
+			value = multiValue{Type: memberGroup, Value: uuid.UUID(member.GroupID.Value).String()}
+		}
+		value.Ref = location(r, "/"+value.Type+"s/"+value.Value)
+		res.Members = append(res.Members, value)
+	}
+
+	return toResource(res)
+}
+
+// getGroupByID returns the group of the path, 404 if it doesn't exist
+func (h *Handler) getGroupByID(r *http.Request) (*domain.Group, error) {
+	id, err := pathID(r, "Group")
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := h.store.GetGroupById(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, repository.ErrGroupNotFound) {
+			return nil, notFound("Group")
+		}
+		return nil, err
+	}
+
+	return group, nil
+}
+
+// resolveMembers returns the members of the resource, members without a type
+// are users if a user with the id exists and groups otherwise
+func (h *Handler) resolveMembers(r *http.Request, values []multiValue) ([]domain.GroupMember, error) {
+	members := make([]domain.GroupMember, 0, len(values))
+	for _, value := range values {
+		parsed, err := uuid.Parse(value.Value)
+		if err != nil {
+			return nil, invalidValue("bad member %q", value.Value)
+		}
+		id := domain.UUID(parsed)
+
+		typ := value.Type
+		if typ == "" {
+			typ = memberGroup
+			_, err := h.store.GetUserById(r.Context(), id)
+			if err == nil {
+				typ = memberUser
+			} else if !errors.Is(err, repository.ErrNotFound) {
+				return nil, err
+			}
+		}
+
+		switch typ {
+		case memberUser:
+			members = append(members, domain.GroupMember{UserID: domain.NewOptUUID(id)})
+		case memberGroup:
+			members = append(members, domain.GroupMember{GroupID: domain.NewOptUUID(id)})
+		default:
+			return nil, invalidValue("bad type %q of member %q", value.Type, value.Value)
+		}
+	}
+
+	return members, nil
+}
+
+// listGroups filters all groups of the organization,
+// members are loaded only if the filter compares them
+func (h *Handler) listGroups(_ http.ResponseWriter, r *http.Request) (int, any, error) {
+	q, err := parseListQuery(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	withMembers := q.filter != nil && mentions(q.filter, "members")
+
+	var groups []domain.Group
+	for offset := int64(0); ; {
+		page, err := h.store.ListGroups(r.Context(), offset)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		for _, group := range page {
+			if q.filter != nil {
+				var res resource
+				var err error
+				if withMembers {
+					res, err = h.groupResource(r, &group)
+				} else {
+					res, err = toResource(newGroupResource(r, &group))
+				}
+				if err != nil {
+					return 0, nil, err
+				}
+				if !q.filter.Match(res) {
+					continue
+				}
+			}
+			groups = append(groups, group)
+		}
+
+		if len(page) == 0 {
+			break
+		}
+		offset += int64(len(page))
+	}
+
+	from, to := q.page(len(groups))
+	resources := make([]resource, 0, to-from)
+	for _, group := range groups[from:to] {
+		res, err := h.groupResource(r, &group)
+		if err != nil {
+			return 0, nil, err
+		}
+		resources = append(resources, res)
+	}
+
+	return http.StatusOK, q.response(len(groups), resources), nil
+}
+
+func (h *Handler) getGroup(_ http.ResponseWriter, r *http.Request) (int, any, error) {
+	group, err := h.getGroupByID(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	res, err := h.groupResource(r, group)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, res, nil
+}
+
+// createGroup creates the group and adds its members, members are checked first
+// so that a bad member doesn't leave a group behind
+func (h *Handler) createGroup(w http.ResponseWriter, r *http.Request) (int, any, error) {
+	var in groupResource
+	if err := decode(r, &in); err != nil {
+		return 0, nil, err
+	}
+	if in.DisplayName == "" {
+		return 0, nil, invalidValue("displayName is required")
+	}
+
+	members, err := h.resolveMembers(r, in.Members)
+	if err != nil {
+		return 0, nil, err
+	}
+	for _, member := range members {
+		if err := h.checkMember(r, member); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	created, err := h.srvs.GroupsCreateGroup(r.Context(), &domain.Group{
+		Name: domain.NewOptString(in.DisplayName),
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	if err := serviceError(created); err != nil {
+		return 0, nil, err
+	}
+	group := created.(*domain.Group)
+
+	if err := h.updateMembers(r, group.ID.Value, nil, members); err != nil {
+		return 0, nil, err
+	}
+
+	res, err := h.groupResource(r, group)
+	if err != nil {
+		return 0, nil, err
+	}
+	w.Header().Set("Location", location(r, "/Groups/"+uuid.UUID(group.ID.Value).String()))
+
+	return http.StatusCreated, res, nil
+}
+
+// checkMember returns 400 if the member doesn't exist
+func (h *Handler) checkMember(r *http.Request, member domain.GroupMember) error {
+	var err error
+	if member.UserID.IsSet() {
+		_, err = h.store.GetUserById(r.Context(), member.UserID.Value)
+	} else {
+		_, err = h.store.GetGroupById(r.Context(), member.GroupID.Value)
+	}
+
+	if errors.Is(err, repository.ErrNotFound) || errors.Is(err, repository.ErrGroupNotFound) {
+		return invalidValue("member %s not found", uuid.UUID(memberID(member)))
+	}
+	return err
+}
+
+func (h *Handler) replaceGroup(_ http.ResponseWriter, r *http.Request) (int, any, error) {
+	current, err := h.getGroupByID(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var in groupResource
+	if err := decode(r, &in); err != nil {
+		return 0, nil, err
+	}
+
+	return h.updateGroup(r, current, &in)
+}
+
+// patchGroup applies the operations to the resource of the group and updates the changed
+// attributes. Members are compared by their ids, so members of operations need no type
+func (h *Handler) patchGroup(_ http.ResponseWriter, r *http.Request) (int, any, error) {
+	current, err := h.getGroupByID(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	ops, err := decodePatch(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	res, err := h.groupResource(r, current)
+	if err != nil {
+		return 0, nil, err
+	}
+	for _, op := range ops {
+		if err := applyPatch(res, op); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	var in groupResource
+	if err := fromResource(res, &in); err != nil {
+		return 0, nil, err
+	}
+
+	return h.updateGroup(r, current, &in)
+}
+
+// updateGroup renames the group and changes its members to the ones of the resource
+func (h *Handler) updateGroup(r *http.Request, current *domain.Group, in *groupResource) (int, any, error) {
+	if in.DisplayName == "" {
+		return 0, nil, invalidValue("displayName is required")
+	}
+
+	currentMembers, err := h.store.ListGroupMembers(r.Context(), current.ID.Value)
+	if err != nil {
+		return 0, nil, err
+	}
+	members, err := h.resolveMembers(r, in.Members)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if in.DisplayName != current.Name.Value {
+		res, err := h.srvs.GroupsPatchGroup(r.Context(), &domain.Group{
+			Name: domain.NewOptString(in.DisplayName),
+		}, domain.GroupsPatchGroupParams{GroupId: current.ID.Value})
+		if err != nil {
+			return 0, nil, err
+		}
+		if err := serviceError(res); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	if err := h.updateMembers(r, current.ID.Value, currentMembers, members); err != nil {
+		return 0, nil, err
+	}
+
+	updated, err := h.store.GetGroupById(r.Context(), current.ID.Value)
+	if err != nil {
+		return 0, nil, err
+	}
+	res, err := h.groupResource(r, updated)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return http.StatusOK, res, nil
+}
+
+// updateMembers removes current members which aren't members and adds the rest of members
+func (h *Handler) updateMembers(r *http.Request, groupID domain.UUID, current, members []domain.GroupMember) error {
+	keep := make(map[domain.UUID]bool, len(members))
+	for _, member := range members {
+		keep[memberID(member)] = true
+	}
+
+	existing := make(map[domain.UUID]bool, len(current))
+	for _, member := range current {
+		id := memberID(member)
+		existing[id] = true
+		if keep[id] {
+			continue
+		}
+
+		res, err := h.srvs.GroupsRemoveGroupMember(r.Context(), domain.GroupsRemoveGroupMemberParams{
+			GroupId:  groupID,
+			MemberId: id,
+		})
+		if err != nil {
+			return err
+		}
+		if err := serviceError(res); err != nil {
+			return err
+		}
+	}
+
+	for _, member := range members {
+		if existing[memberID(member)] {
+			continue
+		}
+		existing[memberID(member)] = true
+
+		res, err := h.srvs.GroupsAddGroupMember(r.Context(), &member, domain.GroupsAddGroupMemberParams{
+			GroupId: groupID,
+		})
+		if err != nil {
+			return err
+		}
+		if err := serviceError(res); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func memberID(member domain.GroupMember) domain.UUID {
+	if member.GroupID.IsSet() {
+		return member.GroupID.Value
+	}
+	return member.UserID.Value
+}
+
+func (h *Handler) deleteGroup(_ http.ResponseWriter, r *http.Request) (int, any, error) {
+	group, err := h.getGroupByID(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	res, err := h.srvs.GroupsDeleteGroup(r.Context(), domain.GroupsDeleteGroupParams{
+		GroupId: group.ID.Value,
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	if err := serviceError(res); err != nil {
+		return 0, nil, err
+	}
+
+	return http.StatusNoContent, nil, nil
+}
@@ -0,0 +1,324 @@
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// PatchOp is an operation of PATCH requests of RFC 7644 3.5.2
+type PatchOp struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+type patchRequest struct {
+	Schemas    []string  `json:"schemas"`
+	Operations []PatchOp `json:"Operations"`
+}
+
+// decodePatch reads the PATCH request, operations are required
+func decodePatch(r *http.Request) ([]PatchOp, error) {
+	var req patchRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+
+	if len(req.Schemas) != 0 && !slices.Contains(req.Schemas, schemaPatchOp) {
+		return nil, &Error{
+			Status:   http.StatusBadRequest,
+			ScimType: "invalidSyntax",
+			Detail:   fmt.Sprintf("schemas must be %s", schemaPatchOp),
+		}
+	}
+	if len(req.Operations) == 0 {
+		return nil, &Error{
+			Status:   http.StatusBadRequest,
+			ScimType: "invalidSyntax",
+			Detail:   "no operations",
+		}
+	}
+
+	return req.Operations, nil
+}
+
+// patchPath is the target of an operation: an attribute, values of a multi-valued
+// attribute matched by the filter, or a sub-attribute of them
+type patchPath struct {
+	attr   string
+	filter Filter
+	sub    string
+}
+
+func parsePatchPath(s string) (patchPath, error) {
+	path := patchPath{}
+
+	attr := s
+	if i := strings.IndexByte(s, '['); i >= 0 {
+		j := strings.LastIndexByte(s, ']')
+		if j < i {
+			return path, invalidPath(s)
+		}
+
+		filter, err := ParseFilter(s[i+1 : j])
+		if err != nil {
+			return path, invalidPath(s)
+		}
+		path.filter = filter
+
+		attr = s[:i]
+		if rest := s[j+1:]; rest != "" {
+			sub, ok := strings.CutPrefix(rest, ".")
+			if !ok || !validName(sub) {
+				return path, invalidPath(s)
+			}
+			path.sub = sub
+		}
+	}
+
+	parsed, err := parseAttrPath(attr)
+	if err != nil || (path.filter != nil && parsed.sub != "") {
+		return path, invalidPath(s)
+	}
+	path.attr = parsed.attr
+	if parsed.sub != "" {
+		path.sub = parsed.sub
+	}
+
+	return path, nil
+}
+
+func invalidPath(path string) *Error {
+	return &Error{
+		Status:   http.StatusBadRequest,
+		ScimType: "invalidPath",
+		Detail:   fmt.Sprintf("invalid path %q", path),
+	}
+}
+
+func noTarget(path string) *Error {
+	return &Error{
+		Status:   http.StatusBadRequest,
+		ScimType: "noTarget",
+		Detail:   fmt.Sprintf("no values match path %q", path),
+	}
+}
+
+// applyPatch applies the operation to the json representation of the resource,
+// mutability of attributes is checked by the caller on the result
+func applyPatch(resource map[string]any, op PatchOp) error {
+	var value any
+	if len(op.Value) != 0 {
+		if err := json.Unmarshal(op.Value, &value); err != nil {
+			return &Error{
+				Status:   http.StatusBadRequest,
+				ScimType: "invalidValue",
+				Detail:   fmt.Sprintf("bad value of operation: %s", err),
+			}
+		}
+	}
+
+	switch name := strings.ToLower(op.Op); name {
+	case "add", "replace":
+		if op.Path != "" {
+			path, err := parsePatchPath(op.Path)
+			if err != nil {
+				return err
+			}
+			return setValue(resource, path, value, name == "replace", op.Path)
+		}
+
+		// without a path the value holds attributes of the resource
+		attrs, ok := value.(map[string]any)
+		if !ok {
+			return &Error{
+				Status:   http.StatusBadRequest,
+				ScimType: "invalidValue",
+				Detail:   "value of an operation without path must be an object",
+			}
+		}
+		for key, value := range attrs {
+			path, err := parsePatchPath(key)
+			if err != nil {
+				return err
+			}
+			if err := setValue(resource, path, value, name == "replace", key); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "remove":
+		if op.Path == "" {
+			return noTarget(op.Path)
+		}
+		path, err := parsePatchPath(op.Path)
+		if err != nil {
+			return err
+		}
+		removeValue(resource, path, value)
+		return nil
+	}
+
+	return &Error{
+		Status:   http.StatusBadRequest,
+		ScimType: "invalidSyntax",
+		Detail:   fmt.Sprintf("unknown operation %q", op.Op),
+	}
+}
+
+// multi-valued attributes of the resources, lower-cased
+var multiValued = map[string]bool{
+	"emails":  true,
+	"roles":   true,
+	"groups":  true,
+	"members": true,
+}
+
+// key returns the key of the attribute in m, names of attributes are case-insensitive
+func key(m map[string]any, attr string) string {
+	for k := range m {
+		if strings.EqualFold(k, attr) {
+			return k
+		}
+	}
+	return attr
+}
+
+func setValue(resource map[string]any, path patchPath, value any, replace bool, raw string) error {
+	k := key(resource, path.attr)
+
+	if path.filter != nil {
+		list, _ := resource[k].([]any)
+		matched := false
+		for i, item := range list {
+			item, ok := item.(map[string]any)
+			if !ok || !path.filter.Match(item) {
+				continue
+			}
+			matched = true
+
+			if path.sub != "" {
+				item[key(item, path.sub)] = value
+			} else {
+				list[i] = value
+			}
+		}
+		if !matched {
+			return noTarget(raw)
+		}
+		return nil
+	}
+
+	if path.sub != "" {
+		switch current := resource[k].(type) {
+		case map[string]any:
+			current[key(current, path.sub)] = value
+		case []any:
+			for _, item := range current {
+				if item, ok := item.(map[string]any); ok {
+					item[key(item, path.sub)] = value
+				}
+			}
+		default:
+			resource[k] = map[string]any{path.sub: value}
+		}
+		return nil
+	}
+
+	if _, ok := value.([]any); !ok && value != nil && multiValued[strings.ToLower(path.attr)] {
+		value = []any{value}
+	}
+
+	// values are added to multi-valued attributes, the same values aren't repeated
+	if list, ok := resource[k].([]any); ok && !replace {
+		added, _ := value.([]any)
+		for _, item := range added {
+			if !containsValue(list, item) {
+				list = append(list, item)
+			}
+		}
+		resource[k] = list
+		return nil
+	}
+
+	resource[k] = value
+	return nil
+}
+
+// removeValue removes the target of the path, a missing target is already removed.
+// Values of a multi-valued attribute without a filter are removed if the value lists them
+func removeValue(resource map[string]any, path patchPath, value any) {
+	k := key(resource, path.attr)
+	current, ok := resource[k]
+	if !ok {
+		return
+	}
+
+	list, multi := current.([]any)
+	switch {
+	case path.filter != nil:
+		kept := list[:0]
+		for _, item := range list {
+			m, ok := item.(map[string]any)
+			if !ok || !path.filter.Match(m) {
+				kept = append(kept, item)
+				continue
+			}
+			if path.sub != "" {
+				delete(m, key(m, path.sub))
+				kept = append(kept, m)
+			}
+		}
+		resource[k] = kept
+	case path.sub != "":
+		if m, ok := current.(map[string]any); ok {
+			delete(m, key(m, path.sub))
+		}
+		for _, item := range list {
+			if m, ok := item.(map[string]any); ok {
+				delete(m, key(m, path.sub))
+			}
+		}
+	case multi && value != nil:
+		removed, ok := value.([]any)
+		if !ok {
+			removed = []any{value}
+		}
+		kept := list[:0]
+		for _, item := range list {
+			if !containsValue(removed, item) {
+				kept = append(kept, item)
+			}
+		}
+		resource[k] = kept
+	default:
+		delete(resource, k)
+	}
+}
+
+// containsValue reports whether list has the item, values of multi-valued
+// attributes are the same if their "value" sub-attributes are
+func containsValue(list []any, item any) bool {
+	value, ok := subValue(item)
+	if !ok {
+		return false
+	}
+	for _, v := range list {
+		if other, ok := subValue(v); ok && other == value {
+			return true
+		}
+	}
+	return false
+}
+
+// subValue returns the "value" sub-attribute of the item, or the item if it is a string
+func subValue(item any) (string, bool) {
+	if m, ok := item.(map[string]any); ok {
+		item = m[key(m, "value")]
+	}
+	value, ok := item.(string)
+	return value, ok
+}
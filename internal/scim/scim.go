@@ -0,0 +1,548 @@
+// Package scim provisions users and groups of an organization by SCIM 2.0 (RFC 7643, 7644).
+// Identity providers authenticate by the bearer token of the configuration, resources are
+// mapped onto users and groups and changed through the handlers of the REST API, so they
+// are validated and recorded the same way
+package scim
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/lib/config"
+	"github.com/liriquew/test_task/internal/lib/consistency"
+	"github.com/liriquew/test_task/internal/lib/tenant"
+	"github.com/liriquew/test_task/internal/repository"
+	"github.com/liriquew/test_task/internal/service"
+	"github.com/liriquew/test_task/pkg/logger/sl"
+)
+
+// BasePath is the prefix of the endpoints
+const BasePath = "/scim/v2"
+
+// schemas of resources and messages
+const (
+	SchemaUser  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SchemaGroup = "urn:ietf:params:scim:schemas:core:2.0:Group"
+
+	schemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	schemaResourceType          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+	schemaSchema                = "urn:ietf:params:scim:schemas:core:2.0:Schema"
+
+	schemaListResponse = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	schemaPatchOp      = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	schemaError        = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+const (
+	contentType = "application/scim+json"
+	maxBodySize = 1 << 20
+
+	// resources of a list response
+	defaultCount = 100
+	maxCount     = 200
+)
+
+// Error is the error response of RFC 7644 3.12
+type Error struct {
+	Status   int
+	ScimType string
+	Detail   string
+}
+
+func (e *Error) Error() string {
+	return e.Detail
+}
+
+func (e *Error) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Schemas  []string `json:"schemas"`
+		Status   string   `json:"status"`
+		ScimType string   `json:"scimType,omitempty"`
+		Detail   string   `json:"detail,omitempty"`
+	}{
+		Schemas:  []string{schemaError},
+		Status:   strconv.Itoa(e.Status),
+		ScimType: e.ScimType,
+		Detail:   e.Detail,
+	})
+}
+
+func invalidValue(format string, args ...any) *Error {
+	return &Error{
+		Status:   http.StatusBadRequest,
+		ScimType: "invalidValue",
+		Detail:   fmt.Sprintf(format, args...),
+	}
+}
+
+// Store reads users and groups of the organization of ctx, changes go through the service
+type Store interface {
+	GetOrganizationByName(context.Context, string) (*domain.Organization, error)
+
+	GetUserById(context.Context, domain.UUID) (*domain.User, error)
+	GetUserByUsername(context.Context, string) (*domain.User, error)
+	ExportUsers(context.Context, repository.UserFilter, domain.OptUUID, func(domain.User) error) error
+	GetUserGroups(context.Context, domain.UUID) ([]domain.Group, error)
+
+	ListGroups(context.Context, int64) ([]domain.Group, error)
+	GetGroupById(context.Context, domain.UUID) (*domain.Group, error)
+	ListGroupMembers(context.Context, domain.UUID) ([]domain.GroupMember, error)
+}
+
+// Handler serves the endpoints under BasePath to the provisioner of the organization
+type Handler struct {
+	log   *slog.Logger
+	srvs  *service.Service
+	store Store
+
+	token        string
+	organization string
+
+	mux *http.ServeMux
+}
+
+func NewHandler(log *slog.Logger, srvs *service.Service, store Store, cfg config.SCIMConfig) (*Handler, error) {
+	if cfg.Token == "" {
+		return nil, errors.New("scim: token is required")
+	}
+
+	h := &Handler{
+		log:          log,
+		srvs:         srvs,
+		store:        store,
+		token:        cfg.Token,
+		organization: cfg.Organization,
+		mux:          http.NewServeMux(),
+	}
+
+	routes := map[string]handlerFunc{
+		"GET /Users":         h.listUsers,
+		"POST /Users":        h.createUser,
+		"GET /Users/{id}":    h.getUser,
+		"PUT /Users/{id}":    h.replaceUser,
+		"PATCH /Users/{id}":  h.patchUser,
+		"DELETE /Users/{id}": h.deleteUser,
+
+		"GET /Groups":         h.listGroups,
+		"POST /Groups":        h.createGroup,
+		"GET /Groups/{id}":    h.getGroup,
+		"PUT /Groups/{id}":    h.replaceGroup,
+		"PATCH /Groups/{id}":  h.patchGroup,
+		"DELETE /Groups/{id}": h.deleteGroup,
+
+		"GET /ServiceProviderConfig": h.serviceProviderConfig,
+		"GET /ResourceTypes":         h.listResourceTypes,
+		"GET /ResourceTypes/{id}":    h.getResourceType,
+		"GET /Schemas":               h.listSchemas,
+		"GET /Schemas/{id}":          h.getSchema,
+	}
+	for pattern, handle := range routes {
+		method, path, _ := strings.Cut(pattern, " ")
+		h.mux.Handle(method+" "+BasePath+path, h.handle(handle))
+	}
+	h.mux.Handle("/", h.handle(func(http.ResponseWriter, *http.Request) (int, any, error) {
+		return 0, nil, &Error{Status: http.StatusNotFound, Detail: "endpoint not found"}
+	}))
+
+	return h, nil
+}
+
+// ServeHTTP checks the bearer token and serves the request in the organization
+// of the provisioner. Reads go to the primary, so the provisioner reads its writes
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	scheme, token, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !strings.EqualFold(scheme, "Bearer") || subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) != 1 {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="scim"`)
+		writeJSON(w, http.StatusUnauthorized, &Error{
+			Status: http.StatusUnauthorized,
+			Detail: "invalid bearer token",
+		})
+		return
+	}
+
+	org, err := h.store.GetOrganizationByName(r.Context(), h.organization)
+	if err != nil {
+		h.log.Error("error while getting organization of scim", slog.String("organization", h.organization), sl.Err(err))
+		writeJSON(w, http.StatusInternalServerError, &Error{
+			Status: http.StatusInternalServerError,
+			Detail: "internal error",
+		})
+		return
+	}
+
+	ctx := tenant.WithTenant(r.Context(), org.ID.Value)
+	ctx = consistency.WithPrimary(ctx)
+
+	h.mux.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// handlerFunc returns the status and the body of the response,
+// the body is omitted if it is nil
+type handlerFunc func(w http.ResponseWriter, r *http.Request) (int, any, error)
+
+func (h *Handler) handle(handle handlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status, body, err := handle(w, r)
+		if err != nil {
+			var scimErr *Error
+			if !errors.As(err, &scimErr) {
+				h.log.Error("error while serving scim request", slog.String("path", r.URL.Path), sl.Err(err))
+				scimErr = &Error{Status: http.StatusInternalServerError, Detail: "internal error"}
+			}
+			writeJSON(w, scimErr.Status, scimErr)
+			return
+		}
+
+		if body == nil {
+			w.WriteHeader(status)
+			return
+		}
+
+		// attributes of resources are projected by the query of the request
+		query := r.URL.Query()
+		switch body := body.(type) {
+		case resource:
+			err = project(body, query)
+		case *listResponse:
+			for _, r := range body.Resources {
+				if err = project(r, query); err != nil {
+					break
+				}
+			}
+		}
+		if err != nil {
+			scimErr := err.(*Error)
+			writeJSON(w, scimErr.Status, scimErr)
+			return
+		}
+		writeJSON(w, status, body)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// decode reads the json body of the request
+func decode(r *http.Request, v any) error {
+	if err := json.NewDecoder(http.MaxBytesReader(nil, r.Body, maxBodySize)).Decode(v); err != nil {
+		return &Error{
+			Status:   http.StatusBadRequest,
+			ScimType: "invalidSyntax",
+			Detail:   fmt.Sprintf("bad request body: %s", err),
+		}
+	}
+	return nil
+}
+
+// pathID parses the id of the resource of the request path
+func pathID(r *http.Request, resourceType string) (domain.UUID, error) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return domain.UUID{}, notFound(resourceType)
+	}
+	return domain.UUID(id), nil
+}
+
+func notFound(resourceType string) *Error {
+	return &Error{
+		Status: http.StatusNotFound,
+		Detail: fmt.Sprintf("%s not found", strings.ToLower(resourceType)),
+	}
+}
+
+// serviceError returns the error of the error response of the service, nil for other responses
+func serviceError(res any) error {
+	switch res := res.(type) {
+	case *domain.ValidationErrorResponse:
+		return invalidValue("%s", res.Message)
+	case *domain.AlreadyExistsResponse:
+		return &Error{Status: http.StatusConflict, ScimType: "uniqueness", Detail: string(res.Message)}
+	case *domain.LastAdminResponse:
+		return &Error{Status: http.StatusConflict, Detail: string(res.Message)}
+	case *domain.PatchTestFailedResponse:
+		return &Error{Status: http.StatusConflict, Detail: string(res.Message)}
+	case *domain.ServicePatchUserConflict:
+		switch {
+		case res.IsLastAdminResponse():
+			return serviceError(&res.LastAdminResponse)
+		case res.IsPatchTestFailedResponse():
+			return serviceError(&res.PatchTestFailedResponse)
+		}
+		return serviceError(&res.AlreadyExistsResponse)
+	case *domain.NotFoundResponse:
+		return &Error{Status: http.StatusNotFound, Detail: string(res.Message)}
+	case *domain.ForbiddenResponse:
+		return &Error{Status: http.StatusForbidden, Detail: string(res.Message)}
+	case *domain.InternalErrorResponse:
+		return fmt.Errorf("service: %s", res.Message)
+	}
+
+	return nil
+}
+
+// resource is the json representation of users and groups
+type resource = map[string]any
+
+// toResource returns the json representation of v
+func toResource(v any) (resource, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	r := resource{}
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// fromResource decodes the json representation into v
+func fromResource(r resource, v any) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return invalidValue("bad attributes: %s", err)
+	}
+	return nil
+}
+
+// project keeps the attributes of the query parameter attributes, or drops the ones of
+// excludedAttributes. The id and the schemas are always returned
+func project(r resource, query map[string][]string) error {
+	attributes, err := attrPaths(strings.Join(query["attributes"], ","))
+	if err != nil {
+		return err
+	}
+	excluded, err := attrPaths(strings.Join(query["excludedAttributes"], ","))
+	if err != nil {
+		return err
+	}
+
+	if len(attributes) != 0 {
+		projected := resource{}
+		for _, path := range attributes {
+			k := key(r, path.attr)
+			value, ok := r[k]
+			if !ok {
+				continue
+			}
+			if path.sub == "" {
+				projected[k] = value
+				continue
+			}
+			projected[k] = projectSub(projected[k], value, path.sub)
+		}
+		for k := range r {
+			if _, ok := projected[k]; !ok && k != "id" && k != "schemas" {
+				delete(r, k)
+			}
+		}
+		for k, value := range projected {
+			r[k] = value
+		}
+	}
+
+	for _, path := range excluded {
+		if strings.EqualFold(path.attr, "id") || strings.EqualFold(path.attr, "schemas") {
+			continue
+		}
+		removeValue(r, patchPath{attr: path.attr, sub: path.sub}, nil)
+	}
+
+	return nil
+}
+
+// projectSub adds the sub-attribute of value to the projected value
+func projectSub(projected, value any, sub string) any {
+	switch value := value.(type) {
+	case map[string]any:
+		m, ok := projected.(map[string]any)
+		if !ok {
+			m = map[string]any{}
+		}
+		if k := key(value, sub); value[k] != nil {
+			m[k] = value[k]
+		}
+		return m
+	case []any:
+		list, ok := projected.([]any)
+		if !ok {
+			list = make([]any, len(value))
+		}
+		for i, item := range value {
+			list[i] = projectSub(list[i], item, sub)
+		}
+		return list
+	}
+
+	return projected
+}
+
+func attrPaths(list string) ([]attrPath, error) {
+	var paths []attrPath
+	for _, s := range strings.Split(list, ",") {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+		path, err := parseAttrPath(s)
+		if err != nil {
+			return nil, invalidValue("bad attribute %q", s)
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// listResponse is the response of queries of RFC 7644 3.4.2
+type listResponse struct {
+	Schemas      []string   `json:"schemas"`
+	TotalResults int        `json:"totalResults"`
+	StartIndex   int        `json:"startIndex"`
+	ItemsPerPage int        `json:"itemsPerPage"`
+	Resources    []resource `json:"Resources"`
+}
+
+// listQuery is the filter and the page of a list request
+type listQuery struct {
+	filter Filter
+	// startIndex is 1-based
+	startIndex int
+	count      int
+}
+
+func parseListQuery(r *http.Request) (listQuery, error) {
+	query := r.URL.Query()
+	q := listQuery{startIndex: 1, count: defaultCount}
+
+	if s := query.Get("filter"); s != "" {
+		filter, err := ParseFilter(s)
+		if err != nil {
+			return q, &Error{
+				Status:   http.StatusBadRequest,
+				ScimType: "invalidFilter",
+				Detail:   err.Error(),
+			}
+		}
+		q.filter = filter
+	}
+
+	// out of range values are clamped, RFC 7644 3.4.2.4
+	if s := query.Get("startIndex"); s != "" {
+		startIndex, err := strconv.Atoi(s)
+		if err != nil {
+			return q, invalidValue("bad startIndex %q", s)
+		}
+		q.startIndex = max(startIndex, 1)
+	}
+	if s := query.Get("count"); s != "" {
+		count, err := strconv.Atoi(s)
+		if err != nil {
+			return q, invalidValue("bad count %q", s)
+		}
+		q.count = min(max(count, 0), maxCount)
+	}
+
+	return q, nil
+}
+
+// page returns the bounds of the page of total results
+func (q listQuery) page(total int) (from, to int) {
+	from = min(q.startIndex-1, total)
+	return from, min(from+q.count, total)
+}
+
+func (q listQuery) response(total int, resources []resource) *listResponse {
+	if resources == nil {
+		resources = []resource{}
+	}
+	return &listResponse{
+		Schemas:      []string{schemaListResponse},
+		TotalResults: total,
+		StartIndex:   q.startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}
+}
+
+// mentions reports whether the filter compares the attribute
+func mentions(f Filter, attr string) bool {
+	switch f := f.(type) {
+	case andFilter:
+		return mentions(f.left, attr) || mentions(f.right, attr)
+	case orFilter:
+		return mentions(f.left, attr) || mentions(f.right, attr)
+	case notFilter:
+		return mentions(f.filter, attr)
+	case valueFilter:
+		return strings.EqualFold(f.attr, attr)
+	case compareFilter:
+		return strings.EqualFold(f.path.attr, attr)
+	}
+	return false
+}
+
+// meta is the metadata of resources
+type meta struct {
+	ResourceType string `json:"resourceType"`
+	Location     string `json:"location"`
+}
+
+// location returns the url of the resource, the host of the request is the host of the service
+func location(r *http.Request, path string) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+
+	return scheme + "://" + r.Host + BasePath + path
+}
+
+// multiValue is a value of multi-valued attributes
+type multiValue struct {
+	Value   string   `json:"value"`
+	Display string   `json:"display,omitempty"`
+	Type    string   `json:"type,omitempty"`
+	Primary flexBool `json:"primary,omitempty"`
+	Ref     string   `json:"$ref,omitempty"`
+}
+
+// flexBool accepts "True" and "False" strings sent by some identity providers
+type flexBool bool
+
+func (b *flexBool) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		value, err := strconv.ParseBool(strings.ToLower(s))
+		if err != nil {
+			return fmt.Errorf("bad boolean %q", s)
+		}
+		*b = flexBool(value)
+		return nil
+	}
+
+	var value bool
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*b = flexBool(value)
+	return nil
+}
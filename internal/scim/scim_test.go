@@ -0,0 +1,376 @@
+package scim_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/liriquew/test_task/internal/lib/config"
+	"github.com/liriquew/test_task/internal/repository/memory"
+	"github.com/liriquew/test_task/internal/scim"
+	"github.com/liriquew/test_task/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+const token = "provisioner-token"
+
+type client struct {
+	t       *testing.T
+	handler http.Handler
+	token   string
+}
+
+func newClient(t *testing.T) client {
+	t.Helper()
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	repo := memory.New()
+
+	h, err := scim.NewHandler(log, service.New(log, repo), repo, config.SCIMConfig{
+		Token:        token,
+		Organization: "default",
+	})
+	require.NoError(t, err)
+
+	return client{t: t, handler: h, token: token}
+}
+
+// do sends the request with the body, the json response is decoded into a map
+func (c client) do(method, path string, body any) (int, map[string]any, http.Header) {
+	c.t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		require.NoError(c.t, err)
+		reader = bytes.NewReader(data)
+	}
+
+	req := httptest.NewRequest(method, scim.BasePath+path, reader)
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Content-Type", "application/scim+json")
+	rec := httptest.NewRecorder()
+	c.handler.ServeHTTP(rec, req)
+
+	var res map[string]any
+	if rec.Body.Len() != 0 {
+		require.Equal(c.t, "application/scim+json", rec.Header().Get("Content-Type"))
+		require.NoError(c.t, json.Unmarshal(rec.Body.Bytes(), &res))
+	}
+
+	return rec.Code, res, rec.Header()
+}
+
+func (c client) createUser(userName, email string) string {
+	c.t.Helper()
+
+	code, res, _ := c.do(http.MethodPost, "/Users", map[string]any{
+		"schemas":  []string{scim.SchemaUser},
+		"userName": userName,
+		"emails":   []any{map[string]any{"value": email, "primary": true}},
+	})
+	require.Equal(c.t, http.StatusCreated, code, res)
+
+	return res["id"].(string)
+}
+
+func TestAuthentication(t *testing.T) {
+	c := newClient(t)
+
+	for _, token := range []string{"", "wrong"} {
+		c := c
+		c.token = token
+		code, res, header := c.do(http.MethodGet, "/Users", nil)
+		require.Equal(t, http.StatusUnauthorized, code)
+		require.Equal(t, "401", res["status"])
+		require.Contains(t, header.Get("WWW-Authenticate"), "Bearer")
+	}
+
+	_, err := scim.NewHandler(nil, nil, nil, config.SCIMConfig{})
+	require.Error(t, err)
+}
+
+func TestUsers(t *testing.T) {
+	c := newClient(t)
+
+	code, res, header := c.do(http.MethodPost, "/Users", map[string]any{
+		"schemas":  []string{scim.SchemaUser},
+		"userName": "username001",
+		"password": "Passw0rd1",
+		"active":   "True",
+		"emails": []any{
+			map[string]any{"value": "home@gmail.com", "type": "home"},
+			map[string]any{"value": "Work@mail.ru", "type": "work", "primary": "true"},
+		},
+		"roles": []any{map[string]any{"value": "admin"}},
+	})
+	require.Equal(t, http.StatusCreated, code, res)
+	id := res["id"].(string)
+	require.Equal(t, "username001", res["userName"])
+	require.Equal(t, true, res["active"])
+	require.NotContains(t, res, "password")
+	require.Equal(t, "work@mail.ru", res["emails"].([]any)[0].(map[string]any)["value"])
+	require.Equal(t, []any{map[string]any{"value": "admin"}}, res["roles"])
+	require.Equal(t, "http://example.com/scim/v2/Users/"+id, header.Get("Location"))
+
+	code, res, _ = c.do(http.MethodPost, "/Users", map[string]any{
+		"userName": "USERNAME001",
+		"emails":   []any{map[string]any{"value": "other@mail.ru"}},
+	})
+	require.Equal(t, http.StatusConflict, code)
+	require.Equal(t, "uniqueness", res["scimType"])
+
+	code, res, _ = c.do(http.MethodPost, "/Users", map[string]any{
+		"userName": "short",
+		"emails":   []any{map[string]any{"value": "other@mail.ru"}},
+	})
+	require.Equal(t, http.StatusBadRequest, code)
+	require.Equal(t, "invalidValue", res["scimType"])
+
+	// a random password is set if it isn't provided
+	other := c.createUser("username002", "other@mail.ru")
+
+	t.Run("Get", func(t *testing.T) {
+		code, res, _ := c.do(http.MethodGet, "/Users/"+id+"?attributes=userName,emails.value", nil)
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, map[string]any{
+			"id":       id,
+			"schemas":  []any{scim.SchemaUser},
+			"userName": "username001",
+			"emails":   []any{map[string]any{"value": "work@mail.ru"}},
+		}, res)
+
+		code, res, _ = c.do(http.MethodGet, "/Users/"+id+"?excludedAttributes=emails,meta,id", nil)
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, id, res["id"])
+		require.NotContains(t, res, "emails")
+		require.NotContains(t, res, "meta")
+
+		code, res, _ = c.do(http.MethodGet, "/Users/019763a9-7fc4-7e1a-9756-41c2ec1b9980", nil)
+		require.Equal(t, http.StatusNotFound, code)
+		require.Equal(t, "404", res["status"])
+
+		code, _, _ = c.do(http.MethodGet, "/Users/bad", nil)
+		require.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		list := func(query url.Values) map[string]any {
+			code, res, _ := c.do(http.MethodGet, "/Users?"+query.Encode(), nil)
+			require.Equal(t, http.StatusOK, code, res)
+			return res
+		}
+		userNames := func(res map[string]any) []string {
+			var userNames []string
+			for _, user := range res["Resources"].([]any) {
+				userNames = append(userNames, user.(map[string]any)["userName"].(string))
+			}
+			return userNames
+		}
+
+		res := list(url.Values{"filter": {`userName eq "Username001"`}})
+		require.Equal(t, float64(1), res["totalResults"])
+		require.Equal(t, []string{"username001"}, userNames(res))
+
+		res = list(url.Values{"filter": {`userName eq "username009"`}})
+		require.Equal(t, float64(0), res["totalResults"])
+		require.Equal(t, []any{}, res["Resources"])
+
+		res = list(url.Values{"filter": {`emails[type eq "work" and value ew "@mail.ru"] and not (roles pr)`}})
+		require.Equal(t, []string{"username002"}, userNames(res))
+
+		res = list(url.Values{"filter": {`userName sw "username"`}, "startIndex": {"2"}, "count": {"1"}})
+		require.Equal(t, float64(2), res["totalResults"])
+		require.Equal(t, float64(2), res["startIndex"])
+		require.Equal(t, float64(1), res["itemsPerPage"])
+		require.Len(t, res["Resources"], 1)
+
+		code, res, _ := c.do(http.MethodGet, "/Users?filter=userName+is+x", nil)
+		require.Equal(t, http.StatusBadRequest, code)
+		require.Equal(t, "invalidFilter", res["scimType"])
+	})
+
+	t.Run("Patch", func(t *testing.T) {
+		code, res, _ := c.do(http.MethodPatch, "/Users/"+other, map[string]any{
+			"schemas": []string{"urn:ietf:params:scim:api:messages:2.0:PatchOp"},
+			"Operations": []any{
+				map[string]any{"op": "replace", "path": `emails[type eq "work"].value`, "value": "changed@mail.ru"},
+				map[string]any{"op": "add", "path": "roles", "value": []any{map[string]any{"value": "admin"}}},
+				map[string]any{"op": "replace", "value": map[string]any{"active": true}},
+			},
+		})
+		require.Equal(t, http.StatusOK, code, res)
+		require.Equal(t, "changed@mail.ru", res["emails"].([]any)[0].(map[string]any)["value"])
+		require.Equal(t, []any{map[string]any{"value": "admin"}}, res["roles"])
+
+		code, res, _ = c.do(http.MethodPatch, "/Users/"+other, map[string]any{
+			"Operations": []any{map[string]any{"op": "replace", "path": "active", "value": false}},
+		})
+		require.Equal(t, http.StatusBadRequest, code)
+		require.Equal(t, "mutability", res["scimType"])
+
+		code, res, _ = c.do(http.MethodPatch, "/Users/"+other, map[string]any{
+			"Operations": []any{map[string]any{"op": "remove", "path": "userName"}},
+		})
+		require.Equal(t, http.StatusBadRequest, code)
+		require.Equal(t, "invalidValue", res["scimType"])
+
+		code, res, _ = c.do(http.MethodPatch, "/Users/"+other, map[string]any{
+			"schemas":  []string{scim.SchemaUser},
+			"userName": "username003",
+		})
+		require.Equal(t, http.StatusBadRequest, code)
+		require.Equal(t, "invalidSyntax", res["scimType"])
+	})
+
+	t.Run("Put", func(t *testing.T) {
+		// the admin role is taken away by omission
+		code, res, _ := c.do(http.MethodPut, "/Users/"+other, map[string]any{
+			"schemas":  []string{scim.SchemaUser},
+			"userName": "username003",
+		})
+		require.Equal(t, http.StatusOK, code, res)
+		require.Equal(t, "username003", res["userName"])
+		require.Equal(t, "changed@mail.ru", res["emails"].([]any)[0].(map[string]any)["value"])
+		require.NotContains(t, res, "roles")
+
+		code, res, _ = c.do(http.MethodPut, "/Users/"+id, map[string]any{"userName": "username001"})
+		require.Equal(t, http.StatusConflict, code)
+		require.Equal(t, "conflict, last administrator can't be removed", res["detail"])
+		require.NotContains(t, res, "scimType")
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		code, _, _ := c.do(http.MethodDelete, "/Users/"+other, nil)
+		require.Equal(t, http.StatusNoContent, code)
+
+		code, _, _ = c.do(http.MethodDelete, "/Users/"+other, nil)
+		require.Equal(t, http.StatusNotFound, code)
+	})
+}
+
+func TestGroups(t *testing.T) {
+	c := newClient(t)
+	user := c.createUser("username001", "username@mail.ru")
+	other := c.createUser("username002", "other@mail.ru")
+
+	code, res, _ := c.do(http.MethodPost, "/Groups", map[string]any{
+		"schemas":     []string{scim.SchemaGroup},
+		"displayName": "group001",
+		"members":     []any{map[string]any{"value": user}},
+	})
+	require.Equal(t, http.StatusCreated, code, res)
+	id := res["id"].(string)
+	require.Equal(t, []any{map[string]any{
+		"value": user,
+		"type":  "User",
+		"$ref":  "http://example.com/scim/v2/Users/" + user,
+	}}, res["members"])
+
+	code, res, _ = c.do(http.MethodPost, "/Groups", map[string]any{
+		"displayName": "group002",
+		"members":     []any{map[string]any{"value": "019763a9-7fc4-7e1a-9756-41c2ec1b9980"}},
+	})
+	require.Equal(t, http.StatusBadRequest, code)
+	require.Equal(t, "invalidValue", res["scimType"])
+
+	// the group of a failed creation isn't left behind
+	code, res, _ = c.do(http.MethodGet, "/Groups", nil)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, float64(1), res["totalResults"])
+
+	members := func(res map[string]any) []string {
+		var members []string
+		for _, member := range res["members"].([]any) {
+			members = append(members, member.(map[string]any)["value"].(string))
+		}
+		return members
+	}
+
+	t.Run("Patch", func(t *testing.T) {
+		code, res, _ := c.do(http.MethodPatch, "/Groups/"+id, map[string]any{
+			"schemas": []string{"urn:ietf:params:scim:api:messages:2.0:PatchOp"},
+			"Operations": []any{
+				map[string]any{"op": "add", "path": "members", "value": []any{map[string]any{"value": other}}},
+				map[string]any{"op": "replace", "path": "displayName", "value": "group002"},
+			},
+		})
+		require.Equal(t, http.StatusOK, code, res)
+		require.Equal(t, "group002", res["displayName"])
+		require.ElementsMatch(t, []string{user, other}, members(res))
+
+		code, res, _ = c.do(http.MethodPatch, "/Groups/"+id, map[string]any{
+			"Operations": []any{
+				map[string]any{"op": "remove", "path": `members[value eq "` + user + `"]`},
+			},
+		})
+		require.Equal(t, http.StatusOK, code, res)
+		require.Equal(t, []string{other}, members(res))
+
+		// the user lists its groups
+		_, res, _ = c.do(http.MethodGet, "/Users/"+other, nil)
+		require.Equal(t, id, res["groups"].([]any)[0].(map[string]any)["value"])
+		require.Equal(t, "group002", res["groups"].([]any)[0].(map[string]any)["display"])
+	})
+
+	t.Run("List", func(t *testing.T) {
+		code, res, _ := c.do(http.MethodGet, "/Groups?"+url.Values{
+			"filter": {`members[value eq "` + other + `"]`},
+		}.Encode(), nil)
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, float64(1), res["totalResults"])
+
+		_, res, _ = c.do(http.MethodGet, "/Groups?"+url.Values{"filter": {`displayName eq "group001"`}}.Encode(), nil)
+		require.Equal(t, float64(0), res["totalResults"])
+	})
+
+	t.Run("Put", func(t *testing.T) {
+		code, res, _ := c.do(http.MethodPut, "/Groups/"+id, map[string]any{
+			"schemas":     []string{scim.SchemaGroup},
+			"displayName": "group002",
+			"members":     []any{map[string]any{"value": user, "type": "User"}},
+		})
+		require.Equal(t, http.StatusOK, code, res)
+		require.Equal(t, []string{user}, members(res))
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		code, _, _ := c.do(http.MethodDelete, "/Groups/"+id, nil)
+		require.Equal(t, http.StatusNoContent, code)
+
+		code, _, _ = c.do(http.MethodGet, "/Groups/"+id, nil)
+		require.Equal(t, http.StatusNotFound, code)
+	})
+}
+
+func TestDiscovery(t *testing.T) {
+	c := newClient(t)
+
+	code, res, _ := c.do(http.MethodGet, "/ServiceProviderConfig", nil)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, map[string]any{"supported": true}, res["patch"])
+
+	code, res, _ = c.do(http.MethodGet, "/ResourceTypes", nil)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, float64(2), res["totalResults"])
+
+	code, res, _ = c.do(http.MethodGet, "/ResourceTypes/Group", nil)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, scim.SchemaGroup, res["schema"])
+
+	code, res, _ = c.do(http.MethodGet, "/Schemas/"+scim.SchemaUser, nil)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "User", res["name"])
+
+	code, _, _ = c.do(http.MethodGet, "/Schemas/unknown", nil)
+	require.Equal(t, http.StatusNotFound, code)
+
+	code, res, _ = c.do(http.MethodGet, "/Unknown", nil)
+	require.Equal(t, http.StatusNotFound, code)
+	require.Equal(t, "404", res["status"])
+}
@@ -0,0 +1,363 @@
+package scim
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/repository"
+	"github.com/liriquew/test_task/internal/service"
+)
+
+// roleAdmin is the role of administrators
+const roleAdmin = "admin"
+
+// userResource is the user of RFC 7643 4.1. The email is the primary or the first one
+// of emails, administrators have the admin role. Users are always active, they are
+// deprovisioned by deletion
+type userResource struct {
+	Schemas  []string `json:"schemas"`
+	ID       string   `json:"id,omitempty"`
+	UserName string   `json:"userName"`
+	// Password is write-only
+	Password string       `json:"password,omitempty"`
+	Active   *flexBool    `json:"active,omitempty"`
+	Emails   []multiValue `json:"emails,omitempty"`
+	Roles    []multiValue `json:"roles,omitempty"`
+	// Groups are read-only, membership is changed by groups
+	Groups []multiValue `json:"groups,omitempty"`
+	Meta   *meta        `json:"meta,omitempty"`
+}
+
+// toUser returns the user of the resource, the email and the admin flag are set if present
+func (u *userResource) toUser() (*domain.User, error) {
+	if u.Active != nil && !*u.Active {
+		return nil, &Error{
+			Status:   http.StatusBadRequest,
+			ScimType: "mutability",
+			Detail:   "users can't be deactivated, delete them to deprovision",
+		}
+	}
+
+	user := &domain.User{
+		Username: domain.NewOptString(u.UserName),
+		IsAdmin: domain.NewOptBool(slices.ContainsFunc(u.Roles, func(role multiValue) bool {
+			return strings.EqualFold(role.Value, roleAdmin)
+		})),
+	}
+	if u.Password != "" {
+		user.Password.SetTo(u.Password)
+	}
+
+	if len(u.Emails) != 0 {
+		email := u.Emails[0]
+		if i := slices.IndexFunc(u.Emails, func(email multiValue) bool { return bool(email.Primary) }); i >= 0 {
+			email = u.Emails[i]
+		}
+		user.Email.SetTo(email.Value)
+	}
+
+	return user, nil
+}
+
+// userResource returns the resource of the user with its groups
+func (h *Handler) userResource(r *http.Request, user *domain.User) (resource, error) {
+	groups, err := h.store.GetUserGroups(r.Context(), user.ID.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	res := newUserResource(r, user)
+	for _, group := range groups {
+		id := uuid.UUID(group.ID.Value).String()
+		res.Groups = append(res.Groups, multiValue{
+			Value:   id,
+			Display: group.Name.Value,
+			Ref:     location(r, "/Groups/"+id),
+		})
+	}
+
+	return toResource(res)
+}
+
+func newUserResource(r *http.Request, user *domain.User) *userResource {
+	id := uuid.UUID(user.ID.Value).String()
+	active := flexBool(true)
+
+	res := &userResource{
+		Schemas:  []string{SchemaUser},
+		ID:       id,
+		UserName: user.Username.Value,
+		Active:   &active,
+		Meta: &meta{
+			ResourceType: "User",
+			Location:     location(r, "/Users/"+id),
+		},
+	}
+	if user.Email.Value != "" {
+		res.Emails = []multiValue{{Value: user.Email.Value, Type: "work", Primary: true}}
+	}
+	if user.IsAdmin.Value {
+		res.Roles = []multiValue{{Value: roleAdmin}}
+	}
+
+	return res
+}
+
+// getUserByID returns the user of the path, 404 if it doesn't exist
+func (h *Handler) getUserByID(r *http.Request) (*domain.User, error) {
+	id, err := pathID(r, "User")
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := h.store.GetUserById(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, notFound("User")
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// listUsers filters all users of the organization. Groups are loaded only if the filter
+// compares them, userName eq is looked up by the username
+func (h *Handler) listUsers(_ http.ResponseWriter, r *http.Request) (int, any, error) {
+	q, err := parseListQuery(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	ctx := r.Context()
+
+	var users []domain.User
+	if username, ok := userNameEq(q.filter); ok {
+		user, err := h.store.GetUserByUsername(ctx, username)
+		if err != nil && !errors.Is(err, repository.ErrNotFound) {
+			return 0, nil, err
+		}
+		if user != nil {
+			users = append(users, *user)
+		}
+	} else {
+		withGroups := q.filter != nil && mentions(q.filter, "groups")
+		err := h.store.ExportUsers(ctx, repository.UserFilter{}, domain.OptUUID{}, func(user domain.User) error {
+			if q.filter == nil {
+				users = append(users, user)
+				return nil
+			}
+
+			var res resource
+			var err error
+			if withGroups {
+				res, err = h.userResource(r, &user)
+			} else {
+				res, err = toResource(newUserResource(r, &user))
+			}
+			if err != nil {
+				return err
+			}
+			if q.filter.Match(res) {
+				users = append(users, user)
+			}
+			return nil
+		})
+		if err != nil {
+			return 0, nil, err
+		}
+	}
+
+	from, to := q.page(len(users))
+	resources := make([]resource, 0, to-from)
+	for _, user := range users[from:to] {
+		res, err := h.userResource(r, &user)
+		if err != nil {
+			return 0, nil, err
+		}
+		resources = append(resources, res)
+	}
+
+	return http.StatusOK, q.response(len(users), resources), nil
+}
+
+// userNameEq returns the username of the filter userName eq "username"
+func userNameEq(f Filter) (string, bool) {
+	cmp, ok := f.(compareFilter)
+	if !ok || cmp.op != "eq" || cmp.path.sub != "" || !strings.EqualFold(cmp.path.attr, "userName") {
+		return "", false
+	}
+	username, ok := cmp.value.(string)
+	return username, ok
+}
+
+func (h *Handler) getUser(_ http.ResponseWriter, r *http.Request) (int, any, error) {
+	user, err := h.getUserByID(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	res, err := h.userResource(r, user)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, res, nil
+}
+
+// createUser creates the user, a random password is set if the provisioner
+// doesn't manage passwords
+func (h *Handler) createUser(w http.ResponseWriter, r *http.Request) (int, any, error) {
+	var in userResource
+	if err := decode(r, &in); err != nil {
+		return 0, nil, err
+	}
+	if in.UserName == "" {
+		return 0, nil, invalidValue("userName is required")
+	}
+
+	user, err := in.toUser()
+	if err != nil {
+		return 0, nil, err
+	}
+	if !user.Password.IsSet() {
+		user.Password.SetTo(service.GeneratePassword())
+	}
+
+	created, err := h.srvs.ServiceCreateUser(r.Context(), user)
+	if err != nil {
+		return 0, nil, err
+	}
+	if err := serviceError(created); err != nil {
+		return 0, nil, err
+	}
+
+	user = created.(*domain.User)
+	res, err := h.userResource(r, user)
+	if err != nil {
+		return 0, nil, err
+	}
+	w.Header().Set("Location", location(r, "/Users/"+uuid.UUID(user.ID.Value).String()))
+
+	return http.StatusCreated, res, nil
+}
+
+// replaceUser replaces attributes of the user, the password is kept if it is absent
+func (h *Handler) replaceUser(_ http.ResponseWriter, r *http.Request) (int, any, error) {
+	current, err := h.getUserByID(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var in userResource
+	if err := decode(r, &in); err != nil {
+		return 0, nil, err
+	}
+	if in.UserName == "" {
+		return 0, nil, invalidValue("userName is required")
+	}
+
+	user, err := in.toUser()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return h.updateUser(r, current, user)
+}
+
+// patchUser applies the operations to the resource of the user and updates the changed attributes
+func (h *Handler) patchUser(_ http.ResponseWriter, r *http.Request) (int, any, error) {
+	current, err := h.getUserByID(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	ops, err := decodePatch(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// groups are read-only, they are ignored like the rest of read-only attributes
+	res, err := toResource(newUserResource(r, current))
+	if err != nil {
+		return 0, nil, err
+	}
+	for _, op := range ops {
+		if err := applyPatch(res, op); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	var in userResource
+	if err := fromResource(res, &in); err != nil {
+		return 0, nil, err
+	}
+	if in.UserName == "" {
+		return 0, nil, invalidValue("userName is required")
+	}
+
+	user, err := in.toUser()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return h.updateUser(r, current, user)
+}
+
+// updateUser patches attributes of the user which differ from the current ones
+func (h *Handler) updateUser(r *http.Request, current, user *domain.User) (int, any, error) {
+	patch := &domain.User{Password: user.Password}
+	if user.Username.Value != current.Username.Value {
+		patch.Username = user.Username
+	}
+	if user.Email.IsSet() && !strings.EqualFold(user.Email.Value, current.Email.Value) {
+		patch.Email = user.Email
+	}
+	if user.IsAdmin.Value != current.IsAdmin.Value {
+		patch.IsAdmin = user.IsAdmin
+	}
+
+	if patch.Username.IsSet() || patch.Password.IsSet() || patch.Email.IsSet() || patch.IsAdmin.IsSet() {
+		res, err := h.srvs.ServicePatchUser(r.Context(), patch, domain.ServicePatchUserParams{
+			UserId: current.ID.Value,
+		})
+		if err != nil {
+			return 0, nil, err
+		}
+		if err := serviceError(res); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	updated, err := h.store.GetUserById(r.Context(), current.ID.Value)
+	if err != nil {
+		return 0, nil, err
+	}
+	res, err := h.userResource(r, updated)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return http.StatusOK, res, nil
+}
+
+func (h *Handler) deleteUser(_ http.ResponseWriter, r *http.Request) (int, any, error) {
+	user, err := h.getUserByID(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	res, err := h.srvs.ServiceDeleteUser(r.Context(), domain.ServiceDeleteUserParams{
+		UserId: user.ID.Value,
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	if err := serviceError(res); err != nil {
+		return 0, nil, err
+	}
+
+	return http.StatusNoContent, nil, nil
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/lib/tenant"
+	"github.com/liriquew/test_task/internal/repository"
+)
+
+// ErrLastAdmin is returned when an action leaves the organization without administrators
+var ErrLastAdmin = errors.New("last administrator can't be removed")
+
+const lastAdmin = domain.LastAdminResponseMessageConflictLastAdministratorCanTBeRemoved
+
+// defaultAdmin is both username and password of the administrator
+// seeded by migrations of earlier versions
+const defaultAdmin = "admin"
+
+// checkLastAdmin returns ErrLastAdmin when userID is the only administrator
+// of the organization and the action takes the permissions away. A deleted user
+// loses permissions granted by groups too, a demoted one keeps them.
+// Must be called inside WithTx, admins stay locked until the end of the transaction
+func checkLastAdmin(ctx context.Context, repo Repository, userID domain.UUID, deleting bool) error {
+	admins, err := repo.LockAdmins(ctx)
+	if err != nil {
+		return err
+	}
+	if len(admins) != 1 || admins[0] != userID {
+		return nil
+	}
+
+	if !deleting {
+		groups, err := repo.GetUserGroups(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if slices.ContainsFunc(groups, func(g domain.Group) bool { return g.IsAdmin.Value }) {
+			return nil
+		}
+	}
+
+	return ErrLastAdmin
+}
+
+// updateUser updates the user, demotion of the last administrator is rejected
+func (s *Service) updateUser(ctx context.Context, user *domain.User) error {
+	if !user.IsAdmin.IsSet() || user.IsAdmin.Value {
+		return s.repo.UpdateUser(ctx, user)
+	}
+
+	return s.repo.WithTx(ctx, func(repo Repository) error {
+		if err := checkLastAdmin(ctx, repo, user.ID.Value, false); err != nil {
+			return err
+		}
+		return repo.UpdateUser(ctx, user)
+	})
+}
+
+// BootstrapAdmin grants admin permissions to the user with the username,
+// the user is created if it doesn't exist, password is replaced if provided.
+// It bypasses the API and is used to recover an organization without administrators
+func (s *Service) BootstrapAdmin(ctx context.Context, user *domain.User) (domain.UUID, error) {
+	if user.Username.Value == "" {
+		return domain.UUID{}, errors.New("empty username")
+	}
+
+	NormalizeUser(user)
+	if errResp := ValidateUser(user); errResp != nil {
+		return domain.UUID{}, errors.New(string(errResp.Message))
+	}
+
+	if user.Password.Value != "" {
+		hash, internalErr := hashPassword(user.Password.Value)
+		if internalErr != nil {
+			return domain.UUID{}, errors.New(string(internalErr.Message))
+		}
+		user.Password.SetTo(hash)
+	}
+	user.IsAdmin.SetTo(true)
+
+	var id domain.UUID
+	err := s.repo.WithTx(ctx, func(repo Repository) error {
+		existing, err := repo.GetUserByUsername(ctx, user.Username.Value)
+		if errors.Is(err, repository.ErrNotFound) {
+			if user.Password.Value == "" || user.Email.Value == "" {
+				return errors.New("email and password are required for a new user")
+			}
+
+			created, err := repo.CreateUser(ctx, user)
+			if err != nil {
+				return err
+			}
+			id = *created
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		id = existing.ID.Value
+		update := &domain.User{
+			ID:       existing.ID,
+			Password: user.Password,
+			Email:    user.Email,
+			IsAdmin:  user.IsAdmin,
+		}
+		return repo.UpdateUser(ctx, update)
+	})
+	if err != nil {
+		return domain.UUID{}, fmt.Errorf("bootstrap admin: %w", err)
+	}
+
+	return id, nil
+}
+
+// InitAdmin creates the first administrator of the default organization if it has
+// no administrators, the administrator must change the password on the first login.
+// A random password is generated if it isn't provided. Returns the password
+// and whether the administrator was created
+func (s *Service) InitAdmin(ctx context.Context, user *domain.User) (string, bool, error) {
+	if user.Username.Value == "" || user.Email.Value == "" {
+		return "", false, errors.New("init admin: username and email are required")
+	}
+
+	password := user.Password.Value
+	if password == "" {
+		password = GeneratePassword()
+		user.Password.SetTo(password)
+	}
+
+	NormalizeUser(user)
+	if errResp := ValidateUser(user); errResp != nil {
+		return "", false, fmt.Errorf("init admin: %s", errResp.Message)
+	}
+
+	hash, internalErr := hashPassword(password)
+	if internalErr != nil {
+		return "", false, errors.New(string(internalErr.Message))
+	}
+	user.Password.SetTo(hash)
+	user.IsAdmin.SetTo(true)
+	user.MustChangePassword.SetTo(true)
+
+	ctx = tenant.WithTenant(ctx, tenant.Default)
+
+	var created bool
+	err := s.repo.WithTx(ctx, func(repo Repository) error {
+		created = false
+
+		admins, err := repo.LockAdmins(ctx)
+		if err != nil {
+			return err
+		}
+		if len(admins) != 0 {
+			return nil
+		}
+
+		if _, err := repo.CreateUser(ctx, user); err != nil {
+			return err
+		}
+		created = true
+		return nil
+	})
+	if errors.Is(err, repository.ErrUsernameExists) {
+		// another instance could create the administrator at the same time
+		if admins, lockErr := s.repo.LockAdmins(ctx); lockErr == nil && len(admins) != 0 {
+			return "", false, nil
+		}
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("init admin: %w", err)
+	}
+	if !created {
+		return "", false, nil
+	}
+
+	return password, true, nil
+}
+
+// DefaultCredentialInUse reports whether admin:admin, seeded by migrations
+// of earlier versions, still signs in to the default organization
+func (s *Service) DefaultCredentialInUse(ctx context.Context) (bool, error) {
+	ctx = tenant.WithTenant(ctx, tenant.Default)
+
+	user, err := s.repo.GetUserByUsername(ctx, defaultAdmin)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return checkPassword(user.Password.Value, defaultAdmin), nil
+}
+
+// BootstrapPasswordInUse reports whether the administrator of the default organization
+// created on the first run with the username still must change its password
+func (s *Service) BootstrapPasswordInUse(ctx context.Context, username string) (bool, error) {
+	ctx = tenant.WithTenant(ctx, tenant.Default)
+
+	user, err := s.repo.GetUserByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return user.MustChangePassword.Value, nil
+}
@@ -0,0 +1,318 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/lib/tenant"
+	"github.com/liriquew/test_task/internal/repository"
+	"github.com/liriquew/test_task/internal/service"
+	"github.com/liriquew/test_task/internal/service/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestLastAdmin(t *testing.T) {
+	admin := domain.UUID{1}
+	other := domain.UUID{2}
+
+	type deps struct {
+		repo *mocks.MockRepository
+	}
+
+	type test struct {
+		name  string
+		setup func(d deps)
+		call  func(s *service.Service) any
+		res   any
+	}
+
+	demote := func(s *service.Service) any {
+		res, _ := s.ServicePatchUser(context.Background(), &domain.User{
+			IsAdmin: domain.NewOptBool(false),
+		}, domain.ServicePatchUserParams{
+			UserId: admin,
+		})
+		return res
+	}
+	remove := func(s *service.Service) any {
+		res, _ := s.ServiceDeleteUser(context.Background(), domain.ServiceDeleteUserParams{
+			UserId: admin,
+		})
+		return res
+	}
+
+	tests := []test{
+		{
+			name: "Delete last admin",
+			setup: func(d deps) {
+				ExpectTx(d.repo)
+				d.repo.EXPECT().
+					LockAdmins(gomock.Any()).
+					Return([]domain.UUID{admin}, nil)
+			},
+			call: remove,
+			res: &domain.LastAdminResponse{
+				Message: domain.LastAdminResponseMessageConflictLastAdministratorCanTBeRemoved,
+			},
+		},
+		{
+			name: "Delete one of admins",
+			setup: func(d deps) {
+				ExpectTx(d.repo)
+				d.repo.EXPECT().
+					LockAdmins(gomock.Any()).
+					Return([]domain.UUID{admin, other}, nil)
+				d.repo.EXPECT().
+					DeleteUser(gomock.Any(), admin).
+					Return(nil)
+			},
+			call: remove,
+			res:  &domain.ServiceDeleteUserOK{},
+		},
+		{
+			name: "Demote last admin",
+			setup: func(d deps) {
+				ExpectTx(d.repo)
+				d.repo.EXPECT().
+					LockAdmins(gomock.Any()).
+					Return([]domain.UUID{admin}, nil)
+				d.repo.EXPECT().
+					GetUserGroups(gomock.Any(), admin).
+					Return([]domain.Group{{IsAdmin: domain.NewOptBool(false)}}, nil)
+			},
+			call: demote,
+			res: &domain.ServicePatchUserConflict{
+				Type: domain.LastAdminResponseServicePatchUserConflict,
+				LastAdminResponse: domain.LastAdminResponse{
+					Message: domain.LastAdminResponseMessageConflictLastAdministratorCanTBeRemoved,
+				},
+			},
+		},
+		{
+			name: "Demote admin of admin group",
+			setup: func(d deps) {
+				ExpectTx(d.repo)
+				d.repo.EXPECT().
+					LockAdmins(gomock.Any()).
+					Return([]domain.UUID{admin}, nil)
+				d.repo.EXPECT().
+					GetUserGroups(gomock.Any(), admin).
+					Return([]domain.Group{{IsAdmin: domain.NewOptBool(true)}}, nil)
+				d.repo.EXPECT().
+					UpdateUser(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			call: demote,
+			res:  &domain.ServicePatchUserOK{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			d := deps{
+				repo: mocks.NewMockRepository(ctrl),
+			}
+			tt.setup(d)
+			s := service.New(StubLogger(), d.repo)
+
+			require.Equal(t, tt.res, tt.call(s))
+		})
+	}
+}
+
+func TestBootstrapAdmin(t *testing.T) {
+	t.Run("Create", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockRepository(ctrl)
+		ExpectTx(repo)
+		repo.EXPECT().
+			GetUserByUsername(gomock.Any(), "username1").
+			Return(nil, repository.ErrNotFound)
+		repo.EXPECT().
+			CreateUser(gomock.Any(), gomock.Cond(func(u *domain.User) bool {
+				return u.IsAdmin.Value && u.Password.Value != "password123A"
+			})).
+			Return(&domain.UUID{1}, nil)
+		s := service.New(StubLogger(), repo)
+
+		id, err := s.BootstrapAdmin(context.Background(), &domain.User{
+			Username: domain.NewOptString("username1"),
+			Password: domain.NewOptString("password123A"),
+			Email:    domain.NewOptString("valid@mail.ru"),
+		})
+		require.NoError(t, err)
+		require.Equal(t, domain.UUID{1}, id)
+	})
+
+	t.Run("Promote", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockRepository(ctrl)
+		ExpectTx(repo)
+		repo.EXPECT().
+			GetUserByUsername(gomock.Any(), "username1").
+			Return(&domain.User{ID: domain.NewOptUUID(domain.UUID{1})}, nil)
+		repo.EXPECT().
+			UpdateUser(gomock.Any(), &domain.User{
+				ID:      domain.NewOptUUID(domain.UUID{1}),
+				IsAdmin: domain.NewOptBool(true),
+			}).
+			Return(nil)
+		s := service.New(StubLogger(), repo)
+
+		id, err := s.BootstrapAdmin(context.Background(), &domain.User{
+			Username: domain.NewOptString("username1"),
+		})
+		require.NoError(t, err)
+		require.Equal(t, domain.UUID{1}, id)
+	})
+}
+
+func TestInitAdmin(t *testing.T) {
+	t.Run("Generated password", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var created *domain.User
+		repo := mocks.NewMockRepository(ctrl)
+		ExpectTx(repo)
+		repo.EXPECT().
+			LockAdmins(gomock.Any()).
+			DoAndReturn(func(ctx context.Context) ([]domain.UUID, error) {
+				RequireTenant(t, tenant.Default, ctx)
+				return nil, nil
+			})
+		repo.EXPECT().
+			CreateUser(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, u *domain.User) (*domain.UUID, error) {
+				created = u
+				return &domain.UUID{1}, nil
+			})
+		s := service.New(StubLogger(), repo)
+
+		password, ok, err := s.InitAdmin(context.Background(), &domain.User{
+			Username: domain.NewOptString("administrator"),
+			Email:    domain.NewOptString("admin@mail.ru"),
+		})
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.NotEmpty(t, password)
+		require.True(t, created.IsAdmin.Value)
+		require.True(t, created.MustChangePassword.Value)
+		require.NotEqual(t, password, created.Password.Value)
+	})
+
+	t.Run("Admin exists", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockRepository(ctrl)
+		ExpectTx(repo)
+		repo.EXPECT().
+			LockAdmins(gomock.Any()).
+			Return([]domain.UUID{{1}}, nil)
+		s := service.New(StubLogger(), repo)
+
+		password, ok, err := s.InitAdmin(context.Background(), &domain.User{
+			Username: domain.NewOptString("administrator"),
+			Email:    domain.NewOptString("admin@mail.ru"),
+			Password: domain.NewOptString("password123A"),
+		})
+		require.NoError(t, err)
+		require.False(t, ok)
+		require.Empty(t, password)
+	})
+
+	t.Run("Invalid password", func(t *testing.T) {
+		s := service.New(StubLogger(), mocks.NewMockRepository(gomock.NewController(t)))
+
+		_, _, err := s.InitAdmin(context.Background(), &domain.User{
+			Username: domain.NewOptString("administrator"),
+			Email:    domain.NewOptString("admin@mail.ru"),
+			Password: domain.NewOptString("admin"),
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestDefaultCredentialInUse(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		inUse    bool
+	}{
+		{
+			name:     "Default password",
+			password: "admin",
+			inUse:    true,
+		},
+		{
+			name:     "Changed password",
+			password: "password123A",
+			inUse:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMockRepository(gomock.NewController(t))
+			repo.EXPECT().
+				GetUserByUsername(gomock.Any(), "admin").
+				Return(&domain.User{
+					Password: domain.NewOptString(StubPasswordHash(t, tt.password)),
+				}, nil)
+			s := service.New(StubLogger(), repo)
+
+			inUse, err := s.DefaultCredentialInUse(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, tt.inUse, inUse)
+		})
+	}
+}
+
+func TestBootstrapPasswordInUse(t *testing.T) {
+	tests := []struct {
+		name  string
+		user  *domain.User
+		err   error
+		inUse bool
+	}{
+		{
+			name:  "Password isn't changed",
+			user:  &domain.User{MustChangePassword: domain.NewOptBool(true)},
+			inUse: true,
+		},
+		{
+			name:  "Changed password",
+			user:  &domain.User{},
+			inUse: false,
+		},
+		{
+			name:  "Not found",
+			err:   repository.ErrNotFound,
+			inUse: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMockRepository(gomock.NewController(t))
+			repo.EXPECT().
+				GetUserByUsername(gomock.Any(), "administrator").
+				Return(tt.user, tt.err)
+			s := service.New(StubLogger(), repo)
+
+			inUse, err := s.BootstrapPasswordInUse(context.Background(), "administrator")
+			require.NoError(t, err)
+			require.Equal(t, tt.inUse, inUse)
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-faster/jx"
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/repository"
+	"github.com/liriquew/test_task/pkg/logger/sl"
+)
+
+// errBatchFailed rolls back the transaction of an atomic batch
+var errBatchFailed = errors.New("batch operation failed")
+
+func (s *Service) ServiceBatchUsers(
+	ctx context.Context,
+	operations []domain.BatchOperation,
+	params domain.ServiceBatchUsersParams,
+) (domain.ServiceBatchUsersRes, error) {
+	report := &domain.BatchReport{
+		Atomic:  params.Atomic.Value,
+		Results: make([]domain.BatchItemResult, 0, len(operations)),
+	}
+
+	if !report.Atomic {
+		for _, op := range operations {
+			report.Results = append(report.Results, s.batchOperation(ctx, op))
+		}
+		report.Committed = true
+
+		return report, nil
+	}
+
+	// results are reported by the last attempt of the transaction
+	var (
+		results []domain.BatchItemResult
+		opErr   error
+	)
+	err := s.repo.WithTx(ctx, func(repo Repository) error {
+		batch := &batchRepository{Repository: repo}
+		s := s.withRepo(batch)
+		results, opErr = results[:0], nil
+		for _, op := range operations {
+			batch.err = nil
+			res := s.batchOperation(ctx, op)
+			results = append(results, res)
+			// the error of the repository is returned as is, so WithTx retries
+			// the batch on serialization failure
+			if res.Status == http.StatusInternalServerError && batch.err != nil {
+				opErr = batch.err
+				return opErr
+			}
+			if res.Status >= http.StatusBadRequest {
+				return errBatchFailed
+			}
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errBatchFailed) && !errors.Is(err, opErr) {
+		s.log.Warn("error while executing batch", sl.Err(err))
+		return &domain.InternalErrorResponse{
+			Message: domain.InternalErrorResponseMessage(
+				fmt.Sprintf("internal error: %s", err),
+			),
+		}, nil
+	}
+	report.Committed = err == nil
+	report.Results = append(report.Results, results...)
+
+	// operations after the failed one aren't executed
+	for range len(operations) - len(report.Results) {
+		report.Results = append(report.Results, domain.BatchItemResult{
+			Status: http.StatusFailedDependency,
+		})
+	}
+
+	return report, nil
+}
+
+// batchOperation executes op with the handler of the single user endpoint
+func (s *Service) batchOperation(ctx context.Context, op domain.BatchOperation) domain.BatchItemResult {
+	needID := op.Op != domain.BatchOperationOpCreate
+	needUser := op.Op != domain.BatchOperationOpDelete
+	if needID && !op.ID.IsSet() || needUser && !op.User.IsSet() {
+		return batchResult(&domain.ValidationErrorResponse{
+			Message: domain.ValidationErrorMessageBadParams,
+		})
+	}
+
+	var (
+		res any
+		err error
+	)
+	switch op.Op {
+	case domain.BatchOperationOpCreate:
+		res, err = s.ServiceCreateUser(ctx, &op.User.Value)
+	case domain.BatchOperationOpPatch:
+		res, err = s.ServicePatchUser(ctx, &op.User.Value, domain.ServicePatchUserParams{
+			UserId: op.ID.Value,
+		})
+	case domain.BatchOperationOpPut:
+		res, err = s.ServicePutUser(ctx, &op.User.Value, domain.ServicePutUserParams{
+			UserId: op.ID.Value,
+		})
+	case domain.BatchOperationOpDelete:
+		res, err = s.ServiceDeleteUser(ctx, domain.ServiceDeleteUserParams{
+			UserId: op.ID.Value,
+		})
+	}
+	if err != nil {
+		return batchResult(&domain.InternalErrorResponse{
+			Message: domain.InternalErrorResponseMessage(
+				fmt.Sprintf("internal error: %s", err),
+			),
+		})
+	}
+
+	return batchResult(res)
+}
+
+// batchRepository keeps the last error of the repository methods used by operations
+// of a batch, handlers turn it into the internal error response
+type batchRepository struct {
+	Repository
+	err error
+}
+
+func (r *batchRepository) keep(err error) error {
+	if err != nil {
+		r.err = err
+	}
+	return err
+}
+
+func (r *batchRepository) CreateUser(ctx context.Context, u *domain.User) (*domain.UUID, error) {
+	id, err := r.Repository.CreateUser(ctx, u)
+	return id, r.keep(err)
+}
+
+func (r *batchRepository) GetUserById(ctx context.Context, id domain.UUID) (*domain.User, error) {
+	u, err := r.Repository.GetUserById(ctx, id)
+	return u, r.keep(err)
+}
+
+func (r *batchRepository) UpdateUser(ctx context.Context, u *domain.User) error {
+	return r.keep(r.Repository.UpdateUser(ctx, u))
+}
+
+func (r *batchRepository) DeleteUser(ctx context.Context, id domain.UUID) error {
+	return r.keep(r.Repository.DeleteUser(ctx, id))
+}
+
+func (r *batchRepository) GetUserGroups(ctx context.Context, id domain.UUID) ([]domain.Group, error) {
+	groups, err := r.Repository.GetUserGroups(ctx, id)
+	return groups, r.keep(err)
+}
+
+func (r *batchRepository) LockAdmins(ctx context.Context) ([]domain.UUID, error) {
+	admins, err := r.Repository.LockAdmins(ctx)
+	return admins, r.keep(err)
+}
+
+// WithTx joins the transaction of the batch, fn keeps its errors in r
+func (r *batchRepository) WithTx(ctx context.Context, fn func(Repository) error, opts ...repository.TxOption) error {
+	return r.Repository.WithTx(ctx, func(Repository) error {
+		return fn(r)
+	}, opts...)
+}
+
+// batchResult converts a response of the single user endpoint
+// into the status code and body it would be sent with
+func batchResult(res any) domain.BatchItemResult {
+	result := domain.BatchItemResult{
+		Status: http.StatusOK,
+	}
+	switch res.(type) {
+	case *domain.User:
+		result.Status = http.StatusCreated
+	case *domain.ValidationErrorResponse:
+		result.Status = http.StatusBadRequest
+	case *domain.ForbiddenResponse:
+		result.Status = http.StatusForbidden
+	case *domain.NotFoundResponse:
+		result.Status = http.StatusNotFound
+	case *domain.AlreadyExistsResponse, *domain.LastAdminResponse, *domain.ServicePatchUserConflict:
+		result.Status = http.StatusConflict
+	case *domain.InternalErrorResponse:
+		result.Status = http.StatusInternalServerError
+	}
+
+	if body, ok := res.(interface{ Encode(*jx.Encoder) }); ok {
+		e := jx.Encoder{}
+		body.Encode(&e)
+		result.Body = e.Bytes()
+	}
+
+	return result
+}
@@ -0,0 +1,162 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/repository"
+	"github.com/liriquew/test_task/internal/service"
+	"github.com/liriquew/test_task/internal/service/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBatchUsers(t *testing.T) {
+	validUser := func() domain.OptUser {
+		return domain.NewOptUser(domain.User{
+			Username: domain.NewOptString("username1"),
+			Password: domain.NewOptString("password123A"),
+			Email:    domain.NewOptString("valid@mail.ru"),
+		})
+	}
+	invalidUser := domain.NewOptUser(domain.User{
+		Username: domain.NewOptString("юзернейм1"),
+		Password: domain.NewOptString("password123A"),
+		Email:    domain.NewOptString("valid@mail.ru"),
+	})
+
+	t.Run("Atomic rolled back", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockRepository(ctrl)
+		ExpectTx(repo)
+		repo.EXPECT().
+			CreateUser(gomock.Any(), gomock.Any()).
+			Return(&domain.UUID{}, nil)
+		s := service.New(StubLogger(), repo)
+
+		res, err := s.ServiceBatchUsers(context.Background(), []domain.BatchOperation{
+			{Op: domain.BatchOperationOpCreate, User: validUser()},
+			{Op: domain.BatchOperationOpCreate, User: invalidUser},
+			{Op: domain.BatchOperationOpDelete, ID: domain.NewOptUUID(domain.UUID{})},
+		}, domain.ServiceBatchUsersParams{
+			Atomic: domain.NewOptBool(true),
+		})
+		require.Nil(t, err)
+
+		report, ok := res.(*domain.BatchReport)
+		require.True(t, ok)
+		require.True(t, report.Atomic)
+		require.False(t, report.Committed)
+		require.Len(t, report.Results, 3)
+		require.Equal(t, int32(201), report.Results[0].Status)
+		require.Equal(t, int32(400), report.Results[1].Status)
+		require.JSONEq(t, `{"message":"invalid username"}`, string(report.Results[1].Body))
+		require.Equal(t, int32(424), report.Results[2].Status)
+	})
+
+	t.Run("Atomic retried", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockRepository(ctrl)
+		// the transaction is retried once the error of the repository reaches it,
+		// as on serialization failure
+		errSerialization := errors.New("serialization failure")
+		repo.EXPECT().
+			WithTx(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, fn func(service.Repository) error, _ ...repository.TxOption) error {
+				if err := fn(repo); !errors.Is(err, errSerialization) {
+					return err
+				}
+				return fn(repo)
+			})
+		gomock.InOrder(
+			repo.EXPECT().
+				CreateUser(gomock.Any(), gomock.Any()).
+				Return(&domain.UUID{}, nil),
+			repo.EXPECT().
+				CreateUser(gomock.Any(), gomock.Any()).
+				Return(nil, errSerialization),
+			repo.EXPECT().
+				CreateUser(gomock.Any(), gomock.Any()).
+				Return(&domain.UUID{}, nil).
+				Times(2),
+		)
+		s := service.New(StubLogger(), repo)
+
+		res, err := s.ServiceBatchUsers(context.Background(), []domain.BatchOperation{
+			{Op: domain.BatchOperationOpCreate, User: validUser()},
+			{Op: domain.BatchOperationOpCreate, User: validUser()},
+		}, domain.ServiceBatchUsersParams{
+			Atomic: domain.NewOptBool(true),
+		})
+		require.Nil(t, err)
+
+		report, ok := res.(*domain.BatchReport)
+		require.True(t, ok)
+		require.True(t, report.Committed)
+		require.Len(t, report.Results, 2)
+		require.Equal(t, int32(201), report.Results[0].Status)
+		require.Equal(t, int32(201), report.Results[1].Status)
+	})
+
+	t.Run("Atomic failed by the repository", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockRepository(ctrl)
+		ExpectTx(repo)
+		repo.EXPECT().
+			CreateUser(gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("connection reset"))
+		s := service.New(StubLogger(), repo)
+
+		res, err := s.ServiceBatchUsers(context.Background(), []domain.BatchOperation{
+			{Op: domain.BatchOperationOpCreate, User: validUser()},
+			{Op: domain.BatchOperationOpCreate, User: validUser()},
+		}, domain.ServiceBatchUsersParams{
+			Atomic: domain.NewOptBool(true),
+		})
+		require.Nil(t, err)
+
+		report, ok := res.(*domain.BatchReport)
+		require.True(t, ok)
+		require.False(t, report.Committed)
+		require.Len(t, report.Results, 2)
+		require.Equal(t, int32(500), report.Results[0].Status)
+		require.Equal(t, int32(424), report.Results[1].Status)
+	})
+
+	t.Run("Independent", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockRepository(ctrl)
+		ExpectTx(repo)
+		repo.EXPECT().
+			LockAdmins(gomock.Any()).
+			Return([]domain.UUID{{1}}, nil)
+		repo.EXPECT().
+			DeleteUser(gomock.Any(), domain.UUID{}).
+			Return(nil)
+		s := service.New(StubLogger(), repo)
+
+		res, err := s.ServiceBatchUsers(context.Background(), []domain.BatchOperation{
+			{Op: domain.BatchOperationOpPatch, User: validUser()},
+			{Op: domain.BatchOperationOpDelete, ID: domain.NewOptUUID(domain.UUID{})},
+		}, domain.ServiceBatchUsersParams{})
+		require.Nil(t, err)
+
+		require.Equal(t, &domain.BatchReport{
+			Committed: true,
+			Results: []domain.BatchItemResult{
+				{Status: 400, Body: []byte(`{"message":"bad params"}`)},
+				{Status: 200},
+			},
+		}, res)
+	})
+}
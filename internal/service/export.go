@@ -0,0 +1,185 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"io"
+	"mime"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/repository"
+	"github.com/liriquew/test_task/pkg/logger/sl"
+)
+
+const (
+	exportNDJSON = "application/x-ndjson"
+	exportCSV    = "text/csv"
+	exportJSON   = "application/json"
+)
+
+func (s *Service) ServiceExportUsers(
+	ctx context.Context,
+	params domain.ServiceExportUsersParams,
+) (domain.ServiceExportUsersRes, error) {
+	format, ok := negotiateExportFormat(params.Accept.Value)
+	if !ok {
+		return &domain.ValidationErrorResponse{
+			Message: domain.ValidationErrorMessageBadParams,
+		}, nil
+	}
+
+	filter := repository.UserFilter{
+		IsAdmin: params.IsAdmin,
+		Search:  params.Search,
+	}
+
+	// the export is written into the pipe while the response is being sent,
+	// pipe reader is closed by the response encoder, that stops the export
+	// if the client has gone
+	pr, pw := io.Pipe()
+	go func() {
+		var err error
+		switch format {
+		case exportCSV:
+			err = s.exportUsersCSV(ctx, pw, filter, params.Cursor)
+		case exportJSON:
+			err = s.exportUsersJSON(ctx, pw, filter, params.Cursor)
+		default:
+			err = s.exportUsersNDJSON(ctx, pw, filter, params.Cursor)
+		}
+		if err != nil {
+			s.log.Warn("error while exporting users", sl.Err(err))
+		}
+		pw.CloseWithError(err)
+	}()
+
+	if format == exportCSV {
+		return &domain.ServiceExportUsersOKTextCsvHeaders{
+			ContentType: exportCSV,
+			Response:    domain.ServiceExportUsersOKTextCsv{Data: pr},
+		}, nil
+	}
+
+	return &domain.ServiceExportUsersOKApplicationHeaders{
+		ContentType: format,
+		Response:    domain.ServiceExportUsersOKApplication{Data: pr},
+	}, nil
+}
+
+func (s *Service) exportUsersNDJSON(
+	ctx context.Context,
+	w io.Writer,
+	filter repository.UserFilter,
+	cursor domain.OptUUID,
+) error {
+	bw := bufio.NewWriter(w)
+	err := s.repo.ExportUsers(ctx, filter, cursor, func(user domain.User) error {
+		data, err := user.MarshalJSON()
+		if err != nil {
+			return err
+		}
+		if _, err := bw.Write(data); err != nil {
+			return err
+		}
+		return bw.WriteByte('\n')
+	})
+	if err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+// exportUsersJSON writes the array of users, an element per user as it's read
+func (s *Service) exportUsersJSON(
+	ctx context.Context,
+	w io.Writer,
+	filter repository.UserFilter,
+	cursor domain.OptUUID,
+) error {
+	bw := bufio.NewWriter(w)
+	if err := bw.WriteByte('['); err != nil {
+		return err
+	}
+
+	first := true
+	err := s.repo.ExportUsers(ctx, filter, cursor, func(user domain.User) error {
+		data, err := user.MarshalJSON()
+		if err != nil {
+			return err
+		}
+		if !first {
+			if err := bw.WriteByte(','); err != nil {
+				return err
+			}
+		}
+		first = false
+		_, err = bw.Write(data)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if err := bw.WriteByte(']'); err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+func (s *Service) exportUsersCSV(
+	ctx context.Context,
+	w io.Writer,
+	filter repository.UserFilter,
+	cursor domain.OptUUID,
+) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"id", "username", "email", "is_admin"}); err != nil {
+		return err
+	}
+
+	err := s.repo.ExportUsers(ctx, filter, cursor, func(user domain.User) error {
+		return cw.Write([]string{
+			uuid.UUID(user.ID.Value).String(),
+			user.Username.Value,
+			user.Email.Value,
+			strconv.FormatBool(user.IsAdmin.Value),
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// negotiateExportFormat picks the first supported media type from Accept,
+// NDJSON is used when Accept is empty or allows any type
+func negotiateExportFormat(accept string) (string, bool) {
+	if strings.TrimSpace(accept) == "" {
+		return exportNDJSON, true
+	}
+
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		switch mediaType {
+		case exportNDJSON, exportCSV, exportJSON:
+			return mediaType, true
+		case "*/*", "application/*":
+			return exportNDJSON, true
+		case "text/*":
+			return exportCSV, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,123 @@
+package service_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/repository"
+	"github.com/liriquew/test_task/internal/service"
+	"github.com/liriquew/test_task/internal/service/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestExportUsers(t *testing.T) {
+	users := []domain.User{
+		{
+			ID:       domain.NewOptUUID(domain.UUID{}),
+			Username: domain.NewOptString("username1"),
+			Email:    domain.NewOptString("valid@mail.ru"),
+			IsAdmin:  domain.NewOptBool(true),
+		},
+		{
+			ID:       domain.NewOptUUID(domain.UUID{1}),
+			Username: domain.NewOptString("username2"),
+			Email:    domain.NewOptString("other@mail.ru"),
+			IsAdmin:  domain.NewOptBool(false),
+		},
+	}
+	export := func(
+		_ context.Context,
+		_ repository.UserFilter,
+		_ domain.OptUUID,
+		fn func(domain.User) error,
+	) error {
+		for _, user := range users {
+			if err := fn(user); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	type test struct {
+		name        string
+		params      domain.ServiceExportUsersParams
+		contentType string
+		body        string
+	}
+
+	tests := []test{
+		{
+			name:        "NDJSON by default",
+			contentType: "application/x-ndjson",
+			body: `{"id":"00000000-0000-0000-0000-000000000000","username":"username1","email":"valid@mail.ru","is_admin":true}` + "\n" +
+				`{"id":"01000000-0000-0000-0000-000000000000","username":"username2","email":"other@mail.ru","is_admin":false}` + "\n",
+		},
+		{
+			name: "CSV",
+			params: domain.ServiceExportUsersParams{
+				Accept: domain.NewOptString("text/csv"),
+			},
+			contentType: "text/csv",
+			body: "id,username,email,is_admin\n" +
+				"00000000-0000-0000-0000-000000000000,username1,valid@mail.ru,true\n" +
+				"01000000-0000-0000-0000-000000000000,username2,other@mail.ru,false\n",
+		},
+		{
+			name: "JSON array",
+			params: domain.ServiceExportUsersParams{
+				Accept: domain.NewOptString("application/json"),
+			},
+			contentType: "application/json",
+			body: `[{"id":"00000000-0000-0000-0000-000000000000","username":"username1","email":"valid@mail.ru","is_admin":true},` +
+				`{"id":"01000000-0000-0000-0000-000000000000","username":"username2","email":"other@mail.ru","is_admin":false}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockRepository(ctrl)
+			repo.EXPECT().
+				ExportUsers(gomock.Any(), repository.UserFilter{}, domain.OptUUID{}, gomock.Any()).
+				DoAndReturn(export)
+			s := service.New(StubLogger(), repo)
+
+			res, err := s.ServiceExportUsers(context.Background(), tt.params)
+			require.Nil(t, err)
+
+			var contentType string
+			var reader io.Reader
+			switch res := res.(type) {
+			case *domain.ServiceExportUsersOKApplicationHeaders:
+				contentType, reader = res.ContentType, res.Response
+			case *domain.ServiceExportUsersOKTextCsvHeaders:
+				contentType, reader = res.ContentType, res.Response
+			default:
+				t.Fatalf("unexpected response %T", res)
+			}
+			require.Equal(t, tt.contentType, contentType)
+
+			body, err := io.ReadAll(reader)
+			require.NoError(t, err)
+			require.Equal(t, tt.body, string(body))
+		})
+	}
+
+	t.Run("Unsupported Accept", func(t *testing.T) {
+		s := service.New(StubLogger(), mocks.NewMockRepository(gomock.NewController(t)))
+
+		res, err := s.ServiceExportUsers(context.Background(), domain.ServiceExportUsersParams{
+			Accept: domain.NewOptString("application/xml"),
+		})
+		require.Nil(t, err)
+		require.Equal(t, &domain.ValidationErrorResponse{
+			Message: domain.ValidationErrorMessageBadParams,
+		}, res)
+	})
+}
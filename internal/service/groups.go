@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/repository"
+	"github.com/liriquew/test_task/pkg/logger/sl"
+)
+
+func (s *Service) GroupsListGroups(
+	ctx context.Context,
+	params domain.GroupsListGroupsParams,
+) (domain.GroupsListGroupsRes, error) {
+	groups, err := s.repo.ListGroups(ctx, params.Offset.Value)
+	if err != nil {
+		s.log.Warn("error while getting groups in ListGroups", sl.Err(err))
+		return &domain.InternalErrorResponse{}, nil
+	}
+
+	res := domain.GroupsListGroupsOKApplicationJSON(groups)
+
+	return &res, nil
+}
+
+func (s *Service) GroupsCreateGroup(
+	ctx context.Context,
+	group *domain.Group,
+) (domain.GroupsCreateGroupRes, error) {
+	if group.Name.Value == "" {
+		return &domain.ValidationErrorResponse{
+			Message: badGroupName,
+		}, nil
+	}
+
+	// validate group
+	if errResp := ValidateGroup(group); errResp != nil {
+		return errResp, nil
+	}
+
+	uuid, err := s.repo.CreateGroup(ctx, group)
+	if err != nil {
+		s.log.Warn("error while creating group", sl.Err(err))
+		if errors.Is(err, repository.ErrGroupNameExists) {
+			return &domain.AlreadyExistsResponse{
+				Message: domain.AlreadyExistsResponseMessageAlreadyExistsGroupNameTaken,
+			}, nil
+		}
+
+		return &domain.InternalErrorResponse{
+			Message: domain.InternalErrorResponseMessage(
+				fmt.Sprintf("internal error while creating group error: %s", err),
+			),
+		}, nil
+	}
+
+	group.ID.SetTo(*uuid)
+
+	return group, nil
+}
+
+func (s *Service) GroupsGetGroup(
+	ctx context.Context,
+	params domain.GroupsGetGroupParams,
+) (domain.GroupsGetGroupRes, error) {
+	group, err := s.repo.GetGroupById(ctx, params.GroupId)
+	if err != nil {
+		s.log.Warn("error while getting group by id", sl.Err(err))
+		if errors.Is(err, repository.ErrGroupNotFound) {
+			return &domain.NotFoundResponse{
+				Message: "group not found",
+			}, nil
+		}
+
+		return &domain.InternalErrorResponse{
+			Message: domain.InternalErrorResponseMessage(
+				fmt.Sprintf("internal error: %s", err),
+			),
+		}, nil
+	}
+
+	return group, nil
+}
+
+func (s *Service) GroupsPatchGroup(
+	ctx context.Context,
+	group *domain.Group,
+	params domain.GroupsPatchGroupParams,
+) (domain.GroupsPatchGroupRes, error) {
+	group.ID.SetTo(params.GroupId)
+
+	// validate group
+	if errResp := ValidateGroup(group); errResp != nil {
+		return errResp, nil
+	}
+
+	if err := s.repo.UpdateGroup(ctx, group); err != nil {
+		s.log.Warn("error while patching group", sl.Err(err))
+		if errors.Is(err, repository.ErrGroupNotFound) {
+			return &domain.NotFoundResponse{
+				Message: "group not found",
+			}, nil
+		}
+		if errors.Is(err, repository.ErrGroupNameExists) {
+			return &domain.AlreadyExistsResponse{
+				Message: domain.AlreadyExistsResponseMessageAlreadyExistsGroupNameTaken,
+			}, nil
+		}
+		if errors.Is(err, repository.ErrEmptyUpdate) {
+			return &domain.ValidationErrorResponse{
+				Message: "nothing to update",
+			}, nil
+		}
+
+		return &domain.InternalErrorResponse{
+			Message: domain.InternalErrorResponseMessage(
+				fmt.Sprintf("internal error: %s", err),
+			),
+		}, nil
+	}
+
+	return &domain.GroupsPatchGroupOK{}, nil
+}
+
+func (s *Service) GroupsDeleteGroup(
+	ctx context.Context,
+	params domain.GroupsDeleteGroupParams,
+) (domain.GroupsDeleteGroupRes, error) {
+	err := s.repo.DeleteGroup(ctx, params.GroupId)
+	if err != nil {
+		s.log.Warn("error while deleting group", sl.Err(err))
+		return &domain.InternalErrorResponse{
+			Message: domain.InternalErrorResponseMessage(
+				fmt.Sprintf("internal error: %s", err),
+			),
+		}, nil
+	}
+
+	return &domain.GroupsDeleteGroupOK{}, nil
+}
+
+func (s *Service) GroupsListGroupMembers(
+	ctx context.Context,
+	params domain.GroupsListGroupMembersParams,
+) (domain.GroupsListGroupMembersRes, error) {
+	// distinguish an empty group from a missing one
+	if _, err := s.repo.GetGroupById(ctx, params.GroupId); err != nil {
+		s.log.Warn("error while getting group by id in ListGroupMembers", sl.Err(err))
+		if errors.Is(err, repository.ErrGroupNotFound) {
+			return &domain.NotFoundResponse{
+				Message: "group not found",
+			}, nil
+		}
+
+		return &domain.InternalErrorResponse{
+			Message: domain.InternalErrorResponseMessage(
+				fmt.Sprintf("internal error: %s", err),
+			),
+		}, nil
+	}
+
+	members, err := s.repo.ListGroupMembers(ctx, params.GroupId)
+	if err != nil {
+		s.log.Warn("error while getting group members", sl.Err(err))
+		return &domain.InternalErrorResponse{}, nil
+	}
+
+	res := domain.GroupsListGroupMembersOKApplicationJSON(members)
+
+	return &res, nil
+}
+
+func (s *Service) GroupsAddGroupMember(
+	ctx context.Context,
+	member *domain.GroupMember,
+	params domain.GroupsAddGroupMemberParams,
+) (domain.GroupsAddGroupMemberRes, error) {
+	// validate member
+	if errResp := ValidateGroupMember(member); errResp != nil {
+		return errResp, nil
+	}
+
+	if err := s.repo.AddGroupMember(ctx, params.GroupId, member); err != nil {
+		s.log.Warn("error while adding group member", sl.Err(err))
+		if errors.Is(err, repository.ErrGroupCycle) {
+			return &domain.ValidationErrorResponse{
+				Message: domain.ValidationErrorMessageGroupMembershipCycle,
+			}, nil
+		}
+		if errors.Is(err, repository.ErrMemberExists) {
+			return &domain.AlreadyExistsResponse{
+				Message: domain.AlreadyExistsResponseMessageAlreadyExistsMemberOfTheGroup,
+			}, nil
+		}
+		if errors.Is(err, repository.ErrGroupNotFound) {
+			return &domain.NotFoundResponse{
+				Message: "group not found",
+			}, nil
+		}
+		if errors.Is(err, repository.ErrNotFound) {
+			return &domain.NotFoundResponse{
+				Message: "user not found",
+			}, nil
+		}
+
+		return &domain.InternalErrorResponse{
+			Message: domain.InternalErrorResponseMessage(
+				fmt.Sprintf("internal error: %s", err),
+			),
+		}, nil
+	}
+
+	return &domain.GroupsAddGroupMemberOK{}, nil
+}
+
+func (s *Service) GroupsRemoveGroupMember(
+	ctx context.Context,
+	params domain.GroupsRemoveGroupMemberParams,
+) (domain.GroupsRemoveGroupMemberRes, error) {
+	if err := s.repo.RemoveGroupMember(ctx, params.GroupId, params.MemberId); err != nil {
+		s.log.Warn("error while removing group member", sl.Err(err))
+		if errors.Is(err, repository.ErrMemberNotFound) {
+			return &domain.NotFoundResponse{
+				Message: "member not found",
+			}, nil
+		}
+
+		return &domain.InternalErrorResponse{
+			Message: domain.InternalErrorResponseMessage(
+				fmt.Sprintf("internal error: %s", err),
+			),
+		}, nil
+	}
+
+	return &domain.GroupsRemoveGroupMemberOK{}, nil
+}
+
+func (s *Service) ServiceGetUserGroups(
+	ctx context.Context,
+	params domain.ServiceGetUserGroupsParams,
+) (domain.ServiceGetUserGroupsRes, error) {
+	// distinguish a user without groups from a missing one
+	if _, err := s.repo.GetUserById(ctx, params.UserId); err != nil {
+		s.log.Warn("error while getting user by id in GetUserGroups", sl.Err(err))
+		if errors.Is(err, repository.ErrNotFound) {
+			return &domain.NotFoundResponse{
+				Message: "user not found",
+			}, nil
+		}
+
+		return &domain.InternalErrorResponse{
+			Message: domain.InternalErrorResponseMessage(
+				fmt.Sprintf("internal error: %s", err),
+			),
+		}, nil
+	}
+
+	groups, err := s.repo.GetUserGroups(ctx, params.UserId)
+	if err != nil {
+		s.log.Warn("error while getting user groups", sl.Err(err))
+		return &domain.InternalErrorResponse{}, nil
+	}
+
+	res := domain.ServiceGetUserGroupsOKApplicationJSON(groups)
+
+	return &res, nil
+}
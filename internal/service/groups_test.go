@@ -0,0 +1,233 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/repository"
+	"github.com/liriquew/test_task/internal/service"
+	"github.com/liriquew/test_task/internal/service/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateGroup(t *testing.T) {
+	type deps struct {
+		repo *mocks.MockRepository
+	}
+
+	type test struct {
+		name  string
+		setup func(d deps, t *test)
+		group domain.Group
+		res   domain.GroupsCreateGroupRes
+	}
+
+	tests := []test{
+		{
+			name: "All valid",
+			setup: func(d deps, t *test) {
+				d.repo.EXPECT().
+					CreateGroup(gomock.Any(), &t.group).
+					Return(&domain.UUID{}, nil)
+			},
+			group: domain.Group{
+				Name:    domain.NewOptString("developers"),
+				IsAdmin: domain.NewOptBool(true),
+			},
+			res: &domain.Group{
+				ID:      domain.NewOptUUID(domain.UUID{}),
+				Name:    domain.NewOptString("developers"),
+				IsAdmin: domain.NewOptBool(true),
+			},
+		},
+		{
+			name:  "Empty name",
+			setup: nil,
+			group: domain.Group{},
+			res: &domain.ValidationErrorResponse{
+				Message: domain.ValidationErrorMessageInvalidGroupName,
+			},
+		},
+		{
+			name:  "Invalid name",
+			setup: nil,
+			group: domain.Group{
+				Name: domain.NewOptString("dev team"),
+			},
+			res: &domain.ValidationErrorResponse{
+				Message: domain.ValidationErrorMessageInvalidGroupName,
+			},
+		},
+		{
+			name: "Name taken",
+			setup: func(d deps, t *test) {
+				d.repo.EXPECT().
+					CreateGroup(gomock.Any(), &t.group).
+					Return(nil, repository.ErrGroupNameExists)
+			},
+			group: domain.Group{
+				Name: domain.NewOptString("developers"),
+			},
+			res: &domain.AlreadyExistsResponse{
+				Message: domain.AlreadyExistsResponseMessageAlreadyExistsGroupNameTaken,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			d := deps{
+				repo: mocks.NewMockRepository(ctrl),
+			}
+			if tt.setup != nil {
+				tt.setup(d, &tt)
+			}
+			s := service.New(StubLogger(), d.repo)
+
+			res, err := s.GroupsCreateGroup(context.Background(), &tt.group)
+			require.NoError(t, err)
+			require.Equal(t, tt.res, res)
+		})
+	}
+}
+
+func TestAddGroupMember(t *testing.T) {
+	type deps struct {
+		repo *mocks.MockRepository
+	}
+
+	type test struct {
+		name   string
+		setup  func(d deps, t *test)
+		member domain.GroupMember
+		res    domain.GroupsAddGroupMemberRes
+	}
+
+	tests := []test{
+		{
+			name: "User member",
+			setup: func(d deps, t *test) {
+				d.repo.EXPECT().
+					AddGroupMember(gomock.Any(), domain.UUID{}, &t.member).
+					Return(nil)
+			},
+			member: domain.GroupMember{
+				UserID: domain.NewOptUUID(domain.UUID{1}),
+			},
+			res: &domain.GroupsAddGroupMemberOK{},
+		},
+		{
+			name:   "No member",
+			setup:  nil,
+			member: domain.GroupMember{},
+			res: &domain.ValidationErrorResponse{
+				Message: domain.ValidationErrorMessageInvalidMember,
+			},
+		},
+		{
+			name:  "Both user and group",
+			setup: nil,
+			member: domain.GroupMember{
+				UserID:  domain.NewOptUUID(domain.UUID{1}),
+				GroupID: domain.NewOptUUID(domain.UUID{2}),
+			},
+			res: &domain.ValidationErrorResponse{
+				Message: domain.ValidationErrorMessageInvalidMember,
+			},
+		},
+		{
+			name: "Cycle",
+			setup: func(d deps, t *test) {
+				d.repo.EXPECT().
+					AddGroupMember(gomock.Any(), domain.UUID{}, &t.member).
+					Return(repository.ErrGroupCycle)
+			},
+			member: domain.GroupMember{
+				GroupID: domain.NewOptUUID(domain.UUID{2}),
+			},
+			res: &domain.ValidationErrorResponse{
+				Message: domain.ValidationErrorMessageGroupMembershipCycle,
+			},
+		},
+		{
+			name: "Already member",
+			setup: func(d deps, t *test) {
+				d.repo.EXPECT().
+					AddGroupMember(gomock.Any(), domain.UUID{}, &t.member).
+					Return(repository.ErrMemberExists)
+			},
+			member: domain.GroupMember{
+				UserID: domain.NewOptUUID(domain.UUID{1}),
+			},
+			res: &domain.AlreadyExistsResponse{
+				Message: domain.AlreadyExistsResponseMessageAlreadyExistsMemberOfTheGroup,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			d := deps{
+				repo: mocks.NewMockRepository(ctrl),
+			}
+			if tt.setup != nil {
+				tt.setup(d, &tt)
+			}
+			s := service.New(StubLogger(), d.repo)
+
+			res, err := s.GroupsAddGroupMember(context.Background(), &tt.member, domain.GroupsAddGroupMemberParams{
+				GroupId: domain.UUID{},
+			})
+			require.NoError(t, err)
+			require.Equal(t, tt.res, res)
+		})
+	}
+}
+
+func TestGetUserGroups(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Not found", func(t *testing.T) {
+		t.Parallel()
+		repo := mocks.NewMockRepository(gomock.NewController(t))
+		repo.EXPECT().
+			GetUserById(gomock.Any(), domain.UUID{}).
+			Return(nil, repository.ErrNotFound)
+		s := service.New(StubLogger(), repo)
+
+		res, err := s.ServiceGetUserGroups(context.Background(), domain.ServiceGetUserGroupsParams{})
+		require.NoError(t, err)
+		require.IsType(t, &domain.NotFoundResponse{}, res)
+	})
+
+	t.Run("Groups", func(t *testing.T) {
+		t.Parallel()
+		repo := mocks.NewMockRepository(gomock.NewController(t))
+		groups := []domain.Group{
+			{
+				ID:   domain.NewOptUUID(domain.UUID{1}),
+				Name: domain.NewOptString("developers"),
+			},
+		}
+		repo.EXPECT().
+			GetUserById(gomock.Any(), domain.UUID{}).
+			Return(&domain.User{}, nil)
+		repo.EXPECT().
+			GetUserGroups(gomock.Any(), domain.UUID{}).
+			Return(groups, nil)
+		s := service.New(StubLogger(), repo)
+
+		res, err := s.ServiceGetUserGroups(context.Background(), domain.ServiceGetUserGroupsParams{})
+		require.NoError(t, err)
+		expected := domain.ServiceGetUserGroupsOKApplicationJSON(groups)
+		require.Equal(t, &expected, res)
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/lib/tenant"
 	"github.com/liriquew/test_task/internal/repository"
 	"github.com/liriquew/test_task/pkg/logger/sl"
 )
@@ -21,7 +22,16 @@ func (s *Service) ServiceListUsers(
 	domain.ServiceListUsersRes,
 	error,
 ) {
-	users, err := s.repo.ListUsers(ctx, params.Offset.Value)
+	requested, selected, errResp := userFields(params.Fields, params.Expand)
+	if errResp != nil {
+		return errResp, nil
+	}
+	filter := repository.UserFilter{
+		IsAdmin: params.IsAdmin,
+		Search:  params.Search,
+		Fields:  selected,
+	}
+	users, err := s.repo.ListUsers(ctx, filter, params.Offset.Value)
 	if err != nil {
 		s.log.Warn("error while getting users in ListUsers", sl.Err(err))
 		return &domain.InternalErrorResponse{}, nil
@@ -29,6 +39,10 @@ func (s *Service) ServiceListUsers(
 
 	for i := range users {
 		users[i].Password.Value = ""
+		if err := s.expandUser(ctx, &users[i], requested, params.Expand); err != nil {
+			s.log.Warn("error while expanding users in ListUsers", sl.Err(err))
+			return &domain.InternalErrorResponse{}, nil
+		}
 	}
 
 	res := domain.ServiceListUsersOKApplicationJSON(users)
@@ -57,6 +71,7 @@ func (s *Service) ServiceCreateUser(
 	}
 
 	// validate user
+	NormalizeUser(user)
 	if errResp := ValidateUser(user); errResp != nil {
 		return errResp, nil
 	}
@@ -97,7 +112,14 @@ func (s *Service) ServiceGetUser(
 	ctx context.Context,
 	params domain.ServiceGetUserParams,
 ) (domain.ServiceGetUserRes, error) {
-	user, err := s.repo.GetUserById(ctx, params.UserId)
+	requested, selected, errResp := userFields(params.Fields, params.Expand)
+	if errResp != nil {
+		return errResp, nil
+	}
+	user, err := s.repo.GetUserFieldsById(ctx, params.UserId, selected)
+	if err == nil {
+		err = s.expandUser(ctx, user, requested, params.Expand)
+	}
 	if err != nil {
 		s.log.Warn("error while getting user by id", sl.Err(err))
 		if errors.Is(err, repository.ErrNotFound) {
@@ -120,9 +142,20 @@ func (s *Service) ServiceDeleteUser(
 	ctx context.Context,
 	params domain.ServiceDeleteUserParams,
 ) (domain.ServiceDeleteUserRes, error) {
-	err := s.repo.DeleteUser(ctx, params.UserId)
+	err := s.repo.WithTx(ctx, func(repo Repository) error {
+		if err := checkLastAdmin(ctx, repo, params.UserId, true); err != nil {
+			return err
+		}
+		return repo.DeleteUser(ctx, params.UserId)
+	})
 	if err != nil {
 		s.log.Warn("error while deleting user", sl.Err(err))
+		if errors.Is(err, ErrLastAdmin) {
+			return &domain.LastAdminResponse{
+				Message: lastAdmin,
+			}, nil
+		}
+
 		return &domain.InternalErrorResponse{
 			Message: domain.InternalErrorResponseMessage(
 				fmt.Sprintf("internal error: %s", err),
@@ -133,14 +166,29 @@ func (s *Service) ServiceDeleteUser(
 	return &domain.ServiceDeleteUserOK{}, nil
 }
 
+// ServicePatchUser updates the fields of the user set in the request. Merge patch and
+// JSON Patch are applied to the stored user, they can clear fields and test values
 func (s *Service) ServicePatchUser(
 	ctx context.Context,
-	user *domain.User,
+	req domain.ServicePatchUserReq,
 	params domain.ServicePatchUserParams,
 ) (domain.ServicePatchUserRes, error) {
+	switch req := req.(type) {
+	case *domain.UserMergePatch:
+		return s.patchStoredUser(ctx, params.UserId, func(current *domain.User) (*domain.User, error) {
+			return mergePatch(current, req), nil
+		})
+	case *domain.ServicePatchUserReqApplicationJSONPatchJSON:
+		return s.patchStoredUser(ctx, params.UserId, func(current *domain.User) (*domain.User, error) {
+			return jsonPatch(current, *req)
+		})
+	}
+
+	user := req.(*domain.User)
 	user.ID.Value = params.UserId
 
 	// validate user
+	NormalizeUser(user)
 	if errResp := ValidateUser(user); errResp != nil {
 		return errResp, nil
 	}
@@ -151,29 +199,8 @@ func (s *Service) ServicePatchUser(
 	}
 	user.Password.Value = hash
 
-	if err := s.repo.UpdateUser(ctx, user); err != nil {
-		s.log.Warn("error while patching user PatchUser", sl.Err(err))
-		if errors.Is(err, repository.ErrUsernameExists) {
-			return &domain.AlreadyExistsResponse{
-				Message: "username already exists",
-			}, nil
-		}
-		if errors.Is(err, repository.ErrEmailExists) {
-			return &domain.AlreadyExistsResponse{
-				Message: "email already exists",
-			}, nil
-		}
-		if errors.Is(err, repository.ErrEmptyUpdate) {
-			return &domain.ValidationErrorResponse{
-				Message: "nothing to update",
-			}, nil
-		}
-
-		return &domain.InternalErrorResponse{
-			Message: domain.InternalErrorResponseMessage(
-				fmt.Sprintf("internal error: %s", err),
-			),
-		}, nil
+	if err := s.updateUser(ctx, user); err != nil {
+		return s.patchUserError(err), nil
 	}
 
 	return &domain.ServicePatchUserOK{}, nil
@@ -201,6 +228,7 @@ func (s *Service) ServicePutUser(
 	}
 
 	// validate user
+	NormalizeUser(user)
 	if errResp := ValidateUser(user); errResp != nil {
 		return errResp, nil
 	}
@@ -213,8 +241,18 @@ func (s *Service) ServicePutUser(
 
 	user.ID.SetTo(params.UserId)
 
-	if err := s.repo.UpdateUser(ctx, user); err != nil {
+	if err := s.updateUser(ctx, user); err != nil {
 		s.log.Warn("error while updating user in PutUser", sl.Err(err))
+		if errors.Is(err, repository.ErrNotFound) {
+			return &domain.NotFoundResponse{
+				Message: "user not found",
+			}, nil
+		}
+		if errors.Is(err, ErrLastAdmin) {
+			return &domain.LastAdminResponse{
+				Message: lastAdmin,
+			}, nil
+		}
 		if errors.Is(err, repository.ErrUsernameExists) {
 			return &domain.ValidationErrorResponse{
 				Message: "username already exists",
@@ -235,3 +273,56 @@ func (s *Service) ServicePutUser(
 
 	return &domain.ServicePutUserOK{}, nil
 }
+
+// ChangePassword sets the password of the authenticated user
+// and clears must_change_password
+func (s *Service) ChangePassword(
+	ctx context.Context,
+	req *domain.PasswordChange,
+) (domain.ChangePasswordRes, error) {
+	principal := ctx.Value(CurrentUser{}).(Principal)
+
+	user := &domain.User{
+		ID:                 domain.NewOptUUID(principal.ID),
+		Password:           domain.NewOptString(req.Password),
+		MustChangePassword: domain.NewOptBool(false),
+	}
+	if errResp := ValidateUser(user); errResp != nil {
+		return errResp, nil
+	}
+
+	// the password is changed in the organization of the user, X-Tenant-ID doesn't apply
+	ctx = tenant.WithTenant(ctx, principal.TenantID)
+
+	stored, err := s.repo.GetUserById(ctx, principal.ID)
+	if err != nil {
+		s.log.Warn("error while getting user in ChangePassword", sl.Err(err))
+		return &domain.InternalErrorResponse{
+			Message: domain.InternalErrorResponseMessage(
+				fmt.Sprintf("internal error: %s", err),
+			),
+		}, nil
+	}
+	if checkPassword(stored.Password.Value, req.Password) {
+		return &domain.ValidationErrorResponse{
+			Message: domain.ValidationErrorMessagePasswordNotChanged,
+		}, nil
+	}
+
+	hash, internalErr := hashPassword(user.Password.Value)
+	if internalErr != nil {
+		return internalErr, nil
+	}
+	user.Password.Value = hash
+
+	if err := s.repo.UpdateUser(ctx, user); err != nil {
+		s.log.Warn("error while changing password", sl.Err(err))
+		return &domain.InternalErrorResponse{
+			Message: domain.InternalErrorResponseMessage(
+				fmt.Sprintf("internal error: %s", err),
+			),
+		}, nil
+	}
+
+	return &domain.ChangePasswordOK{}, nil
+}
@@ -2,13 +2,18 @@ package service_test
 
 import (
 	"context"
+	"errors"
 	"log/slog"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/lib/tenant"
 	"github.com/liriquew/test_task/internal/repository"
 	"github.com/liriquew/test_task/internal/service"
 	"github.com/liriquew/test_task/internal/service/mocks"
+	"github.com/ogen-go/ogen/ogenerrors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -19,6 +24,24 @@ func StubLogger() *slog.Logger {
 	return log
 }
 
+// ExpectTx makes transactional functions run against the mock itself
+func ExpectTx(repo *mocks.MockRepository) *gomock.Call {
+	return repo.EXPECT().
+		WithTx(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, fn func(service.Repository) error, _ ...repository.TxOption) error {
+			return fn(repo)
+		})
+}
+
+// RequireTenant checks that ctx is scoped to the organization
+func RequireTenant(t *testing.T, want domain.UUID, ctx context.Context) {
+	t.Helper()
+
+	id, ok := tenant.FromContext(ctx)
+	require.True(t, ok)
+	require.Equal(t, want, id)
+}
+
 func TestListUsers(t *testing.T) {
 	t.Parallel()
 	repo := mocks.NewMockRepository(gomock.NewController(t))
@@ -34,7 +57,7 @@ func TestListUsers(t *testing.T) {
 	res := domain.ServiceListUsersOKApplicationJSON(users)
 	repo.
 		EXPECT().
-		ListUsers(gomock.Any(), int64(100)).
+		ListUsers(gomock.Any(), repository.UserFilter{}, int64(100)).
 		Return(users, nil)
 	s := service.New(StubLogger(), repo)
 
@@ -51,6 +74,105 @@ func TestListUsers(t *testing.T) {
 	)
 }
 
+func TestUserProjection(t *testing.T) {
+	t.Parallel()
+	id := domain.UUID{1}
+	groups := []domain.Group{{
+		ID:   domain.NewOptUUID(domain.UUID{2}),
+		Name: domain.NewOptString("group001"),
+	}}
+
+	t.Run("ListUsers", func(t *testing.T) {
+		repo := mocks.NewMockRepository(gomock.NewController(t))
+		repo.EXPECT().
+			ListUsers(gomock.Any(), repository.UserFilter{
+				Fields: repository.UserFields{"username"},
+			}, int64(0)).
+			Return([]domain.User{{Username: domain.NewOptString("username")}}, nil)
+		s := service.New(StubLogger(), repo)
+
+		res, err := s.ServiceListUsers(context.Background(), domain.ServiceListUsersParams{
+			Fields: []domain.UserField{domain.UserFieldUsername},
+		})
+		require.NoError(t, err)
+		require.Equal(t, &domain.ServiceListUsersOKApplicationJSON{
+			{Username: domain.NewOptString("username")},
+		}, res)
+	})
+
+	t.Run("GetUser", func(t *testing.T) {
+		repo := mocks.NewMockRepository(gomock.NewController(t))
+		// the id is selected to expand groups
+		repo.EXPECT().
+			GetUserFieldsById(gomock.Any(), id, repository.UserFields{"email", "id"}).
+			Return(&domain.User{
+				ID:    domain.NewOptUUID(id),
+				Email: domain.NewOptString("valid@mail.ru"),
+			}, nil)
+		repo.EXPECT().
+			GetUserGroups(gomock.Any(), id).
+			Return(groups, nil)
+		s := service.New(StubLogger(), repo)
+
+		res, err := s.ServiceGetUser(context.Background(), domain.ServiceGetUserParams{
+			UserId: id,
+			Fields: []domain.UserField{domain.UserFieldEmail},
+			Expand: []domain.UserExpand{domain.UserExpandGroups},
+		})
+		require.NoError(t, err)
+		require.Equal(t, &domain.User{
+			Email:  domain.NewOptString("valid@mail.ru"),
+			Groups: groups,
+		}, res)
+	})
+
+	t.Run("Unknown values", func(t *testing.T) {
+		s := service.New(StubLogger(), mocks.NewMockRepository(gomock.NewController(t)))
+
+		res, err := s.ServiceGetUser(context.Background(), domain.ServiceGetUserParams{
+			UserId: id,
+			Expand: []domain.UserExpand{domain.UserExpandGroups, "roles"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, &domain.ValidationErrorResponse{
+			Message: domain.ValidationErrorMessageInvalidExpand,
+		}, res)
+
+		list, err := s.ServiceListUsers(context.Background(), domain.ServiceListUsersParams{
+			Fields: []domain.UserField{"password"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, &domain.ValidationErrorResponse{
+			Message: domain.ValidationErrorMessageInvalidFields,
+		}, list)
+	})
+
+	t.Run("Rejected while decoding", func(t *testing.T) {
+		next := func(_ context.Context, w http.ResponseWriter, _ *http.Request, _ error) {
+			w.WriteHeader(http.StatusTeapot)
+		}
+		handle := func(err error) *httptest.ResponseRecorder {
+			w := httptest.NewRecorder()
+			service.ProjectionErrorHandler(next)(
+				context.Background(), w, httptest.NewRequest(http.MethodGet, "/users/", nil), err,
+			)
+			return w
+		}
+
+		w := handle(&ogenerrors.DecodeParamsError{
+			Err: &ogenerrors.DecodeParamError{Name: "expand", In: "query", Err: errors.New("invalid value: roles")},
+		})
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.JSONEq(t, `{"message":"invalid expand"}`, w.Body.String())
+
+		// other parameters are handled by next
+		w = handle(&ogenerrors.DecodeParamsError{
+			Err: &ogenerrors.DecodeParamError{Name: "offset", In: "query", Err: errors.New("invalid")},
+		})
+		require.Equal(t, http.StatusTeapot, w.Code)
+	})
+}
+
 func TestCreateUser(t *testing.T) {
 	type deps struct {
 		repo *mocks.MockRepository
@@ -85,6 +207,28 @@ func TestCreateUser(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Email normalized",
+			setup: func(d deps, t *test) {
+				d.repo.EXPECT().
+					CreateUser(gomock.Any(), gomock.Cond(func(u *domain.User) bool {
+						return u.Email.Value == "valid@mail.ru"
+					})).
+					Return(&domain.UUID{}, nil)
+			},
+			user: domain.User{
+				Username: domain.NewOptString("username1"),
+				Password: domain.NewOptString("password123A"),
+				Email:    domain.NewOptString(" Valid@Mail.RU "),
+			},
+			res: &domain.User{
+				ID:       domain.NewOptUUID(domain.UUID{}),
+				Username: domain.NewOptString("username1"),
+				Password: domain.NewOptString(""),
+				Email:    domain.NewOptString("valid@mail.ru"),
+			},
+			wantErr: false,
+		},
 		{
 			name:  "Invalid Username",
 			setup: nil,
@@ -363,3 +507,65 @@ func TestPutUser(t *testing.T) {
 		})
 	}
 }
+
+func TestChangePassword(t *testing.T) {
+	orgID := domain.UUID{1}
+	ctx := context.WithValue(context.Background(), service.CurrentUser{}, service.Principal{
+		ID:                 domain.UUID{2},
+		TenantID:           orgID,
+		MustChangePassword: true,
+	})
+
+	stored := &domain.User{
+		ID:       domain.NewOptUUID(domain.UUID{2}),
+		Password: domain.NewOptString(StubPasswordHash(t, "password123B")),
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		repo := mocks.NewMockRepository(gomock.NewController(t))
+		repo.EXPECT().
+			GetUserById(gomock.Any(), domain.UUID{2}).
+			DoAndReturn(func(ctx context.Context, id domain.UUID) (*domain.User, error) {
+				RequireTenant(t, orgID, ctx)
+				return stored, nil
+			})
+		repo.EXPECT().
+			UpdateUser(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, u *domain.User) error {
+				RequireTenant(t, orgID, ctx)
+				require.Equal(t, domain.NewOptUUID(domain.UUID{2}), u.ID)
+				require.Equal(t, domain.NewOptBool(false), u.MustChangePassword)
+				require.NotEqual(t, "password123A", u.Password.Value)
+				return nil
+			})
+		s := service.New(StubLogger(), repo)
+
+		res, err := s.ChangePassword(ctx, &domain.PasswordChange{Password: "password123A"})
+		require.NoError(t, err)
+		require.Equal(t, &domain.ChangePasswordOK{}, res)
+	})
+
+	t.Run("Same password", func(t *testing.T) {
+		repo := mocks.NewMockRepository(gomock.NewController(t))
+		repo.EXPECT().
+			GetUserById(gomock.Any(), domain.UUID{2}).
+			Return(stored, nil)
+		s := service.New(StubLogger(), repo)
+
+		res, err := s.ChangePassword(ctx, &domain.PasswordChange{Password: "password123B"})
+		require.NoError(t, err)
+		require.Equal(t, &domain.ValidationErrorResponse{
+			Message: domain.ValidationErrorMessagePasswordNotChanged,
+		}, res)
+	})
+
+	t.Run("Invalid password", func(t *testing.T) {
+		s := service.New(StubLogger(), mocks.NewMockRepository(gomock.NewController(t)))
+
+		res, err := s.ChangePassword(ctx, &domain.PasswordChange{Password: "admin"})
+		require.NoError(t, err)
+		require.Equal(t, &domain.ValidationErrorResponse{
+			Message: domain.ValidationErrorMessageInvalidPassword,
+		}, res)
+	})
+}
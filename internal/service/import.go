@@ -0,0 +1,491 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/repository"
+	"github.com/liriquew/test_task/pkg/logger/sl"
+)
+
+const (
+	// rows are written to the repository by batches of this size,
+	// so the body is never held in memory as a whole
+	importBatchSize = 500
+	// max length of a single NDJSON line
+	importMaxLineSize = 64 * 1024
+)
+
+// importRow is a parsed row of the import body,
+// failed is set when the row was rejected before reaching the repository
+type importRow struct {
+	line   int64
+	user   domain.User
+	failed string
+}
+
+type importReader interface {
+	// Next returns io.EOF when there are no more rows,
+	// any other error aborts the import
+	Next() (*importRow, error)
+}
+
+func (s *Service) ServiceImportUsers(
+	ctx context.Context,
+	req domain.ServiceImportUsersReq,
+	params domain.ServiceImportUsersParams,
+) (domain.ServiceImportUsersRes, error) {
+	var (
+		reader importReader
+		err    error
+	)
+	switch req := req.(type) {
+	case *domain.ServiceImportUsersReqTextCsv:
+		reader, err = newCSVImportReader(req.Data)
+	case *domain.ServiceImportUsersReqApplicationXNdjson:
+		reader = newNDJSONImportReader(req.Data)
+	default:
+		err = fmt.Errorf("unsupported import body %T", req)
+	}
+	if err != nil {
+		s.log.Warn("error while reading import header", sl.Err(err))
+		return &domain.ValidationErrorResponse{
+			Message: domain.ValidationErrorMessageBadParams,
+		}, nil
+	}
+
+	onConflict := params.OnConflict.Or(domain.ServiceImportUsersOnConflictFail)
+	dryRun := params.DryRun.Value
+
+	report := &domain.ImportReport{
+		DryRun: dryRun,
+		Rows:   []domain.ImportRowResult{},
+	}
+
+	// rows are committed by batches, unless a conflict must roll back the whole import
+	// or a dry run must check rows against previous batches, then the import runs
+	// in a single transaction. The body is read once, so the transaction isn't retried
+	if onConflict != domain.ServiceImportUsersOnConflictFail && !dryRun {
+		if _, errResp := s.importRows(ctx, reader, onConflict, dryRun, report); errResp != nil {
+			return errResp, nil
+		}
+		report.Committed = true
+
+		return report, nil
+	}
+
+	var errResp *domain.InternalErrorResponse
+	err = s.repo.WithTx(ctx, func(repo Repository) error {
+		var conflict bool
+		conflict, errResp = s.withRepo(repo).importRows(ctx, reader, onConflict, dryRun, report)
+		if errResp != nil || conflict || dryRun {
+			return errImportRolledBack
+		}
+		return nil
+	}, repository.WithRetries(0))
+	if errResp != nil {
+		return errResp, nil
+	}
+	if err != nil && !errors.Is(err, errImportRolledBack) {
+		s.log.Warn("error while importing users", sl.Err(err))
+		return &domain.InternalErrorResponse{
+			Message: domain.InternalErrorResponseMessage(
+				fmt.Sprintf("internal error: %s", err),
+			),
+		}, nil
+	}
+	report.Committed = err == nil
+
+	return report, nil
+}
+
+// errImportRolledBack rolls back the transaction of a dry run
+// or of an import stopped by a conflict
+var errImportRolledBack = errors.New("import rolled back")
+
+// importRows reads rows of the body and imports them by batches, conflict is true
+// when the import was stopped by a conflict with on_conflict=fail
+func (s *Service) importRows(
+	ctx context.Context,
+	reader importReader,
+	onConflict domain.ServiceImportUsersOnConflict,
+	dryRun bool,
+	report *domain.ImportReport,
+) (conflict bool, errResp *domain.InternalErrorResponse) {
+	batch := make([]*importRow, 0, importBatchSize)
+	for {
+		row, err := reader.Next()
+		if err != nil && !errors.Is(err, io.EOF) {
+			s.log.Warn("error while reading import body", sl.Err(err))
+			return false, &domain.InternalErrorResponse{
+				Message: domain.InternalErrorResponseMessage(
+					fmt.Sprintf("internal error while reading import body: %s", err),
+				),
+			}
+		}
+		if row != nil {
+			batch = append(batch, row)
+		}
+
+		if len(batch) == importBatchSize || (errors.Is(err, io.EOF) && len(batch) != 0) {
+			conflict, errResp := s.importBatch(ctx, batch, onConflict, dryRun, report)
+			if conflict || errResp != nil {
+				return conflict, errResp
+			}
+			batch = batch[:0]
+		}
+
+		if errors.Is(err, io.EOF) {
+			return false, nil
+		}
+	}
+}
+
+// importBatch validates rows, hashes passwords and writes the valid rows,
+// results are appended to the report in the order of rows. With on_conflict=fail
+// the results end at the first conflict and conflict is true
+func (s *Service) importBatch(
+	ctx context.Context,
+	batch []*importRow,
+	onConflict domain.ServiceImportUsersOnConflict,
+	dryRun bool,
+	report *domain.ImportReport,
+) (conflict bool, errResp *domain.InternalErrorResponse) {
+	valid := make([]*importRow, 0, len(batch))
+	for _, row := range batch {
+		if row.failed == "" {
+			row.failed = validateImportUser(&row.user)
+		}
+		if row.failed == "" {
+			valid = append(valid, row)
+		}
+	}
+
+	// hash is useless when nothing is written
+	if !dryRun {
+		if errResp := hashImportPasswords(valid); errResp != nil {
+			s.log.Warn("error while hashing passwords in ImportUsers", slog.String("error", string(errResp.Message)))
+			return false, errResp
+		}
+	}
+
+	var results []domain.ImportRowResult
+	write := func(repo Repository) error {
+		rows := valid
+		// only an update import changes existing users
+		if onConflict == domain.ServiceImportUsersOnConflictUpdate {
+			var err error
+			if rows, err = keepLastAdmin(ctx, repo, valid); err != nil {
+				return err
+			}
+		}
+
+		users := make([]domain.User, len(rows))
+		for i, row := range rows {
+			users[i] = row.user
+		}
+		if len(users) == 0 {
+			return nil
+		}
+
+		var err error
+		results, err = repo.ImportUsers(ctx, users, onConflict)
+		return err
+	}
+
+	if len(valid) != 0 {
+		var err error
+		if onConflict == domain.ServiceImportUsersOnConflictUpdate {
+			// admins stay locked until the batch is written
+			err = s.repo.WithTx(ctx, write)
+		} else {
+			err = write(s.repo)
+		}
+		if err != nil {
+			s.log.Warn("error while importing users", sl.Err(err))
+			return false, &domain.InternalErrorResponse{
+				Message: domain.InternalErrorResponseMessage(
+					fmt.Sprintf("internal error: %s", err),
+				),
+			}
+		}
+	}
+
+	for _, row := range batch {
+		res := domain.ImportRowResult{
+			Status: domain.ImportRowResultStatusFailed,
+			Error:  domain.NewOptString(row.failed),
+		}
+		if row.failed == "" {
+			res, results = results[0], results[1:]
+			// rows are failed by the storage only on conflicts
+			conflict = res.Status == domain.ImportRowResultStatusFailed &&
+				onConflict == domain.ServiceImportUsersOnConflictFail
+		}
+		res.Line = row.line
+
+		switch res.Status {
+		case domain.ImportRowResultStatusCreated:
+			report.Created++
+		case domain.ImportRowResultStatusUpdated:
+			report.Updated++
+		case domain.ImportRowResultStatusSkipped:
+			report.Skipped++
+		case domain.ImportRowResultStatusFailed:
+			report.Failed++
+		}
+		report.Rows = append(report.Rows, res)
+
+		if conflict {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// keepLastAdmin fails rows of an update import which demote the last administrator
+// of the organization and returns the other rows. Admins of admin groups keep their
+// permissions, so nothing is failed if there is one.
+// Must be called inside WithTx, admins stay locked until the end of the transaction
+func keepLastAdmin(ctx context.Context, repo Repository, rows []*importRow) ([]*importRow, error) {
+	demotes := func(row *importRow) bool {
+		return row.user.IsAdmin.IsSet() && !row.user.IsAdmin.Value
+	}
+	if !slices.ContainsFunc(rows, demotes) {
+		return rows, nil
+	}
+
+	ids, err := repo.LockAdmins(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// usernames of admins which lose permissions on demotion
+	admins := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		groups, err := repo.GetUserGroups(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if slices.ContainsFunc(groups, func(g domain.Group) bool { return g.IsAdmin.Value }) {
+			return rows, nil
+		}
+
+		user, err := repo.GetUserById(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		admins[strings.ToLower(user.Username.Value)] = true
+	}
+
+	kept := make([]*importRow, 0, len(rows))
+	for _, row := range rows {
+		username := strings.ToLower(row.user.Username.Value)
+		if demotes(row) && admins[username] {
+			if len(admins) == 1 {
+				row.failed = string(lastAdmin)
+				continue
+			}
+			delete(admins, username)
+		}
+		kept = append(kept, row)
+	}
+
+	return kept, nil
+}
+
+// validateImportUser returns the reason of rejection or empty string
+func validateImportUser(user *domain.User) string {
+	if user.Username.Value == "" {
+		return "empty username"
+	}
+	if user.Password.Value == "" {
+		return "empty password"
+	}
+	if user.Email.Value == "" {
+		return "empty email"
+	}
+
+	NormalizeUser(user)
+	if errResp := ValidateUser(user); errResp != nil {
+		return string(errResp.Message)
+	}
+
+	return ""
+}
+
+// hashImportPasswords hashes passwords of rows in place,
+// bcrypt is cpu bound, so the pool is limited by the number of cpus
+func hashImportPasswords(rows []*importRow) *domain.InternalErrorResponse {
+	var (
+		wg      sync.WaitGroup
+		once    sync.Once
+		errResp *domain.InternalErrorResponse
+	)
+
+	jobs := make(chan *importRow)
+	for range min(runtime.GOMAXPROCS(0), len(rows)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for row := range jobs {
+				hash, internalErr := hashPassword(row.user.Password.Value)
+				if internalErr != nil {
+					once.Do(func() { errResp = internalErr })
+					continue
+				}
+				row.user.Password.SetTo(hash)
+			}
+		}()
+	}
+
+	for _, row := range rows {
+		jobs <- row
+	}
+	close(jobs)
+	wg.Wait()
+
+	return errResp
+}
+
+var importCSVColumns = map[string]bool{
+	"username": true,
+	"email":    true,
+	"password": true,
+	"is_admin": false,
+}
+
+type csvImportReader struct {
+	r       *csv.Reader
+	columns []string
+	line    int64
+}
+
+// newCSVImportReader reads the header, columns may go in any order,
+// is_admin column is optional
+func newCSVImportReader(r io.Reader) (*csvImportReader, error) {
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+	cr.FieldsPerRecord = 0
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read csv header: %w", err)
+	}
+
+	columns := make([]string, len(header))
+	seen := make(map[string]bool, len(header))
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(column))
+		if _, ok := importCSVColumns[column]; !ok || seen[column] {
+			return nil, fmt.Errorf("unexpected csv column %q", column)
+		}
+		seen[column] = true
+		columns[i] = column
+	}
+	for column, required := range importCSVColumns {
+		if required && !seen[column] {
+			return nil, fmt.Errorf("missing csv column %q", column)
+		}
+	}
+
+	return &csvImportReader{
+		r:       cr,
+		columns: columns,
+	}, nil
+}
+
+func (r *csvImportReader) Next() (*importRow, error) {
+	record, err := r.r.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, io.EOF
+	}
+	r.line++
+
+	row := &importRow{line: r.line}
+	if err != nil {
+		// malformed record fails the row only, the reader continues from the next one
+		var parseErr *csv.ParseError
+		if !errors.As(err, &parseErr) {
+			return nil, err
+		}
+		row.failed = parseErr.Err.Error()
+		return row, nil
+	}
+
+	for i, value := range record {
+		switch r.columns[i] {
+		case "username":
+			row.user.Username.SetTo(value)
+		case "email":
+			row.user.Email.SetTo(value)
+		case "password":
+			row.user.Password.SetTo(value)
+		case "is_admin":
+			// an empty value keeps is_admin of an updated user
+			if value == "" {
+				continue
+			}
+			isAdmin, err := strconv.ParseBool(value)
+			if err != nil {
+				row.failed = "invalid is_admin"
+				continue
+			}
+			row.user.IsAdmin.SetTo(isAdmin)
+		}
+	}
+
+	return row, nil
+}
+
+type ndjsonImportReader struct {
+	s    *bufio.Scanner
+	line int64
+}
+
+func newNDJSONImportReader(r io.Reader) *ndjsonImportReader {
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), importMaxLineSize)
+
+	return &ndjsonImportReader{
+		s: s,
+	}
+}
+
+func (r *ndjsonImportReader) Next() (*importRow, error) {
+	for r.s.Scan() {
+		data := bytes.TrimSpace(r.s.Bytes())
+		// blank lines aren't rows
+		if len(data) == 0 {
+			continue
+		}
+		r.line++
+
+		row := &importRow{line: r.line}
+		if err := row.user.UnmarshalJSON(data); err != nil {
+			row.failed = "invalid json"
+		}
+		// id is always generated by the storage
+		row.user.ID.Reset()
+
+		return row, nil
+	}
+	if err := r.s.Err(); err != nil {
+		return nil, err
+	}
+
+	return nil, io.EOF
+}
@@ -0,0 +1,293 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/repository"
+	"github.com/liriquew/test_task/internal/service"
+	"github.com/liriquew/test_task/internal/service/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestImportUsers(t *testing.T) {
+	type deps struct {
+		repo *mocks.MockRepository
+	}
+
+	type test struct {
+		name   string
+		setup  func(d deps)
+		req    domain.ServiceImportUsersReq
+		params domain.ServiceImportUsersParams
+		res    domain.ServiceImportUsersRes
+	}
+
+	tests := []test{
+		{
+			name: "CSV with invalid row",
+			setup: func(d deps) {
+				ExpectTx(d.repo)
+				d.repo.EXPECT().
+					ImportUsers(gomock.Any(), gomock.Cond(func(users []domain.User) bool {
+						return len(users) == 1 &&
+							users[0].Username.Value == "username1" &&
+							users[0].Email.Value == "valid@mail.ru" &&
+							users[0].Password.Value != "password123A" &&
+							users[0].IsAdmin.Value
+					}), domain.ServiceImportUsersOnConflictFail).
+					Return([]domain.ImportRowResult{
+						{
+							Status: domain.ImportRowResultStatusCreated,
+							ID:     domain.NewOptUUID(domain.UUID{}),
+						},
+					}, nil)
+			},
+			req: &domain.ServiceImportUsersReqTextCsv{
+				Data: strings.NewReader(
+					"email,username,password,is_admin\n" +
+						"Valid@Mail.ru,username1,password123A,true\n" +
+						"valid@mail.ru,юзернейм1,password123A,\n",
+				),
+			},
+			res: &domain.ImportReport{
+				Committed: true,
+				Created:   1,
+				Failed:    1,
+				Rows: []domain.ImportRowResult{
+					{
+						Line:   1,
+						Status: domain.ImportRowResultStatusCreated,
+						ID:     domain.NewOptUUID(domain.UUID{}),
+					},
+					{
+						Line:   2,
+						Status: domain.ImportRowResultStatusFailed,
+						Error:  domain.NewOptString(string(domain.ValidationErrorMessageInvalidUsername)),
+					},
+				},
+			},
+		},
+		{
+			name: "NDJSON dry run",
+			setup: func(d deps) {
+				ExpectTx(d.repo)
+				d.repo.EXPECT().
+					ImportUsers(gomock.Any(), []domain.User{
+						{
+							Username: domain.NewOptString("username1"),
+							Password: domain.NewOptString("password123A"),
+							Email:    domain.NewOptString("valid@mail.ru"),
+						},
+					}, domain.ServiceImportUsersOnConflictSkip).
+					Return([]domain.ImportRowResult{
+						{Status: domain.ImportRowResultStatusSkipped},
+					}, nil)
+			},
+			req: &domain.ServiceImportUsersReqApplicationXNdjson{
+				Data: strings.NewReader(
+					`{"username":"username1","password":"password123A","email":"valid@mail.ru"}` + "\n" +
+						"\n" +
+						`{"username":` + "\n",
+				),
+			},
+			params: domain.ServiceImportUsersParams{
+				DryRun:     domain.NewOptBool(true),
+				OnConflict: domain.NewOptServiceImportUsersOnConflict(domain.ServiceImportUsersOnConflictSkip),
+			},
+			res: &domain.ImportReport{
+				DryRun:  true,
+				Skipped: 1,
+				Failed:  1,
+				Rows: []domain.ImportRowResult{
+					{
+						Line:   1,
+						Status: domain.ImportRowResultStatusSkipped,
+					},
+					{
+						Line:   2,
+						Status: domain.ImportRowResultStatusFailed,
+						Error:  domain.NewOptString("invalid json"),
+					},
+				},
+			},
+		},
+		{
+			name: "CSV conflict stops import",
+			setup: func(d deps) {
+				ExpectTx(d.repo)
+				d.repo.EXPECT().
+					ImportUsers(gomock.Any(), gomock.Len(2), domain.ServiceImportUsersOnConflictFail).
+					Return([]domain.ImportRowResult{
+						{
+							Status: domain.ImportRowResultStatusFailed,
+							Error:  domain.NewOptString(repository.ErrUsernameExists.Error()),
+						},
+						{
+							Status: domain.ImportRowResultStatusCreated,
+							ID:     domain.NewOptUUID(domain.UUID{}),
+						},
+					}, nil)
+			},
+			req: &domain.ServiceImportUsersReqTextCsv{
+				Data: strings.NewReader(
+					"username,email,password\n" +
+						"username1,valid@mail.ru,password123A\n" +
+						"username2,other@mail.ru,password123A\n",
+				),
+			},
+			res: &domain.ImportReport{
+				Failed: 1,
+				Rows: []domain.ImportRowResult{
+					{
+						Line:   1,
+						Status: domain.ImportRowResultStatusFailed,
+						Error:  domain.NewOptString(repository.ErrUsernameExists.Error()),
+					},
+				},
+			},
+		},
+		{
+			name: "CSV skip commits by batches",
+			setup: func(d deps) {
+				d.repo.EXPECT().
+					ImportUsers(gomock.Any(), gomock.Len(1), domain.ServiceImportUsersOnConflictSkip).
+					Return([]domain.ImportRowResult{
+						{Status: domain.ImportRowResultStatusSkipped},
+					}, nil)
+			},
+			req: &domain.ServiceImportUsersReqTextCsv{
+				Data: strings.NewReader(
+					"username,email,password\n" +
+						"username1,valid@mail.ru,password123A\n",
+				),
+			},
+			params: domain.ServiceImportUsersParams{
+				OnConflict: domain.NewOptServiceImportUsersOnConflict(domain.ServiceImportUsersOnConflictSkip),
+			},
+			res: &domain.ImportReport{
+				Committed: true,
+				Skipped:   1,
+				Rows: []domain.ImportRowResult{
+					{
+						Line:   1,
+						Status: domain.ImportRowResultStatusSkipped,
+					},
+				},
+			},
+		},
+		{
+			name: "CSV update without is_admin",
+			setup: func(d deps) {
+				ExpectTx(d.repo)
+				d.repo.EXPECT().
+					ImportUsers(gomock.Any(), gomock.Cond(func(users []domain.User) bool {
+						return len(users) == 1 && !users[0].IsAdmin.IsSet()
+					}), domain.ServiceImportUsersOnConflictUpdate).
+					Return([]domain.ImportRowResult{
+						{
+							Status: domain.ImportRowResultStatusUpdated,
+							ID:     domain.NewOptUUID(domain.UUID{}),
+						},
+					}, nil)
+			},
+			req: &domain.ServiceImportUsersReqTextCsv{
+				Data: strings.NewReader(
+					"username,email,password\n" +
+						"username1,valid@mail.ru,password123A\n",
+				),
+			},
+			params: domain.ServiceImportUsersParams{
+				OnConflict: domain.NewOptServiceImportUsersOnConflict(domain.ServiceImportUsersOnConflictUpdate),
+			},
+			res: &domain.ImportReport{
+				Committed: true,
+				Updated:   1,
+				Rows: []domain.ImportRowResult{
+					{
+						Line:   1,
+						Status: domain.ImportRowResultStatusUpdated,
+						ID:     domain.NewOptUUID(domain.UUID{}),
+					},
+				},
+			},
+		},
+		{
+			name: "CSV update demotes last admin",
+			setup: func(d deps) {
+				ExpectTx(d.repo)
+				d.repo.EXPECT().
+					LockAdmins(gomock.Any()).
+					Return([]domain.UUID{{1}}, nil)
+				d.repo.EXPECT().
+					GetUserGroups(gomock.Any(), domain.UUID{1}).
+					Return([]domain.Group{}, nil)
+				d.repo.EXPECT().
+					GetUserById(gomock.Any(), domain.UUID{1}).
+					Return(&domain.User{Username: domain.NewOptString("username1")}, nil)
+			},
+			req: &domain.ServiceImportUsersReqTextCsv{
+				Data: strings.NewReader(
+					"username,email,password,is_admin\n" +
+						"username1,valid@mail.ru,password123A,false\n",
+				),
+			},
+			params: domain.ServiceImportUsersParams{
+				OnConflict: domain.NewOptServiceImportUsersOnConflict(domain.ServiceImportUsersOnConflictUpdate),
+			},
+			res: &domain.ImportReport{
+				Committed: true,
+				Failed:    1,
+				Rows: []domain.ImportRowResult{
+					{
+						Line:   1,
+						Status: domain.ImportRowResultStatusFailed,
+						Error:  domain.NewOptString(string(domain.LastAdminResponseMessageConflictLastAdministratorCanTBeRemoved)),
+					},
+				},
+			},
+		},
+		{
+			name:  "Unknown CSV column",
+			setup: nil,
+			req: &domain.ServiceImportUsersReqTextCsv{
+				Data: strings.NewReader("username,email,password,role\n"),
+			},
+			res: &domain.ValidationErrorResponse{
+				Message: domain.ValidationErrorMessageBadParams,
+			},
+		},
+		{
+			name:  "Missing CSV column",
+			setup: nil,
+			req: &domain.ServiceImportUsersReqTextCsv{
+				Data: strings.NewReader("username,password\n"),
+			},
+			res: &domain.ValidationErrorResponse{
+				Message: domain.ValidationErrorMessageBadParams,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			d := deps{
+				repo: mocks.NewMockRepository(ctrl),
+			}
+			if tt.setup != nil {
+				tt.setup(d)
+			}
+			s := service.New(StubLogger(), d.repo)
+
+			res, err := s.ServiceImportUsers(context.Background(), tt.req, tt.params)
+			require.Nil(t, err)
+			require.Equal(t, tt.res, res)
+		})
+	}
+}
@@ -5,12 +5,18 @@ import (
 	"encoding/base64"
 	"errors"
 	"log/slog"
+	"strconv"
+	"strings"
 
+	"github.com/google/uuid"
 	"github.com/ogen-go/ogen/middleware"
+	"github.com/ogen-go/ogen/ogenerrors"
 	"golang.org/x/crypto/bcrypt"
 
 	api "github.com/liriquew/test_task/internal/domain"
 	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/lib/consistency"
+	"github.com/liriquew/test_task/internal/lib/tenant"
 	"github.com/liriquew/test_task/internal/repository"
 	"github.com/liriquew/test_task/pkg/logger/sl"
 )
@@ -21,15 +27,52 @@ var (
 )
 
 type (
-	IsAdmin struct{}
+	IsAdmin      struct{}
+	IsSuperAdmin struct{}
+	// CurrentUser is the key of the authenticated Principal
+	CurrentUser struct{}
 )
 
+// Principal is the authenticated user of the request
+type Principal struct {
+	ID       domain.UUID
+	TenantID domain.UUID
+	// MustChangePassword restricts the user to ChangePassword
+	MustChangePassword bool
+}
+
+// TenantHeader scopes a request of a super-admin to another organization
+const TenantHeader = "X-Tenant-ID"
+
+// ReadYourWritesHeader makes reads of the request go to the primary database
+const ReadYourWritesHeader = "X-Read-Your-Writes"
+
 func (m *UserServiceMiddleware) HandleBasicAuth(
 	ctx context.Context,
 	operationName api.OperationName,
 	t domain.BasicAuth,
 ) (context.Context, error) {
-	user, err := m.repo.GetUserByUsername(ctx, t.Username)
+	tenantID := tenant.Default
+	username := t.Username
+
+	// organization/username, just username for the default organization
+	if orgName, name, ok := strings.Cut(t.Username, "/"); ok {
+		org, err := m.repo.GetOrganizationByName(ctx, orgName)
+		if err != nil {
+			if errors.Is(err, repository.ErrOrganizationNotFound) {
+				return ctx, ErrUnauthorized
+			}
+
+			m.log.Warn("error while getting organization in Basic Auth", sl.Err(err))
+			return nil, err
+		}
+
+		tenantID = org.ID.Value
+		username = name
+	}
+	ctx = tenant.WithTenant(ctx, tenantID)
+
+	user, err := m.repo.GetUserByUsername(ctx, username)
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
 			return ctx, ErrUnauthorized
@@ -49,8 +92,109 @@ func (m *UserServiceMiddleware) HandleBasicAuth(
 		return ctx, ErrUnauthorized
 	}
 
-	ctx = context.WithValue(ctx, IsAdmin{}, user.IsAdmin.Value)
-	return ctx, nil
+	isAdmin := user.IsAdmin.Value
+	if !isAdmin {
+		// admin permissions can be granted by any of user groups
+		groups, err := m.repo.GetUserGroups(ctx, user.ID.Value)
+		if err != nil {
+			m.log.Warn("error while getting user groups in Basic Auth", sl.Err(err))
+			return nil, err
+		}
+		for _, group := range groups {
+			isAdmin = isAdmin || group.IsAdmin.Value
+		}
+	}
+
+	return WithPrincipal(ctx, Principal{
+		ID:                 user.ID.Value,
+		TenantID:           tenantID,
+		MustChangePassword: user.MustChangePassword.Value,
+	}, isAdmin), nil
+}
+
+// WithPrincipal returns ctx of the request authenticated as the principal,
+// scoped to the organization of the principal
+func WithPrincipal(ctx context.Context, principal Principal, isAdmin bool) context.Context {
+	ctx = tenant.WithTenant(ctx, principal.TenantID)
+	ctx = context.WithValue(ctx, IsAdmin{}, isAdmin)
+	ctx = context.WithValue(ctx, IsSuperAdmin{}, isAdmin && principal.TenantID == tenant.Default)
+	ctx = context.WithValue(ctx, CurrentUser{}, principal)
+	return ctx
+}
+
+// RequirePasswordChange rejects every request of a user with must_change_password
+// except ChangePassword and Health, so the initial password can't be used for anything else
+func (m *UserServiceMiddleware) RequirePasswordChange() middleware.Middleware {
+	return func(
+		req middleware.Request,
+		next middleware.Next,
+	) (middleware.Response, error) {
+		principal, _ := req.Context.Value(CurrentUser{}).(Principal)
+		if !principal.MustChangePassword {
+			return next(req)
+		}
+		// health has no forbidden response
+		if req.OperationName == domain.ChangePasswordOperation || req.OperationName == domain.HealthOperation {
+			return next(req)
+		}
+
+		return middleware.Response{
+			Type: &domain.ForbiddenResponse{
+				Message: domain.ForbiddenResponseMessageForbiddenPasswordChangeRequired,
+			},
+		}, nil
+	}
+}
+
+// ResolveTenant scopes the request to the organization from X-Tenant-ID header,
+// only super-admins are allowed to use it
+func (m *UserServiceMiddleware) ResolveTenant() middleware.Middleware {
+	return func(
+		req middleware.Request,
+		next middleware.Next,
+	) (middleware.Response, error) {
+		header := req.Raw.Header.Get(TenantHeader)
+		if header == "" {
+			return next(req)
+		}
+
+		isSuperAdmin := req.Context.Value(IsSuperAdmin{}).(bool)
+		if !isSuperAdmin {
+			return middleware.Response{
+				Type: &domain.ForbiddenResponse{
+					Message: domain.ForbiddenResponseMessageForbiddenSuperAdminPermissionRequired,
+				},
+			}, nil
+		}
+
+		id, err := uuid.Parse(header)
+		if err != nil {
+			return middleware.Response{}, badTenantHeader(req, err)
+		}
+
+		if _, err := m.repo.GetOrganizationById(req.Context, domain.UUID(id)); err != nil {
+			if errors.Is(err, repository.ErrOrganizationNotFound) {
+				return middleware.Response{}, badTenantHeader(req, err)
+			}
+
+			m.log.Warn("error while getting organization in ResolveTenant", sl.Err(err))
+			return middleware.Response{}, err
+		}
+
+		req.SetContext(tenant.WithTenant(req.Context, domain.UUID(id)))
+		return next(req)
+	}
+}
+
+// badTenantHeader is reported by ogen error handler as 400
+func badTenantHeader(req middleware.Request, err error) error {
+	return &ogenerrors.DecodeParamsError{
+		OperationContext: ogenerrors.OperationContext{
+			Name: req.OperationName,
+			ID:   req.OperationID,
+		},
+		Err: err,
+	}
 }
 
 func (m *UserServiceMiddleware) CheckAdminPermission() middleware.Middleware {
@@ -58,8 +202,24 @@ func (m *UserServiceMiddleware) CheckAdminPermission() middleware.Middleware {
 		req middleware.Request,
 		next middleware.Next,
 	) (middleware.Response, error) {
+		if strings.HasPrefix(req.OperationName, "Organizations") {
+			isSuperAdmin := req.Context.Value(IsSuperAdmin{}).(bool)
+			if isSuperAdmin {
+				return next(req)
+			}
+
+			return middleware.Response{
+				Type: &domain.ForbiddenResponse{
+					Message: domain.ForbiddenResponseMessageForbiddenSuperAdminPermissionRequired,
+				},
+			}, nil
+		}
+
 		isAdmin := req.Context.Value(IsAdmin{}).(bool)
-		if isAdmin || req.OperationName == "ServiceListUsers" {
+		if isAdmin ||
+			req.OperationName == "ServiceListUsers" ||
+			req.OperationName == "ServiceWatchUsers" ||
+			req.OperationName == domain.ChangePasswordOperation {
 			return next(req)
 		}
 
@@ -69,6 +229,22 @@ func (m *UserServiceMiddleware) CheckAdminPermission() middleware.Middleware {
 	}
 }
 
+// ReadYourWrites sends reads of the request to the primary database if it has
+// X-Read-Your-Writes: true header, so a client sees changes it has just made
+// instead of the lagging state of a replica
+func ReadYourWrites() middleware.Middleware {
+	return func(
+		req middleware.Request,
+		next middleware.Next,
+	) (middleware.Response, error) {
+		if required, _ := strconv.ParseBool(req.Raw.Header.Get(ReadYourWritesHeader)); required {
+			req.SetContext(consistency.WithPrimary(req.Context))
+		}
+
+		return next(req)
+	}
+}
+
 func Logging(logger *slog.Logger) middleware.Middleware {
 	logger.Info("logger msg")
 	return func(
@@ -0,0 +1,174 @@
+package service_test
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http/httptest"
+	"testing"
+
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/lib/consistency"
+	"github.com/liriquew/test_task/internal/lib/tenant"
+	"github.com/liriquew/test_task/internal/repository"
+	"github.com/liriquew/test_task/internal/service"
+	"github.com/liriquew/test_task/internal/service/mocks"
+	"github.com/ogen-go/ogen/middleware"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func StubPasswordHash(t *testing.T, password string) string {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(hash)
+}
+
+func TestHandleBasicAuthTenant(t *testing.T) {
+	t.Parallel()
+
+	orgID := domain.UUID{1}
+	admin := &domain.User{
+		ID:       domain.NewOptUUID(domain.UUID{2}),
+		Username: domain.NewOptString("username1"),
+		Password: domain.NewOptString(StubPasswordHash(t, "password123A")),
+		IsAdmin:  domain.NewOptBool(true),
+	}
+
+	t.Run("Default organization", func(t *testing.T) {
+		t.Parallel()
+		repo := mocks.NewMockRepository(gomock.NewController(t))
+		repo.EXPECT().
+			GetUserByUsername(gomock.Any(), "username1").
+			Return(admin, nil)
+		m := service.NewMiddleware(StubLogger(), repo)
+
+		ctx, err := m.HandleBasicAuth(context.Background(), domain.ServiceListUsersOperation, domain.BasicAuth{
+			Username: "username1",
+			Password: "password123A",
+		})
+		require.NoError(t, err)
+		RequireTenant(t, tenant.Default, ctx)
+		require.True(t, ctx.Value(service.IsSuperAdmin{}).(bool))
+	})
+
+	t.Run("Organization admin", func(t *testing.T) {
+		t.Parallel()
+		repo := mocks.NewMockRepository(gomock.NewController(t))
+		repo.EXPECT().
+			GetOrganizationByName(gomock.Any(), "acme").
+			Return(&domain.Organization{ID: domain.NewOptUUID(orgID)}, nil)
+		repo.EXPECT().
+			GetUserByUsername(gomock.Any(), "username1").
+			DoAndReturn(func(ctx context.Context, _ string) (*domain.User, error) {
+				RequireTenant(t, orgID, ctx)
+				return admin, nil
+			})
+		m := service.NewMiddleware(StubLogger(), repo)
+
+		ctx, err := m.HandleBasicAuth(context.Background(), domain.ServiceListUsersOperation, domain.BasicAuth{
+			Username: "acme/username1",
+			Password: "password123A",
+		})
+		require.NoError(t, err)
+		RequireTenant(t, orgID, ctx)
+		require.True(t, ctx.Value(service.IsAdmin{}).(bool))
+		require.False(t, ctx.Value(service.IsSuperAdmin{}).(bool))
+	})
+
+	t.Run("Unknown organization", func(t *testing.T) {
+		t.Parallel()
+		repo := mocks.NewMockRepository(gomock.NewController(t))
+		repo.EXPECT().
+			GetOrganizationByName(gomock.Any(), "unknown").
+			Return(nil, repository.ErrOrganizationNotFound)
+		m := service.NewMiddleware(StubLogger(), repo)
+
+		_, err := m.HandleBasicAuth(context.Background(), domain.ServiceListUsersOperation, domain.BasicAuth{
+			Username: "unknown/username1",
+			Password: "password123A",
+		})
+		require.ErrorIs(t, err, service.ErrUnauthorized)
+	})
+}
+
+func TestRequirePasswordChange(t *testing.T) {
+	t.Parallel()
+
+	user := &domain.User{
+		ID:                 domain.NewOptUUID(domain.UUID{2}),
+		Username:           domain.NewOptString("username1"),
+		Password:           domain.NewOptString(StubPasswordHash(t, "password123A")),
+		IsAdmin:            domain.NewOptBool(true),
+		MustChangePassword: domain.NewOptBool(true),
+	}
+
+	repo := mocks.NewMockRepository(gomock.NewController(t))
+	repo.EXPECT().
+		GetUserByUsername(gomock.Any(), "username1").
+		Return(user, nil)
+	m := service.NewMiddleware(StubLogger(), repo)
+
+	ctx, err := m.HandleBasicAuth(context.Background(), domain.ServiceListUsersOperation, domain.BasicAuth{
+		Username: "username1",
+		Password: "password123A",
+	})
+	require.NoError(t, err)
+	require.Equal(t, service.Principal{
+		ID:                 domain.UUID{2},
+		TenantID:           tenant.Default,
+		MustChangePassword: true,
+	}, ctx.Value(service.CurrentUser{}))
+
+	next := func(req middleware.Request) (middleware.Response, error) {
+		return middleware.Response{Type: &domain.ChangePasswordOK{}}, nil
+	}
+
+	resp, err := m.RequirePasswordChange()(middleware.Request{
+		Context:       ctx,
+		OperationName: domain.ServiceListUsersOperation,
+	}, next)
+	require.NoError(t, err)
+	require.Equal(t, &domain.ForbiddenResponse{
+		Message: domain.ForbiddenResponseMessageForbiddenPasswordChangeRequired,
+	}, resp.Type)
+
+	resp, err = m.RequirePasswordChange()(middleware.Request{
+		Context:       ctx,
+		OperationName: domain.ChangePasswordOperation,
+	}, next)
+	require.NoError(t, err)
+	require.Equal(t, &domain.ChangePasswordOK{}, resp.Type)
+}
+
+func TestReadYourWrites(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		header  string
+		primary bool
+	}{
+		{header: "", primary: false},
+		{header: "false", primary: false},
+		{header: "true", primary: true},
+		{header: "1", primary: true},
+	}
+
+	for _, c := range cases {
+		raw := httptest.NewRequest("GET", "/users", nil)
+		if c.header != "" {
+			raw.Header.Set(service.ReadYourWritesHeader, c.header)
+		}
+
+		var primary bool
+		_, err := service.ReadYourWrites()(middleware.Request{
+			Context: context.Background(),
+			Raw:     raw,
+		}, func(req middleware.Request) (middleware.Response, error) {
+			primary = consistency.PrimaryRequired(req.Context)
+			return middleware.Response{}, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, c.primary, primary, "header %q", c.header)
+	}
+}
@@ -11,9 +11,13 @@ package mocks
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
 
 	api "github.com/liriquew/test_task/internal/domain"
+	repository "github.com/liriquew/test_task/internal/repository"
+	service "github.com/liriquew/test_task/internal/service"
+	watch "github.com/liriquew/test_task/internal/watch"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -41,6 +45,50 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AddGroupMember mocks base method.
+func (m *MockRepository) AddGroupMember(arg0 context.Context, arg1 api.UUID, arg2 *api.GroupMember) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddGroupMember", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddGroupMember indicates an expected call of AddGroupMember.
+func (mr *MockRepositoryMockRecorder) AddGroupMember(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddGroupMember", reflect.TypeOf((*MockRepository)(nil).AddGroupMember), arg0, arg1, arg2)
+}
+
+// CreateGroup mocks base method.
+func (m *MockRepository) CreateGroup(arg0 context.Context, arg1 *api.Group) (*api.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGroup", arg0, arg1)
+	ret0, _ := ret[0].(*api.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGroup indicates an expected call of CreateGroup.
+func (mr *MockRepositoryMockRecorder) CreateGroup(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGroup", reflect.TypeOf((*MockRepository)(nil).CreateGroup), arg0, arg1)
+}
+
+// CreateOrganization mocks base method.
+func (m *MockRepository) CreateOrganization(arg0 context.Context, arg1 *api.Organization) (*api.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrganization", arg0, arg1)
+	ret0, _ := ret[0].(*api.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrganization indicates an expected call of CreateOrganization.
+func (mr *MockRepositoryMockRecorder) CreateOrganization(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganization", reflect.TypeOf((*MockRepository)(nil).CreateOrganization), arg0, arg1)
+}
+
 // CreateUser mocks base method.
 func (m *MockRepository) CreateUser(arg0 context.Context, arg1 *api.User) (*api.UUID, error) {
 	m.ctrl.T.Helper()
@@ -56,6 +104,49 @@ func (mr *MockRepositoryMockRecorder) CreateUser(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockRepository)(nil).CreateUser), arg0, arg1)
 }
 
+// CreateWebhook mocks base method.
+func (m *MockRepository) CreateWebhook(arg0 context.Context, arg1 *api.Webhook) (*api.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", arg0, arg1)
+	ret0, _ := ret[0].(*api.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockRepositoryMockRecorder) CreateWebhook(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockRepository)(nil).CreateWebhook), arg0, arg1)
+}
+
+// DeleteGroup mocks base method.
+func (m *MockRepository) DeleteGroup(arg0 context.Context, arg1 api.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGroup", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGroup indicates an expected call of DeleteGroup.
+func (mr *MockRepositoryMockRecorder) DeleteGroup(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroup", reflect.TypeOf((*MockRepository)(nil).DeleteGroup), arg0, arg1)
+}
+
+// DeleteOrganization mocks base method.
+func (m *MockRepository) DeleteOrganization(arg0 context.Context, arg1 api.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrganization", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOrganization indicates an expected call of DeleteOrganization.
+func (mr *MockRepositoryMockRecorder) DeleteOrganization(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrganization", reflect.TypeOf((*MockRepository)(nil).DeleteOrganization), arg0, arg1)
+}
+
 // DeleteUser mocks base method.
 func (m *MockRepository) DeleteUser(arg0 context.Context, arg1 api.UUID) error {
 	m.ctrl.T.Helper()
@@ -70,6 +161,79 @@ func (mr *MockRepositoryMockRecorder) DeleteUser(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockRepository)(nil).DeleteUser), arg0, arg1)
 }
 
+// DeleteWebhook mocks base method.
+func (m *MockRepository) DeleteWebhook(arg0 context.Context, arg1 api.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockRepositoryMockRecorder) DeleteWebhook(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockRepository)(nil).DeleteWebhook), arg0, arg1)
+}
+
+// ExportUsers mocks base method.
+func (m *MockRepository) ExportUsers(arg0 context.Context, arg1 repository.UserFilter, arg2 api.OptUUID, arg3 func(api.User) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportUsers", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportUsers indicates an expected call of ExportUsers.
+func (mr *MockRepositoryMockRecorder) ExportUsers(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportUsers", reflect.TypeOf((*MockRepository)(nil).ExportUsers), arg0, arg1, arg2, arg3)
+}
+
+// GetGroupById mocks base method.
+func (m *MockRepository) GetGroupById(arg0 context.Context, arg1 api.UUID) (*api.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupById", arg0, arg1)
+	ret0, _ := ret[0].(*api.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupById indicates an expected call of GetGroupById.
+func (mr *MockRepositoryMockRecorder) GetGroupById(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupById", reflect.TypeOf((*MockRepository)(nil).GetGroupById), arg0, arg1)
+}
+
+// GetOrganizationById mocks base method.
+func (m *MockRepository) GetOrganizationById(arg0 context.Context, arg1 api.UUID) (*api.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganizationById", arg0, arg1)
+	ret0, _ := ret[0].(*api.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganizationById indicates an expected call of GetOrganizationById.
+func (mr *MockRepositoryMockRecorder) GetOrganizationById(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizationById", reflect.TypeOf((*MockRepository)(nil).GetOrganizationById), arg0, arg1)
+}
+
+// GetOrganizationByName mocks base method.
+func (m *MockRepository) GetOrganizationByName(arg0 context.Context, arg1 string) (*api.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganizationByName", arg0, arg1)
+	ret0, _ := ret[0].(*api.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganizationByName indicates an expected call of GetOrganizationByName.
+func (mr *MockRepositoryMockRecorder) GetOrganizationByName(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizationByName", reflect.TypeOf((*MockRepository)(nil).GetOrganizationByName), arg0, arg1)
+}
+
 // GetUserById mocks base method.
 func (m *MockRepository) GetUserById(arg0 context.Context, arg1 api.UUID) (*api.User, error) {
 	m.ctrl.T.Helper()
@@ -100,19 +264,212 @@ func (mr *MockRepositoryMockRecorder) GetUserByUsername(arg0, arg1 any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockRepository)(nil).GetUserByUsername), arg0, arg1)
 }
 
+// GetUserFieldsById mocks base method.
+func (m *MockRepository) GetUserFieldsById(arg0 context.Context, arg1 api.UUID, arg2 repository.UserFields) (*api.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserFieldsById", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*api.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserFieldsById indicates an expected call of GetUserFieldsById.
+func (mr *MockRepositoryMockRecorder) GetUserFieldsById(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserFieldsById", reflect.TypeOf((*MockRepository)(nil).GetUserFieldsById), arg0, arg1, arg2)
+}
+
+// GetUserGroups mocks base method.
+func (m *MockRepository) GetUserGroups(arg0 context.Context, arg1 api.UUID) ([]api.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserGroups", arg0, arg1)
+	ret0, _ := ret[0].([]api.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserGroups indicates an expected call of GetUserGroups.
+func (mr *MockRepositoryMockRecorder) GetUserGroups(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserGroups", reflect.TypeOf((*MockRepository)(nil).GetUserGroups), arg0, arg1)
+}
+
+// GetWebhookById mocks base method.
+func (m *MockRepository) GetWebhookById(arg0 context.Context, arg1 api.UUID) (*api.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookById", arg0, arg1)
+	ret0, _ := ret[0].(*api.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookById indicates an expected call of GetWebhookById.
+func (mr *MockRepositoryMockRecorder) GetWebhookById(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookById", reflect.TypeOf((*MockRepository)(nil).GetWebhookById), arg0, arg1)
+}
+
+// ImportUsers mocks base method.
+func (m *MockRepository) ImportUsers(arg0 context.Context, arg1 []api.User, arg2 api.ServiceImportUsersOnConflict) ([]api.ImportRowResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportUsers", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]api.ImportRowResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportUsers indicates an expected call of ImportUsers.
+func (mr *MockRepositoryMockRecorder) ImportUsers(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportUsers", reflect.TypeOf((*MockRepository)(nil).ImportUsers), arg0, arg1, arg2)
+}
+
+// ListGroupMembers mocks base method.
+func (m *MockRepository) ListGroupMembers(arg0 context.Context, arg1 api.UUID) ([]api.GroupMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGroupMembers", arg0, arg1)
+	ret0, _ := ret[0].([]api.GroupMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGroupMembers indicates an expected call of ListGroupMembers.
+func (mr *MockRepositoryMockRecorder) ListGroupMembers(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroupMembers", reflect.TypeOf((*MockRepository)(nil).ListGroupMembers), arg0, arg1)
+}
+
+// ListGroups mocks base method.
+func (m *MockRepository) ListGroups(arg0 context.Context, arg1 int64) ([]api.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGroups", arg0, arg1)
+	ret0, _ := ret[0].([]api.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGroups indicates an expected call of ListGroups.
+func (mr *MockRepositoryMockRecorder) ListGroups(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroups", reflect.TypeOf((*MockRepository)(nil).ListGroups), arg0, arg1)
+}
+
+// ListOrganizations mocks base method.
+func (m *MockRepository) ListOrganizations(arg0 context.Context, arg1 int64) ([]api.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrganizations", arg0, arg1)
+	ret0, _ := ret[0].([]api.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrganizations indicates an expected call of ListOrganizations.
+func (mr *MockRepositoryMockRecorder) ListOrganizations(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrganizations", reflect.TypeOf((*MockRepository)(nil).ListOrganizations), arg0, arg1)
+}
+
 // ListUsers mocks base method.
-func (m *MockRepository) ListUsers(arg0 context.Context, arg1 int64) ([]api.User, error) {
+func (m *MockRepository) ListUsers(arg0 context.Context, arg1 repository.UserFilter, arg2 int64) ([]api.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUsers", arg0, arg1)
+	ret := m.ctrl.Call(m, "ListUsers", arg0, arg1, arg2)
 	ret0, _ := ret[0].([]api.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListUsers indicates an expected call of ListUsers.
-func (mr *MockRepositoryMockRecorder) ListUsers(arg0, arg1 any) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) ListUsers(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockRepository)(nil).ListUsers), arg0, arg1, arg2)
+}
+
+// ListWebhookDeliveries mocks base method.
+func (m *MockRepository) ListWebhookDeliveries(arg0 context.Context, arg1 api.UUID, arg2 int64) ([]api.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookDeliveries", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]api.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookDeliveries indicates an expected call of ListWebhookDeliveries.
+func (mr *MockRepositoryMockRecorder) ListWebhookDeliveries(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookDeliveries", reflect.TypeOf((*MockRepository)(nil).ListWebhookDeliveries), arg0, arg1, arg2)
+}
+
+// ListWebhooks mocks base method.
+func (m *MockRepository) ListWebhooks(arg0 context.Context, arg1 int64) ([]api.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhooks", arg0, arg1)
+	ret0, _ := ret[0].([]api.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhooks indicates an expected call of ListWebhooks.
+func (mr *MockRepositoryMockRecorder) ListWebhooks(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhooks", reflect.TypeOf((*MockRepository)(nil).ListWebhooks), arg0, arg1)
+}
+
+// LockAdmins mocks base method.
+func (m *MockRepository) LockAdmins(arg0 context.Context) ([]api.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockAdmins", arg0)
+	ret0, _ := ret[0].([]api.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockAdmins indicates an expected call of LockAdmins.
+func (mr *MockRepositoryMockRecorder) LockAdmins(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockAdmins", reflect.TypeOf((*MockRepository)(nil).LockAdmins), arg0)
+}
+
+// RedeliverWebhookDelivery mocks base method.
+func (m *MockRepository) RedeliverWebhookDelivery(arg0 context.Context, arg1, arg2 api.UUID) (*api.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RedeliverWebhookDelivery", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*api.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RedeliverWebhookDelivery indicates an expected call of RedeliverWebhookDelivery.
+func (mr *MockRepositoryMockRecorder) RedeliverWebhookDelivery(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedeliverWebhookDelivery", reflect.TypeOf((*MockRepository)(nil).RedeliverWebhookDelivery), arg0, arg1, arg2)
+}
+
+// RemoveGroupMember mocks base method.
+func (m *MockRepository) RemoveGroupMember(arg0 context.Context, arg1, arg2 api.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveGroupMember", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveGroupMember indicates an expected call of RemoveGroupMember.
+func (mr *MockRepositoryMockRecorder) RemoveGroupMember(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveGroupMember", reflect.TypeOf((*MockRepository)(nil).RemoveGroupMember), arg0, arg1, arg2)
+}
+
+// UpdateGroup mocks base method.
+func (m *MockRepository) UpdateGroup(arg0 context.Context, arg1 *api.Group) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGroup", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGroup indicates an expected call of UpdateGroup.
+func (mr *MockRepositoryMockRecorder) UpdateGroup(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockRepository)(nil).ListUsers), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGroup", reflect.TypeOf((*MockRepository)(nil).UpdateGroup), arg0, arg1)
 }
 
 // UpdateUser mocks base method.
@@ -128,3 +485,89 @@ func (mr *MockRepositoryMockRecorder) UpdateUser(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockRepository)(nil).UpdateUser), arg0, arg1)
 }
+
+// UpdateWebhook mocks base method.
+func (m *MockRepository) UpdateWebhook(arg0 context.Context, arg1 *api.Webhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhook", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWebhook indicates an expected call of UpdateWebhook.
+func (mr *MockRepositoryMockRecorder) UpdateWebhook(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhook", reflect.TypeOf((*MockRepository)(nil).UpdateWebhook), arg0, arg1)
+}
+
+// WithTx mocks base method.
+func (m *MockRepository) WithTx(arg0 context.Context, arg1 func(service.Repository) error, arg2 ...repository.TxOption) error {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WithTx", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockRepositoryMockRecorder) WithTx(arg0, arg1 any, arg2 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockRepository)(nil).WithTx), varargs...)
+}
+
+// MockChangeStream is a mock of ChangeStream interface.
+type MockChangeStream struct {
+	ctrl     *gomock.Controller
+	recorder *MockChangeStreamMockRecorder
+	isgomock struct{}
+}
+
+// MockChangeStreamMockRecorder is the mock recorder for MockChangeStream.
+type MockChangeStreamMockRecorder struct {
+	mock *MockChangeStream
+}
+
+// NewMockChangeStream creates a new mock instance.
+func NewMockChangeStream(ctrl *gomock.Controller) *MockChangeStream {
+	mock := &MockChangeStream{ctrl: ctrl}
+	mock.recorder = &MockChangeStreamMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChangeStream) EXPECT() *MockChangeStreamMockRecorder {
+	return m.recorder
+}
+
+// Stream mocks base method.
+func (m *MockChangeStream) Stream(ctx context.Context, w io.Writer, sub *watch.Subscription, revokes func(repository.OutboxEvent) bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stream", ctx, w, sub, revokes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stream indicates an expected call of Stream.
+func (mr *MockChangeStreamMockRecorder) Stream(ctx, w, sub, revokes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stream", reflect.TypeOf((*MockChangeStream)(nil).Stream), ctx, w, sub, revokes)
+}
+
+// Subscribe mocks base method.
+func (m *MockChangeStream) Subscribe(ctx context.Context, tenantID api.UUID, lastEventID *int64) (*watch.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", ctx, tenantID, lastEventID)
+	ret0, _ := ret[0].(*watch.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockChangeStreamMockRecorder) Subscribe(ctx, tenantID, lastEventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockChangeStream)(nil).Subscribe), ctx, tenantID, lastEventID)
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/lib/tenant"
+	"github.com/liriquew/test_task/internal/repository"
+	"github.com/liriquew/test_task/pkg/logger/sl"
+)
+
+func (s *Service) OrganizationsListOrganizations(
+	ctx context.Context,
+	params domain.OrganizationsListOrganizationsParams,
+) (domain.OrganizationsListOrganizationsRes, error) {
+	orgs, err := s.repo.ListOrganizations(ctx, params.Offset.Value)
+	if err != nil {
+		s.log.Warn("error while getting organizations in ListOrganizations", sl.Err(err))
+		return &domain.InternalErrorResponse{}, nil
+	}
+
+	res := domain.OrganizationsListOrganizationsOKApplicationJSON(orgs)
+
+	return &res, nil
+}
+
+func (s *Service) OrganizationsCreateOrganization(
+	ctx context.Context,
+	org *domain.Organization,
+) (domain.OrganizationsCreateOrganizationRes, error) {
+	if org.Name.Value == "" {
+		return &domain.ValidationErrorResponse{
+			Message: badOrganizationName,
+		}, nil
+	}
+
+	// validate organization
+	if errResp := ValidateOrganization(org); errResp != nil {
+		return errResp, nil
+	}
+
+	uuid, err := s.repo.CreateOrganization(ctx, org)
+	if err != nil {
+		s.log.Warn("error while creating organization", sl.Err(err))
+		if errors.Is(err, repository.ErrOrganizationNameExists) {
+			return &domain.AlreadyExistsResponse{
+				Message: domain.AlreadyExistsResponseMessageAlreadyExistsOrganizationNameTaken,
+			}, nil
+		}
+
+		return &domain.InternalErrorResponse{
+			Message: domain.InternalErrorResponseMessage(
+				fmt.Sprintf("internal error while creating organization error: %s", err),
+			),
+		}, nil
+	}
+
+	org.ID.SetTo(*uuid)
+
+	return org, nil
+}
+
+func (s *Service) OrganizationsGetOrganization(
+	ctx context.Context,
+	params domain.OrganizationsGetOrganizationParams,
+) (domain.OrganizationsGetOrganizationRes, error) {
+	org, err := s.repo.GetOrganizationById(ctx, params.OrganizationId)
+	if err != nil {
+		s.log.Warn("error while getting organization by id", sl.Err(err))
+		if errors.Is(err, repository.ErrOrganizationNotFound) {
+			return &domain.NotFoundResponse{
+				Message: "organization not found",
+			}, nil
+		}
+
+		return &domain.InternalErrorResponse{
+			Message: domain.InternalErrorResponseMessage(
+				fmt.Sprintf("internal error: %s", err),
+			),
+		}, nil
+	}
+
+	return org, nil
+}
+
+func (s *Service) OrganizationsDeleteOrganization(
+	ctx context.Context,
+	params domain.OrganizationsDeleteOrganizationParams,
+) (domain.OrganizationsDeleteOrganizationRes, error) {
+	// super-admins belong to the default organization
+	if params.OrganizationId == tenant.Default {
+		return &domain.ValidationErrorResponse{
+			Message: domain.ValidationErrorMessageBadParams,
+		}, nil
+	}
+
+	err := s.repo.DeleteOrganization(ctx, params.OrganizationId)
+	if err != nil {
+		s.log.Warn("error while deleting organization", sl.Err(err))
+		return &domain.InternalErrorResponse{
+			Message: domain.InternalErrorResponseMessage(
+				fmt.Sprintf("internal error: %s", err),
+			),
+		}, nil
+	}
+
+	return &domain.OrganizationsDeleteOrganizationOK{}, nil
+}
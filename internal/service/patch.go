@@ -0,0 +1,468 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/repository"
+	"github.com/liriquew/test_task/pkg/logger/sl"
+)
+
+var (
+	// ErrInvalidPatch is returned for patches which can't be applied to the user
+	ErrInvalidPatch = errors.New("invalid patch")
+	// ErrPatchTest is returned when a test operation of JSON Patch fails
+	ErrPatchTest = errors.New("patch test failed")
+)
+
+// errInvalidUser aborts the transaction of a patch whose result isn't valid
+var errInvalidUser = errors.New("invalid user")
+
+// patchStoredUser applies patch to the stored user and updates the fields it changed.
+// The user is read and updated in a single transaction, so the user patch was applied to
+// is the one updated and test operations hold until the update
+func (s *Service) patchStoredUser(
+	ctx context.Context,
+	id domain.UUID,
+	patch func(current *domain.User) (*domain.User, error),
+) (domain.ServicePatchUserRes, error) {
+	var invalid *domain.ValidationErrorResponse
+
+	err := s.repo.WithTx(ctx, func(repo Repository) error {
+		invalid = nil
+
+		current, err := repo.GetUserById(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		patched, err := patch(current)
+		if err != nil {
+			return err
+		}
+		NormalizeUser(patched)
+
+		user, changed := changedFields(current, patched)
+		if !changed {
+			return nil
+		}
+		if invalid = ValidateUser(user); invalid != nil {
+			return errInvalidUser
+		}
+
+		hash, internalErr := hashPassword(user.Password.Value)
+		if internalErr != nil {
+			return errors.New(string(internalErr.Message))
+		}
+		user.Password.Value = hash
+
+		return s.withRepo(repo).updateUser(ctx, user)
+	}, repository.WithIsolation(sql.LevelRepeatableRead))
+	if invalid != nil {
+		return invalid, nil
+	}
+	if err != nil {
+		return s.patchUserError(err), nil
+	}
+
+	return &domain.ServicePatchUserOK{}, nil
+}
+
+// changedFields returns the user with the fields of patched which differ from current,
+// the password is changed whenever patched has one
+func changedFields(current, patched *domain.User) (*domain.User, bool) {
+	user := &domain.User{ID: current.ID}
+	if patched.Username.Value != current.Username.Value {
+		user.Username.SetTo(patched.Username.Value)
+	}
+	if patched.Email.Value != current.Email.Value {
+		user.Email.SetTo(patched.Email.Value)
+	}
+	if patched.IsAdmin.Value != current.IsAdmin.Value {
+		user.IsAdmin.SetTo(patched.IsAdmin.Value)
+	}
+	if patched.Password.IsSet() {
+		user.Password.SetTo(patched.Password.Value)
+	}
+
+	changed := user.Username.IsSet() || user.Email.IsSet() || user.IsAdmin.IsSet() || user.Password.IsSet()
+	return user, changed
+}
+
+// mergePatch applies JSON Merge Patch of RFC 7396 to the user, null clears the field
+func mergePatch(current *domain.User, patch *domain.UserMergePatch) *domain.User {
+	user := &domain.User{
+		ID:       current.ID,
+		Username: current.Username,
+		Email:    current.Email,
+		IsAdmin:  current.IsAdmin,
+	}
+
+	if patch.Username.IsSet() {
+		user.Username.SetTo(patch.Username.Value)
+	}
+	if patch.Password.IsSet() {
+		user.Password.SetTo(patch.Password.Value)
+	}
+	if patch.Email.IsSet() {
+		user.Email.SetTo(patch.Email.Value)
+	}
+	if patch.IsAdmin.IsSet() {
+		user.IsAdmin.SetTo(patch.IsAdmin.Value)
+	}
+
+	return user
+}
+
+// jsonPatch applies JSON Patch of RFC 6902 to the json document of the user. The document
+// has no password, it can be added or replaced. Removed fields are cleared,
+// id and must_change_password are read-only
+func jsonPatch(current *domain.User, ops []domain.JSONPatchOperation) (*domain.User, error) {
+	var doc any = map[string]any{
+		"id":                   uuid.UUID(current.ID.Value).String(),
+		"username":             current.Username.Value,
+		"email":                current.Email.Value,
+		"is_admin":             current.IsAdmin.Value,
+		"must_change_password": current.MustChangePassword.Value,
+	}
+	readOnly := map[string]any{
+		"id":                   doc.(map[string]any)["id"],
+		"must_change_password": current.MustChangePassword.Value,
+	}
+
+	for i, op := range ops {
+		var err error
+		if doc, err = applyOperation(doc, op); err != nil {
+			return nil, fmt.Errorf("operation %d: %w", i, err)
+		}
+	}
+
+	fields, ok := doc.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: user must be an object", ErrInvalidPatch)
+	}
+	for field, value := range readOnly {
+		if !reflect.DeepEqual(fields[field], value) {
+			return nil, fmt.Errorf("%w: %s is read-only", ErrInvalidPatch, field)
+		}
+	}
+
+	user := &domain.User{ID: current.ID}
+	for field, value := range fields {
+		var ok bool
+		switch field {
+		case "id", "must_change_password":
+			ok = true
+		case "username":
+			user.Username.Value, ok = value.(string)
+		case "email":
+			user.Email.Value, ok = value.(string)
+		case "password":
+			var password string
+			password, ok = value.(string)
+			user.Password.SetTo(password)
+		case "is_admin":
+			user.IsAdmin.Value, ok = value.(bool)
+		}
+		if !ok {
+			return nil, fmt.Errorf("%w: bad value of %q", ErrInvalidPatch, field)
+		}
+	}
+
+	return user, nil
+}
+
+// applyOperation applies the operation to the document and returns the new document
+func applyOperation(doc any, op domain.JSONPatchOperation) (any, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := operationValue(op)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case domain.JSONPatchOperationOpAdd:
+		return addValue(doc, path, value)
+	case domain.JSONPatchOperationOpRemove:
+		doc, _, err := removeValue(doc, path)
+		return doc, err
+	case domain.JSONPatchOperationOpReplace:
+		if _, err := getValue(doc, path); err != nil {
+			return nil, err
+		}
+		if len(path) == 0 {
+			return value, nil
+		}
+		doc, _, err := removeValue(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		return addValue(doc, path, value)
+	case domain.JSONPatchOperationOpMove, domain.JSONPatchOperationOpCopy:
+		from, err := parsePointer(op.From.Value)
+		if err != nil || !op.From.IsSet() {
+			return nil, fmt.Errorf("%w: bad from %q", ErrInvalidPatch, op.From.Value)
+		}
+
+		if op.Op == domain.JSONPatchOperationOpCopy {
+			value, err := getValue(doc, from)
+			if err != nil {
+				return nil, err
+			}
+			return addValue(doc, path, deepCopy(value))
+		}
+
+		if len(path) > len(from) && reflect.DeepEqual(path[:len(from)], from) {
+			return nil, fmt.Errorf("%w: %q is moved into itself", ErrInvalidPatch, op.From.Value)
+		}
+		doc, value, err := removeValue(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		return addValue(doc, path, value)
+	case domain.JSONPatchOperationOpTest:
+		current, err := getValue(doc, path)
+		if err != nil || !reflect.DeepEqual(current, value) {
+			return nil, fmt.Errorf("%w: %s", ErrPatchTest, op.Path)
+		}
+		return doc, nil
+	}
+
+	return nil, fmt.Errorf("%w: unknown operation %q", ErrInvalidPatch, op.Op)
+}
+
+// operationValue decodes the value of add, replace and test operations
+func operationValue(op domain.JSONPatchOperation) (any, error) {
+	switch op.Op {
+	case domain.JSONPatchOperationOpAdd, domain.JSONPatchOperationOpReplace, domain.JSONPatchOperationOpTest:
+	default:
+		return nil, nil
+	}
+
+	if len(op.Value) == 0 {
+		return nil, fmt.Errorf("%w: %s requires value", ErrInvalidPatch, op.Op)
+	}
+
+	var value any
+	if err := json.Unmarshal(op.Value, &value); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPatch, err)
+	}
+	return value, nil
+}
+
+// parsePointer parses JSON Pointer of RFC 6901 into reference tokens
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w: bad path %q", ErrInvalidPatch, pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+	return tokens, nil
+}
+
+// arrayIndex parses the index of the array element, "-" is the end of the array for add
+func arrayIndex(token string, length int, add bool) (int, error) {
+	if add && token == "-" {
+		return length, nil
+	}
+
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || (token != "0" && strings.HasPrefix(token, "0")) {
+		return 0, fmt.Errorf("%w: bad array index %q", ErrInvalidPatch, token)
+	}
+
+	limit := length
+	if add {
+		limit++
+	}
+	if i >= limit {
+		return 0, fmt.Errorf("%w: array index %d out of range", ErrInvalidPatch, i)
+	}
+	return i, nil
+}
+
+func getValue(doc any, path []string) (any, error) {
+	for _, token := range path {
+		switch container := doc.(type) {
+		case map[string]any:
+			value, ok := container[token]
+			if !ok {
+				return nil, fmt.Errorf("%w: %q not found", ErrInvalidPatch, token)
+			}
+			doc = value
+		case []any:
+			i, err := arrayIndex(token, len(container), false)
+			if err != nil {
+				return nil, err
+			}
+			doc = container[i]
+		default:
+			return nil, fmt.Errorf("%w: %q not found", ErrInvalidPatch, token)
+		}
+	}
+	return doc, nil
+}
+
+// updateParent replaces the parent of the last token of path with the result of update
+func updateParent(doc any, path []string, update func(parent any, token string) (any, error)) (any, error) {
+	if len(path) == 1 {
+		return update(doc, path[0])
+	}
+
+	child, err := getValue(doc, path[:1])
+	if err != nil {
+		return nil, err
+	}
+	child, err = updateParent(child, path[1:], update)
+	if err != nil {
+		return nil, err
+	}
+
+	switch container := doc.(type) {
+	case map[string]any:
+		container[path[0]] = child
+	case []any:
+		i, _ := arrayIndex(path[0], len(container), false)
+		container[i] = child
+	}
+	return doc, nil
+}
+
+func addValue(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	return updateParent(doc, path, func(parent any, token string) (any, error) {
+		switch container := parent.(type) {
+		case map[string]any:
+			container[token] = value
+			return container, nil
+		case []any:
+			i, err := arrayIndex(token, len(container), true)
+			if err != nil {
+				return nil, err
+			}
+			container = append(container[:i], append([]any{value}, container[i:]...)...)
+			return container, nil
+		}
+		return nil, fmt.Errorf("%w: %q not found", ErrInvalidPatch, token)
+	})
+}
+
+// removeValue removes the value of path from the document and returns the new document and the value
+func removeValue(doc any, path []string) (any, any, error) {
+	if len(path) == 0 {
+		return nil, nil, fmt.Errorf("%w: the user can't be removed", ErrInvalidPatch)
+	}
+
+	var removed any
+	doc, err := updateParent(doc, path, func(parent any, token string) (any, error) {
+		switch container := parent.(type) {
+		case map[string]any:
+			value, ok := container[token]
+			if !ok {
+				return nil, fmt.Errorf("%w: %q not found", ErrInvalidPatch, token)
+			}
+			removed = value
+			delete(container, token)
+			return container, nil
+		case []any:
+			i, err := arrayIndex(token, len(container), false)
+			if err != nil {
+				return nil, err
+			}
+			removed = container[i]
+			return append(container[:i:i], container[i+1:]...), nil
+		}
+		return nil, fmt.Errorf("%w: %q not found", ErrInvalidPatch, token)
+	})
+	return doc, removed, err
+}
+
+func deepCopy(value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		copied := make(map[string]any, len(value))
+		for k, v := range value {
+			copied[k] = deepCopy(v)
+		}
+		return copied
+	case []any:
+		copied := make([]any, len(value))
+		for i, v := range value {
+			copied[i] = deepCopy(v)
+		}
+		return copied
+	}
+	return value
+}
+
+// patchConflict returns the conflict of a patch, conflicts of patchUser
+// are discriminated by the message
+func patchConflict(message domain.AlreadyExistsResponseMessage) *domain.ServicePatchUserConflict {
+	return &domain.ServicePatchUserConflict{
+		Type:                  domain.ServicePatchUserConflictType(message),
+		AlreadyExistsResponse: domain.AlreadyExistsResponse{Message: message},
+	}
+}
+
+// patchUserError returns the response of the error of a user update
+func (s *Service) patchUserError(err error) domain.ServicePatchUserRes {
+	s.log.Warn("error while patching user PatchUser", sl.Err(err))
+
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		return &domain.NotFoundResponse{
+			Message: "user not found",
+		}
+	case errors.Is(err, ErrLastAdmin):
+		return &domain.ServicePatchUserConflict{
+			Type:              domain.LastAdminResponseServicePatchUserConflict,
+			LastAdminResponse: domain.LastAdminResponse{Message: lastAdmin},
+		}
+	case errors.Is(err, repository.ErrUsernameExists):
+		return patchConflict(domain.AlreadyExistsResponseMessageAlreadyExistsUsernameTaken)
+	case errors.Is(err, repository.ErrEmailExists):
+		return patchConflict(domain.AlreadyExistsResponseMessageAlreadyExistsEmailTaken)
+	case errors.Is(err, repository.ErrEmptyUpdate):
+		return &domain.ValidationErrorResponse{
+			Message: "nothing to update",
+		}
+	case errors.Is(err, ErrPatchTest):
+		return &domain.ServicePatchUserConflict{
+			Type: domain.PatchTestFailedResponseServicePatchUserConflict,
+			PatchTestFailedResponse: domain.PatchTestFailedResponse{
+				Message: domain.PatchTestFailedResponseMessageConflictPatchTestFailed,
+			},
+		}
+	case errors.Is(err, ErrInvalidPatch):
+		return &domain.ValidationErrorResponse{
+			Message: domain.ValidationErrorMessageInvalidPatch,
+		}
+	}
+
+	return &domain.InternalErrorResponse{
+		Message: domain.InternalErrorResponseMessage(
+			fmt.Sprintf("internal error: %s", err),
+		),
+	}
+}
@@ -0,0 +1,173 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-faster/jx"
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/repository"
+	"github.com/liriquew/test_task/internal/service"
+	"github.com/liriquew/test_task/internal/service/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPatchStoredUser(t *testing.T) {
+	id := domain.UUID{1}
+	stored := func() *domain.User {
+		return &domain.User{
+			ID:       domain.NewOptUUID(id),
+			Username: domain.NewOptString("username1"),
+			Email:    domain.NewOptString("valid@mail.ru"),
+			IsAdmin:  domain.NewOptBool(false),
+		}
+	}
+	op := func(op domain.JSONPatchOperationOp, path, value string) domain.JSONPatchOperation {
+		return domain.JSONPatchOperation{Op: op, Path: path, Value: jx.Raw(value)}
+	}
+
+	tests := []struct {
+		name   string
+		req    domain.ServicePatchUserReq
+		update *domain.User
+		res    domain.ServicePatchUserRes
+	}{
+		{
+			name: "Merge patch keeps absent fields",
+			req: &domain.UserMergePatch{
+				Email: domain.NewOptNilString("NEW@mail.ru"),
+			},
+			update: &domain.User{
+				ID:    domain.NewOptUUID(id),
+				Email: domain.NewOptString("new@mail.ru"),
+			},
+			res: &domain.ServicePatchUserOK{},
+		},
+		{
+			name: "Merge patch null clears the field",
+			req: &domain.UserMergePatch{
+				Email: domain.OptNilString{Set: true, Null: true},
+			},
+			res: &domain.ValidationErrorResponse{
+				Message: domain.ValidationErrorMessageInvalidEmail,
+			},
+		},
+		{
+			name: "Merge patch without changes",
+			req: &domain.UserMergePatch{
+				Username: domain.NewOptNilString("username1"),
+			},
+			res: &domain.ServicePatchUserOK{},
+		},
+		{
+			name: "JSON Patch copy without from",
+			req: &domain.ServicePatchUserReqApplicationJSONPatchJSON{
+				op(domain.JSONPatchOperationOpCopy, "/username", ``),
+			},
+			res: &domain.ValidationErrorResponse{
+				Message: domain.ValidationErrorMessageInvalidPatch,
+			},
+		},
+		{
+			name: "JSON Patch replace",
+			req: &domain.ServicePatchUserReqApplicationJSONPatchJSON{
+				op(domain.JSONPatchOperationOpTest, "/email", `"valid@mail.ru"`),
+				op(domain.JSONPatchOperationOpReplace, "/username", `"username2"`),
+				op(domain.JSONPatchOperationOpReplace, "/is_admin", `true`),
+			},
+			update: &domain.User{
+				ID:       domain.NewOptUUID(id),
+				Username: domain.NewOptString("username2"),
+				IsAdmin:  domain.NewOptBool(true),
+			},
+			res: &domain.ServicePatchUserOK{},
+		},
+		{
+			name: "JSON Patch failed test",
+			req: &domain.ServicePatchUserReqApplicationJSONPatchJSON{
+				op(domain.JSONPatchOperationOpTest, "/email", `"other@mail.ru"`),
+				op(domain.JSONPatchOperationOpReplace, "/username", `"username2"`),
+			},
+			res: &domain.ServicePatchUserConflict{
+				Type: domain.PatchTestFailedResponseServicePatchUserConflict,
+				PatchTestFailedResponse: domain.PatchTestFailedResponse{
+					Message: domain.PatchTestFailedResponseMessageConflictPatchTestFailed,
+				},
+			},
+		},
+		{
+			name: "JSON Patch removes the field",
+			req: &domain.ServicePatchUserReqApplicationJSONPatchJSON{
+				op(domain.JSONPatchOperationOpRemove, "/username", ``),
+			},
+			res: &domain.ValidationErrorResponse{
+				Message: domain.ValidationErrorMessageInvalidUsername,
+			},
+		},
+		{
+			name: "JSON Patch of read-only field",
+			req: &domain.ServicePatchUserReqApplicationJSONPatchJSON{
+				op(domain.JSONPatchOperationOpReplace, "/id", `"00000000-0000-0000-0000-000000000000"`),
+			},
+			res: &domain.ValidationErrorResponse{
+				Message: domain.ValidationErrorMessageInvalidPatch,
+			},
+		},
+		{
+			name: "JSON Patch of bad value",
+			req: &domain.ServicePatchUserReqApplicationJSONPatchJSON{
+				op(domain.JSONPatchOperationOpAdd, "/is_admin", `"true"`),
+			},
+			res: &domain.ValidationErrorResponse{
+				Message: domain.ValidationErrorMessageInvalidPatch,
+			},
+		},
+		{
+			name: "JSON Patch of missing path",
+			req: &domain.ServicePatchUserReqApplicationJSONPatchJSON{
+				op(domain.JSONPatchOperationOpReplace, "/name", `"username2"`),
+			},
+			res: &domain.ValidationErrorResponse{
+				Message: domain.ValidationErrorMessageInvalidPatch,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMockRepository(gomock.NewController(t))
+			ExpectTx(repo).AnyTimes()
+			repo.EXPECT().
+				GetUserById(gomock.Any(), id).
+				Return(stored(), nil)
+			if tt.update != nil {
+				repo.EXPECT().
+					UpdateUser(gomock.Any(), tt.update).
+					Return(nil)
+			}
+
+			s := service.New(StubLogger(), repo)
+			res, err := s.ServicePatchUser(context.Background(), tt.req, domain.ServicePatchUserParams{
+				UserId: id,
+			})
+			require.NoError(t, err)
+			require.Equal(t, tt.res, res)
+		})
+	}
+
+	t.Run("Not found", func(t *testing.T) {
+		repo := mocks.NewMockRepository(gomock.NewController(t))
+		ExpectTx(repo)
+		repo.EXPECT().
+			GetUserById(gomock.Any(), id).
+			Return(nil, repository.ErrNotFound)
+
+		s := service.New(StubLogger(), repo)
+		res, err := s.ServicePatchUser(context.Background(), &domain.UserMergePatch{}, domain.ServicePatchUserParams{
+			UserId: id,
+		})
+		require.NoError(t, err)
+		require.Equal(t, &domain.NotFoundResponse{Message: "user not found"}, res)
+	})
+}
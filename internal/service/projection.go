@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/go-faster/jx"
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/repository"
+	"github.com/ogen-go/ogen/ogenerrors"
+)
+
+// userFields returns the requested fields and the fields to select, expansions need the id
+// of the user, so it's selected even if it wasn't requested. Unknown fields or
+// expansions are a validation error
+func userFields(
+	fields []domain.UserField,
+	expand []domain.UserExpand,
+) (requested, selected repository.UserFields, errResp *domain.ValidationErrorResponse) {
+	for _, field := range fields {
+		if field.Validate() != nil {
+			return nil, nil, &domain.ValidationErrorResponse{
+				Message: domain.ValidationErrorMessageInvalidFields,
+			}
+		}
+		requested = append(requested, string(field))
+	}
+	for _, e := range expand {
+		if e.Validate() != nil {
+			return nil, nil, &domain.ValidationErrorResponse{
+				Message: domain.ValidationErrorMessageInvalidExpand,
+			}
+		}
+	}
+
+	selected = requested
+	if len(requested) > 0 && len(expand) > 0 && !slices.Contains(requested, "id") {
+		selected = append(slices.Clip(requested), "id")
+	}
+
+	return requested, selected, nil
+}
+
+// expandUser sets the related resources of the user and resets the fields which weren't requested
+func (s *Service) expandUser(
+	ctx context.Context,
+	user *domain.User,
+	requested repository.UserFields,
+	expand []domain.UserExpand,
+) error {
+	if slices.Contains(expand, domain.UserExpandGroups) {
+		groups, err := s.repo.GetUserGroups(ctx, user.ID.Value)
+		if err != nil {
+			return err
+		}
+		user.Groups = groups
+	}
+
+	requested.Project(user)
+	return nil
+}
+
+// projectionParams are the messages of invalid query parameters of the projection
+var projectionParams = map[string]domain.ValidationErrorMessage{
+	"fields": domain.ValidationErrorMessageInvalidFields,
+	"expand": domain.ValidationErrorMessageInvalidExpand,
+}
+
+// ProjectionErrorHandler writes unknown values of fields and expand, which are rejected
+// while the parameters are decoded, as the validation error of the operation,
+// other errors are handled by next
+func ProjectionErrorHandler(next ogenerrors.ErrorHandler) ogenerrors.ErrorHandler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request, err error) {
+		var param *ogenerrors.DecodeParamError
+		if !errors.As(err, &param) {
+			next(ctx, w, r, err)
+			return
+		}
+		message, ok := projectionParams[param.Name]
+		if !ok {
+			next(ctx, w, r, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+
+		e := jx.Encoder{}
+		res := domain.ValidationErrorResponse{Message: message}
+		res.Encode(&e)
+		w.Write(e.Bytes())
+	}
+}
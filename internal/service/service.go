@@ -2,33 +2,108 @@ package service
 
 import (
 	"context"
+	"io"
 	"log/slog"
 
 	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/repository"
+	"github.com/liriquew/test_task/internal/watch"
 )
 
 //go:generate mockgen -source=service.go -destination=mocks/repository.go -package=mocks
 type Repository interface {
-	ListUsers(context.Context, int64) ([]domain.User, error)
+	ListUsers(context.Context, repository.UserFilter, int64) ([]domain.User, error)
 
 	CreateUser(context.Context, *domain.User) (*domain.UUID, error)
 	GetUserById(context.Context, domain.UUID) (*domain.User, error)
+	GetUserFieldsById(context.Context, domain.UUID, repository.UserFields) (*domain.User, error)
 	UpdateUser(context.Context, *domain.User) error
 	DeleteUser(context.Context, domain.UUID) error
 
 	GetUserByUsername(context.Context, string) (*domain.User, error)
+
+	ImportUsers(context.Context, []domain.User, domain.ServiceImportUsersOnConflict) ([]domain.ImportRowResult, error)
+	ExportUsers(context.Context, repository.UserFilter, domain.OptUUID, func(domain.User) error) error
+
+	ListGroups(context.Context, int64) ([]domain.Group, error)
+
+	CreateGroup(context.Context, *domain.Group) (*domain.UUID, error)
+	GetGroupById(context.Context, domain.UUID) (*domain.Group, error)
+	UpdateGroup(context.Context, *domain.Group) error
+	DeleteGroup(context.Context, domain.UUID) error
+
+	ListGroupMembers(context.Context, domain.UUID) ([]domain.GroupMember, error)
+	AddGroupMember(context.Context, domain.UUID, *domain.GroupMember) error
+	RemoveGroupMember(context.Context, domain.UUID, domain.UUID) error
+
+	GetUserGroups(context.Context, domain.UUID) ([]domain.Group, error)
+	LockAdmins(context.Context) ([]domain.UUID, error)
+
+	ListOrganizations(context.Context, int64) ([]domain.Organization, error)
+
+	CreateOrganization(context.Context, *domain.Organization) (*domain.UUID, error)
+	GetOrganizationById(context.Context, domain.UUID) (*domain.Organization, error)
+	GetOrganizationByName(context.Context, string) (*domain.Organization, error)
+	DeleteOrganization(context.Context, domain.UUID) error
+
+	ListWebhooks(context.Context, int64) ([]domain.Webhook, error)
+
+	CreateWebhook(context.Context, *domain.Webhook) (*domain.UUID, error)
+	GetWebhookById(context.Context, domain.UUID) (*domain.Webhook, error)
+	UpdateWebhook(context.Context, *domain.Webhook) error
+	DeleteWebhook(context.Context, domain.UUID) error
+
+	ListWebhookDeliveries(context.Context, domain.UUID, int64) ([]domain.WebhookDelivery, error)
+	RedeliverWebhookDelivery(context.Context, domain.UUID, domain.UUID) (*domain.WebhookDelivery, error)
+
+	// WithTx runs fn with a repository bound to a single transaction,
+	// the transaction is committed when fn returns nil, fn is retried
+	// on serialization failure, so it must return repository errors as is
+	WithTx(context.Context, func(Repository) error, ...repository.TxOption) error
+}
+
+// ChangeStream streams changes of users to GET /users:watch
+type ChangeStream interface {
+	Subscribe(ctx context.Context, tenantID domain.UUID, lastEventID *int64) (*watch.Subscription, error)
+	Stream(ctx context.Context, w io.Writer, sub *watch.Subscription, revokes func(repository.OutboxEvent) bool) error
 }
 
 type Service struct {
 	repo Repository
 	log  *slog.Logger
+	// changes is nil if the change stream isn't available
+	changes ChangeStream
 }
 
-func New(log *slog.Logger, repo Repository) *Service {
-	return &Service{
+type Option func(*Service)
+
+// WithChanges makes GET /users:watch stream changes of users from changes
+func WithChanges(changes ChangeStream) Option {
+	return func(s *Service) {
+		s.changes = changes
+	}
+}
+
+func New(log *slog.Logger, repo Repository, opts ...Option) *Service {
+	s := &Service{
 		repo: repo,
 		log:  log,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// withRepo returns a copy of the service working with repo,
+// used to run handlers inside a transaction
+func (s *Service) withRepo(repo Repository) *Service {
+	return &Service{
+		repo:    repo,
+		log:     s.log,
+		changes: s.changes,
+	}
 }
 
 type UserServiceMiddleware struct {
@@ -1,8 +1,10 @@
 package service
 
 import (
+	"crypto/rand"
 	"encoding/base64"
 	"fmt"
+	"strings"
 
 	domain "github.com/liriquew/test_task/internal/domain"
 	"golang.org/x/crypto/bcrypt"
@@ -22,3 +24,29 @@ func hashPassword(password string) (string, *domain.InternalErrorResponse) {
 	}
 	return base64.StdEncoding.EncodeToString(passwordHash), nil
 }
+
+// checkPassword compares the password with the base64 encoded bcrypt hash
+func checkPassword(hash, password string) bool {
+	passwordHash, err := base64.StdEncoding.DecodeString(hash)
+	if err != nil {
+		return false
+	}
+
+	return bcrypt.CompareHashAndPassword(passwordHash, []byte(password)) == nil
+}
+
+// GeneratePassword returns a random password accepted by validatePassword
+func GeneratePassword() string {
+	for {
+		// base32 text has upper letters and digits only
+		password := rand.Text()[:10] + strings.ToLower(rand.Text()[:10])
+		if validatePassword(password) {
+			return password
+		}
+	}
+}
+
+// generateSecret returns a random key of webhook signatures
+func generateSecret() string {
+	return rand.Text() + rand.Text()
+}
@@ -1,18 +1,37 @@
 package service
 
 import (
+	"net/url"
 	"regexp"
 	"strings"
 
 	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/lib/netguard"
 )
 
 const (
 	badUsername = domain.ValidationErrorMessageInvalidUsername
 	badPassword = domain.ValidationErrorMessageInvalidPassword
 	badEmail    = domain.ValidationErrorMessageInvalidEmail
+
+	badGroupName = domain.ValidationErrorMessageInvalidGroupName
+	badMember    = domain.ValidationErrorMessageInvalidMember
+
+	badOrganizationName = domain.ValidationErrorMessageInvalidOrganizationName
+
+	badWebhookURL = domain.ValidationErrorMessageInvalidWebhookURL
 )
 
+// NormalizeUser brings user fields to the stored form,
+// emails are compared and stored in lower case
+func NormalizeUser(user *domain.User) {
+	if user.Email.IsSet() {
+		user.Email.Value = strings.ToLower(strings.TrimSpace(user.Email.Value))
+	}
+	// must_change_password is read only, it is managed by the service
+	user.MustChangePassword.Reset()
+}
+
 func ValidateUser(user *domain.User) *domain.ValidationErrorResponse {
 	if user.Username.IsSet() && !validateUsername(user.Username.Value) {
 		return &domain.ValidationErrorResponse{
@@ -35,6 +54,57 @@ func ValidateUser(user *domain.User) *domain.ValidationErrorResponse {
 	return nil
 }
 
+func ValidateGroup(group *domain.Group) *domain.ValidationErrorResponse {
+	if group.Name.IsSet() && !validateGroupName(group.Name.Value) {
+		return &domain.ValidationErrorResponse{
+			Message: badGroupName,
+		}
+	}
+
+	return nil
+}
+
+// ValidateGroupMember checks that exactly one of user_id, group_id is provided
+func ValidateGroupMember(member *domain.GroupMember) *domain.ValidationErrorResponse {
+	if member.UserID.IsSet() == member.GroupID.IsSet() {
+		return &domain.ValidationErrorResponse{
+			Message: badMember,
+		}
+	}
+
+	return nil
+}
+
+func ValidateOrganization(org *domain.Organization) *domain.ValidationErrorResponse {
+	// same rules as for group names
+	if org.Name.IsSet() && !validateGroupName(org.Name.Value) {
+		return &domain.ValidationErrorResponse{
+			Message: badOrganizationName,
+		}
+	}
+
+	return nil
+}
+
+// NormalizeWebhook drops read only and empty fields of the webhook,
+// an empty secret isn't stored, a new one is generated on creation
+func NormalizeWebhook(webhook *domain.Webhook) {
+	webhook.Failures.Reset()
+	if webhook.Secret.IsSet() && webhook.Secret.Value == "" {
+		webhook.Secret.Reset()
+	}
+}
+
+func ValidateWebhook(webhook *domain.Webhook) *domain.ValidationErrorResponse {
+	if webhook.URL.IsSet() && !validateWebhookURL(webhook.URL.Value) {
+		return &domain.ValidationErrorResponse{
+			Message: badWebhookURL,
+		}
+	}
+
+	return nil
+}
+
 var (
 	usernameRegexp = regexp.MustCompile(`^[a-zA-Z0-9]{4,}$`)
 	passwordRegexp = regexp.MustCompile(`^([a-z]|[A-Z]|[0-9]){5,}$`)
@@ -85,3 +155,32 @@ func validateEmail(email string) bool {
 	email = strings.ToLower(email)
 	return emailRegexp.MatchString(email)
 }
+
+func validateGroupName(name string) bool {
+	if len(name) < 3 || len(name) > 127 {
+		return false
+	}
+	for _, char := range name {
+		l := 'a' <= char && char <= 'z'
+		u := 'A' <= char && char <= 'Z'
+		d := '0' <= char && char <= '9'
+		s := char == '-' || char == '_'
+
+		if !l && !u && !d && !s {
+			return false
+		}
+	}
+
+	return true
+}
+
+// validateWebhookURL accepts absolute http and https URLs of hosts which may be public,
+// the worker checks resolved addresses once more
+func validateWebhookURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+
+	return (u.Scheme == "http" || u.Scheme == "https") && netguard.PublicHost(u.Hostname())
+}
@@ -123,3 +123,100 @@ func TestValidateEmail(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateGroupName(t *testing.T) {
+	tests := []struct {
+		name      string
+		groupName string
+		res       bool
+	}{
+		{
+			name:      "Valid name",
+			groupName: "backend_team-1",
+			res:       true,
+		},
+		{
+			name:      "Short name",
+			groupName: "ab",
+			res:       false,
+		},
+		{
+			name:      "Invalid character",
+			groupName: "backend team",
+			res:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := validateGroupName(tt.groupName)
+			require.Equal(t, tt.res, res)
+		})
+	}
+}
+
+func TestValidateWebhookURL(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		res  bool
+	}{
+		{
+			name: "Valid https url",
+			url:  "https://example.com/hooks?source=users",
+			res:  true,
+		},
+		{
+			name: "Valid http url with port",
+			url:  "http://example.com:9000/hooks",
+			res:  true,
+		},
+		{
+			name: "Localhost",
+			url:  "http://localhost:9000/hooks",
+			res:  false,
+		},
+		{
+			name: "Loopback address",
+			url:  "http://127.0.0.1/hooks",
+			res:  false,
+		},
+		{
+			name: "Private address",
+			url:  "https://10.0.0.1/hooks",
+			res:  false,
+		},
+		{
+			name: "Cloud metadata",
+			url:  "http://169.254.169.254/latest/meta-data",
+			res:  false,
+		},
+		{
+			name: "Private ipv6 address",
+			url:  "http://[fd00::1]/hooks",
+			res:  false,
+		},
+		{
+			name: "Relative url",
+			url:  "/hooks",
+			res:  false,
+		},
+		{
+			name: "Unsupported scheme",
+			url:  "ftp://example.com/hooks",
+			res:  false,
+		},
+		{
+			name: "Without host",
+			url:  "https:///hooks",
+			res:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := validateWebhookURL(tt.url)
+			require.Equal(t, tt.res, res)
+		})
+	}
+}
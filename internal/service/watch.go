@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/lib/tenant"
+	"github.com/liriquew/test_task/internal/repository"
+	"github.com/liriquew/test_task/internal/watch"
+	"github.com/liriquew/test_task/pkg/logger/sl"
+)
+
+const eventStream = "text/event-stream"
+
+var _ ChangeStream = (*watch.Hub)(nil)
+
+// ServiceWatchUsers streams changes of users of the organization as server-sent events.
+// Events are written into the pipe while the response is being sent, pipe reader
+// is closed by the response encoder, that stops the stream if the client has gone
+func (s *Service) ServiceWatchUsers(
+	ctx context.Context,
+	params domain.ServiceWatchUsersParams,
+) (domain.ServiceWatchUsersRes, error) {
+	var lastEventID *int64
+	if params.LastEventID.IsSet() {
+		id, err := strconv.ParseInt(strings.TrimSpace(params.LastEventID.Value), 10, 64)
+		if err != nil || id < 0 {
+			return &domain.ValidationErrorResponse{
+				Message: domain.ValidationErrorMessageBadParams,
+			}, nil
+		}
+		lastEventID = &id
+	}
+
+	if s.changes == nil {
+		return &domain.InternalErrorResponse{
+			Message: "internal error: change stream is not available",
+		}, nil
+	}
+
+	tenantID, ok := tenant.FromContext(ctx)
+	if !ok {
+		return &domain.InternalErrorResponse{
+			Message: domain.InternalErrorResponseMessage(
+				fmt.Sprintf("internal error: %s", tenant.ErrNoTenant),
+			),
+		}, nil
+	}
+
+	sub, err := s.changes.Subscribe(ctx, tenantID, lastEventID)
+	if err != nil {
+		s.log.Warn("error while subscribing to changes", sl.Err(err))
+		return &domain.InternalErrorResponse{
+			Message: domain.InternalErrorResponseMessage(
+				fmt.Sprintf("internal error: %s", err),
+			),
+		}, nil
+	}
+
+	principal, _ := ctx.Value(CurrentUser{}).(Principal)
+
+	pr, pw := io.Pipe()
+	go func() {
+		defer sub.Close()
+
+		err := s.changes.Stream(ctx, pw, sub, func(event repository.OutboxEvent) bool {
+			return revokesWatcher(principal, event)
+		})
+		if err != nil && !errors.Is(err, io.ErrClosedPipe) {
+			s.log.Warn("error while streaming changes", sl.Err(err))
+		}
+		pw.Close()
+	}()
+
+	return &domain.ServiceWatchUsersOK{Data: pr}, nil
+}
+
+// revokesWatcher reports whether the event ends the authorization of the watcher, credentials
+// are checked once per request, so the stream is closed when the watcher is deleted or its
+// password is changed, reconnection authenticates it again
+func revokesWatcher(watcher Principal, event repository.OutboxEvent) bool {
+	if domain.UUID(event.UserID) != watcher.ID {
+		return false
+	}
+
+	switch event.Type {
+	case repository.EventUserDeleted:
+		return true
+	case repository.EventUserUpdated:
+		var data repository.UserEventData
+		if err := json.Unmarshal(event.Payload, &data); err != nil {
+			return true
+		}
+		return slices.Contains(data.Changed, "password")
+	}
+
+	return false
+}
+
+// FlushEventStream flushes every write of server-sent events responses, so events
+// aren't held in the buffer of the response until it is full
+func FlushEventStream(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		next.ServeHTTP(&flushWriter{ResponseWriter: w, flusher: flusher}, r)
+	})
+}
+
+type flushWriter struct {
+	http.ResponseWriter
+	flusher http.Flusher
+	stream  bool
+}
+
+func (w *flushWriter) WriteHeader(status int) {
+	if w.Header().Get("Content-Type") == eventStream {
+		w.stream = true
+		w.Header().Set("Cache-Control", "no-cache")
+		// proxies buffer responses by default
+		w.Header().Set("X-Accel-Buffering", "no")
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *flushWriter) Write(p []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(p)
+	if err == nil && w.stream {
+		w.flusher.Flush()
+	}
+
+	return n, err
+}
+
+func (w *flushWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,80 @@
+package service_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/lib/config"
+	"github.com/liriquew/test_task/internal/lib/tenant"
+	"github.com/liriquew/test_task/internal/repository/memory"
+	"github.com/liriquew/test_task/internal/service"
+	"github.com/liriquew/test_task/internal/service/mocks"
+	"github.com/liriquew/test_task/internal/watch"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWatchUsers(t *testing.T) {
+	t.Run("Bad Last-Event-ID", func(t *testing.T) {
+		s := service.New(StubLogger(), mocks.NewMockRepository(gomock.NewController(t)))
+
+		res, err := s.ServiceWatchUsers(context.Background(), domain.ServiceWatchUsersParams{
+			LastEventID: domain.NewOptString("last"),
+		})
+		require.NoError(t, err)
+		require.Equal(t, &domain.ValidationErrorResponse{
+			Message: domain.ValidationErrorMessageBadParams,
+		}, res)
+	})
+
+	t.Run("Revoked", func(t *testing.T) {
+		ctx := tenant.WithTenant(context.Background(), tenant.Default)
+		repo := memory.New()
+
+		hub := watch.NewHub(StubLogger(), repo, config.WatchConfig{Interval: 10 * time.Millisecond})
+		hubCtx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go hub.Run(hubCtx)
+
+		watcher, err := repo.CreateUser(ctx, &domain.User{
+			Username: domain.NewOptString("username1"),
+			Password: domain.NewOptString("password"),
+			Email:    domain.NewOptString("username1@mail.ru"),
+		})
+		require.NoError(t, err)
+		other, err := repo.CreateUser(ctx, &domain.User{
+			Username: domain.NewOptString("username2"),
+			Password: domain.NewOptString("password"),
+			Email:    domain.NewOptString("username2@mail.ru"),
+		})
+		require.NoError(t, err)
+
+		s := service.New(StubLogger(), repo, service.WithChanges(hub))
+		res, err := s.ServiceWatchUsers(context.WithValue(ctx, service.CurrentUser{}, service.Principal{
+			ID:       *watcher,
+			TenantID: tenant.Default,
+		}), domain.ServiceWatchUsersParams{})
+		require.NoError(t, err)
+
+		stream, ok := res.(*domain.ServiceWatchUsersOK)
+		require.True(t, ok)
+
+		// a change of the password of another user doesn't end the stream
+		require.NoError(t, repo.UpdateUser(ctx, &domain.User{
+			ID:       domain.NewOptUUID(*other),
+			Password: domain.NewOptString("password"),
+		}))
+		require.NoError(t, repo.UpdateUser(ctx, &domain.User{
+			ID:       domain.NewOptUUID(*watcher),
+			Password: domain.NewOptString("password"),
+		}))
+
+		body, err := io.ReadAll(stream)
+		require.NoError(t, err)
+		require.Equal(t, 2, strings.Count(string(body), "event: user.updated\n"))
+	})
+}
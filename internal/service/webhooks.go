@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/repository"
+	"github.com/liriquew/test_task/pkg/logger/sl"
+)
+
+func (s *Service) WebhooksListWebhooks(
+	ctx context.Context,
+	params domain.WebhooksListWebhooksParams,
+) (domain.WebhooksListWebhooksRes, error) {
+	webhooks, err := s.repo.ListWebhooks(ctx, params.Offset.Value)
+	if err != nil {
+		s.log.Warn("error while getting webhooks in ListWebhooks", sl.Err(err))
+		return &domain.InternalErrorResponse{}, nil
+	}
+
+	res := domain.WebhooksListWebhooksOKApplicationJSON(webhooks)
+
+	return &res, nil
+}
+
+// WebhooksCreateWebhook registers the webhook, the secret is returned only in this response
+func (s *Service) WebhooksCreateWebhook(
+	ctx context.Context,
+	webhook *domain.Webhook,
+) (domain.WebhooksCreateWebhookRes, error) {
+	NormalizeWebhook(webhook)
+
+	if webhook.URL.Value == "" {
+		return &domain.ValidationErrorResponse{
+			Message: badWebhookURL,
+		}, nil
+	}
+
+	// validate webhook
+	if errResp := ValidateWebhook(webhook); errResp != nil {
+		return errResp, nil
+	}
+
+	if !webhook.Secret.IsSet() {
+		webhook.Secret.SetTo(generateSecret())
+	}
+	if webhook.Events == nil {
+		webhook.Events = []domain.WebhookEvent{}
+	}
+	webhook.Enabled.SetTo(webhook.Enabled.Or(true))
+
+	uuid, err := s.repo.CreateWebhook(ctx, webhook)
+	if err != nil {
+		s.log.Warn("error while creating webhook", sl.Err(err))
+		return &domain.InternalErrorResponse{
+			Message: domain.InternalErrorResponseMessage(
+				fmt.Sprintf("internal error while creating webhook error: %s", err),
+			),
+		}, nil
+	}
+
+	webhook.ID.SetTo(*uuid)
+	webhook.Failures.SetTo(0)
+
+	return webhook, nil
+}
+
+func (s *Service) WebhooksGetWebhook(
+	ctx context.Context,
+	params domain.WebhooksGetWebhookParams,
+) (domain.WebhooksGetWebhookRes, error) {
+	webhook, err := s.repo.GetWebhookById(ctx, params.WebhookId)
+	if err != nil {
+		s.log.Warn("error while getting webhook by id", sl.Err(err))
+		if errors.Is(err, repository.ErrWebhookNotFound) {
+			return &domain.NotFoundResponse{
+				Message: "webhook not found",
+			}, nil
+		}
+
+		return &domain.InternalErrorResponse{
+			Message: domain.InternalErrorResponseMessage(
+				fmt.Sprintf("internal error: %s", err),
+			),
+		}, nil
+	}
+
+	return webhook, nil
+}
+
+func (s *Service) WebhooksPatchWebhook(
+	ctx context.Context,
+	webhook *domain.Webhook,
+	params domain.WebhooksPatchWebhookParams,
+) (domain.WebhooksPatchWebhookRes, error) {
+	webhook.ID.SetTo(params.WebhookId)
+	NormalizeWebhook(webhook)
+
+	// validate webhook
+	if errResp := ValidateWebhook(webhook); errResp != nil {
+		return errResp, nil
+	}
+
+	if err := s.repo.UpdateWebhook(ctx, webhook); err != nil {
+		s.log.Warn("error while patching webhook", sl.Err(err))
+		if errors.Is(err, repository.ErrWebhookNotFound) {
+			return &domain.NotFoundResponse{
+				Message: "webhook not found",
+			}, nil
+		}
+		if errors.Is(err, repository.ErrEmptyUpdate) {
+			return &domain.ValidationErrorResponse{
+				Message: "nothing to update",
+			}, nil
+		}
+
+		return &domain.InternalErrorResponse{
+			Message: domain.InternalErrorResponseMessage(
+				fmt.Sprintf("internal error: %s", err),
+			),
+		}, nil
+	}
+
+	return &domain.WebhooksPatchWebhookOK{}, nil
+}
+
+func (s *Service) WebhooksDeleteWebhook(
+	ctx context.Context,
+	params domain.WebhooksDeleteWebhookParams,
+) (domain.WebhooksDeleteWebhookRes, error) {
+	err := s.repo.DeleteWebhook(ctx, params.WebhookId)
+	if err != nil {
+		s.log.Warn("error while deleting webhook", sl.Err(err))
+		return &domain.InternalErrorResponse{
+			Message: domain.InternalErrorResponseMessage(
+				fmt.Sprintf("internal error: %s", err),
+			),
+		}, nil
+	}
+
+	return &domain.WebhooksDeleteWebhookOK{}, nil
+}
+
+func (s *Service) WebhooksListWebhookDeliveries(
+	ctx context.Context,
+	params domain.WebhooksListWebhookDeliveriesParams,
+) (domain.WebhooksListWebhookDeliveriesRes, error) {
+	// distinguish a webhook without deliveries from a missing one
+	if _, err := s.repo.GetWebhookById(ctx, params.WebhookId); err != nil {
+		s.log.Warn("error while getting webhook by id in ListWebhookDeliveries", sl.Err(err))
+		if errors.Is(err, repository.ErrWebhookNotFound) {
+			return &domain.NotFoundResponse{
+				Message: "webhook not found",
+			}, nil
+		}
+
+		return &domain.InternalErrorResponse{
+			Message: domain.InternalErrorResponseMessage(
+				fmt.Sprintf("internal error: %s", err),
+			),
+		}, nil
+	}
+
+	deliveries, err := s.repo.ListWebhookDeliveries(ctx, params.WebhookId, params.Offset.Value)
+	if err != nil {
+		s.log.Warn("error while getting webhook deliveries", sl.Err(err))
+		return &domain.InternalErrorResponse{}, nil
+	}
+
+	res := domain.WebhooksListWebhookDeliveriesOKApplicationJSON(deliveries)
+
+	return &res, nil
+}
+
+func (s *Service) WebhooksRedeliverWebhookDelivery(
+	ctx context.Context,
+	params domain.WebhooksRedeliverWebhookDeliveryParams,
+) (domain.WebhooksRedeliverWebhookDeliveryRes, error) {
+	delivery, err := s.repo.RedeliverWebhookDelivery(ctx, params.WebhookId, params.DeliveryId)
+	if err != nil {
+		s.log.Warn("error while redelivering webhook delivery", sl.Err(err))
+		if errors.Is(err, repository.ErrDeliveryNotFound) {
+			return &domain.NotFoundResponse{
+				Message: "delivery not found",
+			}, nil
+		}
+
+		return &domain.InternalErrorResponse{
+			Message: domain.InternalErrorResponseMessage(
+				fmt.Sprintf("internal error: %s", err),
+			),
+		}, nil
+	}
+
+	return delivery, nil
+}
@@ -0,0 +1,123 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/repository"
+	"github.com/liriquew/test_task/internal/service"
+	"github.com/liriquew/test_task/internal/service/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateWebhook(t *testing.T) {
+	type deps struct {
+		repo *mocks.MockRepository
+	}
+
+	type test struct {
+		name    string
+		setup   func(d deps, t *test)
+		webhook domain.Webhook
+		res     domain.WebhooksCreateWebhookRes
+	}
+
+	tests := []test{
+		{
+			name: "All valid",
+			setup: func(d deps, t *test) {
+				d.repo.EXPECT().
+					CreateWebhook(gomock.Any(), &t.webhook).
+					Return(&domain.UUID{}, nil)
+			},
+			webhook: domain.Webhook{
+				URL:      domain.NewOptString("https://example.com/hooks"),
+				Events:   []domain.WebhookEvent{domain.WebhookEventUserDeleted},
+				Secret:   domain.NewOptString("secret"),
+				Failures: domain.NewOptInt32(10),
+			},
+			res: &domain.Webhook{
+				ID:       domain.NewOptUUID(domain.UUID{}),
+				URL:      domain.NewOptString("https://example.com/hooks"),
+				Events:   []domain.WebhookEvent{domain.WebhookEventUserDeleted},
+				Secret:   domain.NewOptString("secret"),
+				Enabled:  domain.NewOptBool(true),
+				Failures: domain.NewOptInt32(0),
+			},
+		},
+		{
+			name:    "Empty url",
+			setup:   nil,
+			webhook: domain.Webhook{},
+			res: &domain.ValidationErrorResponse{
+				Message: domain.ValidationErrorMessageInvalidWebhookURL,
+			},
+		},
+		{
+			name:  "Invalid url",
+			setup: nil,
+			webhook: domain.Webhook{
+				URL: domain.NewOptString("example.com/hooks"),
+			},
+			res: &domain.ValidationErrorResponse{
+				Message: domain.ValidationErrorMessageInvalidWebhookURL,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			d := deps{
+				repo: mocks.NewMockRepository(ctrl),
+			}
+			if tt.setup != nil {
+				tt.setup(d, &tt)
+			}
+			s := service.New(StubLogger(), d.repo)
+
+			res, err := s.WebhooksCreateWebhook(context.Background(), &tt.webhook)
+			require.NoError(t, err)
+			require.Equal(t, tt.res, res)
+		})
+	}
+}
+
+func TestListWebhookDeliveries(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Not found", func(t *testing.T) {
+		t.Parallel()
+		repo := mocks.NewMockRepository(gomock.NewController(t))
+		repo.EXPECT().
+			GetWebhookById(gomock.Any(), domain.UUID{1}).
+			Return(nil, repository.ErrWebhookNotFound)
+		s := service.New(StubLogger(), repo)
+
+		res, err := s.WebhooksListWebhookDeliveries(context.Background(), domain.WebhooksListWebhookDeliveriesParams{
+			WebhookId: domain.UUID{1},
+		})
+		require.NoError(t, err)
+		require.Equal(t, &domain.NotFoundResponse{Message: "webhook not found"}, res)
+	})
+
+	t.Run("Redeliver not found", func(t *testing.T) {
+		t.Parallel()
+		repo := mocks.NewMockRepository(gomock.NewController(t))
+		repo.EXPECT().
+			RedeliverWebhookDelivery(gomock.Any(), domain.UUID{1}, domain.UUID{2}).
+			Return(nil, repository.ErrDeliveryNotFound)
+		s := service.New(StubLogger(), repo)
+
+		res, err := s.WebhooksRedeliverWebhookDelivery(context.Background(), domain.WebhooksRedeliverWebhookDeliveryParams{
+			WebhookId:  domain.UUID{1},
+			DeliveryId: domain.UUID{2},
+		})
+		require.NoError(t, err)
+		require.Equal(t, &domain.NotFoundResponse{Message: "delivery not found"}, res)
+	})
+}
@@ -0,0 +1,89 @@
+package watch
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/liriquew/test_task/internal/repository"
+)
+
+// EventReset tells the watcher that events after its last event were pruned from the log,
+// the list of users must be reloaded
+const EventReset = "reset"
+
+// delay of reconnection of the watcher, sent in the first message of the stream
+const retryDelay = time.Second
+
+// Stream writes events of the subscription to w as server-sent events until ctx is done
+// or the subscription ends, comments are written every heartbeat in between. The stream
+// also ends after a new event revokes returns true for, revokes authorizes the watcher
+// to receive events after it. Events of the log were committed before the subscription,
+// they don't revoke it. Every message is a single write to w
+func (h *Hub) Stream(
+	ctx context.Context,
+	w io.Writer,
+	sub *Subscription,
+	revokes func(repository.OutboxEvent) bool,
+) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "retry: %d\n", retryDelay.Milliseconds())
+	switch {
+	case sub.reset:
+		fmt.Fprintf(bw, "id: %d\nevent: %s\ndata: {}\n", sub.head, EventReset)
+	case !sub.resumed:
+		// a message without data sets the id the watcher resumes from
+		fmt.Fprintf(bw, "id: %d\n", sub.head)
+	}
+	bw.WriteByte('\n')
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+
+	for _, event := range sub.backlog {
+		if err := writeEvent(bw, event); err != nil {
+			return err
+		}
+	}
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-sub.events:
+			if !ok {
+				return sub.err
+			}
+			if err := writeEvent(bw, event); err != nil {
+				return err
+			}
+			if revokes(event) {
+				return nil
+			}
+		case <-heartbeat.C:
+			bw.WriteString(": heartbeat\n\n")
+			if err := bw.Flush(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// writeEvent writes the event named by its type with the event as data
+func writeEvent(bw *bufio.Writer, event repository.OutboxEvent) error {
+	// json has no line breaks, the payload is compacted
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(bw, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+	return bw.Flush()
+}
@@ -0,0 +1,373 @@
+// Package watch streams changes of users to watchers of GET /users:watch. The repository
+// writes events to the bounded log of changes in the transaction of the change, the hub
+// reads new events of the log when postgres notifies it of a commit, or every interval,
+// and broadcasts them to subscriptions of their organization. A subscription resumes
+// from the log by the id of the last received event
+package watch
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/lib/config"
+	"github.com/liriquew/test_task/internal/repository"
+	"github.com/liriquew/test_task/pkg/logger/sl"
+)
+
+// Store is the log of changes of the repository
+type Store interface {
+	ListChanges(ctx context.Context, after int64, limit int) ([]repository.OutboxEvent, error)
+	LastChangeID(ctx context.Context) (int64, error)
+	PruneChanges(ctx context.Context, keep int) (int64, error)
+}
+
+// Notifier is a store that notifies of committed changes, stores without it are polled
+type Notifier interface {
+	ListenChanges(ctx context.Context, notify func()) error
+}
+
+var (
+	ErrClosed = errors.New("change stream is closed")
+	// ErrLagging closes a subscription that doesn't receive events as fast as they come
+	ErrLagging = errors.New("watcher fell behind the change stream")
+)
+
+const (
+	// events read from the log at once
+	batchSize = 500
+	// how often the log is pruned
+	pruneInterval = time.Minute
+)
+
+// Hub broadcasts events of the log of changes to subscriptions
+type Hub struct {
+	log   *slog.Logger
+	store Store
+
+	interval  time.Duration
+	heartbeat time.Duration
+	logSize   int
+	buffer    int
+
+	// wake makes Run read the log, it is sent by notifications
+	wake chan struct{}
+	// ready is closed when the id of the latest event is known
+	ready chan struct{}
+
+	mu sync.Mutex
+	// last is the id of the latest broadcast event
+	last   int64
+	subs   map[*Subscription]struct{}
+	closed bool
+	// listeners get every broadcast event, see OnChange
+	listeners []func(repository.OutboxEvent)
+}
+
+func NewHub(log *slog.Logger, store Store, cfg config.WatchConfig) *Hub {
+	h := &Hub{
+		log:       log.With(slog.String("component", "watch")),
+		store:     store,
+		interval:  cfg.Interval,
+		heartbeat: cfg.Heartbeat,
+		logSize:   cfg.LogSize,
+		buffer:    cfg.Buffer,
+		wake:      make(chan struct{}, 1),
+		ready:     make(chan struct{}),
+		subs:      map[*Subscription]struct{}{},
+	}
+	if h.interval <= 0 {
+		h.interval = time.Second
+	}
+	if h.heartbeat <= 0 {
+		h.heartbeat = 15 * time.Second
+	}
+	if h.logSize <= 0 {
+		h.logSize = 10000
+	}
+	if h.buffer <= 0 {
+		h.buffer = 256
+	}
+
+	return h
+}
+
+// Run broadcasts new events of the log until ctx is done, then closes the hub.
+// The log is read on every notification of the store and every interval
+func (h *Hub) Run(ctx context.Context) {
+	defer h.Close()
+
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	// events before the start of the hub are read by resumed subscriptions only
+	for {
+		last, err := h.store.LastChangeID(ctx)
+		if err == nil {
+			h.mu.Lock()
+			h.last = last
+			h.mu.Unlock()
+			close(h.ready)
+			break
+		}
+		if ctx.Err() == nil {
+			h.log.Warn("error while getting the latest change", sl.Err(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	if notifier, ok := h.store.(Notifier); ok {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			h.listen(ctx, notifier)
+		}()
+	}
+
+	prune := time.NewTicker(pruneInterval)
+	defer prune.Stop()
+
+	for {
+		if err := h.poll(ctx); err != nil && ctx.Err() == nil {
+			h.log.Warn("error while reading changes", sl.Err(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-h.wake:
+		case <-ticker.C:
+		case <-prune.C:
+			if _, err := h.store.PruneChanges(ctx, h.logSize); err != nil && ctx.Err() == nil {
+				h.log.Warn("error while pruning changes", sl.Err(err))
+			}
+		}
+	}
+}
+
+// listen wakes Run on notifications until ctx is done, a failed listener is restarted
+func (h *Hub) listen(ctx context.Context, notifier Notifier) {
+	notify := func() {
+		select {
+		case h.wake <- struct{}{}:
+		default:
+		}
+	}
+
+	for {
+		err := notifier.ListenChanges(ctx, notify)
+		if ctx.Err() != nil {
+			return
+		}
+		h.log.Warn("error while listening to changes", sl.Err(err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(h.interval):
+		}
+	}
+}
+
+// poll broadcasts events of the log after the last broadcast one
+func (h *Hub) poll(ctx context.Context) error {
+	for {
+		h.mu.Lock()
+		after := h.last
+		h.mu.Unlock()
+
+		events, err := h.store.ListChanges(ctx, after, batchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			h.broadcast(event)
+		}
+		if len(events) < batchSize {
+			return nil
+		}
+	}
+}
+
+func (h *Hub) broadcast(event repository.OutboxEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.last = event.ID
+	for _, fn := range h.listeners {
+		fn(event)
+	}
+	for sub := range h.subs {
+		if sub.tenantID != event.TenantID || event.ID <= sub.after {
+			continue
+		}
+
+		select {
+		case sub.events <- event:
+		default:
+			h.remove(sub, ErrLagging)
+		}
+	}
+}
+
+// OnChange calls fn with every event the hub reads from the log, events of all
+// organizations, including the ones of other instances of the service. fn is called
+// under the lock of the hub, it must not block
+func (h *Hub) OnChange(fn func(repository.OutboxEvent)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.listeners = append(h.listeners, fn)
+}
+
+// remove closes events of the subscription, h.mu must be held
+func (h *Hub) remove(sub *Subscription, err error) {
+	if _, ok := h.subs[sub]; !ok {
+		return
+	}
+
+	delete(h.subs, sub)
+	sub.err = err
+	close(sub.events)
+}
+
+// Close ends all subscriptions and refuses new ones, it is called on shutdown
+// of the server, so streams don't keep it waiting
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for sub := range h.subs {
+		h.remove(sub, nil)
+	}
+}
+
+// Subscription receives events of an organization
+type Subscription struct {
+	hub      *Hub
+	tenantID uuid.UUID
+	// after is the id of the last event the watcher received,
+	// broadcast events up to it are skipped
+	after int64
+
+	// head is the id of the latest event when the subscription was made,
+	// events after it are broadcast
+	head int64
+	// resumed is set if the subscription continues from an event of the log
+	resumed bool
+	// reset is set if events after the last received one are no longer in the log
+	reset   bool
+	backlog []repository.OutboxEvent
+
+	events chan repository.OutboxEvent
+	// err is the reason events were closed, nil on close of the hub
+	err error
+}
+
+// Subscribe returns the subscription to events of the organization. A subscription with
+// lastEventID receives events after it from the log first, if the event is no longer in
+// the log the subscription is reset and receives new events only
+func (h *Hub) Subscribe(ctx context.Context, tenantID domain.UUID, lastEventID *int64) (*Subscription, error) {
+	select {
+	case <-h.ready:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	sub := &Subscription{
+		hub:      h,
+		tenantID: uuid.UUID(tenantID),
+		events:   make(chan repository.OutboxEvent, h.buffer),
+	}
+
+	// the hub may not have read the latest events yet, events up to head
+	// were committed before the subscription
+	head, err := h.store.LastChangeID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		return nil, ErrClosed
+	}
+	sub.head = max(head, h.last)
+	sub.after = sub.head
+	if lastEventID != nil {
+		sub.resumed = true
+		// the hub may be behind the instance the watcher received the event from
+		sub.after = max(sub.after, *lastEventID)
+	}
+	h.subs[sub] = struct{}{}
+	h.mu.Unlock()
+
+	// events after head are broadcast
+	if lastEventID == nil || *lastEventID >= sub.head {
+		return sub, nil
+	}
+
+	backlog, err := h.backlog(ctx, sub.tenantID, *lastEventID, sub.head)
+	if err != nil {
+		sub.Close()
+		return nil, err
+	}
+	sub.backlog = backlog
+	sub.reset = backlog == nil
+
+	return sub, nil
+}
+
+// backlog returns events of the organization after lastEventID up to head from the log,
+// nil if lastEventID is no longer in the log
+func (h *Hub) backlog(ctx context.Context, tenantID uuid.UUID, lastEventID, head int64) ([]repository.OutboxEvent, error) {
+	// the last received event itself is read to check it is still in the log
+	events, err := h.store.ListChanges(ctx, lastEventID-1, batchSize)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 || events[0].ID != lastEventID {
+		return nil, nil
+	}
+
+	backlog := []repository.OutboxEvent{}
+	for {
+		for _, event := range events {
+			if event.ID > head {
+				return backlog, nil
+			}
+			if event.ID > lastEventID && event.TenantID == tenantID {
+				backlog = append(backlog, event)
+			}
+		}
+		if len(events) < batchSize {
+			return backlog, nil
+		}
+
+		events, err = h.store.ListChanges(ctx, events[len(events)-1].ID, batchSize)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Close stops the subscription
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	s.hub.remove(s, nil)
+}
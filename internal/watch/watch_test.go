@@ -0,0 +1,273 @@
+package watch_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/lib/config"
+	"github.com/liriquew/test_task/internal/lib/tenant"
+	"github.com/liriquew/test_task/internal/repository"
+	"github.com/liriquew/test_task/internal/repository/memory"
+	"github.com/liriquew/test_task/internal/watch"
+	"github.com/stretchr/testify/require"
+)
+
+func stubLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func createUser(t *testing.T, ctx context.Context, repo *memory.Repository, username string) domain.UUID {
+	t.Helper()
+
+	id, err := repo.CreateUser(ctx, &domain.User{
+		Username: domain.NewOptString(username),
+		Password: domain.NewOptString("password"),
+		Email:    domain.NewOptString(username + "@mail.ru"),
+	})
+	require.NoError(t, err)
+
+	return *id
+}
+
+func startHub(t *testing.T, repo *memory.Repository, cfg config.WatchConfig) *watch.Hub {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	hub := watch.NewHub(stubLogger(), repo, cfg)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		hub.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	return hub
+}
+
+// stream streams the subscription into the returned reader of messages
+func stream(t *testing.T, hub *watch.Hub, sub *watch.Subscription, revokes func(repository.OutboxEvent) bool) (*bufio.Scanner, <-chan error) {
+	t.Helper()
+
+	pr, pw := io.Pipe()
+	errs := make(chan error, 1)
+	go func() {
+		err := hub.Stream(context.Background(), pw, sub, revokes)
+		pw.Close()
+		errs <- err
+	}()
+	t.Cleanup(func() {
+		pr.Close()
+		sub.Close()
+	})
+
+	scanner := bufio.NewScanner(pr)
+	scanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		if i := strings.Index(string(data), "\n\n"); i >= 0 {
+			return i + 2, data[:i], nil
+		}
+		if atEOF && len(data) != 0 {
+			return len(data), data, nil
+		}
+		return 0, nil, nil
+	})
+
+	return scanner, errs
+}
+
+func next(t *testing.T, messages *bufio.Scanner) string {
+	t.Helper()
+
+	require.True(t, messages.Scan())
+	return messages.Text()
+}
+
+func never(repository.OutboxEvent) bool {
+	return false
+}
+
+func TestStream(t *testing.T) {
+	ctx := tenant.WithTenant(context.Background(), tenant.Default)
+	repo := memory.New()
+	createUser(t, ctx, repo, "username1")
+
+	hub := startHub(t, repo, config.WatchConfig{Interval: 10 * time.Millisecond, Heartbeat: time.Hour})
+
+	sub, err := hub.Subscribe(ctx, tenant.Default, nil)
+	require.NoError(t, err)
+	messages, _ := stream(t, hub, sub, never)
+
+	// the watcher resumes from the latest event of the log
+	require.Equal(t, "retry: 1000\nid: 1", next(t, messages))
+
+	// events of other organizations aren't streamed
+	org, err := repo.CreateOrganization(context.Background(), &domain.Organization{
+		Name: domain.NewOptString("other"),
+	})
+	require.NoError(t, err)
+	createUser(t, tenant.WithTenant(context.Background(), *org), repo, "username2")
+
+	id := createUser(t, ctx, repo, "username3")
+
+	message := next(t, messages)
+	header, data, ok := strings.Cut(message, "\ndata: ")
+	require.True(t, ok)
+	require.Equal(t, "id: 3\nevent: user.created", header)
+
+	var event repository.OutboxEvent
+	require.NoError(t, json.Unmarshal([]byte(data), &event))
+	require.Equal(t, uuid.UUID(id), event.UserID)
+	require.Equal(t, uuid.UUID(tenant.Default), event.TenantID)
+	require.JSONEq(t, `{
+		"username": "username3",
+		"email": "username3@mail.ru",
+		"is_admin": false,
+		"must_change_password": false
+	}`, string(event.Payload))
+}
+
+func TestStreamHeartbeat(t *testing.T) {
+	repo := memory.New()
+	hub := startHub(t, repo, config.WatchConfig{Heartbeat: 10 * time.Millisecond})
+
+	sub, err := hub.Subscribe(context.Background(), tenant.Default, nil)
+	require.NoError(t, err)
+	messages, _ := stream(t, hub, sub, never)
+
+	require.Equal(t, "retry: 1000\nid: 0", next(t, messages))
+	require.Equal(t, ": heartbeat", next(t, messages))
+}
+
+func TestResume(t *testing.T) {
+	ctx := tenant.WithTenant(context.Background(), tenant.Default)
+	repo := memory.New()
+	for _, username := range []string{"username1", "username2", "username3"} {
+		createUser(t, ctx, repo, username)
+	}
+
+	hub := startHub(t, repo, config.WatchConfig{Interval: 10 * time.Millisecond, Heartbeat: time.Hour})
+
+	// events after the last received one are read from the log
+	lastEventID := int64(1)
+	sub, err := hub.Subscribe(ctx, tenant.Default, &lastEventID)
+	require.NoError(t, err)
+	messages, _ := stream(t, hub, sub, never)
+
+	require.Equal(t, "retry: 1000", next(t, messages))
+	require.True(t, strings.HasPrefix(next(t, messages), "id: 2\nevent: user.created\n"))
+	require.True(t, strings.HasPrefix(next(t, messages), "id: 3\nevent: user.created\n"))
+
+	createUser(t, ctx, repo, "username4")
+	require.True(t, strings.HasPrefix(next(t, messages), "id: 4\nevent: user.created\n"))
+
+	// the watcher is reset if its last event was pruned
+	_, err = repo.PruneChanges(ctx, 2)
+	require.NoError(t, err)
+
+	sub, err = hub.Subscribe(ctx, tenant.Default, &lastEventID)
+	require.NoError(t, err)
+	messages, _ = stream(t, hub, sub, never)
+
+	require.Equal(t, "retry: 1000\nid: 4\nevent: reset\ndata: {}", next(t, messages))
+}
+
+func TestStreamEnd(t *testing.T) {
+	ctx := tenant.WithTenant(context.Background(), tenant.Default)
+	repo := memory.New()
+	hub := startHub(t, repo, config.WatchConfig{Interval: 10 * time.Millisecond, Heartbeat: time.Hour})
+
+	t.Run("Revoked", func(t *testing.T) {
+		sub, err := hub.Subscribe(ctx, tenant.Default, nil)
+		require.NoError(t, err)
+		messages, errs := stream(t, hub, sub, func(event repository.OutboxEvent) bool {
+			return event.Type == repository.EventUserDeleted
+		})
+		next(t, messages)
+
+		id := createUser(t, ctx, repo, "username1")
+		require.NoError(t, repo.DeleteUser(ctx, id))
+
+		require.Contains(t, next(t, messages), "event: user.created\n")
+		require.Contains(t, next(t, messages), "event: user.deleted\n")
+		require.False(t, messages.Scan())
+		require.NoError(t, <-errs)
+	})
+
+	t.Run("Lagging", func(t *testing.T) {
+		repo := memory.New()
+		hub := startHub(t, repo, config.WatchConfig{Interval: 10 * time.Millisecond, Heartbeat: time.Hour, Buffer: 1})
+
+		lagging, err := hub.Subscribe(ctx, tenant.Default, nil)
+		require.NoError(t, err)
+		sub, err := hub.Subscribe(ctx, tenant.Default, nil)
+		require.NoError(t, err)
+		messages, _ := stream(t, hub, sub, never)
+		next(t, messages)
+
+		// events are broadcast to both subscriptions, the second one overflows the buffer
+		// of the subscription that isn't read
+		createUser(t, ctx, repo, "username2")
+		next(t, messages)
+		createUser(t, ctx, repo, "username3")
+		next(t, messages)
+
+		messages, errs := stream(t, hub, lagging, never)
+		next(t, messages)
+		require.Contains(t, next(t, messages), "event: user.created\n")
+		require.False(t, messages.Scan())
+		require.ErrorIs(t, <-errs, watch.ErrLagging)
+	})
+
+	t.Run("Closed", func(t *testing.T) {
+		sub, err := hub.Subscribe(ctx, tenant.Default, nil)
+		require.NoError(t, err)
+		messages, errs := stream(t, hub, sub, never)
+		next(t, messages)
+
+		hub.Close()
+		require.False(t, messages.Scan())
+		require.NoError(t, <-errs)
+
+		_, err = hub.Subscribe(ctx, tenant.Default, nil)
+		require.ErrorIs(t, err, watch.ErrClosed)
+	})
+}
+
+func TestOnChange(t *testing.T) {
+	repo := memory.New()
+	hub := startHub(t, repo, config.WatchConfig{Interval: 10 * time.Millisecond})
+
+	events := make(chan repository.OutboxEvent, 2)
+	hub.OnChange(func(event repository.OutboxEvent) {
+		events <- event
+	})
+
+	// the subscription waits for the hub to start, events before it aren't broadcast
+	sub, err := hub.Subscribe(context.Background(), tenant.Default, nil)
+	require.NoError(t, err)
+	sub.Close()
+
+	// listeners get events of every organization
+	other := tenant.WithTenant(context.Background(), domain.UUID{1})
+	first := createUser(t, tenant.WithTenant(context.Background(), tenant.Default), repo, "username1")
+	second := createUser(t, other, repo, "username2")
+
+	for _, id := range []domain.UUID{first, second} {
+		select {
+		case event := <-events:
+			require.Equal(t, uuid.UUID(id), event.UserID)
+		case <-time.After(time.Second):
+			t.Fatal("event isn't received")
+		}
+	}
+}
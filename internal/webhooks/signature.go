@@ -0,0 +1,64 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// headers of deliveries
+const (
+	WebhookIDHeader = "X-Webhook-ID"
+	// DeliveryIDHeader identifies the delivery, it is the same for every attempt
+	DeliveryIDHeader = "X-Webhook-Delivery"
+	// EventIDHeader identifies the event, consumers deduplicate by it
+	EventIDHeader   = "X-Webhook-Event-ID"
+	EventHeader     = "X-Webhook-Event"
+	TimestampHeader = "X-Webhook-Timestamp"
+	// SignatureHeader is sha256= and hex of HMAC-SHA256 of the timestamp, a dot and the body,
+	// keyed by the secret of the webhook
+	SignatureHeader = "X-Webhook-Signature"
+)
+
+const signaturePrefix = "sha256="
+
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrExpiredTimestamp = errors.New("webhook timestamp is out of tolerance")
+)
+
+// Sign returns the signature of the body sent at timestamp, unix seconds
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature of a delivery received at now. A delivery signed more than
+// tolerance away from now is rejected, so a captured request can't be replayed later
+func Verify(secret string, header http.Header, body []byte, tolerance time.Duration, now time.Time) error {
+	timestamp, err := strconv.ParseInt(header.Get(TimestampHeader), 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	signature := header.Get(SignatureHeader)
+	if !strings.HasPrefix(signature, signaturePrefix) ||
+		!hmac.Equal([]byte(signature), []byte(Sign(secret, timestamp, body))) {
+		return ErrInvalidSignature
+	}
+
+	if now.Sub(time.Unix(timestamp, 0)).Abs() > tolerance {
+		return ErrExpiredTimestamp
+	}
+
+	return nil
+}
@@ -0,0 +1,274 @@
+// Package webhooks delivers events of user changes to webhooks registered by organizations.
+// The outbox relay enqueues a delivery of every event to each subscribed webhook, the worker
+// posts deliveries signed by the secret of the webhook and retries failed ones with backoff.
+// Delivery is at-least-once, consumers must deduplicate by the event id
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/liriquew/test_task/internal/lib/config"
+	"github.com/liriquew/test_task/internal/lib/netguard"
+	"github.com/liriquew/test_task/internal/repository"
+	"github.com/liriquew/test_task/pkg/logger/sl"
+)
+
+// Store keeps webhooks and their deliveries
+type Store interface {
+	EnqueueDeliveries(ctx context.Context, event repository.OutboxEvent) (int, error)
+	ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]repository.DeliveryJob, error)
+	CompleteDelivery(ctx context.Context, result repository.DeliveryResult) (bool, error)
+}
+
+// Enqueuer is the publisher of the outbox relay, it queues deliveries of events to webhooks
+type Enqueuer struct {
+	store Store
+}
+
+func NewEnqueuer(store Store) *Enqueuer {
+	return &Enqueuer{store: store}
+}
+
+func (e *Enqueuer) Publish(ctx context.Context, event repository.OutboxEvent) error {
+	_, err := e.store.EnqueueDeliveries(ctx, event)
+	return err
+}
+
+func (e *Enqueuer) Close() error {
+	return nil
+}
+
+// maximum length of the error of a delivery
+const maxErrorLength = 512
+
+// Worker attempts due deliveries
+type Worker struct {
+	log    *slog.Logger
+	store  Store
+	client *http.Client
+
+	interval  time.Duration
+	batchSize int
+	// lease of claimed deliveries, an attempt not completed within it is repeated
+	lease time.Duration
+
+	maxAttempts  int32
+	backoff      time.Duration
+	maxBackoff   time.Duration
+	disableAfter int32
+}
+
+func NewWorker(log *slog.Logger, store Store, cfg config.WebhooksConfig) *Worker {
+	w := &Worker{
+		log:          log.With(slog.String("component", "webhooks")),
+		store:        store,
+		client:       newClient(cfg),
+		interval:     cfg.Interval,
+		batchSize:    cfg.BatchSize,
+		maxAttempts:  int32(cfg.MaxAttempts),
+		backoff:      cfg.Backoff,
+		maxBackoff:   cfg.MaxBackoff,
+		disableAfter: int32(max(cfg.DisableAfter, 0)),
+	}
+	if w.client.Timeout <= 0 {
+		w.client.Timeout = 5 * time.Second
+	}
+	if w.interval <= 0 {
+		w.interval = time.Second
+	}
+	if w.batchSize <= 0 {
+		w.batchSize = 10
+	}
+	if w.maxAttempts <= 0 {
+		w.maxAttempts = 1
+	}
+	if w.backoff <= 0 {
+		w.backoff = time.Second
+	}
+	if w.maxBackoff < w.backoff {
+		w.maxBackoff = w.backoff
+	}
+	// attempts of a batch run at once, the lease outlives the slowest of them
+	w.lease = 2*w.client.Timeout + time.Second
+
+	return w
+}
+
+// newClient returns the client of deliveries. Redirects aren't followed and connections
+// to addresses which aren't public are rejected after the name is resolved, so a webhook
+// can't reach internal services, cloud metadata included
+func newClient(cfg config.WebhooksConfig) *http.Client {
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if !cfg.AllowPrivateNetworks {
+		dialer.Control = netguard.Control
+		// a proxy would dial the webhook instead of the dialer
+		transport.Proxy = nil
+	}
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   cfg.Timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// Run polls due deliveries every interval until ctx is done
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.Drain(ctx); err != nil && ctx.Err() == nil {
+			w.log.Warn("error while delivering webhooks", sl.Err(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Drain attempts deliveries until none is due
+func (w *Worker) Drain(ctx context.Context) error {
+	for {
+		jobs, err := w.store.ClaimDeliveries(ctx, w.batchSize, w.lease)
+		if err != nil {
+			return err
+		}
+
+		var wg sync.WaitGroup
+		errs := make([]error, len(jobs))
+		for i, job := range jobs {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				result := w.attempt(ctx, job)
+				// an attempt interrupted by shutdown is repeated when the lease expires
+				if ctx.Err() != nil {
+					return
+				}
+				errs[i] = w.complete(ctx, job, result)
+			}()
+		}
+		wg.Wait()
+
+		for _, err := range errs {
+			if err != nil {
+				return err
+			}
+		}
+		if len(jobs) < w.batchSize {
+			return nil
+		}
+	}
+}
+
+// attempt posts the delivery to the webhook, the attempt succeeds on a 2xx response
+func (w *Worker) attempt(ctx context.Context, job repository.DeliveryJob) repository.DeliveryResult {
+	result := repository.DeliveryResult{
+		ID:           job.ID,
+		WebhookID:    job.WebhookID,
+		DisableAfter: w.disableAfter,
+	}
+
+	status, err := w.post(ctx, job)
+	result.ResponseStatus = int32(status)
+	if err == nil {
+		result.Succeeded = true
+		return result
+	}
+
+	result.Error = err.Error()
+	if len(result.Error) > maxErrorLength {
+		result.Error = result.Error[:maxErrorLength]
+	}
+	if attempts := job.Attempts + 1; attempts < w.maxAttempts {
+		result.NextAttemptAt = time.Now().Add(w.retryAfter(attempts))
+	}
+
+	return result
+}
+
+// post sends the delivery, returns the status of the response, zero if there is no response
+func (w *Worker) post(ctx context.Context, job repository.DeliveryJob) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, job.URL, bytes.NewReader(job.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookIDHeader, job.WebhookID.String())
+	req.Header.Set(DeliveryIDHeader, job.ID.String())
+	req.Header.Set(EventIDHeader, strconv.FormatInt(job.EventID, 10))
+	req.Header.Set(EventHeader, job.EventType)
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(job.Secret, timestamp, job.Payload))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// drain the body, so the connection is reused
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// retryAfter returns the delay after the failed attempt, it doubles with every attempt
+func (w *Worker) retryAfter(attempts int32) time.Duration {
+	delay := w.backoff
+	for range attempts - 1 {
+		if delay >= w.maxBackoff/2 {
+			return w.maxBackoff
+		}
+		delay *= 2
+	}
+
+	return delay
+}
+
+func (w *Worker) complete(ctx context.Context, job repository.DeliveryJob, result repository.DeliveryResult) error {
+	disabled, err := w.store.CompleteDelivery(ctx, result)
+	if err != nil {
+		return err
+	}
+
+	if !result.Succeeded {
+		w.log.Warn("webhook delivery attempt failed",
+			slog.String("webhook_id", job.WebhookID.String()),
+			slog.String("delivery_id", job.ID.String()),
+			slog.Int64("event_id", job.EventID),
+			slog.Int("attempt", int(job.Attempts+1)),
+			slog.String("error", result.Error),
+		)
+	}
+	if disabled {
+		w.log.Warn("webhook is disabled after failed attempts",
+			slog.String("webhook_id", job.WebhookID.String()),
+			slog.Int("failures", int(w.disableAfter)),
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,224 @@
+package webhooks_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/liriquew/test_task/internal/lib/config"
+	"github.com/liriquew/test_task/internal/lib/netguard"
+	"github.com/liriquew/test_task/internal/lib/tenant"
+	"github.com/liriquew/test_task/internal/outbox"
+	"github.com/liriquew/test_task/internal/repository"
+	"github.com/liriquew/test_task/internal/repository/memory"
+	"github.com/liriquew/test_task/internal/webhooks"
+	"github.com/stretchr/testify/require"
+)
+
+func stubLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"id":1}`)
+	now := time.Unix(1_700_000_000, 0)
+
+	header := http.Header{}
+	header.Set(webhooks.TimestampHeader, strconv.FormatInt(now.Unix(), 10))
+	header.Set(webhooks.SignatureHeader, webhooks.Sign("secret", now.Unix(), body))
+
+	require.NoError(t, webhooks.Verify("secret", header, body, time.Minute, now.Add(30*time.Second)))
+
+	require.ErrorIs(t, webhooks.Verify("other", header, body, time.Minute, now), webhooks.ErrInvalidSignature)
+	require.ErrorIs(t, webhooks.Verify("secret", header, []byte(`{"id":2}`), time.Minute, now), webhooks.ErrInvalidSignature)
+	// a replayed request is rejected after the tolerance
+	require.ErrorIs(t, webhooks.Verify("secret", header, body, time.Minute, now.Add(2*time.Minute)), webhooks.ErrExpiredTimestamp)
+
+	// the timestamp is signed as well
+	header.Set(webhooks.TimestampHeader, strconv.FormatInt(now.Unix()+1, 10))
+	require.ErrorIs(t, webhooks.Verify("secret", header, body, time.Minute, now), webhooks.ErrInvalidSignature)
+}
+
+func TestWorker(t *testing.T) {
+	ctx := tenant.WithTenant(context.Background(), tenant.Default)
+	repo := memory.New()
+
+	var status atomic.Int32
+	status.Store(http.StatusServiceUnavailable)
+
+	var received atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, webhooks.Verify("secret", r.Header, body, time.Minute, time.Now()))
+		require.Equal(t, repository.EventUserCreated, r.Header.Get(webhooks.EventHeader))
+		require.NotEmpty(t, r.Header.Get(webhooks.DeliveryIDHeader))
+
+		received.Add(1)
+		w.WriteHeader(int(status.Load()))
+	}))
+	defer srv.Close()
+
+	id, err := repo.CreateWebhook(ctx, &domain.Webhook{
+		URL:    domain.NewOptString(srv.URL),
+		Secret: domain.NewOptString("secret"),
+	})
+	require.NoError(t, err)
+
+	// the relay enqueues deliveries of created users
+	_, err = repo.CreateUser(ctx, &domain.User{
+		Username: domain.NewOptString("username1"),
+		Password: domain.NewOptString("password"),
+		Email:    domain.NewOptString("username1@mail.ru"),
+	})
+	require.NoError(t, err)
+	relay := outbox.NewRelay(stubLogger(), repo, webhooks.NewEnqueuer(repo), config.OutboxConfig{})
+	require.NoError(t, relay.Drain(ctx))
+
+	worker := webhooks.NewWorker(stubLogger(), repo, config.WebhooksConfig{
+		Timeout:              time.Second,
+		AllowPrivateNetworks: true,
+		MaxAttempts:          3,
+		Backoff:              time.Millisecond,
+		MaxBackoff:           time.Millisecond,
+		DisableAfter:         5,
+	})
+
+	// failed attempts are retried after the backoff
+	require.NoError(t, worker.Drain(ctx))
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, worker.Drain(ctx))
+
+	deliveries, err := repo.ListWebhookDeliveries(ctx, *id, 0)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	require.Equal(t, domain.WebhookDeliveryStatusPending, deliveries[0].Status)
+	require.Equal(t, int32(2), deliveries[0].Attempts)
+	require.Equal(t, int32(http.StatusServiceUnavailable), deliveries[0].ResponseStatus.Value)
+
+	status.Store(http.StatusNoContent)
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, worker.Drain(ctx))
+
+	deliveries, err = repo.ListWebhookDeliveries(ctx, *id, 0)
+	require.NoError(t, err)
+	require.Equal(t, domain.WebhookDeliveryStatusSucceeded, deliveries[0].Status)
+	require.Equal(t, int32(3), deliveries[0].Attempts)
+	require.Equal(t, int32(3), received.Load())
+
+	// delivered deliveries are not attempted again
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, worker.Drain(ctx))
+	require.Equal(t, int32(3), received.Load())
+}
+
+func TestWorkerDisable(t *testing.T) {
+	ctx := tenant.WithTenant(context.Background(), tenant.Default)
+	repo := memory.New()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	id, err := repo.CreateWebhook(ctx, &domain.Webhook{
+		URL:    domain.NewOptString(srv.URL),
+		Secret: domain.NewOptString("secret"),
+	})
+	require.NoError(t, err)
+
+	enqueuer := webhooks.NewEnqueuer(repo)
+	for i := range 3 {
+		require.NoError(t, enqueuer.Publish(ctx, repository.OutboxEvent{
+			ID:       int64(i + 1),
+			Type:     repository.EventUserDeleted,
+			TenantID: uuid.UUID(tenant.Default),
+			Payload:  []byte(`{}`),
+		}))
+	}
+
+	worker := webhooks.NewWorker(stubLogger(), repo, config.WebhooksConfig{
+		Timeout:              time.Second,
+		AllowPrivateNetworks: true,
+		BatchSize:            1,
+		MaxAttempts:          1,
+		DisableAfter:         2,
+	})
+	require.NoError(t, worker.Drain(ctx))
+
+	// the webhook is disabled after two failures in a row, the last delivery waits for it
+	webhook, err := repo.GetWebhookById(ctx, *id)
+	require.NoError(t, err)
+	require.False(t, webhook.Enabled.Value)
+	require.Equal(t, int32(2), webhook.Failures.Value)
+
+	deliveries, err := repo.ListWebhookDeliveries(ctx, *id, 0)
+	require.NoError(t, err)
+	statuses := map[domain.WebhookDeliveryStatus]int{}
+	for _, delivery := range deliveries {
+		statuses[delivery.Status]++
+	}
+	require.Equal(t, map[domain.WebhookDeliveryStatus]int{
+		domain.WebhookDeliveryStatusFailed:  2,
+		domain.WebhookDeliveryStatusPending: 1,
+	}, statuses)
+}
+
+func TestWorkerPrivateNetworks(t *testing.T) {
+	ctx := tenant.WithTenant(context.Background(), tenant.Default)
+	repo := memory.New()
+
+	var received atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received.Add(1)
+		http.Redirect(w, r, "/redirected", http.StatusFound)
+	}))
+	defer srv.Close()
+
+	id, err := repo.CreateWebhook(ctx, &domain.Webhook{
+		URL:    domain.NewOptString(srv.URL),
+		Secret: domain.NewOptString("secret"),
+	})
+	require.NoError(t, err)
+
+	var eventID int64
+	deliver := func(cfg config.WebhooksConfig) domain.WebhookDelivery {
+		t.Helper()
+
+		eventID++
+		require.NoError(t, webhooks.NewEnqueuer(repo).Publish(ctx, repository.OutboxEvent{
+			ID:       eventID,
+			Type:     repository.EventUserDeleted,
+			TenantID: uuid.UUID(tenant.Default),
+			Payload:  []byte(`{}`),
+		}))
+		require.NoError(t, webhooks.NewWorker(stubLogger(), repo, cfg).Drain(ctx))
+
+		deliveries, err := repo.ListWebhookDeliveries(ctx, *id, 0)
+		require.NoError(t, err)
+		i := slices.IndexFunc(deliveries, func(d domain.WebhookDelivery) bool { return d.EventID == eventID })
+		require.NotEqual(t, -1, i)
+		return deliveries[i]
+	}
+
+	// the loopback address of the server isn't dialed
+	delivery := deliver(config.WebhooksConfig{MaxAttempts: 1})
+	require.Equal(t, domain.WebhookDeliveryStatusFailed, delivery.Status)
+	require.Contains(t, delivery.Error.Value, netguard.ErrForbiddenAddress.Error())
+	require.Equal(t, int32(0), received.Load())
+
+	// redirects aren't followed
+	delivery = deliver(config.WebhooksConfig{MaxAttempts: 1, AllowPrivateNetworks: true})
+	require.Equal(t, domain.WebhookDeliveryStatusFailed, delivery.Status)
+	require.Equal(t, int32(http.StatusFound), delivery.ResponseStatus.Value)
+	require.Equal(t, int32(1), received.Load())
+}
@@ -1,16 +1,6 @@
 -- +goose Up
--- +goose StatementBegin
-INSERT INTO users (username, password, email, is_admin)
-VALUES
-    (
-    'admin',
-    'JDJhJDEwJFZpdWxBdHNCVHVDazlzLmY3WVMwTGU4LzUya2p6Li9sMUl3QW1HQW5tdVZ4U28vajNrS3hp',
-    'admin@admin.ru',
-    true
-    )
--- +goose StatementEnd
+-- the initial administrator is no longer seeded with a fixed password,
+-- the service creates it on the first run from the bootstrap section of the config (internal/app/bootstrap.go).
+-- Databases migrated before keep admin:admin, it must change the password on the next login
 
 -- +goose Down
--- +goose StatementBegin
-
--- +goose StatementEnd
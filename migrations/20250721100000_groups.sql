@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS groups (
+    id UUID PRIMARY KEY NOT NULL DEFAULT gen_random_uuid(),
+    name VARCHAR(127) UNIQUE NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    is_admin bool NOT NULL DEFAULT false
+);
+
+-- member is either a user or a nested group
+CREATE TABLE IF NOT EXISTS group_members (
+    group_id UUID NOT NULL REFERENCES groups (id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users (id) ON DELETE CASCADE,
+    member_group_id UUID REFERENCES groups (id) ON DELETE CASCADE,
+    CONSTRAINT group_members_one_member CHECK ((user_id IS NULL) <> (member_group_id IS NULL)),
+    CONSTRAINT group_members_user_key UNIQUE (group_id, user_id),
+    CONSTRAINT group_members_group_key UNIQUE (group_id, member_group_id)
+);
+
+CREATE INDEX idx_group_members_user_id ON group_members (user_id);
+CREATE INDEX idx_group_members_member_group_id ON group_members (member_group_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS group_members;
+DROP TABLE IF EXISTS groups;
+
+-- +goose StatementEnd
@@ -0,0 +1,53 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS organizations (
+    id UUID PRIMARY KEY NOT NULL DEFAULT gen_random_uuid(),
+    name VARCHAR(127) UNIQUE NOT NULL
+);
+
+-- existing users and groups are moved to the default organization,
+-- its admins are super-admins
+INSERT INTO organizations (id, name)
+VALUES ('00000000-0000-0000-0000-000000000000', 'default');
+
+ALTER TABLE users
+    ADD COLUMN tenant_id UUID NOT NULL
+    DEFAULT '00000000-0000-0000-0000-000000000000'
+    REFERENCES organizations (id) ON DELETE CASCADE;
+ALTER TABLE users ALTER COLUMN tenant_id DROP DEFAULT;
+
+ALTER TABLE users DROP CONSTRAINT users_username_key;
+DROP INDEX IF EXISTS idx_users_username;
+ALTER TABLE users ADD CONSTRAINT users_tenant_username_key UNIQUE (tenant_id, username);
+-- emails were never unique, existing duplicates are reported by the migration
+-- of case-insensitive users, which makes them unique
+
+ALTER TABLE groups
+    ADD COLUMN tenant_id UUID NOT NULL
+    DEFAULT '00000000-0000-0000-0000-000000000000'
+    REFERENCES organizations (id) ON DELETE CASCADE;
+ALTER TABLE groups ALTER COLUMN tenant_id DROP DEFAULT;
+
+ALTER TABLE groups DROP CONSTRAINT groups_name_key;
+ALTER TABLE groups ADD CONSTRAINT groups_tenant_name_key UNIQUE (tenant_id, name);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE groups DROP CONSTRAINT groups_tenant_name_key;
+DELETE FROM groups WHERE tenant_id <> '00000000-0000-0000-0000-000000000000';
+ALTER TABLE groups ADD CONSTRAINT groups_name_key UNIQUE (name);
+ALTER TABLE groups DROP COLUMN tenant_id;
+
+ALTER TABLE users DROP CONSTRAINT users_tenant_username_key;
+DELETE FROM users WHERE tenant_id <> '00000000-0000-0000-0000-000000000000';
+ALTER TABLE users ADD CONSTRAINT users_username_key UNIQUE (username);
+CREATE INDEX idx_users_username ON users (username);
+ALTER TABLE users DROP COLUMN tenant_id;
+
+DROP TABLE IF EXISTS organizations;
+
+-- +goose StatementEnd
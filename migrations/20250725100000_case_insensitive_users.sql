@@ -0,0 +1,51 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- pre-flight: report users which differ only in case (or surrounding spaces of an email)
+-- and abort, duplicates must be resolved manually before the migration
+DO $$
+DECLARE
+    dup RECORD;
+    found BOOLEAN := false;
+BEGIN
+    FOR dup IN
+        SELECT tenant_id, 'username' AS field, lower(username) AS value, string_agg(id::text, ', ') AS ids
+        FROM users
+        GROUP BY tenant_id, lower(username)
+        HAVING count(*) > 1
+        UNION ALL
+        SELECT tenant_id, 'email' AS field, lower(trim(email)) AS value, string_agg(id::text, ', ') AS ids
+        FROM users
+        GROUP BY tenant_id, lower(trim(email))
+        HAVING count(*) > 1
+    LOOP
+        found := true;
+        RAISE WARNING 'duplicate % "%" in organization %: users %', dup.field, dup.value, dup.tenant_id, dup.ids;
+    END LOOP;
+
+    IF found THEN
+        RAISE EXCEPTION 'case-insensitive duplicates of usernames or emails found, see warnings above';
+    END IF;
+END $$;
+
+-- emails are stored normalized
+UPDATE users SET email = lower(trim(email)) WHERE email <> lower(trim(email));
+
+ALTER TABLE users DROP CONSTRAINT users_tenant_username_key;
+-- created by earlier versions of the migration of organizations
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_tenant_email_key;
+
+CREATE UNIQUE INDEX users_tenant_lower_username_key ON users (tenant_id, lower(username));
+CREATE UNIQUE INDEX users_tenant_lower_email_key ON users (tenant_id, lower(email));
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS users_tenant_lower_email_key;
+DROP INDEX IF EXISTS users_tenant_lower_username_key;
+
+ALTER TABLE users ADD CONSTRAINT users_tenant_username_key UNIQUE (tenant_id, username);
+
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN must_change_password BOOLEAN NOT NULL DEFAULT false;
+
+-- admin:admin seeded by the former admin migration
+UPDATE users SET must_change_password = true
+WHERE username = 'admin'
+AND password = 'JDJhJDEwJFZpdWxBdHNCVHVDazlzLmY3WVMwTGU4LzUya2p6Li9sMUl3QW1HQW5tdVZ4U28vajNrS3hp';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users DROP COLUMN must_change_password;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- events of user changes are written in the transaction of the change,
+-- the relay publishes them in id order and deletes published ones
+CREATE TABLE IF NOT EXISTS outbox (
+    id BIGSERIAL PRIMARY KEY,
+    event_type VARCHAR(63) NOT NULL,
+    tenant_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS outbox;
+
+-- +goose StatementEnd
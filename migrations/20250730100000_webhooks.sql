@@ -0,0 +1,49 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- endpoints user events are delivered to, every event if events is empty
+CREATE TABLE IF NOT EXISTS webhooks (
+    id UUID PRIMARY KEY NOT NULL DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL REFERENCES organizations (id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    events TEXT[] NOT NULL DEFAULT '{}',
+    secret TEXT NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    -- failed attempts in a row, the webhook is disabled when it reaches the limit
+    failures INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_webhooks_tenant_id ON webhooks (tenant_id);
+
+-- a delivery of an outbox event to a webhook, the log of attempts.
+-- The unique key makes enqueueing of a relayed event idempotent
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id UUID PRIMARY KEY NOT NULL DEFAULT gen_random_uuid(),
+    webhook_id UUID NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL,
+    event_id BIGINT NOT NULL,
+    event_type VARCHAR(63) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(15) NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    response_status INT,
+    error TEXT,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    delivered_at TIMESTAMPTZ,
+    CONSTRAINT webhook_deliveries_event_key UNIQUE (webhook_id, event_id)
+);
+
+CREATE INDEX idx_webhook_deliveries_pending ON webhook_deliveries (next_attempt_at)
+    WHERE status = 'pending';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhooks;
+
+-- +goose StatementEnd
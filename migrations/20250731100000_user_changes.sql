@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- the bounded log of user events streamed by GET /users:watch, an event is written
+-- with the id of its outbox event in the transaction of the change, so the log
+-- outlives relayed events and streams resume from it. Old events are pruned
+CREATE TABLE IF NOT EXISTS user_changes (
+    id BIGINT PRIMARY KEY,
+    event_type VARCHAR(63) NOT NULL,
+    tenant_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS user_changes;
+
+-- +goose StatementEnd
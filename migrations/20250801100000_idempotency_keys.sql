@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- requests with the Idempotency-Key header and their responses, a key is scoped to
+-- the credentials of the request. status is 0 while the request is in progress,
+-- expired keys are deleted and can be claimed again
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    scope VARCHAR(64) NOT NULL,
+    request_key VARCHAR(255) NOT NULL,
+    fingerprint VARCHAR(64) NOT NULL,
+    status INTEGER NOT NULL DEFAULT 0,
+    header TEXT NOT NULL DEFAULT '',
+    body BYTEA,
+    expires_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (scope, request_key)
+);
+
+CREATE INDEX IF NOT EXISTS idempotency_keys_expires_at_idx ON idempotency_keys (expires_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS idempotency_keys;
+
+-- +goose StatementEnd
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- ids of the log of changes are taken on commit: an outbox event is copied to the log
+-- by a deferred trigger, which takes the advisory lock of writers of events held until
+-- the commit ends. Ids of the log are committed in order, so a reader never skips
+-- an event, while writers are serialized only on commit
+CREATE SEQUENCE IF NOT EXISTS user_changes_id_seq OWNED BY user_changes.id;
+SELECT setval('user_changes_id_seq', COALESCE((SELECT max(id) FROM user_changes), 0) + 1, false);
+
+CREATE OR REPLACE FUNCTION append_user_change() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_advisory_xact_lock(7270200);
+    INSERT INTO user_changes (id, event_type, tenant_id, user_id, payload, created_at)
+    VALUES (nextval('user_changes_id_seq'), NEW.event_type, NEW.tenant_id, NEW.user_id, NEW.payload, NEW.created_at);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE CONSTRAINT TRIGGER outbox_user_changes
+    AFTER INSERT ON outbox
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW EXECUTE FUNCTION append_user_change();
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TRIGGER IF EXISTS outbox_user_changes ON outbox;
+DROP FUNCTION IF EXISTS append_user_change();
+DROP SEQUENCE IF EXISTS user_changes_id_seq;
+
+-- +goose StatementEnd
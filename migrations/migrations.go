@@ -0,0 +1,11 @@
+// Package migrations embeds sql migrations into the service binary,
+// sqlite ones in sqlite/ are kept in sync with postgres ones
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var Postgres embed.FS
+
+//go:embed sqlite/*.sql
+var SQLite embed.FS
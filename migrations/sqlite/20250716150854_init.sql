@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- the same as gen_random_uuid() of postgres, ids are stored as text
+CREATE TABLE IF NOT EXISTS users (
+    id TEXT PRIMARY KEY NOT NULL DEFAULT (
+        lower(hex(randomblob(4))) || '-' ||
+        lower(hex(randomblob(2))) || '-4' ||
+        substr(lower(hex(randomblob(2))), 2) || '-' ||
+        substr('89ab', 1 + abs(random()) % 4, 1) ||
+        substr(lower(hex(randomblob(2))), 2) || '-' ||
+        lower(hex(randomblob(6)))
+    ),
+    username TEXT NOT NULL,
+    password TEXT NOT NULL,
+    email TEXT NOT NULL,
+    is_admin INTEGER NOT NULL DEFAULT 0
+);
+
+-- named index instead of a column constraint, sqlite can't drop constraints
+CREATE UNIQUE INDEX users_username_key ON users (username);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS users_username_key;
+DROP TABLE IF EXISTS users;
+
+-- +goose StatementEnd
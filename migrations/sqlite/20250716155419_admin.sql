@@ -0,0 +1,6 @@
+-- +goose Up
+-- the initial administrator is no longer seeded with a fixed password,
+-- the service creates it on the first run from the bootstrap section of the config (internal/app/bootstrap.go).
+-- Databases migrated before keep admin:admin, it must change the password on the next login
+
+-- +goose Down
@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS groups (
+    id TEXT PRIMARY KEY NOT NULL DEFAULT (
+        lower(hex(randomblob(4))) || '-' ||
+        lower(hex(randomblob(2))) || '-4' ||
+        substr(lower(hex(randomblob(2))), 2) || '-' ||
+        substr('89ab', 1 + abs(random()) % 4, 1) ||
+        substr(lower(hex(randomblob(2))), 2) || '-' ||
+        lower(hex(randomblob(6)))
+    ),
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    is_admin INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE UNIQUE INDEX groups_name_key ON groups (name);
+
+-- member is either a user or a nested group
+CREATE TABLE IF NOT EXISTS group_members (
+    group_id TEXT NOT NULL REFERENCES groups (id) ON DELETE CASCADE,
+    user_id TEXT REFERENCES users (id) ON DELETE CASCADE,
+    member_group_id TEXT REFERENCES groups (id) ON DELETE CASCADE,
+    CONSTRAINT group_members_one_member CHECK ((user_id IS NULL) <> (member_group_id IS NULL)),
+    CONSTRAINT group_members_user_key UNIQUE (group_id, user_id),
+    CONSTRAINT group_members_group_key UNIQUE (group_id, member_group_id)
+);
+
+CREATE INDEX idx_group_members_user_id ON group_members (user_id);
+CREATE INDEX idx_group_members_member_group_id ON group_members (member_group_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS group_members;
+DROP TABLE IF EXISTS groups;
+
+-- +goose StatementEnd
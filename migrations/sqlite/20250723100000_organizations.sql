@@ -0,0 +1,61 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS organizations (
+    id TEXT PRIMARY KEY NOT NULL DEFAULT (
+        lower(hex(randomblob(4))) || '-' ||
+        lower(hex(randomblob(2))) || '-4' ||
+        substr(lower(hex(randomblob(2))), 2) || '-' ||
+        substr('89ab', 1 + abs(random()) % 4, 1) ||
+        substr(lower(hex(randomblob(2))), 2) || '-' ||
+        lower(hex(randomblob(6)))
+    ),
+    name TEXT NOT NULL
+);
+
+CREATE UNIQUE INDEX organizations_name_key ON organizations (name);
+
+-- existing users and groups are moved to the default organization,
+-- its admins are super-admins
+INSERT INTO organizations (id, name)
+VALUES ('00000000-0000-0000-0000-000000000000', 'default');
+
+-- sqlite can't drop the default of a column, the repository always sets tenant_id.
+-- Must be applied with foreign keys disabled, sqlite doesn't add a referencing
+-- column with a default otherwise
+ALTER TABLE users
+    ADD COLUMN tenant_id TEXT NOT NULL
+    DEFAULT '00000000-0000-0000-0000-000000000000'
+    REFERENCES organizations (id) ON DELETE CASCADE;
+
+DROP INDEX IF EXISTS users_username_key;
+CREATE UNIQUE INDEX users_tenant_username_key ON users (tenant_id, username);
+-- emails were never unique, existing duplicates are reported by the migration
+-- of case-insensitive users, which makes them unique
+
+ALTER TABLE groups
+    ADD COLUMN tenant_id TEXT NOT NULL
+    DEFAULT '00000000-0000-0000-0000-000000000000'
+    REFERENCES organizations (id) ON DELETE CASCADE;
+
+DROP INDEX IF EXISTS groups_name_key;
+CREATE UNIQUE INDEX groups_tenant_name_key ON groups (tenant_id, name);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS groups_tenant_name_key;
+DELETE FROM groups WHERE tenant_id <> '00000000-0000-0000-0000-000000000000';
+CREATE UNIQUE INDEX groups_name_key ON groups (name);
+ALTER TABLE groups DROP COLUMN tenant_id;
+
+DROP INDEX IF EXISTS users_tenant_username_key;
+DELETE FROM users WHERE tenant_id <> '00000000-0000-0000-0000-000000000000';
+CREATE UNIQUE INDEX users_username_key ON users (username);
+ALTER TABLE users DROP COLUMN tenant_id;
+
+DROP TABLE IF EXISTS organizations;
+
+-- +goose StatementEnd
@@ -0,0 +1,46 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- pre-flight: abort when users differ only in case (or surrounding spaces of an email),
+-- duplicates must be resolved manually before the migration. sqlite has no procedural
+-- code, so a violated check constraint aborts the migration
+CREATE TEMP TABLE case_insensitive_duplicates (
+    found INTEGER NOT NULL CHECK (found = 0)
+);
+
+INSERT INTO case_insensitive_duplicates (found)
+SELECT count(*) FROM (
+    SELECT 1 FROM users
+    GROUP BY tenant_id, lower(username)
+    HAVING count(*) > 1
+    UNION ALL
+    SELECT 1 FROM users
+    GROUP BY tenant_id, lower(trim(email))
+    HAVING count(*) > 1
+);
+
+DROP TABLE case_insensitive_duplicates;
+
+-- emails are stored normalized
+UPDATE users SET email = lower(trim(email)) WHERE email <> lower(trim(email));
+
+DROP INDEX IF EXISTS users_tenant_username_key;
+-- created by earlier versions of the migration of organizations
+DROP INDEX IF EXISTS users_tenant_email_key;
+
+-- lower() of sqlite folds ASCII only, usernames and emails are validated to be ASCII.
+-- sqlite checks the latest index first, username is reported before email as in postgres
+CREATE UNIQUE INDEX users_tenant_lower_email_key ON users (tenant_id, lower(email));
+CREATE UNIQUE INDEX users_tenant_lower_username_key ON users (tenant_id, lower(username));
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS users_tenant_lower_email_key;
+DROP INDEX IF EXISTS users_tenant_lower_username_key;
+
+CREATE UNIQUE INDEX users_tenant_username_key ON users (tenant_id, username);
+
+-- +goose StatementEnd
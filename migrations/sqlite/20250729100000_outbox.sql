@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- events of user changes are written in the transaction of the change,
+-- the relay publishes them in id order and deletes published ones.
+-- AUTOINCREMENT keeps ids of deleted events from being reused
+CREATE TABLE IF NOT EXISTS outbox (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    event_type TEXT NOT NULL,
+    tenant_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    created_at DATETIME NOT NULL
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS outbox;
+
+-- +goose StatementEnd
@@ -0,0 +1,64 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- endpoints user events are delivered to, every event if events is empty.
+-- events is the array literal of postgres, e.g. {"user.created"}
+CREATE TABLE IF NOT EXISTS webhooks (
+    id TEXT PRIMARY KEY NOT NULL DEFAULT (
+        lower(hex(randomblob(4))) || '-' ||
+        lower(hex(randomblob(2))) || '-4' ||
+        substr(lower(hex(randomblob(2))), 2) || '-' ||
+        substr('89ab', 1 + abs(random()) % 4, 1) ||
+        substr(lower(hex(randomblob(2))), 2) || '-' ||
+        lower(hex(randomblob(6)))
+    ),
+    tenant_id TEXT NOT NULL REFERENCES organizations (id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    events TEXT NOT NULL DEFAULT '{}',
+    secret TEXT NOT NULL,
+    enabled INTEGER NOT NULL DEFAULT 1,
+    -- failed attempts in a row, the webhook is disabled when it reaches the limit
+    failures INTEGER NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL
+);
+
+CREATE INDEX idx_webhooks_tenant_id ON webhooks (tenant_id);
+
+-- a delivery of an outbox event to a webhook, the log of attempts.
+-- The unique key makes enqueueing of a relayed event idempotent
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id TEXT PRIMARY KEY NOT NULL DEFAULT (
+        lower(hex(randomblob(4))) || '-' ||
+        lower(hex(randomblob(2))) || '-4' ||
+        substr(lower(hex(randomblob(2))), 2) || '-' ||
+        substr('89ab', 1 + abs(random()) % 4, 1) ||
+        substr(lower(hex(randomblob(2))), 2) || '-' ||
+        lower(hex(randomblob(6)))
+    ),
+    webhook_id TEXT NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+    tenant_id TEXT NOT NULL,
+    event_id INTEGER NOT NULL,
+    event_type TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    response_status INTEGER,
+    error TEXT,
+    next_attempt_at DATETIME NOT NULL,
+    created_at DATETIME NOT NULL,
+    delivered_at DATETIME,
+    CONSTRAINT webhook_deliveries_event_key UNIQUE (webhook_id, event_id)
+);
+
+CREATE INDEX idx_webhook_deliveries_pending ON webhook_deliveries (next_attempt_at)
+    WHERE status = 'pending';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhooks;
+
+-- +goose StatementEnd
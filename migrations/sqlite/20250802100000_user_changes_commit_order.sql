@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- postgres takes ids of the log of changes on commit, sqlite serializes writers,
+-- so ids of the outbox are committed in order already
+SELECT 1;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+SELECT 1;
+
+-- +goose StatementEnd
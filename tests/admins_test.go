@@ -0,0 +1,31 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+
+	domain "github.com/liriquew/test_task/internal/domain"
+)
+
+func TestLastAdmin(t *testing.T) {
+	t.Parallel()
+
+	org := GetRandomOrganization()
+	orgID := CreateOrganization(t, org)
+
+	admin := GetRandomUser()
+	admin.IsAdmin.SetTo(true)
+	var id Id
+	DoRequest(t, "POST", "users/", admin, GetSuperAdminAuthHeader(orgID), 201, &id)
+	url := fmt.Sprintf("users/%s", id.Id.String())
+
+	demote := &domain.User{IsAdmin: domain.NewOptBool(false)}
+	DoRequest(t, "PATCH", url, demote, GetTenantAuthHeader(org, admin), 409, nil)
+	DoRequest(t, "DELETE", url, nil, GetTenantAuthHeader(org, admin), 409, nil)
+
+	other := GetRandomUser()
+	other.IsAdmin.SetTo(true)
+	DoRequest(t, "POST", "users/", other, GetTenantAuthHeader(org, admin), 201, nil)
+	DoRequest(t, "PATCH", url, demote, GetTenantAuthHeader(org, other), 200, nil)
+	DoRequest(t, "DELETE", url, nil, GetTenantAuthHeader(org, other), 200, nil)
+}
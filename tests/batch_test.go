@@ -0,0 +1,60 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+
+	uuid "github.com/google/uuid"
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchUsers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Atomic rollback", func(t *testing.T) {
+		t.Parallel()
+		user := GetRandomUser()
+		existing := GetRandomUser()
+		CreateUser(t, existing)
+
+		conflict := GetRandomUser()
+		conflict.Username = existing.Username
+
+		var report domain.BatchReport
+		DoRequest(t, "POST", "users:batch?atomic=true", []domain.BatchOperation{
+			{Op: domain.BatchOperationOpCreate, User: domain.NewOptUser(*user)},
+			{Op: domain.BatchOperationOpCreate, User: domain.NewOptUser(*conflict)},
+		}, GetAuthHeader(GetDefaultAdmin()), 200, &report)
+
+		assert.False(t, report.Committed)
+		require.Len(t, report.Results, 2)
+		assert.Equal(t, int32(201), report.Results[0].Status)
+		assert.Equal(t, int32(409), report.Results[1].Status)
+
+		// the first user is rolled back together with the batch
+		DoRequest(t, "GET", "users/", nil, GetAuthHeader(user), 401, nil)
+	})
+
+	t.Run("Independent", func(t *testing.T) {
+		t.Parallel()
+		user := GetRandomUser()
+		id := CreateUser(t, user)
+		missing, _ := uuid.NewV7()
+
+		var report domain.BatchReport
+		DoRequest(t, "POST", "users:batch", []domain.BatchOperation{
+			{Op: domain.BatchOperationOpDelete, ID: domain.NewOptUUID(domain.UUID(id))},
+			// patch without user body is rejected
+			{Op: domain.BatchOperationOpPatch, ID: domain.NewOptUUID(domain.UUID(missing))},
+		}, GetAuthHeader(GetDefaultAdmin()), 200, &report)
+
+		assert.True(t, report.Committed)
+		require.Len(t, report.Results, 2)
+		assert.Equal(t, int32(200), report.Results[0].Status)
+		assert.Equal(t, int32(400), report.Results[1].Status)
+
+		DoRequest(t, "GET", fmt.Sprintf("users/%s", id), nil, GetAuthHeader(GetDefaultAdmin()), 404, nil)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"testing"
 
 	"encoding/base64"
@@ -27,9 +28,53 @@ type Id struct {
 func TestMain(m *testing.M) {
 	cfg = config.MustLoadPathTest("../config/test_config.yaml")
 
+	if err := ChangeInitialPassword(); err != nil {
+		panic(err)
+	}
+
 	m.Run()
 }
 
+// adminPassword replaces the password of the bootstrap administrator
+const adminPassword = "Passw0rdAdmin2"
+
+// ChangeInitialPassword clears must_change_password of the bootstrap administrator
+// by changing the password from the bootstrap one to adminPassword. The bootstrap
+// password is rejected once it's changed, so the tests can run against the service again
+func ChangeInitialPassword() error {
+	admin := GetDefaultAdmin()
+	admin.Password.SetTo(cfg.Bootstrap.Password)
+
+	b, err := json.Marshal(domain.PasswordChange{Password: adminPassword})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", fmt.Sprintf(
+		"http://%s:%d/me/password", cfg.API.Host, cfg.API.Port,
+	), bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	for k, v := range GetAuthHeader(admin) {
+		req.Header.Add(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("change password of %s: status %d", admin.Username.Value, resp.StatusCode)
+	}
+
+	return nil
+}
+
 func Copy(u *domain.User) *domain.User {
 	return &domain.User{
 		ID:       u.ID,
@@ -54,9 +99,9 @@ func GetRandomUser() *domain.User {
 
 func GetDefaultAdmin() *domain.User {
 	return &domain.User{
-		Username: domain.NewOptString("admin"),
-		Email:    domain.NewOptString("admin@admin.ru"),
-		Password: domain.NewOptString("admin"),
+		Username: domain.NewOptString(cfg.Bootstrap.Username),
+		Email:    domain.NewOptString(cfg.Bootstrap.Email),
+		Password: domain.NewOptString(adminPassword),
 		IsAdmin: domain.OptBool{
 			Value: true,
 			Set:   true,
@@ -148,6 +193,20 @@ func TestCreateUser(t *testing.T) {
 		DoRequest(t, "POST", url, user, GetAuthHeader(GetDefaultAdmin()), 409, nil)
 	})
 
+	t.Run("Conflict ignores case", func(t *testing.T) {
+		t.Parallel()
+		user := GetRandomUser()
+		CreateUser(t, user)
+
+		other := GetRandomUser()
+		other.Username.SetTo(strings.ToUpper(user.Username.Value))
+		DoRequest(t, "POST", url, other, GetAuthHeader(GetDefaultAdmin()), 409, nil)
+
+		other = GetRandomUser()
+		other.Email.SetTo(strings.ToUpper(user.Email.Value))
+		DoRequest(t, "POST", url, other, GetAuthHeader(GetDefaultAdmin()), 409, nil)
+	})
+
 	t.Run("Forbidden", func(t *testing.T) {
 		t.Parallel()
 		var id Id
@@ -157,6 +216,16 @@ func TestCreateUser(t *testing.T) {
 		DoRequest(t, "POST", url, user, GetAuthHeader(user), 403, nil)
 	})
 
+	t.Run("Login ignores case", func(t *testing.T) {
+		t.Parallel()
+		user := GetRandomUser()
+		CreateUser(t, user)
+
+		login := Copy(user)
+		login.Username.SetTo(strings.ToUpper(user.Username.Value))
+		DoRequest(t, "GET", url, nil, GetAuthHeader(login), 200, nil)
+	})
+
 	t.Run("Unauthorized", func(t *testing.T) {
 		t.Parallel()
 		DoRequest(t, "POST", url, user, nil, 401, nil)
@@ -230,6 +299,17 @@ func TestPatchUser(t *testing.T) {
 
 		assert.Equal(t, *user, patchedUser)
 	})
+
+	t.Run("Not found", func(t *testing.T) {
+		t.Parallel()
+
+		id, _ := uuid.NewV7()
+		url := fmt.Sprintf("users/%s", id.String())
+		DoRequest(t, "PATCH", url, &domain.User{
+			Email: domain.NewOptString(gofakeit.Email()),
+		}, GetAuthHeader(GetDefaultAdmin()), 404, nil)
+		DoRequest(t, "PUT", url, GetRandomUser(), GetAuthHeader(GetDefaultAdmin()), 404, nil)
+	})
 }
 
 func TestPutUser(t *testing.T) {
@@ -272,6 +352,41 @@ func TestDeleteUser(t *testing.T) {
 	})
 }
 
+func TestChangePassword(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		// must_change_password is read only
+		user := GetRandomUser()
+		user.MustChangePassword.SetTo(true)
+		id := CreateUser(t, user)
+		assert.False(t, GetUser(t, id).MustChangePassword.IsSet())
+
+		// a user without admin permissions can change the password
+		newPassword := GetRandomUser().Password
+		DoRequest(t, "POST", "me/password", &domain.PasswordChange{
+			Password: newPassword.Value,
+		}, GetAuthHeader(user), 200, nil)
+		DoRequest(t, "GET", "users/", nil, GetAuthHeader(user), 401, nil)
+
+		user.Password = newPassword
+		DoRequest(t, "GET", "users/", nil, GetAuthHeader(user), 200, nil)
+	})
+
+	t.Run("Invalid password", func(t *testing.T) {
+		t.Parallel()
+
+		user := GetRandomUser()
+		CreateUser(t, user)
+
+		DoRequest(t, "POST", "me/password", &domain.PasswordChange{
+			Password: "password",
+		}, GetAuthHeader(user), 400, nil)
+	})
+}
+
 func TestListUsers(t *testing.T) {
 	t.Parallel()
 
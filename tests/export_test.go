@@ -0,0 +1,81 @@
+package tests
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"testing"
+
+	uuid "github.com/google/uuid"
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+// ExportUsers requests the export in the organization and returns the response
+func ExportUsers(t *testing.T, orgID uuid.UUID, accept, query string) *http.Response {
+	req, err := http.NewRequest("GET", fmt.Sprintf(
+		"http://%s:%d/users:export?%s", cfg.API.Host, cfg.API.Port, query,
+	), nil)
+	require.NoError(t, err)
+	for k, v := range GetSuperAdminAuthHeader(orgID) {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Accept", accept)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, 200, resp.StatusCode)
+	return resp
+}
+
+func TestExportUsers(t *testing.T) {
+	t.Parallel()
+
+	// fresh organization, so the export contains only users of the test
+	orgID := CreateOrganization(t, GetRandomOrganization())
+
+	const cnt = 5
+	for range cnt {
+		DoRequest(t, "POST", "users/", GetRandomUser(), GetSuperAdminAuthHeader(orgID), 201, nil)
+	}
+
+	t.Run("NDJSON with cursor", func(t *testing.T) {
+		t.Parallel()
+		resp := ExportUsers(t, orgID, "application/x-ndjson", "")
+		defer resp.Body.Close()
+
+		var users []domain.User
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			var user domain.User
+			require.NoError(t, user.UnmarshalJSON(scanner.Bytes()))
+			require.False(t, user.Password.IsSet())
+			users = append(users, user)
+		}
+		require.NoError(t, scanner.Err())
+		require.Len(t, users, cnt)
+
+		cursor := uuid.UUID(users[1].ID.Value).String()
+		resp = ExportUsers(t, orgID, "application/x-ndjson", "cursor="+cursor)
+		defer resp.Body.Close()
+
+		rest := 0
+		scanner = bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			rest++
+		}
+		require.Equal(t, cnt-2, rest)
+	})
+
+	t.Run("CSV", func(t *testing.T) {
+		t.Parallel()
+		resp := ExportUsers(t, orgID, "text/csv", "is_admin=false")
+		defer resp.Body.Close()
+
+		records, err := csv.NewReader(resp.Body).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, cnt+1)
+		require.Equal(t, []string{"id", "username", "email", "is_admin"}, records[0])
+	})
+}
@@ -0,0 +1,116 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v6"
+	uuid "github.com/google/uuid"
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func GetRandomGroup() *domain.Group {
+	return &domain.Group{
+		Name:        domain.NewOptString(gofakeit.Username() + gofakeit.AchAccount()),
+		Description: domain.NewOptString(gofakeit.Sentence(3)),
+		IsAdmin:     domain.NewOptBool(false),
+	}
+}
+
+func CreateGroup(t *testing.T, group *domain.Group) uuid.UUID {
+	const url = "groups/"
+	var id Id
+	DoRequest(t, "POST", url, group, GetAuthHeader(GetDefaultAdmin()), 201, &id)
+	return id.Id
+}
+
+func AddGroupMember(t *testing.T, groupID uuid.UUID, member *domain.GroupMember, code int) {
+	url := fmt.Sprintf("groups/%s/members", groupID.String())
+	DoRequest(t, "POST", url, member, GetAuthHeader(GetDefaultAdmin()), code, nil)
+}
+
+func TestCreateGroup(t *testing.T) {
+	t.Parallel()
+	url := "groups/"
+
+	t.Run("New group", func(t *testing.T) {
+		t.Parallel()
+		group := GetRandomGroup()
+		id := CreateGroup(t, group)
+		group.ID.SetTo(domain.UUID(id))
+
+		var newGroup domain.Group
+		DoRequest(t, "GET", fmt.Sprintf("groups/%s", id.String()), nil, GetAuthHeader(GetDefaultAdmin()), 200, &newGroup)
+		assert.Equal(t, *group, newGroup)
+	})
+
+	t.Run("Conflict", func(t *testing.T) {
+		t.Parallel()
+		group := GetRandomGroup()
+		CreateGroup(t, group)
+		DoRequest(t, "POST", url, group, GetAuthHeader(GetDefaultAdmin()), 409, nil)
+	})
+
+	t.Run("Forbidden", func(t *testing.T) {
+		t.Parallel()
+		user := GetRandomUser()
+		CreateUser(t, user)
+		DoRequest(t, "POST", url, GetRandomGroup(), GetAuthHeader(user), 403, nil)
+	})
+}
+
+func TestGroupMembers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Nested groups", func(t *testing.T) {
+		t.Parallel()
+		user := GetRandomUser()
+		userID := CreateUser(t, user)
+		parentID := CreateGroup(t, GetRandomGroup())
+		childID := CreateGroup(t, GetRandomGroup())
+
+		AddGroupMember(t, childID, &domain.GroupMember{UserID: domain.NewOptUUID(domain.UUID(userID))}, 200)
+		AddGroupMember(t, parentID, &domain.GroupMember{GroupID: domain.NewOptUUID(domain.UUID(childID))}, 200)
+
+		var members []domain.GroupMember
+		DoRequest(t, "GET", fmt.Sprintf("groups/%s/members", parentID.String()), nil, GetAuthHeader(GetDefaultAdmin()), 200, &members)
+		require.Len(t, members, 1)
+		assert.Equal(t, domain.UUID(childID), members[0].GroupID.Value)
+
+		var groups []domain.Group
+		DoRequest(t, "GET", fmt.Sprintf("users/%s/groups", userID.String()), nil, GetAuthHeader(GetDefaultAdmin()), 200, &groups)
+		require.Len(t, groups, 2)
+	})
+
+	t.Run("Cycle", func(t *testing.T) {
+		t.Parallel()
+		aID := CreateGroup(t, GetRandomGroup())
+		bID := CreateGroup(t, GetRandomGroup())
+
+		AddGroupMember(t, aID, &domain.GroupMember{GroupID: domain.NewOptUUID(domain.UUID(bID))}, 200)
+		AddGroupMember(t, bID, &domain.GroupMember{GroupID: domain.NewOptUUID(domain.UUID(aID))}, 400)
+		AddGroupMember(t, aID, &domain.GroupMember{GroupID: domain.NewOptUUID(domain.UUID(aID))}, 400)
+	})
+
+	t.Run("Admin grant", func(t *testing.T) {
+		t.Parallel()
+		user := GetRandomUser()
+		userID := CreateUser(t, user)
+
+		DoRequest(t, "POST", "users/", GetRandomUser(), GetAuthHeader(user), 403, nil)
+
+		group := GetRandomGroup()
+		group.IsAdmin.SetTo(true)
+		groupID := CreateGroup(t, group)
+		AddGroupMember(t, groupID, &domain.GroupMember{UserID: domain.NewOptUUID(domain.UUID(userID))}, 200)
+
+		DoRequest(t, "POST", "users/", GetRandomUser(), GetAuthHeader(user), 201, nil)
+
+		url := fmt.Sprintf("groups/%s/members/%s", groupID.String(), userID.String())
+		DoRequest(t, "DELETE", url, nil, GetAuthHeader(GetDefaultAdmin()), 200, nil)
+
+		DoRequest(t, "POST", "users/", GetRandomUser(), GetAuthHeader(user), 403, nil)
+	})
+}
@@ -0,0 +1,98 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ImportUsers sends the raw body to the import endpoint and decodes the report
+func ImportUsers(t *testing.T, contentType, query, body string) domain.ImportReport {
+	req, err := http.NewRequest("POST", fmt.Sprintf(
+		"http://%s:%d/users:import?%s", cfg.API.Host, cfg.API.Port, query,
+	), strings.NewReader(body))
+	require.NoError(t, err)
+	for k, v := range GetAuthHeader(GetDefaultAdmin()) {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+
+	var report domain.ImportReport
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&report))
+	return report
+}
+
+func TestImportUsers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("CSV", func(t *testing.T) {
+		t.Parallel()
+		user := GetRandomUser()
+		body := "username,email,password\n" +
+			fmt.Sprintf("%s,%s,%s\n", user.Username.Value, user.Email.Value, user.Password.Value) +
+			fmt.Sprintf("%s,%s,%s\n", user.Username.Value, GetRandomUser().Email.Value, user.Password.Value)
+
+		report := ImportUsers(t, "text/csv", "on_conflict=update", body)
+		assert.True(t, report.Committed)
+		assert.Equal(t, int64(1), report.Created)
+		assert.Equal(t, int64(1), report.Updated)
+		require.Len(t, report.Rows, 2)
+
+		DoRequest(t, "GET", "users/", nil, GetAuthHeader(user), 200, nil)
+	})
+
+	t.Run("CSV conflict rolls back", func(t *testing.T) {
+		t.Parallel()
+		user := GetRandomUser()
+		body := "username,email,password\n" +
+			fmt.Sprintf("%s,%s,%s\n", user.Username.Value, user.Email.Value, user.Password.Value) +
+			fmt.Sprintf("%s,%s,%s\n", user.Username.Value, GetRandomUser().Email.Value, user.Password.Value)
+
+		report := ImportUsers(t, "text/csv", "", body)
+		assert.False(t, report.Committed)
+		assert.Equal(t, int64(1), report.Created)
+		assert.Equal(t, int64(1), report.Failed)
+		require.Len(t, report.Rows, 2)
+
+		DoRequest(t, "GET", "users/", nil, GetAuthHeader(user), 401, nil)
+	})
+
+	t.Run("NDJSON dry run", func(t *testing.T) {
+		t.Parallel()
+		user := GetRandomUser()
+		line, err := json.Marshal(user)
+		require.NoError(t, err)
+
+		report := ImportUsers(t, "application/x-ndjson", "dry_run=true", string(line)+"\n")
+		assert.False(t, report.Committed)
+		assert.Equal(t, int64(1), report.Created)
+
+		DoRequest(t, "GET", "users/", nil, GetAuthHeader(user), 401, nil)
+	})
+
+	t.Run("Skip and update", func(t *testing.T) {
+		t.Parallel()
+		user := GetRandomUser()
+		CreateUser(t, user)
+
+		body := "username,email,password,is_admin\n" +
+			fmt.Sprintf("%s,%s,%s,true\n", user.Username.Value, user.Email.Value, user.Password.Value)
+
+		report := ImportUsers(t, "text/csv", "on_conflict=skip", body)
+		assert.Equal(t, int64(1), report.Skipped)
+
+		report = ImportUsers(t, "text/csv", "on_conflict=update", body)
+		assert.Equal(t, int64(1), report.Updated)
+	})
+}
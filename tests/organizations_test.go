@@ -0,0 +1,98 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v6"
+	uuid "github.com/google/uuid"
+	domain "github.com/liriquew/test_task/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func GetRandomOrganization() *domain.Organization {
+	return &domain.Organization{
+		Name: domain.NewOptString(gofakeit.Username() + gofakeit.AchAccount()),
+	}
+}
+
+func CreateOrganization(t *testing.T, org *domain.Organization) uuid.UUID {
+	const url = "organizations/"
+	var id Id
+	DoRequest(t, "POST", url, org, GetAuthHeader(GetDefaultAdmin()), 201, &id)
+	return id.Id
+}
+
+// GetTenantAuthHeader returns auth header of the user from the organization
+func GetTenantAuthHeader(org *domain.Organization, user *domain.User) map[string]string {
+	qualified := Copy(user)
+	qualified.Username.SetTo(org.Name.Value + "/" + user.Username.Value)
+	return GetAuthHeader(qualified)
+}
+
+// GetSuperAdminAuthHeader returns auth header of the default admin scoped to the organization
+func GetSuperAdminAuthHeader(orgID uuid.UUID) map[string]string {
+	header := GetAuthHeader(GetDefaultAdmin())
+	header["X-Tenant-ID"] = orgID.String()
+	return header
+}
+
+func TestOrganizations(t *testing.T) {
+	t.Parallel()
+
+	org := GetRandomOrganization()
+	orgID := CreateOrganization(t, org)
+
+	admin := GetRandomUser()
+	admin.IsAdmin.SetTo(true)
+	DoRequest(t, "POST", "users/", admin, GetSuperAdminAuthHeader(orgID), 201, nil)
+
+	t.Run("Same username in other organization", func(t *testing.T) {
+		t.Parallel()
+		user := GetRandomUser()
+		CreateUser(t, user)
+		DoRequest(t, "POST", "users/", user, GetTenantAuthHeader(org, admin), 201, nil)
+		DoRequest(t, "POST", "users/", user, GetTenantAuthHeader(org, admin), 409, nil)
+	})
+
+	t.Run("Tenant isolation", func(t *testing.T) {
+		t.Parallel()
+		user := GetRandomUser()
+		id := CreateUser(t, user)
+
+		url := fmt.Sprintf("users/%s", id.String())
+		DoRequest(t, "GET", url, nil, GetTenantAuthHeader(org, admin), 404, nil)
+
+		var users []domain.User
+		DoRequest(t, "GET", "users/", nil, GetTenantAuthHeader(org, admin), 200, &users)
+		for _, u := range users {
+			assert.NotEqual(t, domain.UUID(id), u.ID.Value)
+		}
+	})
+
+	t.Run("Tenant admin is not super admin", func(t *testing.T) {
+		t.Parallel()
+		DoRequest(t, "GET", "organizations/", nil, GetTenantAuthHeader(org, admin), 403, nil)
+
+		header := GetTenantAuthHeader(org, admin)
+		header["X-Tenant-ID"] = uuid.Nil.String()
+		DoRequest(t, "GET", "users/", nil, header, 403, nil)
+	})
+
+	t.Run("Unknown organization", func(t *testing.T) {
+		t.Parallel()
+		id, _ := uuid.NewV7()
+		DoRequest(t, "GET", "users/", nil, GetSuperAdminAuthHeader(id), 400, nil)
+		DoRequest(t, "GET", "users/", nil, GetTenantAuthHeader(GetRandomOrganization(), admin), 401, nil)
+	})
+
+	t.Run("Default organization", func(t *testing.T) {
+		t.Parallel()
+		url := fmt.Sprintf("organizations/%s", uuid.Nil.String())
+		var defaultOrg domain.Organization
+		DoRequest(t, "GET", url, nil, GetAuthHeader(GetDefaultAdmin()), 200, &defaultOrg)
+		require.Equal(t, "default", defaultOrg.Name.Value)
+		DoRequest(t, "DELETE", url, nil, GetAuthHeader(GetDefaultAdmin()), 400, nil)
+	})
+}